@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"math"
+	"syscall/js"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+const (
+	petFollowThreshold = 2 // Tiles the player can get away before the pet catches up
+	petTrailDistance   = 1 // Tiles behind the player the pet settles
+)
+
+// Pet is a cosmetic companion that trails the player; it never blocks or is blocked by anything but terrain
+type Pet struct {
+	systems.MovableEntity
+	movementSystem *systems.MovementSystem
+	leaderTileX    int // Leader tile seen on the previous Follow call
+	leaderTileY    int
+	leaderDirX     int // Last direction the leader moved in
+	leaderDirY     int
+}
+
+// NewPet creates a pet at the given world position
+func NewPet(startX, startY float64, gameMap *world.Map) *Pet {
+	pet := &Pet{
+		MovableEntity: systems.MovableEntity{
+			X:         startX,
+			Y:         startY,
+			Width:     12,
+			Height:    12,
+			TargetX:   startX,
+			TargetY:   startY,
+			MoveSpeed: 3.5, // Slightly faster than the player so it can catch up
+		},
+		movementSystem: systems.NewMovementSystem(gameMap),
+	}
+	pet.leaderTileX, pet.leaderTileY = pet.tile()
+	return pet
+}
+
+// tile returns the tile under the center of the pet
+func (p *Pet) tile() (int, int) {
+	return p.movementSystem.GetGameMap().WorldToGrid(p.X+p.Width/2, p.Y+p.Height/2)
+}
+
+// Follow keeps the pet trailing a leader at the given tile, pathing behind it once it gets too far away
+func (p *Pet) Follow(leaderTileX, leaderTileY int) {
+	if leaderTileX != p.leaderTileX || leaderTileY != p.leaderTileY {
+		p.leaderDirX, p.leaderDirY = leaderTileX-p.leaderTileX, leaderTileY-p.leaderTileY
+		p.leaderTileX, p.leaderTileY = leaderTileX, leaderTileY
+	}
+	
+	petX, petY := p.tile()
+	if p.IsMoving() || !systems.ShouldRepositionFollower(petX, petY, leaderTileX, leaderTileY, petFollowThreshold) {
+		return
+	}
+	
+	targetX, targetY := systems.TrailingTile(leaderTileX, leaderTileY, p.leaderDirX, p.leaderDirY, petTrailDistance)
+	p.movementSystem.MoveToTile(p, targetX, targetY)
+}
+
+// Update advances the pet along its current path for dt of simulated time
+func (p *Pet) Update(dt time.Duration) {
+	p.movementSystem.Update(p, dt)
+}
+
+// Draw renders the pet as a small orange circle so it is easy to tell apart from the player and units
+func (p *Pet) Draw(ctx js.Value, cameraX, cameraY float64) {
+	radius := p.Width / 2
+	ctx.Set("fillStyle", "#FFA500")
+	ctx.Call("beginPath")
+	ctx.Call("arc", p.X+radius-cameraX, p.Y+radius-cameraY, radius, 0, 2*math.Pi)
+	ctx.Call("fill")
+}
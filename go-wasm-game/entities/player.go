@@ -2,6 +2,7 @@ package entities
 
 import (
 	"syscall/js"
+	"time"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
 )
@@ -34,10 +35,10 @@ func NewPlayer(startX, startY float64, gameMap *world.Map) *Player {
 	return player
 }
 
-// Update handles player movement logic using the unified movement system
-func (p *Player) Update() {
+// Update moves the player along its path for dt of simulated time using the unified movement system
+func (p *Player) Update(dt time.Duration) {
 	if p.movementSystem != nil {
-		p.movementSystem.Update(p)
+		p.movementSystem.Update(p, dt)
 	}
 }
 
@@ -48,6 +49,25 @@ func (p *Player) MoveToTile(tileX, tileY int) {
 	}
 }
 
+// SetMoveSpeed changes the player's base movement speed; non-positive speeds are ignored
+func (p *Player) SetMoveSpeed(speed float64) {
+	if speed > 0 {
+		p.MovableEntity.MoveSpeed = speed
+	}
+}
+
+// SetIgnoreTerrainSpeed toggles constant-speed movement regardless of tile (vehicle mode)
+func (p *Player) SetIgnoreTerrainSpeed(ignore bool) {
+	p.MovableEntity.IgnoreTerrainSpeed = ignore
+}
+
+// SetOccupancyCheck makes the player treat tiles reported by isOccupied (e.g. unit tiles) as blocked
+func (p *Player) SetOccupancyCheck(isOccupied systems.BlockedFunc) {
+	if p.movementSystem != nil {
+		p.movementSystem.SetBlockedCheck(isOccupied)
+	}
+}
+
 // ClampToMapBounds ensures the player stays within map boundaries using the movement system
 func (p *Player) ClampToMapBounds(mapWidth, mapHeight, tileSize float64) {
 	if p.movementSystem != nil {
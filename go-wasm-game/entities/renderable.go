@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"sort"
+	"syscall/js"
+)
+
+// Renderable is anything drawn in the depth-sorted objects pass (units, the pet, the player)
+type Renderable interface {
+	DepthY() float64 // World Y of the object's base; lower values are drawn first
+	Draw(ctx js.Value, cameraX, cameraY float64)
+}
+
+// SortByDepth orders renderables back to front so objects further down the screen overlap those above,
+// keeping the given order for equal depths
+func SortByDepth(items []Renderable) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DepthY() < items[j].DepthY()
+	})
+}
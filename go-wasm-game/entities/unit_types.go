@@ -16,6 +16,7 @@ type UnitStats struct {
 	Damage  int
 	Speed   int
 	Defense int
+	Range   int // Attack reach in tiles (1 = adjacent only)
 }
 
 // UnitAppearance represents the visual properties of a unit
@@ -23,6 +24,15 @@ type UnitAppearance struct {
 	Icon  string
 	Color string
 	Size  float64
+	FootprintSize float64 // Movement/collision box edge in pixels; 0 matches Size
+}
+
+// Footprint returns the edge length of the unit's movement box, so collision agrees with what is drawn
+func (a UnitAppearance) Footprint() float64 {
+	if a.FootprintSize > 0 {
+		return a.FootprintSize
+	}
+	return a.Size
 }
 
 // UnitTypeDef defines a unit type with its properties
@@ -31,6 +41,7 @@ type UnitTypeDef struct {
 	Stats       UnitStats
 	Appearance  UnitAppearance
 	Description string
+	Capacity    int // Passengers the unit can carry; 0 means it cannot transport
 }
 
 // UnitTypeDefinitions contains all available unit types
@@ -42,6 +53,7 @@ var UnitTypeDefinitions = map[UnitType]UnitTypeDef{
 			Damage:  25,
 			Speed:   2,
 			Defense: 15,
+			Range:   1,
 		},
 		Appearance: UnitAppearance{
 			Icon:  "⚔️",
@@ -57,6 +69,7 @@ var UnitTypeDefinitions = map[UnitType]UnitTypeDef{
 			Damage:  40,
 			Speed:   4,
 			Defense: 5,
+			Range:   4,
 		},
 		Appearance: UnitAppearance{
 			Icon:  "🏹",
@@ -72,6 +85,7 @@ var UnitTypeDefinitions = map[UnitType]UnitTypeDef{
 			Damage:  60,
 			Speed:   3,
 			Defense: 2,
+			Range:   3,
 		},
 		Appearance: UnitAppearance{
 			Icon:  "🔮",
@@ -87,6 +101,7 @@ var UnitTypeDefinitions = map[UnitType]UnitTypeDef{
 			Damage:  15,
 			Speed:   6,
 			Defense: 3,
+			Range:   1,
 		},
 		Appearance: UnitAppearance{
 			Icon:  "👁️",
@@ -94,5 +109,6 @@ var UnitTypeDefinitions = map[UnitType]UnitTypeDef{
 			Size:  18.0,
 		},
 		Description: "A fast reconnaissance unit with high mobility",
+		Capacity:    1,
 	},
 }
\ No newline at end of file
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Area-of-effect damage and healing exposed to JavaScript, so large battles need one call per effect
+
+// damageArea damages every unit within radius of a tile, reporting who was hit and who died
+func damageArea(this js.Value, args []js.Value) interface{} {
+	if len(args) < 4 {
+		return jsError(CodeInvalidArguments, "damageArea requires tileX, tileY, radius, amount")
+	}
+
+	result := State.UnitManager.DamageArea(args[0].Int(), args[1].Int(), args[2].Float(), args[3].Int())
+	return jsSuccess(areaResultJS(result))
+}
+
+// healArea heals units within radius of a tile, optionally only those on one team
+func healArea(this js.Value, args []js.Value) interface{} {
+	if len(args) < 4 {
+		return jsError(CodeInvalidArguments, "healArea requires tileX, tileY, radius, amount")
+	}
+
+	team := units.AnyTeam
+	if len(args) > 4 && !args[4].IsUndefined() && !args[4].IsNull() {
+		team = args[4].Int()
+	}
+	result := State.UnitManager.HealArea(args[0].Int(), args[1].Int(), args[2].Float(), args[3].Int(), team)
+	return jsSuccess(areaResultJS(result))
+}
+
+// areaResultJS converts an area result into plain JS-friendly lists
+func areaResultJS(result units.AreaResult) map[string]interface{} {
+	return map[string]interface{}{
+		"affected": stringsToJS(result.Affected),
+		"killed":   stringsToJS(result.Killed),
+	}
+}
+
+// stringsToJS copies a string slice into a slice js.ValueOf accepts
+func stringsToJS(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
+
+// initializeAreaInterface sets up JavaScript bindings for area effects
+func initializeAreaInterface() {
+	exposeFunc("damageArea", damageArea)
+	
+	exposeFunc("healArea", healArea)
+}
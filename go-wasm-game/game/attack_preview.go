@@ -0,0 +1,128 @@
+package game
+
+import (
+	"math"
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// AttackPreview is the hover feedback for attacking the enemy under the cursor with the selected unit:
+// green when it is already in range, yellow with the approach path when it would have to move first
+type AttackPreview struct {
+	active   bool
+	targetID string
+	result   units.AttackPreview
+	approach MovePreview // Approach path, cached so hovering the same target never repaths
+}
+
+// Active reports whether an attack preview is being shown
+func (p *AttackPreview) Active() bool {
+	return p.active
+}
+
+// TargetID returns the hovered enemy, "" when inactive
+func (p *AttackPreview) TargetID() string {
+	if !p.active {
+		return ""
+	}
+	return p.targetID
+}
+
+// InRange reports whether the previewed target can be attacked without moving
+func (p *AttackPreview) InRange() bool {
+	return p.active && p.result.InRange
+}
+
+// Path returns the approach path to the nearest in-range tile, nil when in range, inactive or unreachable
+func (p *AttackPreview) Path() systems.Path {
+	if !p.active || p.result.InRange {
+		return nil
+	}
+	return p.approach.Path()
+}
+
+// Clear hides the preview
+func (p *AttackPreview) Clear() {
+	p.active = false
+	p.targetID = ""
+	p.approach.Clear()
+}
+
+// PreviewAttack shows whether the selected unit could attack the enemy on a tile, returning false
+// (and hiding the preview) when nothing is selected or no enemy stands there
+func (gs *GameState) PreviewAttack(tileX, tileY int) bool {
+	unit := gs.UnitManager.GetSelectedUnit()
+	target := gs.enemyAt(unit, tileX, tileY)
+	if target == nil {
+		gs.AttackPreview.Clear()
+		return false
+	}
+	
+	result, err := gs.UnitManager.PreviewAttack(unit.ID, target.ID)
+	if err != nil {
+		gs.AttackPreview.Clear()
+		return false
+	}
+	preview := &gs.AttackPreview
+	preview.active, preview.targetID, preview.result = true, target.ID, result
+	switch {
+	case result.InRange:
+		preview.approach.Clear()
+	case result.CanApproach:
+		preview.approach.Update(unit.ID, unit.TileX, unit.TileY, result.TileX, result.TileY, func() systems.Path {
+			return unit.PreviewPath(result.TileX, result.TileY)
+		})
+	default:
+		preview.approach.Clear()
+	}
+	return true
+}
+
+// enemyAt returns the first living unit on a tile that is on another team than unit, nil if none
+func (gs *GameState) enemyAt(unit *units.Unit, tileX, tileY int) *units.Unit {
+	if unit == nil || !unit.IsAlive || unit.IsCarried() {
+		return nil
+	}
+	for _, other := range gs.UnitManager.GetUnitsAtTile(tileX, tileY) {
+		if other.IsAlive && other.Team != unit.Team {
+			return other
+		}
+	}
+	return nil
+}
+
+// RenderAttackPreview rings the hovered enemy, green if in range and yellow otherwise, plus the approach path
+func RenderAttackPreview(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
+	preview := &State.AttackPreview
+	target := State.UnitManager.GetUnit(preview.TargetID())
+	if target == nil {
+		return
+	}
+	
+	color := "rgba(255, 215, 0, 0.9)"
+	if preview.InRange() {
+		color = "rgba(50, 205, 50, 0.9)"
+	}
+	
+	ctx.Call("save")
+	ctx.Set("strokeStyle", color)
+	ctx.Set("lineWidth", 2)
+	centerX, centerY := systems.TileToScreen(State.GameMap, target.TileX, target.TileY, cameraX, cameraY)
+	ctx.Call("beginPath")
+	ctx.Call("arc", centerX, centerY, State.GameMap.TileWidth*0.6, 0, 2*math.Pi)
+	ctx.Call("stroke")
+	
+	if path := preview.Path(); len(path) >= 2 {
+		ctx.Call("setLineDash", []interface{}{2, 6})
+		ctx.Call("beginPath")
+		startX, startY := systems.TileToScreen(State.GameMap, path[0].X, path[0].Y, cameraX, cameraY)
+		ctx.Call("moveTo", startX, startY)
+		for _, step := range path[1:] {
+			stepX, stepY := systems.TileToScreen(State.GameMap, step.X, step.Y, cameraX, cameraY)
+			ctx.Call("lineTo", stepX, stepY)
+		}
+		ctx.Call("stroke")
+	}
+	ctx.Call("restore")
+}
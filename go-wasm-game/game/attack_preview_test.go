@@ -0,0 +1,46 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+)
+
+// Test that hovering an enemy shows green when in range, and yellow with an approach path otherwise
+func TestPreviewAttackOnHoveredEnemy(t *testing.T) {
+	state := newTestState(10, 10)
+	warrior, err := state.UnitManager.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	near, _ := state.UnitManager.CreateUnit(entities.UnitWarrior, 2, 1, "")
+	far, _ := state.UnitManager.CreateUnit(entities.UnitWarrior, 6, 1, "")
+	state.UnitManager.SetUnitTeam(near.ID, 1)
+	state.UnitManager.SetUnitTeam(far.ID, 1)
+	
+	if state.PreviewAttack(2, 1) {
+		t.Fatal("PreviewAttack() with nothing selected = true, want no preview")
+	}
+	state.UnitManager.SelectUnit(warrior.ID)
+	
+	if !state.PreviewAttack(2, 1) || !state.AttackPreview.InRange() || state.AttackPreview.Path() != nil {
+		t.Errorf("adjacent enemy: InRange = %v, Path = %v, want in range with no path", state.AttackPreview.InRange(), state.AttackPreview.Path())
+	}
+	
+	if !state.PreviewAttack(6, 1) || state.AttackPreview.InRange() || state.AttackPreview.TargetID() != far.ID {
+		t.Fatalf("far enemy: InRange = %v, target = %q, want out of range on %s", state.AttackPreview.InRange(), state.AttackPreview.TargetID(), far.ID)
+	}
+	path := state.AttackPreview.Path()
+	if len(path) == 0 || path[len(path)-1].X != 5 || path[len(path)-1].Y != 1 {
+		t.Errorf("approach path = %v, want it to end beside the enemy at (5, 1)", path)
+	}
+	if warrior.IsMoving() {
+		t.Error("hovering an enemy should never move the unit")
+	}
+	
+	if state.PreviewAttack(4, 4) || state.AttackPreview.Active() {
+		t.Error("hovering an empty tile should hide the attack preview")
+	}
+}
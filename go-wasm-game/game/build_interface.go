@@ -0,0 +1,44 @@
+package game
+
+import (
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Construction orders and the rally point exposed to JavaScript
+
+func orderBuild(this js.Value, args []js.Value) interface{} {
+	if len(args) < 4 {
+		return jsError(CodeInvalidArguments, "orderBuild requires unitId, tileX, tileY, tileType")
+	}
+
+	err := State.UnitManager.OrderBuild(args[0].String(), args[1].Int(), args[2].Int(), world.TileType(args[3].Int()))
+	if err != nil {
+		return jsErrorFrom(err)
+	}
+
+	return jsSuccess(nil)
+}
+
+// setRallyPoint sets the tile units spawned from the build queue walk to once grown in; with no arguments it clears the rally point
+func setRallyPoint(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		State.UnitManager.ClearRallyPoint()
+		return jsSuccess(nil)
+	}
+	if len(args) < 2 {
+		return jsError(CodeInvalidArguments, "setRallyPoint requires tileX, tileY")
+	}
+
+	if err := State.UnitManager.SetRallyPoint(args[0].Int(), args[1].Int()); err != nil {
+		return jsErrorFrom(err)
+	}
+
+	return jsSuccess(nil)
+}
+
+// initializeBuildInterface sets up JavaScript bindings for construction orders and the rally point
+func initializeBuildInterface() {
+	exposeFunc("orderBuild", orderBuild)
+	exposeFunc("setRallyPoint", setRallyPoint)
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"syscall/js"
+)
+
+// Camera follow target selection, exposed to JavaScript
+
+// heldView is a restored camera view, held in place until the player moves away from where it stood
+type heldView struct {
+	centerX, centerY float64 // World position the camera stays centered on
+	playerX, playerY float64 // Player position when the view was restored
+}
+
+// CameraFocus returns the world position (top-left) and size of the entity the camera follows
+// A followed unit that has died or been removed is dropped and the camera goes back to the player.
+// A view held by RestoreView is returned as a zero-size focus at its center until the player moves
+func (gs *GameState) CameraFocus() (x, y, width, height float64) {
+	if gs.heldView != nil {
+		if x, y := gs.Player.GetPosition(); gs.CameraFollowTarget == "" && x == gs.heldView.playerX && y == gs.heldView.playerY {
+			return gs.heldView.centerX, gs.heldView.centerY, 0, 0
+		}
+		gs.heldView = nil
+	}
+	
+	if gs.CameraFollowTarget != "" && gs.UnitManager != nil {
+		unit := gs.UnitManager.GetUnit(gs.CameraFollowTarget)
+		if unit != nil && unit.IsAlive {
+			x, y = unit.GetPosition()
+			width, height = unit.GetSize()
+			return x, y, width, height
+		}
+	}
+	gs.CameraFollowTarget = ""
+	
+	x, y = gs.Player.GetPosition()
+	width, height = gs.Player.MovableEntity.GetSize()
+	return x, y, width, height
+}
+
+// followCamera makes the camera follow a unit until it dies or is removed
+func followCamera(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return jsError(CodeInvalidArguments, "followCamera requires unitId")
+	}
+
+	unit := State.UnitManager.GetUnit(args[0].String())
+	if unit == nil || !unit.IsAlive {
+		return jsError(CodeNotFound, "unit not found: "+args[0].String())
+	}
+	State.CameraFollowTarget = unit.ID
+	return jsSuccess(nil)
+}
+
+// followCameraPlayer returns the camera to following the player
+func followCameraPlayer(this js.Value, args []js.Value) interface{} {
+	State.CameraFollowTarget = ""
+	State.heldView = nil
+	return jsSuccess(nil)
+}
+
+// initializeCameraInterface sets up JavaScript bindings for the camera follow target and zoom
+func initializeCameraInterface() {
+	exposeFunc("followCamera", followCamera)
+	exposeFunc("followCameraPlayer", followCameraPlayer)
+	exposeFunc("zoomAt", zoomAt)
+}
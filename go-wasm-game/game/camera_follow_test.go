@@ -0,0 +1,71 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"syscall/js"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that the camera focuses on the followed unit and falls back to the player once it is gone
+func TestCameraFollowTargetResolution(t *testing.T) {
+	state := newTestState(10, 10)
+	state.Player.SetPosition(40, 50)
+	unit, err := state.UnitManager.CreateUnit(entities.UnitWarrior, 6, 6, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	
+	if x, y, _, _ := state.CameraFocus(); x != 40 || y != 50 {
+		t.Errorf("default focus = (%v, %v), want the player at (40, 50)", x, y)
+	}
+	
+	state.CameraFollowTarget = unit.ID
+	unitX, unitY := unit.GetPosition()
+	if x, y, width, _ := state.CameraFocus(); x != unitX || y != unitY || width != unit.Width {
+		t.Errorf("focus = (%v, %v) width %v, want the unit at (%v, %v) width %v", x, y, width, unitX, unitY, unit.Width)
+	}
+	
+	// A dead unit can't be followed
+	state.UnitManager.DamageUnit(unit.ID, 10000)
+	if x, y, _, _ := state.CameraFocus(); x != 40 || y != 50 {
+		t.Errorf("focus after the unit died = (%v, %v), want the player", x, y)
+	}
+	if state.CameraFollowTarget != "" {
+		t.Errorf("CameraFollowTarget = %q, want it cleared after the unit died", state.CameraFollowTarget)
+	}
+	
+	// Neither can a removed one
+	other, _ := state.UnitManager.CreateUnit(entities.UnitScout, 2, 2, "")
+	state.CameraFollowTarget = other.ID
+	state.UnitManager.RemoveUnit(other.ID)
+	if x, y, _, _ := state.CameraFocus(); x != 40 || y != 50 || state.CameraFollowTarget != "" {
+		t.Errorf("focus after removal = (%v, %v) target %q, want the player", x, y, state.CameraFollowTarget)
+	}
+}
+
+// Test the JS follow bindings, including rejecting unknown units
+func TestFollowCameraViaJS(t *testing.T) {
+	state := newTestState(10, 10)
+	state.GameMap.Layers = world.NewLayers()
+	game.InitializeJSInterface()
+	unit, _ := state.UnitManager.CreateUnit(entities.UnitWarrior, 3, 3, "")
+	
+	if result := js.Global().Call("followCamera", unit.ID); !result.Get("success").Bool() || state.CameraFollowTarget != unit.ID {
+		t.Fatalf("followCamera(%s) = %v, target %q", unit.ID, result.Get("error"), state.CameraFollowTarget)
+	}
+	if result := js.Global().Call("followCamera", "unit_404"); result.Get("code").String() != game.CodeNotFound {
+		t.Errorf("followCamera(unknown) code = %v, want %s", result.Get("code"), game.CodeNotFound)
+	}
+	if state.CameraFollowTarget != unit.ID {
+		t.Error("a failed followCamera should keep the current target")
+	}
+	js.Global().Call("followCameraPlayer")
+	if state.CameraFollowTarget != "" {
+		t.Errorf("CameraFollowTarget = %q after followCameraPlayer, want \"\"", state.CameraFollowTarget)
+	}
+}
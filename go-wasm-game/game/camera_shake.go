@@ -0,0 +1,82 @@
+package game
+
+import (
+	"math"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Shake strengths, in pixels of camera offset, and how long each lasts
+const (
+	DeathShakeIntensity  = 6.0
+	DeathShakeDuration   = 300 * time.Millisecond
+	BigHitShakeIntensity = 3.0
+	BigHitShakeDuration  = 150 * time.Millisecond
+)
+
+// BigHitFraction is the share of a unit's max health one hit must take to shake the camera
+const BigHitFraction = 0.25
+
+// CameraShake offsets the camera briefly after impactful events, fading out linearly
+// The offset follows fixed sine waves of the elapsed time, so it is the same every run
+type CameraShake struct {
+	Intensity float64       // Peak offset in pixels of the current shake
+	Duration  time.Duration // Total length of the current shake
+	elapsed   time.Duration // Time since the current shake started
+}
+
+// Start begins a shake unless a stronger one is still running
+func (cs *CameraShake) Start(intensity float64, duration time.Duration) {
+	if duration <= 0 || intensity <= 0 || intensity < cs.Strength() {
+		return
+	}
+	cs.Intensity, cs.Duration, cs.elapsed = intensity, duration, 0
+}
+
+// Update advances the shake by dt, fading it out
+func (cs *CameraShake) Update(dt time.Duration) {
+	if cs.Active() {
+		cs.elapsed += dt
+	}
+}
+
+// Active reports whether the shake still moves the camera
+func (cs *CameraShake) Active() bool {
+	return cs.elapsed < cs.Duration
+}
+
+// Strength returns the current peak offset: the full intensity at the start, falling to 0 at the end
+func (cs *CameraShake) Strength() float64 {
+	if !cs.Active() {
+		return 0
+	}
+	return cs.Intensity * (1 - float64(cs.elapsed)/float64(cs.Duration))
+}
+
+// Offset returns the camera displacement for this moment of the shake, (0, 0) once it has ended
+func (cs *CameraShake) Offset() (float64, float64) {
+	strength := cs.Strength()
+	if strength == 0 {
+		return 0, 0
+	}
+	t := cs.elapsed.Seconds()
+	return strength * math.Sin(t*71), strength * math.Cos(t*53)
+}
+
+// ShakeOffset returns the shake to apply to the drawn view, none while Paused so a paused frame holds still
+func (gs *GameState) ShakeOffset() (float64, float64) {
+	if gs.Paused {
+		return 0, 0
+	}
+	return gs.Shake.Offset()
+}
+
+// shakeOnDamage shakes the camera when a unit dies or takes a big hit
+func (gs *GameState) shakeOnDamage(unit *units.Unit, damage int) {
+	switch {
+	case !unit.IsAlive:
+		gs.Shake.Start(DeathShakeIntensity, DeathShakeDuration)
+	case float64(damage) >= BigHitFraction*float64(unit.MaxStats.Health):
+		gs.Shake.Start(BigHitShakeIntensity, BigHitShakeDuration)
+	}
+}
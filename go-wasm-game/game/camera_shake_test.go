@@ -0,0 +1,109 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"math"
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+)
+
+// Test that a shake fades out over its duration and stops moving the camera once it has elapsed
+func TestCameraShakeDecays(t *testing.T) {
+	var shake game.CameraShake
+	shake.Start(6, 300*time.Millisecond)
+	
+	previous := shake.Strength()
+	if previous != 6 {
+		t.Fatalf("Strength() at start = %v, want 6", previous)
+	}
+	for elapsed := 50 * time.Millisecond; elapsed < 300*time.Millisecond; elapsed += 50 * time.Millisecond {
+		shake.Update(50 * time.Millisecond)
+		strength := shake.Strength()
+		if strength >= previous || strength <= 0 {
+			t.Errorf("Strength() after %v = %v, want below %v and above 0", elapsed, strength, previous)
+		}
+		if x, y := shake.Offset(); math.Abs(x) > strength || math.Abs(y) > strength {
+			t.Errorf("Offset() after %v = (%v, %v), want within %v", elapsed, x, y, strength)
+		}
+		previous = strength
+	}
+	
+	shake.Update(50 * time.Millisecond)
+	if x, y := shake.Offset(); x != 0 || y != 0 || shake.Active() {
+		t.Errorf("Offset() once the duration elapsed = (%v, %v), active %v, want (0, 0) and inactive", x, y, shake.Active())
+	}
+}
+
+// Test that offsets repeat exactly for the same shake and a weaker shake doesn't cut a stronger one short
+func TestCameraShakeDeterministic(t *testing.T) {
+	var first, second game.CameraShake
+	first.Start(4, time.Second)
+	second.Start(4, time.Second)
+	for i := 0; i < 5; i++ {
+		first.Update(16 * time.Millisecond)
+		second.Update(16 * time.Millisecond)
+		fx, fy := first.Offset()
+		sx, sy := second.Offset()
+		if fx != sx || fy != sy {
+			t.Fatalf("frame %d offsets differ: (%v, %v) vs (%v, %v)", i, fx, fy, sx, sy)
+		}
+	}
+	
+	before := first.Strength()
+	first.Start(1, time.Second)
+	if first.Strength() != before {
+		t.Errorf("Strength() after a weaker Start = %v, want the stronger shake's %v", first.Strength(), before)
+	}
+}
+
+// Test that unit deaths and big hits shake the camera while small hits don't
+func TestCameraShakeTriggers(t *testing.T) {
+	tests := []struct {
+		name          string
+		hit           func(state *game.GameState, unitID string)
+		wantIntensity float64
+	}{
+		{"small hit", func(state *game.GameState, id string) { state.UnitManager.DamageUnit(id, 20) }, 0},
+		{"big hit", func(state *game.GameState, id string) { state.UnitManager.DamageUnit(id, 40) }, game.BigHitShakeIntensity},
+		{"death", func(state *game.GameState, id string) { state.UnitManager.KillUnit(id) }, game.DeathShakeIntensity},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newTestState(10, 10)
+			unit, err := state.SpawnUnit(entities.UnitWarrior, 3, 3, "")
+			if err != nil {
+				t.Fatalf("SpawnUnit failed: %v", err)
+			}
+			tt.hit(state, unit.ID)
+			if got := state.Shake.Strength(); got != tt.wantIntensity {
+				t.Errorf("shake strength = %v, want %v", got, tt.wantIntensity)
+			}
+		})
+	}
+}
+
+// Test that simulation ticks leave the shake to the draw loop and a paused game draws it still
+func TestCameraShakeOutsideSimulation(t *testing.T) {
+	state := newTestState(10, 10)
+	state.Shake.Start(game.DeathShakeIntensity, game.DeathShakeDuration)
+	for tick := 0; tick < 60; tick++ {
+		state.Step()
+	}
+	if got := state.Shake.Strength(); got != game.DeathShakeIntensity {
+		t.Errorf("shake strength after a second of ticks = %v, want the untouched %v", got, game.DeathShakeIntensity)
+	}
+	
+	state.Shake.Update(50 * time.Millisecond)
+	if x, y := state.ShakeOffset(); x == 0 && y == 0 {
+		t.Errorf("ShakeOffset() mid-shake = (0, 0), want the camera displaced")
+	}
+	state.Paused = true
+	if x, y := state.ShakeOffset(); x != 0 || y != 0 {
+		t.Errorf("ShakeOffset() while paused = (%v, %v), want (0, 0)", x, y)
+	}
+}
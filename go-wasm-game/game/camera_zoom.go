@@ -0,0 +1,56 @@
+package game
+
+import (
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// Zoom limits and how much one mouse wheel notch zooms by
+const (
+	MinZoom         = 0.5
+	MaxZoom         = 3.0
+	WheelZoomFactor = 1.1
+)
+
+// ZoomAt changes the unit zoom to newZoom, clamped to [MinZoom, MaxZoom], moving the camera with
+// systems.ZoomToCursor so the world point under the cursor stays put. Like RestoreView the camera is
+// clamped to the map and held there until the player moves; returns the view actually applied
+func (gs *GameState) ZoomAt(cursorX, cursorY, newZoom, viewWidth, viewHeight float64) ViewSnapshot {
+	if newZoom < MinZoom {
+		newZoom = MinZoom
+	} else if newZoom > MaxZoom {
+		newZoom = MaxZoom
+	}
+	oldZoom := gs.UnitManager.Renderer().Zoom
+	cameraX, cameraY := systems.ZoomToCursor(gs.CameraX, gs.CameraY, cursorX, cursorY, oldZoom, newZoom)
+	return gs.RestoreView(ViewSnapshot{CameraX: cameraX, CameraY: cameraY, Zoom: newZoom}, viewWidth, viewHeight)
+}
+
+// wheel zooms toward the cursor by WheelZoomFactor per notch, in on scroll up and out on scroll down
+func wheel(this js.Value, args []js.Value) interface{} {
+	event := args[0]
+	event.Call("preventDefault")
+	deltaY := event.Get("deltaY").Float()
+	if deltaY == 0 {
+		return nil
+	}
+
+	zoom := State.UnitManager.Renderer().Zoom * WheelZoomFactor
+	if deltaY > 0 {
+		zoom = State.UnitManager.Renderer().Zoom / WheelZoomFactor
+	}
+	cursorX, cursorY := eventCanvasPoint(event)
+	canvasWidth, canvasHeight := State.Canvas.Get("width").Float(), State.Canvas.Get("height").Float()
+	State.ZoomAt(cursorX, cursorY, zoom, canvasWidth, canvasHeight-GetUIAreaHeight())
+	return nil
+}
+
+// zoomAt zooms toward a point on the canvas, as the mouse wheel does: zoomAt(zoom, cursorX, cursorY)
+func zoomAt(this js.Value, args []js.Value) interface{} {
+	if len(args) < 3 {
+		return jsError(CodeInvalidArguments, "zoomAt requires zoom, cursorX, cursorY")
+	}
+
+	canvasWidth, canvasHeight := State.Canvas.Get("width").Float(), State.Canvas.Get("height").Float()
+	return jsSuccess(viewJS(State.ZoomAt(args[1].Float(), args[2].Float(), args[0].Float(), canvasWidth, canvasHeight-GetUIAreaHeight())))
+}
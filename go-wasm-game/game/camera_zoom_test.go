@@ -0,0 +1,49 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"math"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+)
+
+// Test that zooming keeps the world point under the cursor fixed, clamps the zoom and holds the camera there
+func TestZoomAtKeepsCursorPoint(t *testing.T) {
+	// The 20x10 map is 640x320 world units; the game area shows 200x100 of it
+	tests := []struct {
+		name             string
+		cursorX, cursorY float64
+		zoom             float64
+		wantZoom         float64
+	}{
+		{"zoom in", 50, 40, 2, 2},
+		{"zoom out", 150, 20, 0.8, 0.8},
+		{"clamped in", 100, 50, 10, game.MaxZoom},
+		{"clamped out", 100, 50, 0.1, game.MinZoom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newTestState(20, 10)
+			state.UpdateCamera(200, 100)
+			worldX, worldY := 200+tt.cursorX, 100+tt.cursorY
+
+			view := state.ZoomAt(tt.cursorX, tt.cursorY, tt.zoom, 200, 100)
+			if view.Zoom != tt.wantZoom || state.View().Zoom != tt.wantZoom {
+				t.Errorf("zoom = %v, renderer zoom %v, want %v", view.Zoom, state.View().Zoom, tt.wantZoom)
+			}
+			gotX, gotY := view.CameraX+tt.cursorX/view.Zoom, view.CameraY+tt.cursorY/view.Zoom
+			if math.Abs(gotX-worldX) > 1e-9 || math.Abs(gotY-worldY) > 1e-9 {
+				t.Errorf("world point under cursor moved from (%v, %v) to (%v, %v)", worldX, worldY, gotX, gotY)
+			}
+
+			// The follow camera would otherwise recenter on the player next frame
+			state.Step()
+			if x, y, _, _ := state.CameraFocus(); x != view.CameraX+100 || y != view.CameraY+50 {
+				t.Errorf("CameraFocus() = (%v, %v), want the zoomed view's center (%v, %v)", x, y, view.CameraX+100, view.CameraY+50)
+			}
+		})
+	}
+}
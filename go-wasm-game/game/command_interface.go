@@ -0,0 +1,58 @@
+package game
+
+import "syscall/js"
+
+// JavaScript command dispatcher
+
+// command validates and executes one command object, e.g.
+// {type: "queueMove", unitId: "unit_1", tileX: 4, tileY: 7}
+// Commands naming a missing unit or an out-of-bounds tile are rejected before anything is queued
+func command(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 || args[0].Type() != js.TypeObject {
+		return jsError(CodeInvalidArguments, "command requires a command object")
+	}
+
+	if err := State.Dispatch(commandFromJS(args[0])); err != nil {
+		return jsErrorFrom(err)
+	}
+	return jsSuccess(nil)
+}
+
+// commandFromJS reads a Command from a JS object using the same field names as replay JSON
+func commandFromJS(obj js.Value) Command {
+	str := func(key string) string {
+		if v := obj.Get(key); v.Type() == js.TypeString {
+			return v.String()
+		}
+		return ""
+	}
+	num := func(key string) int {
+		if v := obj.Get(key); v.Type() == js.TypeNumber {
+			return v.Int()
+		}
+		return 0
+	}
+
+	return Command{
+		Type:     CommandType(str("type")),
+		UnitID:   str("unitId"),
+		TargetID: str("targetId"),
+		UnitType: num("unitType"),
+		Name:     str("name"),
+		TileX:    num("tileX"),
+		TileY:    num("tileY"),
+		Level:      num("level"),
+		Experience: num("experience"),
+		Health:     num("health"),
+		MaxHealth:  num("maxHealth"),
+		Team:       num("team"),
+		Kills:       num("kills"),
+		DamageDealt: num("damageDealt"),
+		DamageTaken: num("damageTaken"),
+	}
+}
+
+// initializeCommandInterface sets up the JavaScript binding for the command dispatcher
+func initializeCommandInterface() {
+	exposeFunc("command", command)
+}
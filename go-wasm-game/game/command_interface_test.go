@@ -0,0 +1,100 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"syscall/js"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// jsCommand builds a command object the way a page script would
+func jsCommand(fields map[string]interface{}) js.Value {
+	obj := js.Global().Get("Object").New()
+	for key, value := range fields {
+		obj.Set(key, value)
+	}
+	return obj
+}
+
+// Test that invalid commands are rejected with a code and never reach the unit's move queue
+func TestCommandDispatcherRejectsInvalidCommands(t *testing.T) {
+	state := newTestState(10, 10)
+	state.GameMap.Layers = world.NewLayers()
+	game.InitializeJSInterface()
+	unit, err := state.UnitManager.CreateUnit(entities.UnitWarrior, 0, 0, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	state.UnitManager.MoveUnit(unit.ID, 9, 9) // Busy, so valid queueMoves wait in the queue
+	
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+		code   string
+	}{
+		{"tile past the edge", map[string]interface{}{"type": "queueMove", "unitId": unit.ID, "tileX": 10, "tileY": 2}, game.CodeOutOfBounds},
+		{"negative tile", map[string]interface{}{"type": "queueMove", "unitId": unit.ID, "tileX": 1, "tileY": -1}, game.CodeOutOfBounds},
+		{"missing unit", map[string]interface{}{"type": "queueMove", "unitId": "unit_404", "tileX": 1, "tileY": 1}, game.CodeNotFound},
+		{"missing attack target", map[string]interface{}{"type": "attack", "unitId": unit.ID, "targetId": "unit_404"}, game.CodeNotFound},
+		{"unknown type", map[string]interface{}{"type": "teleport", "unitId": unit.ID}, game.CodeInvalidArguments},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := js.Global().Call("command", jsCommand(tt.fields))
+			if result.Get("success").Bool() {
+				t.Fatalf("command(%v) succeeded, want %s", tt.fields, tt.code)
+			}
+			if code := result.Get("code").String(); code != tt.code {
+				t.Errorf("code = %s, want %s", code, tt.code)
+			}
+			if len(unit.MoveQueue) != 0 {
+				t.Errorf("move queue = %v, want the rejected command kept out", unit.MoveQueue)
+			}
+		})
+	}
+	
+	if result := js.Global().Call("command", "queueMove"); result.Get("code").String() != game.CodeInvalidArguments {
+		t.Errorf("command(non-object) code = %v, want %s", result.Get("code"), game.CodeInvalidArguments)
+	}
+}
+
+// Test that a unit's move queue accepts commands up to its limit and rejects the rest
+func TestCommandDispatcherCapsQueueLength(t *testing.T) {
+	state := newTestState(10, 10)
+	state.GameMap.Layers = world.NewLayers()
+	game.InitializeJSInterface()
+	unit, err := state.UnitManager.CreateUnit(entities.UnitWarrior, 0, 0, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	unit.MaxQueueLength = 3
+	
+	// The first command starts the idle unit moving; the next three wait in the queue
+	for i := 0; i < 4; i++ {
+		result := js.Global().Call("command", jsCommand(map[string]interface{}{"type": "queueMove", "unitId": unit.ID, "tileX": 5, "tileY": i + 1}))
+		if !result.Get("success").Bool() {
+			t.Fatalf("command #%d failed: %v", i, result.Get("error"))
+		}
+	}
+	
+	result := js.Global().Call("command", jsCommand(map[string]interface{}{"type": "queueMove", "unitId": unit.ID, "tileX": 6, "tileY": 6}))
+	if result.Get("success").Bool() || result.Get("code").String() != game.CodeQueueFull {
+		t.Errorf("over-length command = %v (%v), want %s", result.Get("success"), result.Get("code"), game.CodeQueueFull)
+	}
+	if len(unit.MoveQueue) != 3 {
+		t.Errorf("move queue length = %d, want 3", len(unit.MoveQueue))
+	}
+	
+	// A direct move order replaces the whole queue
+	if err := state.Dispatch(game.Command{Type: game.CommandMoveUnit, UnitID: unit.ID, TileX: 8, TileY: 8}); err != nil {
+		t.Fatalf("Dispatch(moveUnit) failed: %v", err)
+	}
+	if len(unit.MoveQueue) != 0 {
+		t.Errorf("move queue length after moveUnit = %d, want 0", len(unit.MoveQueue))
+	}
+}
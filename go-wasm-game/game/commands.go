@@ -0,0 +1,215 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// CommandType identifies a recordable player or unit command
+type CommandType string
+
+const (
+	CommandMovePlayer CommandType = "movePlayer"
+	CommandMoveUnit   CommandType = "moveUnit"
+	CommandQueueMove  CommandType = "queueMove"
+	CommandSpawnUnit  CommandType = "spawnUnit"
+	CommandAttack     CommandType = "attack"
+	CommandKillUnit   CommandType = "killUnit"
+	CommandRemoveUnit CommandType = "removeUnit"
+	CommandTeleportPlayer CommandType = "teleportPlayer"
+)
+
+// ErrUnknownCommand is returned for a command whose type is not one of the CommandType constants
+var ErrUnknownCommand = errors.New("unknown command type")
+
+// Command is a single recorded command; Tick is relative to the start of the recording
+type Command struct {
+	Tick     int         `json:"tick"`
+	Type     CommandType `json:"type"`
+	UnitID   string      `json:"unitId,omitempty"`
+	TargetID string      `json:"targetId,omitempty"`
+	UnitType int         `json:"unitType,omitempty"`
+	Name     string      `json:"name,omitempty"`
+	TileX    int         `json:"tileX"`
+	TileY    int         `json:"tileY"`
+	
+	// Spawn stats, so replays recreate loaded or scripted units exactly; zero keeps the defaults
+	Level      int `json:"level,omitempty"`
+	Experience int `json:"experience,omitempty"`
+	Health     int `json:"health,omitempty"`
+	MaxHealth  int `json:"maxHealth,omitempty"`
+	Team       int `json:"team,omitempty"`
+	Kills       int `json:"kills,omitempty"`
+	DamageDealt int `json:"damageDealt,omitempty"`
+	DamageTaken int `json:"damageTaken,omitempty"`
+	Rally       bool `json:"rally,omitempty"` // Spawned from the build queue, so it walks to the rally point once grown in
+}
+
+// The methods below are the entry points for commands from input, JS and replays
+// Each executes the command and, if a recording is active, logs it with the current tick
+
+// MovePlayer paths the player to a tile
+func (gs *GameState) MovePlayer(tileX, tileY int) {
+	gs.Player.MoveToTile(tileX, tileY)
+	gs.record(Command{Type: CommandMovePlayer, TileX: tileX, TileY: tileY})
+}
+
+// MoveUnit orders a unit to path to a tile
+func (gs *GameState) MoveUnit(unitID string, tileX, tileY int) error {
+	if err := gs.UnitManager.MoveUnit(unitID, tileX, tileY); err != nil {
+		return err
+	}
+	gs.record(Command{Type: CommandMoveUnit, UnitID: unitID, TileX: tileX, TileY: tileY})
+	return nil
+}
+
+// QueueMove adds a destination to a unit's move queue, to be walked after its current move
+func (gs *GameState) QueueMove(unitID string, tileX, tileY int) error {
+	if err := gs.UnitManager.QueueMove(unitID, tileX, tileY); err != nil {
+		return err
+	}
+	gs.record(Command{Type: CommandQueueMove, UnitID: unitID, TileX: tileX, TileY: tileY})
+	return nil
+}
+
+// SpawnUnit creates a fresh unit of the given type on a tile
+func (gs *GameState) SpawnUnit(unitType entities.UnitType, tileX, tileY int, name string) (*units.Unit, error) {
+	return gs.SpawnUnitFull(units.UnitSpec{Type: unitType, TileX: tileX, TileY: tileY, Name: name})
+}
+
+// SpawnUnitFull creates a unit with the level, health, experience and team given in a spec
+func (gs *GameState) SpawnUnitFull(spec units.UnitSpec) (*units.Unit, error) {
+	unit, err := gs.UnitManager.CreateUnitFull(spec)
+	if err != nil {
+		return nil, err
+	}
+	gs.RecordSpawn(unit)
+	return unit, nil
+}
+
+// RecordSpawn logs a unit created outside SpawnUnit (e.g. a random spawn) so replays recreate it exactly
+func (gs *GameState) RecordSpawn(unit *units.Unit) {
+	gs.record(Command{Type: CommandSpawnUnit, UnitType: int(unit.TypeID), Name: unit.Name, TileX: unit.TileX, TileY: unit.TileY,
+		Level: unit.Level, Experience: unit.Experience, Health: unit.CurrentStats.Health, MaxHealth: unit.MaxStats.Health, Team: unit.Team,
+		Kills: unit.Kills, DamageDealt: unit.DamageDealt, DamageTaken: unit.DamageTaken, Rally: unit.AwaitingRally()})
+}
+
+// Attack queues an attack from one unit on another
+func (gs *GameState) Attack(attackerID, targetID string) error {
+	if err := gs.UnitManager.QueueAttack(attackerID, targetID); err != nil {
+		return err
+	}
+	gs.record(Command{Type: CommandAttack, UnitID: attackerID, TargetID: targetID})
+	return nil
+}
+
+// KillUnit kills a unit outright, e.g. from the debug console
+func (gs *GameState) KillUnit(unitID string) error {
+	if err := gs.UnitManager.KillUnit(unitID); err != nil {
+		return err
+	}
+	gs.record(Command{Type: CommandKillUnit, UnitID: unitID})
+	return nil
+}
+
+// RemoveUnit deletes a unit from the game without it dying
+func (gs *GameState) RemoveUnit(unitID string) error {
+	if err := gs.UnitManager.RemoveUnit(unitID); err != nil {
+		return err
+	}
+	gs.record(Command{Type: CommandRemoveUnit, UnitID: unitID})
+	return nil
+}
+
+// TeleportPlayer places the player on a walkable tile instantly, bypassing pathfinding
+func (gs *GameState) TeleportPlayer(tileX, tileY int) error {
+	if !gs.TeleportPlayerToTile(tileX, tileY) {
+		return fmt.Errorf("%w at (%d, %d)", units.ErrNotWalkable, tileX, tileY)
+	}
+	gs.record(Command{Type: CommandTeleportPlayer, TileX: tileX, TileY: tileY})
+	return nil
+}
+
+// record logs a command if a recording is in progress
+func (gs *GameState) record(cmd Command) {
+	if gs.Recorder != nil {
+		gs.Recorder.Record(gs.Tick, cmd)
+	}
+}
+
+// Dispatch validates a command from an external caller and, if it passes, executes it
+// Nothing is queued or recorded for a command that fails validation
+func (gs *GameState) Dispatch(cmd Command) error {
+	if err := gs.ValidateCommand(cmd); err != nil {
+		return err
+	}
+	return gs.executeCommand(cmd)
+}
+
+// ValidateCommand rejects a command naming a missing unit or an out-of-bounds tile
+func (gs *GameState) ValidateCommand(cmd Command) error {
+	switch cmd.Type {
+	case CommandMovePlayer, CommandSpawnUnit, CommandTeleportPlayer:
+	case CommandMoveUnit, CommandQueueMove:
+		if gs.UnitManager.GetUnit(cmd.UnitID) == nil {
+			return fmt.Errorf("%w: %s", units.ErrNotFound, cmd.UnitID)
+		}
+	case CommandAttack:
+		for _, id := range []string{cmd.UnitID, cmd.TargetID} {
+			if gs.UnitManager.GetUnit(id) == nil {
+				return fmt.Errorf("%w: %s", units.ErrNotFound, id)
+			}
+		}
+		return nil
+	case CommandKillUnit, CommandRemoveUnit:
+		if gs.UnitManager.GetUnit(cmd.UnitID) == nil {
+			return fmt.Errorf("%w: %s", units.ErrNotFound, cmd.UnitID)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownCommand, cmd.Type)
+	}
+	
+	if cmd.TileX < 0 || cmd.TileX >= gs.GameMap.Width || cmd.TileY < 0 || cmd.TileY >= gs.GameMap.Height {
+		return fmt.Errorf("%w: (%d, %d)", units.ErrOutOfBounds, cmd.TileX, cmd.TileY)
+	}
+	return nil
+}
+
+// executeCommand re-issues a recorded command through the normal entry points
+func (gs *GameState) executeCommand(cmd Command) error {
+	switch cmd.Type {
+	case CommandMovePlayer:
+		gs.MovePlayer(cmd.TileX, cmd.TileY)
+		return nil
+	case CommandMoveUnit:
+		return gs.MoveUnit(cmd.UnitID, cmd.TileX, cmd.TileY)
+	case CommandQueueMove:
+		return gs.QueueMove(cmd.UnitID, cmd.TileX, cmd.TileY)
+	case CommandSpawnUnit:
+		unit, err := gs.UnitManager.CreateUnitFull(units.UnitSpec{Type: entities.UnitType(cmd.UnitType), TileX: cmd.TileX, TileY: cmd.TileY, Name: cmd.Name,
+			Level: cmd.Level, Experience: cmd.Experience, Health: cmd.Health, MaxHealth: cmd.MaxHealth, Team: cmd.Team,
+			Kills: cmd.Kills, DamageDealt: cmd.DamageDealt, DamageTaken: cmd.DamageTaken})
+		if err != nil {
+			return err
+		}
+		// Only units that rallied when recorded rally again, so the replay matches
+		if cmd.Rally {
+			gs.UnitManager.RallyOnceSpawned(unit.ID)
+		}
+		gs.RecordSpawn(unit)
+		return nil
+	case CommandAttack:
+		return gs.Attack(cmd.UnitID, cmd.TargetID)
+	case CommandKillUnit:
+		return gs.KillUnit(cmd.UnitID)
+	case CommandRemoveUnit:
+		return gs.RemoveUnit(cmd.UnitID)
+	case CommandTeleportPlayer:
+		return gs.TeleportPlayer(cmd.TileX, cmd.TileY)
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownCommand, cmd.Type)
+	}
+}
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// ErrConsoleSyntax is returned for a console line that can't be turned into a command
+var ErrConsoleSyntax = errors.New("invalid console command")
+
+// consoleUsage lists the console commands, keyed by verb
+var consoleUsage = map[string]string{
+	"spawn":    "spawn <type> <tileX> <tileY> [name]",
+	"kill":     "kill <unitId>",
+	"teleport": "teleport <tileX> <tileY>",
+	"goto":     "goto <tileX> <tileY>",
+	"move":     "move <unitId> <tileX> <tileY>",
+	"queue":    "queue <unitId> <tileX> <tileY>",
+	"attack":   "attack <unitId> <targetId>",
+}
+
+// ParseConsoleCommand turns a console line such as "spawn warrior 10 10" into a Command for Dispatch.
+// Arguments are separated by spaces; double quotes keep a name with spaces in one argument
+func ParseConsoleCommand(line string) (Command, error) {
+	tokens, err := tokenizeConsole(line)
+	if err != nil {
+		return Command{}, err
+	}
+	if len(tokens) == 0 {
+		return Command{}, fmt.Errorf("%w: empty line", ErrConsoleSyntax)
+	}
+
+	verb, args := strings.ToLower(tokens[0]), tokens[1:]
+	usage, exists := consoleUsage[verb]
+	if !exists {
+		return Command{}, fmt.Errorf("%w %q", ErrUnknownCommand, verb)
+	}
+	// Required arguments are the <...> placeholders in the usage, optional ones the [...]
+	minArgs := strings.Count(usage, "<")
+	maxArgs := minArgs + strings.Count(usage, "[")
+	if len(args) < minArgs || len(args) > maxArgs {
+		return Command{}, fmt.Errorf("%w: usage: %s", ErrConsoleSyntax, usage)
+	}
+
+	switch verb {
+	case "spawn":
+		unitType, err := consoleUnitType(args[0])
+		if err != nil {
+			return Command{}, err
+		}
+		cmd := Command{Type: CommandSpawnUnit, UnitType: int(unitType)}
+		if cmd.TileX, cmd.TileY, err = consoleTile(args[1], args[2]); err != nil {
+			return Command{}, err
+		}
+		if len(args) > 3 {
+			cmd.Name = args[3]
+		}
+		return cmd, nil
+	case "kill":
+		return Command{Type: CommandKillUnit, UnitID: args[0]}, nil
+	case "attack":
+		return Command{Type: CommandAttack, UnitID: args[0], TargetID: args[1]}, nil
+	case "teleport", "goto":
+		cmd := Command{Type: CommandTeleportPlayer}
+		if verb == "goto" {
+			cmd.Type = CommandMovePlayer
+		}
+		cmd.TileX, cmd.TileY, err = consoleTile(args[0], args[1])
+		return cmd, err
+	default: // move, queue
+		cmd := Command{Type: CommandMoveUnit, UnitID: args[0]}
+		if verb == "queue" {
+			cmd.Type = CommandQueueMove
+		}
+		cmd.TileX, cmd.TileY, err = consoleTile(args[1], args[2])
+		return cmd, err
+	}
+}
+
+// tokenizeConsole splits a line on whitespace, keeping double-quoted text together as one token
+func tokenizeConsole(line string) ([]string, error) {
+	tokens := make([]string, 0)
+	var current strings.Builder
+	inQuotes, inToken := false, false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			inToken = true
+		case (r == ' ' || r == '\t') && !inQuotes:
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("%w: unterminated quote", ErrConsoleSyntax)
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// consoleTile coerces a pair of arguments to tile coordinates
+func consoleTile(xArg, yArg string) (int, int, error) {
+	x, errX := strconv.Atoi(xArg)
+	y, errY := strconv.Atoi(yArg)
+	if errX != nil || errY != nil {
+		return 0, 0, fmt.Errorf("%w: tile (%s, %s) is not a pair of integers", ErrConsoleSyntax, xArg, yArg)
+	}
+	return x, y, nil
+}
+
+// consoleUnitType accepts a unit type by name ("warrior", any case) or by numeric ID
+func consoleUnitType(arg string) (entities.UnitType, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		return entities.UnitType(id), nil
+	}
+	for unitType, typeDef := range entities.UnitTypeDefinitions {
+		if strings.EqualFold(typeDef.Name, arg) {
+			return unitType, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", units.ErrUnknownUnitType, arg)
+}
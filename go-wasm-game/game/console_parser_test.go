@@ -0,0 +1,106 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that console lines are tokenized and their arguments coerced into dispatchable commands
+func TestParseConsoleCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want game.Command
+	}{
+		{"spawn by type name", "spawn warrior 10 10", game.Command{Type: game.CommandSpawnUnit, UnitType: int(entities.UnitWarrior), TileX: 10, TileY: 10}},
+		{"spawn by type ID with quoted name", `spawn 1 3 4 "Big Bob"`, game.Command{Type: game.CommandSpawnUnit, UnitType: 1, TileX: 3, TileY: 4, Name: "Big Bob"}},
+		{"verb and type in any case", "SPAWN Archer 0 2", game.Command{Type: game.CommandSpawnUnit, UnitType: int(entities.UnitArcher), TileX: 0, TileY: 2}},
+		{"kill", "kill unit_3", game.Command{Type: game.CommandKillUnit, UnitID: "unit_3"}},
+		{"teleport with extra spaces", "  teleport   50 \t50 ", game.Command{Type: game.CommandTeleportPlayer, TileX: 50, TileY: 50}},
+		{"goto", "goto 7 8", game.Command{Type: game.CommandMovePlayer, TileX: 7, TileY: 8}},
+		{"move", "move unit_1 4 -2", game.Command{Type: game.CommandMoveUnit, UnitID: "unit_1", TileX: 4, TileY: -2}},
+		{"queue", "queue unit_1 5 6", game.Command{Type: game.CommandQueueMove, UnitID: "unit_1", TileX: 5, TileY: 6}},
+		{"attack", "attack unit_1 unit_2", game.Command{Type: game.CommandAttack, UnitID: "unit_1", TargetID: "unit_2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := game.ParseConsoleCommand(tt.line)
+			if err != nil {
+				t.Fatalf("ParseConsoleCommand(%q) failed: %v", tt.line, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseConsoleCommand(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+// Test that malformed console lines are rejected with an error naming the problem
+func TestParseConsoleCommandRejectsBadLines(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want error
+	}{
+		{"empty line", "   ", game.ErrConsoleSyntax},
+		{"unknown verb", "fly 1 2", game.ErrUnknownCommand},
+		{"missing argument", "teleport 5", game.ErrConsoleSyntax},
+		{"too many arguments", "kill unit_1 unit_2", game.ErrConsoleSyntax},
+		{"non-integer tile", "teleport 5 five", game.ErrConsoleSyntax},
+		{"unknown unit type", "spawn dragon 1 1", units.ErrUnknownUnitType},
+		{"unterminated quote", `spawn warrior 1 1 "Bob`, game.ErrConsoleSyntax},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := game.ParseConsoleCommand(tt.line); !errors.Is(err, tt.want) {
+				t.Errorf("ParseConsoleCommand(%q) error = %v, want %v", tt.line, err, tt.want)
+			}
+		})
+	}
+}
+
+// Test that the console captures keys only while open and runs typed lines through the dispatcher
+func TestDebugConsoleRunsTypedCommands(t *testing.T) {
+	state := newTestState(10, 10)
+	unit, err := state.UnitManager.CreateUnit(entities.UnitWarrior, 2, 2, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+
+	console := &state.Console
+	if console.HandleKey("k", state.RunConsoleLine) {
+		t.Fatalf("HandleKey() consumed a key while closed")
+	}
+	console.HandleKey(game.ConsoleToggleKey, state.RunConsoleLine)
+	for _, key := range []string{"k", "i", "l", "l", "x", "Backspace", " ", "Shift"} {
+		console.HandleKey(key, state.RunConsoleLine)
+	}
+	for _, key := range unit.ID {
+		console.HandleKey(string(key), state.RunConsoleLine)
+	}
+	if want := "kill " + unit.ID; console.Input() != want {
+		t.Fatalf("Input() = %q, want %q", console.Input(), want)
+	}
+
+	console.HandleKey("Enter", state.RunConsoleLine)
+	if unit.IsAlive {
+		t.Errorf("unit alive after %q, want it killed", "kill "+unit.ID)
+	}
+	if out := console.Output(); len(out) != 2 || out[1] != "ok" || console.Input() != "" {
+		t.Errorf("Output() = %v, Input() = %q, want the line echoed, ok and a cleared input", out, console.Input())
+	}
+
+	// Dispatch errors are printed with their JS error code
+	if got := state.RunConsoleLine("kill unit_404"); !strings.HasPrefix(got, game.CodeNotFound) {
+		t.Errorf("RunConsoleLine(kill unit_404) = %q, want a %s error", got, game.CodeNotFound)
+	}
+}
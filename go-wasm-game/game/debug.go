@@ -0,0 +1,58 @@
+package game
+
+import (
+	"syscall/js"
+)
+
+// Debug helpers exposed to JavaScript
+
+// TeleportPlayerToTile instantly places the player centered on a tile, bypassing pathfinding
+// Returns false (and leaves the player untouched) if the tile is not walkable
+func (gs *GameState) TeleportPlayerToTile(tileX, tileY int) bool {
+	if !gs.GameMap.TileDefAt(tileX, tileY).Walkable {
+		return false
+	}
+	
+	worldX, worldY := gs.GameMap.GridToWorld(tileX, tileY)
+	width, height := gs.Player.MovableEntity.GetSize()
+	gs.Player.SetPosition(worldX-width/2, worldY-height/2)
+	return true
+}
+
+func toggleDebugTeleport(this js.Value, args []js.Value) interface{} {
+	State.DebugTeleport = !State.DebugTeleport
+	return jsSuccess(map[string]interface{}{
+		"enabled": State.DebugTeleport,
+	})
+}
+
+// getHeatmap returns unit occupancy counts as a flat row-major array with the grid dimensions
+func getHeatmap(this js.Value, args []js.Value) interface{} {
+	heatmap := State.UnitManager.ExportHeatmap()
+	counts := make([]interface{}, 0, State.GameMap.Width*State.GameMap.Height)
+	for _, row := range heatmap {
+		for _, count := range row {
+			counts = append(counts, count)
+		}
+	}
+	
+	return jsSuccess(map[string]interface{}{
+		"width":  State.GameMap.Width,
+		"height": State.GameMap.Height,
+		"counts": counts,
+	})
+}
+
+func resetHeatmap(this js.Value, args []js.Value) interface{} {
+	State.UnitManager.ResetHeatmap()
+	return jsSuccess(nil)
+}
+
+// initializeDebugInterface sets up JavaScript bindings for debug helpers
+func initializeDebugInterface() {
+	exposeFunc("toggleDebugTeleport", toggleDebugTeleport)
+	
+	exposeFunc("getHeatmap", getHeatmap)
+	
+	exposeFunc("resetHeatmap", resetHeatmap)
+}
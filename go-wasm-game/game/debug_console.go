@@ -0,0 +1,111 @@
+package game
+
+import (
+	"syscall/js"
+	"unicode/utf8"
+)
+
+const (
+	// ConsoleToggleKey opens and closes the debug console
+	ConsoleToggleKey = "`"
+	// ConsoleOutputLines is how many recent output lines the console keeps and draws
+	ConsoleOutputLines = 8
+)
+
+// DebugConsole is a one-line text console for typing debug commands such as "spawn warrior 10 10"
+type DebugConsole struct {
+	open   bool
+	input  string
+	output []string // Recent output, oldest first, at most ConsoleOutputLines
+}
+
+// Open reports whether the console is showing and capturing keystrokes
+func (c *DebugConsole) Open() bool {
+	return c.open
+}
+
+// Input returns the line typed so far
+func (c *DebugConsole) Input() string {
+	return c.input
+}
+
+// Output returns the recent output lines, oldest first
+func (c *DebugConsole) Output() []string {
+	return c.output
+}
+
+// HandleKey applies one keydown event's key and reports whether the console consumed it.
+// Enter runs the typed line through run and prints its result; keys are ignored while closed
+func (c *DebugConsole) HandleKey(key string, run func(line string) string) bool {
+	if key == ConsoleToggleKey {
+		c.open = !c.open
+		return true
+	}
+	if !c.open {
+		return false
+	}
+
+	switch key {
+	case "Enter":
+		if c.input != "" {
+			c.print("> " + c.input)
+			c.print(run(c.input))
+			c.input = ""
+		}
+	case "Escape":
+		c.open = false
+	case "Backspace":
+		if _, size := utf8.DecodeLastRuneInString(c.input); size > 0 {
+			c.input = c.input[:len(c.input)-size]
+		}
+	default:
+		// Named keys like "Shift" or "ArrowUp" are swallowed; only printable characters are typed
+		if utf8.RuneCountInString(key) == 1 {
+			c.input += key
+		}
+	}
+	return true
+}
+
+// print appends an output line, dropping the oldest past ConsoleOutputLines
+func (c *DebugConsole) print(line string) {
+	c.output = append(c.output, line)
+	if len(c.output) > ConsoleOutputLines {
+		c.output = c.output[len(c.output)-ConsoleOutputLines:]
+	}
+}
+
+// RunConsoleLine parses a console line and executes it through the command dispatcher,
+// returning the text to print: "ok" or the error with its JS error code
+func (gs *GameState) RunConsoleLine(line string) string {
+	cmd, err := ParseConsoleCommand(line)
+	if err == nil {
+		err = gs.Dispatch(cmd)
+	}
+	if err != nil {
+		return ErrorCode(err) + ": " + err.Error()
+	}
+	return "ok"
+}
+
+// RenderDebugConsole draws the recent output and the input line along the top of the canvas while the console is open
+func RenderDebugConsole(ctx js.Value, canvasWidth, canvasHeight float64) {
+	console := &State.Console
+	if !console.Open() {
+		return
+	}
+
+	const lineHeight, padding = 16.0, 6.0
+	height := float64(len(console.Output())+1)*lineHeight + 2*padding
+	ctx.Set("fillStyle", "rgba(0, 0, 0, 0.75)")
+	ctx.Call("fillRect", 0, 0, canvasWidth, height)
+
+	ctx.Set("font", "13px monospace")
+	ctx.Set("textBaseline", "top")
+	ctx.Set("fillStyle", "#cccccc")
+	for i, line := range console.Output() {
+		ctx.Call("fillText", line, padding, padding+float64(i)*lineHeight)
+	}
+	ctx.Set("fillStyle", "#ffffff")
+	ctx.Call("fillText", "] "+console.Input()+"_", padding, padding+float64(len(console.Output()))*lineHeight)
+}
@@ -0,0 +1,115 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"errors"
+	"syscall/js"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newTestMap builds an all-grass map without running terrain generation
+func newTestMap(width, height int) *world.Map {
+	m := &world.Map{
+		Width:    width,
+		Height:   height,
+		TileSize: 32.0,
+		Tiles:    make([][]world.TileType, height),
+	}
+	for y := range m.Tiles {
+		m.Tiles[y] = make([]world.TileType, width)
+	}
+	return m
+}
+
+// newTestState initializes the global game state around a small test map
+func newTestState(width, height int) *game.GameState {
+	gameMap := newTestMap(width, height)
+	player := entities.NewPlayer(0, 0, gameMap)
+	game.InitializeState(js.Undefined(), js.Undefined(), player, gameMap, units.NewUnitManager(gameMap), nil)
+	return game.State
+}
+
+// Test that teleporting centers the player on a walkable tile
+func TestTeleportPlayerToWalkableTile(t *testing.T) {
+	state := newTestState(10, 10)
+	state.Player.MoveToTile(9, 9)
+	
+	if !state.TeleportPlayerToTile(4, 6) {
+		t.Fatal("TeleportPlayerToTile(4, 6) = false, want true for grass")
+	}
+	
+	x, y := state.Player.GetPosition()
+	width, height := state.Player.MovableEntity.GetSize()
+	centerX, centerY := state.GameMap.GridToWorld(4, 6)
+	if x+width/2 != centerX || y+height/2 != centerY {
+		t.Errorf("player center = (%v, %v), want (%v, %v)", x+width/2, y+height/2, centerX, centerY)
+	}
+	if state.Player.IsMoving() {
+		t.Error("teleport should cancel any ongoing movement")
+	}
+}
+
+// Test that teleporting onto water is refused and leaves the player in place
+func TestTeleportPlayerRefusesWater(t *testing.T) {
+	state := newTestState(10, 10)
+	state.GameMap.SetTile(3, 3, world.TileWater)
+	state.Player.SetPosition(50, 60)
+	
+	if state.TeleportPlayerToTile(3, 3) {
+		t.Error("TeleportPlayerToTile(3, 3) = true, want false for water")
+	}
+	
+	x, y := state.Player.GetPosition()
+	if x != 50 || y != 60 {
+		t.Errorf("player moved to (%v, %v) after refused teleport, want (50, 60)", x, y)
+	}
+}
+
+// Test that teleporting onto a tile under a blocking overlay is refused
+func TestTeleportPlayerRefusesBlockingOverlay(t *testing.T) {
+	const tileBramble world.TileType = 99
+	world.TileDefinitions[tileBramble] = world.Tile{Name: "Bramble", Overlay: true, OverridesWalkability: true}
+	defer delete(world.TileDefinitions, tileBramble)
+	
+	state := newTestState(10, 10)
+	state.GameMap.SetOverlay(3, 3, tileBramble)
+	if state.TeleportPlayerToTile(3, 3) {
+		t.Error("TeleportPlayerToTile(3, 3) = true, want false under a blocking overlay")
+	}
+	if !state.TeleportPlayerToTile(4, 4) {
+		t.Error("TeleportPlayerToTile(4, 4) = false, want true for open grass")
+	}
+}
+
+// Test that placed units snap to the placement grid and the snapped tile is validated
+func TestPlaceUnitSnapsToGrid(t *testing.T) {
+	state := newTestState(10, 10)
+	state.GameMap.SetTile(4, 4, world.TileWater)
+	state.PlacementGrid = 2
+	
+	unit, err := state.PlaceUnit(entities.UnitWarrior, 5, 7)
+	if err != nil {
+		t.Fatalf("PlaceUnit(5, 7) error = %v", err)
+	}
+	if unit.TileX != 6 || unit.TileY != 8 {
+		t.Errorf("unit placed at (%d, %d), want snapped to (6, 8)", unit.TileX, unit.TileY)
+	}
+	
+	if _, err := state.PlaceUnit(entities.UnitWarrior, 6, 7); !errors.Is(err, units.ErrOccupied) {
+		t.Errorf("PlaceUnit onto the same snapped tile error = %v, want ErrOccupied", err)
+	}
+	if _, err := state.PlaceUnit(entities.UnitWarrior, 3, 4); !errors.Is(err, units.ErrNotWalkable) {
+		t.Errorf("PlaceUnit snapping onto water error = %v, want ErrNotWalkable", err)
+	}
+	
+	state.PlacementGrid = 1
+	if unit, err := state.PlaceUnit(entities.UnitWarrior, 3, 3); err != nil || unit.TileX != 3 || unit.TileY != 3 {
+		t.Errorf("PlaceUnit with grid 1 = %v, %v, want a unit on (3, 3)", unit, err)
+	}
+}
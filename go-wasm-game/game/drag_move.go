@@ -0,0 +1,60 @@
+package game
+
+import "time"
+
+// DragRepathInterval is the minimum time between repaths while dragging the player's destination
+const DragRepathInterval = 100 * time.Millisecond
+
+// DragMove tracks click-and-drag movement: while the mouse button is held the player keeps
+// re-targeting the tile under the cursor, repathing only when that tile changes and the throttle allows
+type DragMove struct {
+	dragging     bool
+	tileX, tileY int       // Tile the player was last sent to
+	lastIssued   time.Time // When the last move was issued
+	pressed      bool      // The press moved the player and the click it ends in hasn't been seen yet
+}
+
+// Dragging reports whether the mouse button is held down for a drag
+func (d *DragMove) Dragging() bool {
+	return d.dragging
+}
+
+// Start begins a drag on a tile; the press itself always moves the player, so it reports true
+func (d *DragMove) Start(tileX, tileY int, now time.Time) bool {
+	d.dragging = true
+	d.pressed = true
+	d.tileX, d.tileY = tileX, tileY
+	d.lastIssued = now
+	return true
+}
+
+// Move reports whether the cursor reaching a tile should re-issue the player's move
+// Only a different tile than the last one issued counts, and no more than once per DragRepathInterval;
+// a throttled tile is picked up by the next event once the interval has passed
+func (d *DragMove) Move(tileX, tileY int, now time.Time) bool {
+	if !d.dragging || (tileX == d.tileX && tileY == d.tileY) || now.Sub(d.lastIssued) < DragRepathInterval {
+		return false
+	}
+	d.tileX, d.tileY = tileX, tileY
+	d.lastIssued = now
+	return true
+}
+
+// Stop ends the drag when the mouse button is released; the click that follows still sees the press
+func (d *DragMove) Stop() {
+	d.dragging = false
+}
+
+// Cancel ends the drag without a click to follow, e.g. when the cursor leaves the canvas
+func (d *DragMove) Cancel() {
+	d.dragging = false
+	d.pressed = false
+}
+
+// ConsumePress reports whether a press already moved the player for the click now arriving, and forgets it,
+// so the click doesn't plan the same move a second time
+func (d *DragMove) ConsumePress() bool {
+	pressed := d.pressed
+	d.pressed = false
+	return pressed
+}
@@ -0,0 +1,87 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+)
+
+// Test that dragging re-issues moves only for new tiles, throttled to DragRepathInterval
+func TestDragMoveRepaths(t *testing.T) {
+	start := time.Unix(0, 0)
+	later := start.Add(game.DragRepathInterval)
+
+	tests := []struct {
+		name     string
+		tileX    int
+		at       time.Time
+		dragging bool
+		want     bool
+	}{
+		{"new tile after interval", 6, later, true, true},
+		{"same tile after interval", 5, later, true, false},
+		{"new tile within interval", 6, start.Add(game.DragRepathInterval / 2), true, false},
+		{"new tile after release", 6, later, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var drag game.DragMove
+			if !drag.Start(5, 5, start) {
+				t.Fatalf("Start() = false, want the press to move the player")
+			}
+			if !tt.dragging {
+				drag.Stop()
+			}
+			if got := drag.Move(tt.tileX, 5, tt.at); got != tt.want {
+				t.Errorf("Move(%d, 5) = %v, want %v", tt.tileX, got, tt.want)
+			}
+		})
+	}
+}
+
+// Test that a throttled tile change is picked up once the interval passes
+func TestDragMoveThrottleCatchesUp(t *testing.T) {
+	start := time.Unix(0, 0)
+	var drag game.DragMove
+	drag.Start(5, 5, start)
+
+	// Rapid events across tiles inside one interval issue nothing
+	for i := 1; i <= 5; i++ {
+		if drag.Move(5+i, 5, start.Add(time.Duration(i)*time.Millisecond)) {
+			t.Fatalf("Move() issued a repath %dms after the last one", i)
+		}
+	}
+	if !drag.Move(10, 5, start.Add(game.DragRepathInterval)) {
+		t.Errorf("Move() after the interval = false, want the latest tile issued")
+	}
+	if drag.Move(10, 5, start.Add(3*game.DragRepathInterval)) {
+		t.Errorf("Move() to the tile already issued = true, want false")
+	}
+}
+
+// Test that the click ending a press skips the move the press already made, once, unless the drag was cancelled
+func TestDragMoveConsumePress(t *testing.T) {
+	var drag game.DragMove
+	if drag.ConsumePress() {
+		t.Fatalf("ConsumePress() before any press = true, want clicks without a press to move the player")
+	}
+
+	drag.Start(5, 5, time.Unix(0, 0))
+	drag.Stop()
+	if !drag.ConsumePress() {
+		t.Errorf("ConsumePress() after press and release = false, want the click to skip its move")
+	}
+	if drag.ConsumePress() {
+		t.Errorf("ConsumePress() a second time = true, want the press forgotten")
+	}
+
+	drag.Start(5, 5, time.Unix(1, 0))
+	drag.Cancel()
+	if drag.Dragging() || drag.ConsumePress() {
+		t.Errorf("after Cancel dragging = %v, want the drag and its press dropped", drag.Dragging())
+	}
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Map editing functions exposed to JavaScript
+
+func paintBrush(this js.Value, args []js.Value) interface{} {
+	if len(args) < 4 {
+		return jsError(CodeInvalidArguments, "paintBrush requires x, y, radius, tileType")
+	}
+
+	x, y, radius := args[0].Int(), args[1].Int(), args[2].Int()
+	tileType := world.TileType(args[3].Int())
+	if tileDef, exists := world.TileDefinitions[tileType]; !exists || tileDef.Overlay || radius < 0 {
+		return jsError(CodeInvalidArguments, "paintBrush requires a known terrain tile type and non-negative radius")
+	}
+
+	var changed int
+	if len(args) > 4 && args[4].Bool() {
+		changed = State.GameMap.PaintBrushOverGrass(x, y, radius, tileType)
+	} else {
+		changed = State.GameMap.PaintBrush(x, y, radius, tileType)
+	}
+
+	return jsSuccess(map[string]interface{}{
+		"changed": changed,
+	})
+}
+
+func undoTileEdit(this js.Value, args []js.Value) interface{} {
+	if !State.GameMap.Undo() {
+		return jsError(CodeNotFound, "nothing to undo")
+	}
+	return jsSuccess(nil)
+}
+
+// initializeEditorInterface sets up JavaScript bindings for map editing
+func initializeEditorInterface() {
+	exposeFunc("paintBrush", paintBrush)
+	
+	exposeFunc("undoTileEdit", undoTileEdit)
+}
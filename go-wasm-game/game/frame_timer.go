@@ -0,0 +1,56 @@
+package game
+
+import "time"
+
+// MaxFrameDT caps the simulated time of one frame, so units don't jump across the map when a
+// backgrounded tab resumes or the browser stalls
+const MaxFrameDT = 100 * time.Millisecond
+
+// FrameTimer turns requestAnimationFrame timestamps into the time each frame should simulate
+type FrameTimer struct {
+	MaxDT   time.Duration // Longest time one frame may simulate; 0 uses MaxFrameDT
+	last    float64       // Previous timestamp in milliseconds
+	started bool
+}
+
+// Frame returns the time since the previous timestamp, clamped to [0, MaxDT]
+// The first frame has nothing to measure against and gets TickDuration
+func (ft *FrameTimer) Frame(timestampMillis float64) time.Duration {
+	if !ft.started {
+		ft.started = true
+		ft.last = timestampMillis
+		return TickDuration
+	}
+	dt := time.Duration((timestampMillis - ft.last) * float64(time.Millisecond))
+	ft.last = timestampMillis
+	if dt < 0 {
+		return 0
+	}
+	maxDT := ft.MaxDT
+	if maxDT <= 0 {
+		maxDT = MaxFrameDT
+	}
+	if dt > maxDT {
+		return maxDT
+	}
+	return dt
+}
+
+// SetMaxFrameDT sets the longest time one draw loop frame may simulate; 0 restores MaxFrameDT
+func (gs *GameState) SetMaxFrameDT(maxDT time.Duration) {
+	gs.frames.MaxDT = maxDT
+}
+
+// StepFrame advances the simulation by the time since the previous draw loop timestamp, in whole
+// TickDuration ticks through StepFor so the simulation runs the same at any frame rate, and returns
+// that time, e.g. for cosmetic effects that should keep pace with the drawn frames.
+// Time short of a whole tick carries over to the next frame, and while Paused every frame counts as no time passed
+// Timestamps are still tracked when paused, so unpausing doesn't replay the paused time as one long frame
+func (gs *GameState) StepFrame(timestampMillis float64) time.Duration {
+	dt := gs.frames.Frame(timestampMillis)
+	if gs.Paused {
+		return 0
+	}
+	gs.StepFor(dt)
+	return dt
+}
@@ -0,0 +1,107 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+)
+
+// Test that frame times come from consecutive timestamps, clamped to zero and MaxFrameDT
+func TestFrameTimerMeasuresTimestamps(t *testing.T) {
+	var timer game.FrameTimer
+	tests := []struct {
+		name      string
+		timestamp float64
+		want      time.Duration
+	}{
+		{"first frame", 1000, game.TickDuration},
+		{"regular frame", 1016, 16 * time.Millisecond},
+		{"slow frame", 1050, 34 * time.Millisecond},
+		{"stalled tab", 5000, game.MaxFrameDT},
+		{"clock went backwards", 4990, 0},
+		{"after going backwards", 5000, 10 * time.Millisecond},
+	}
+	
+	for _, tt := range tests {
+		if got := timer.Frame(tt.timestamp); got != tt.want {
+			t.Errorf("%s: Frame(%v) = %v, want %v", tt.name, tt.timestamp, got, tt.want)
+		}
+	}
+}
+
+// Test that StepFrame runs whole fixed ticks for the time passed, carrying over time short of a tick
+func TestStepFrameAdvancesTicks(t *testing.T) {
+	tests := []struct {
+		name       string
+		timestamps []float64
+		wantTicks  int
+	}{
+		{"first frame only", []float64{0}, 1},
+		{"repeated timestamp", []float64{0, 0, 0}, 1},
+		{"frames shorter than a tick add up", []float64{0, 10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, 7},
+		{"one long frame runs several ticks", []float64{0, 100}, 7},
+	}
+	
+	for _, tt := range tests {
+		state := newTestState(10, 10)
+		for _, timestamp := range tt.timestamps {
+			state.StepFrame(timestamp)
+		}
+		if state.Tick != tt.wantTicks {
+			t.Errorf("%s: Tick = %d, want %d", tt.name, state.Tick, tt.wantTicks)
+		}
+	}
+}
+
+// Test that a configured cap clamps long frames and 0 falls back to MaxFrameDT
+func TestFrameTimerMaxDT(t *testing.T) {
+	timer := game.FrameTimer{MaxDT: time.Second / 20}
+	timer.Frame(0)
+	if got := timer.Frame(2000); got != time.Second/20 {
+		t.Errorf("Frame after 2s with MaxDT 1/20s = %v, want %v", got, time.Second/20)
+	}
+	if got := timer.Frame(2030); got != 30*time.Millisecond {
+		t.Errorf("Frame under the cap = %v, want 30ms", got)
+	}
+	
+	timer.MaxDT = 0
+	if got := timer.Frame(5000); got != game.MaxFrameDT {
+		t.Errorf("Frame with MaxDT 0 = %v, want MaxFrameDT %v", got, game.MaxFrameDT)
+	}
+}
+
+// Test that paused frames simulate nothing and resuming continues from the latest timestamp
+func TestStepFramePaused(t *testing.T) {
+	state := newTestState(20, 20)
+	state.SetMaxFrameDT(time.Second / 20)
+	unit, err := state.SpawnUnit(entities.UnitWarrior, 2, 2, "")
+	if err != nil {
+		t.Fatalf("SpawnUnit failed: %v", err)
+	}
+	state.MoveUnit(unit.ID, 15, 2)
+	state.StepFrame(0)
+	
+	state.Paused = true
+	x, y := unit.GetPosition()
+	tick := state.Tick
+	for _, timestamp := range []float64{16, 5000, 5016} {
+		if dt := state.StepFrame(timestamp); dt != 0 {
+			t.Errorf("paused StepFrame(%v) = %v, want 0", timestamp, dt)
+		}
+	}
+	if nx, ny := unit.GetPosition(); nx != x || ny != y || state.Tick != tick {
+		t.Errorf("paused frames moved the unit to (%v, %v) and ticked %d times, want no change", nx, ny, state.Tick-tick)
+	}
+	
+	state.Paused = false
+	if dt := state.StepFrame(5050); dt != 34*time.Millisecond {
+		t.Errorf("first frame after resuming = %v, want 34ms since the last paused frame", dt)
+	}
+	if nx, _ := unit.GetPosition(); nx <= x {
+		t.Errorf("unit did not move after resuming")
+	}
+}
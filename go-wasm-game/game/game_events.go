@@ -1,19 +1,18 @@
 package game
 
-import "syscall/js"
+import (
+	"syscall/js"
+	"time"
+)
 
 // Game event handlers
 
-var recenterFunc js.Func
-var clickFunc js.Func
-
 func recenterSquare(this js.Value, args []js.Value) interface{} {
 	// Update canvas dimensions
 	State.UpdateCanvasDimensions()
 	
 	// Center the player box in the world map
-	mapWorldWidth := float64(State.GameMap.Width) * State.GameMap.TileSize
-	mapWorldHeight := float64(State.GameMap.Height) * State.GameMap.TileSize
+	mapWorldWidth, mapWorldHeight := State.GameMap.WorldSize()
 	
 	centerX := (mapWorldWidth - State.Player.Width) / 2
 	centerY := (mapWorldHeight - State.Player.Height) / 2
@@ -26,40 +25,120 @@ func recenterSquare(this js.Value, args []js.Value) interface{} {
 	return nil
 }
 
-func click(this js.Value, args []js.Value) interface{} {
-	// Get mouse click coordinates relative to canvas
-	event := args[0]
+// eventCanvasPoint returns a mouse event's position relative to the canvas
+func eventCanvasPoint(event js.Value) (float64, float64) {
 	canvasRect := State.Canvas.Call("getBoundingClientRect")
+	return event.Get("clientX").Float() - canvasRect.Get("left").Float(), event.Get("clientY").Float() - canvasRect.Get("top").Float()
+}
+
+// eventTile converts a mouse event to the map tile under the cursor, reporting false outside the map
+func eventTile(event js.Value) (int, int, bool) {
+	mouseX, mouseY := eventCanvasPoint(event)
 	
-	mouseX := event.Get("clientX").Float() - canvasRect.Get("left").Float()
-	mouseY := event.Get("clientY").Float() - canvasRect.Get("top").Float()
+	// Convert screen coordinates to world, then tile coordinates
+	tileX, tileY := State.GameMap.WorldToGrid(mouseX+State.CameraX, mouseY+State.CameraY)
+	inBounds := tileX >= 0 && tileX < State.GameMap.Width && tileY >= 0 && tileY < State.GameMap.Height
+	return tileX, tileY, inBounds
+}
+
+// previewing reports whether an event belongs to a shift-hover move preview rather than player movement
+func previewing(event js.Value) bool {
+	return event.Get("shiftKey").Truthy() && State.Preview.Active()
+}
+
+func click(this js.Value, args []js.Value) interface{} {
+	// Placement clicks are handled by HandleGameClick, which knows the UI area
+	if State.PlacingUnits {
+		return nil
+	}
 	
-	// Convert screen coordinates to world coordinates
-	worldX := mouseX + State.CameraX
-	worldY := mouseY + State.CameraY
+	// A shift-click confirms the previewed move for the selected unit
+	if previewing(args[0]) {
+		State.CommitPreview()
+		return nil
+	}
 	
-	// Convert world coordinates to tile coordinates
-	tileX, tileY := State.GameMap.WorldToGrid(worldX, worldY)
+	// The press this click ends already sent the player (see mouseDown); only clicks without one move it here
+	if State.Drag.ConsumePress() {
+		return nil
+	}
 	
-	// Check if the tile is within map bounds
-	if tileX >= 0 && tileX < State.GameMap.Width && tileY >= 0 && tileY < State.GameMap.Height {
+	if tileX, tileY, ok := eventTile(args[0]); ok {
 		// Move player to the clicked tile
-		State.Player.MoveToTile(tileX, tileY)
+		State.MovePlayer(tileX, tileY)
 	}
 	
 	return nil
 }
 
+// mouseDown starts a drag, sending the player to the tile under the cursor
+func mouseDown(this js.Value, args []js.Value) interface{} {
+	if State.PlacingUnits || previewing(args[0]) {
+		return nil
+	}
+	if tileX, tileY, ok := eventTile(args[0]); ok && State.Drag.Start(tileX, tileY, time.Now()) {
+		State.MovePlayer(tileX, tileY)
+	}
+	return nil
+}
+
+// mouseMove previews the selected unit's path while Shift is held, and otherwise shows its attack range
+// against a hovered enemy and repaints the player's destination while dragging, throttled by DragMove
+func mouseMove(this js.Value, args []js.Value) interface{} {
+	event := args[0]
+	tileX, tileY, ok := eventTile(event)
+	if event.Get("shiftKey").Truthy() && ok {
+		State.AttackPreview.Clear()
+		State.PreviewMove(tileX, tileY)
+		return nil
+	}
+	State.Preview.Clear()
+	State.PreviewAttack(tileX, tileY)
+	
+	if ok && State.Drag.Dragging() && State.Drag.Move(tileX, tileY, time.Now()) {
+		State.MovePlayer(tileX, tileY)
+	}
+	return nil
+}
+
+// mouseUp ends a drag; the player keeps walking to the last tile it was sent to
+func mouseUp(this js.Value, args []js.Value) interface{} {
+	State.Drag.Stop()
+	return nil
+}
+
+// mouseLeave ends a drag whose button comes up off the canvas, where no click follows
+func mouseLeave(this js.Value, args []js.Value) interface{} {
+	State.Drag.Cancel()
+	return nil
+}
+
+// keyDown feeds keystrokes to the debug console, keeping the page from also handling the ones it uses
+func keyDown(this js.Value, args []js.Value) interface{} {
+	event := args[0]
+	if State.Console.HandleKey(event.Get("key").String(), State.RunConsoleLine) {
+		event.Call("preventDefault")
+	}
+	return nil
+}
+
 // initializeEventHandlers sets up game event listeners and JS function bindings
 func InitializeEventHandlers(canvas js.Value) {
-	// Add event listeners - only mouse click, no keyboard
-	canvas.Call("addEventListener", "click", js.FuncOf(click))
+	// Add event listeners - mouse click and drag, wheel zoom, plus the keyboard for the debug console
+	addEventListener(canvas, "click", click)
+	addEventListener(canvas, "mousedown", mouseDown)
+	addEventListener(canvas, "mousemove", mouseMove)
+	addEventListener(canvas, "mouseup", mouseUp)
+	addEventListener(canvas, "mouseleave", mouseLeave)
+	addEventListener(canvas, "wheel", wheel)
+	addEventListener(js.Global().Get("document"), "keydown", keyDown)
 
 	// Expose recenter function to JavaScript
-	recenterFunc = js.FuncOf(recenterSquare)
-	js.Global().Set("recenterSquare", recenterFunc)
+	exposeFunc("recenterSquare", recenterSquare)
 	
 	// Expose click function to JavaScript (for potential external use)
-	clickFunc = js.FuncOf(click)
-	js.Global().Set("gameClick", clickFunc)
+	exposeFunc("gameClick", click)
+	
+	// Expose shutdown so the page can tear the game down and release its callbacks
+	exposeFunc("destroyGame", destroyGame)
 }
\ No newline at end of file
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"errors"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Stable error codes returned to JavaScript callers in the "code" field
+const (
+	CodeInvalidArguments = "INVALID_ARGUMENTS"
+	CodeOutOfBounds      = "OUT_OF_BOUNDS"
+	CodeOccupied         = "OCCUPIED"
+	CodeNotWalkable      = "NOT_WALKABLE"
+	CodeNotFound         = "NOT_FOUND"
+	CodeUnitDead         = "UNIT_DEAD"
+	CodeUnknownUnitType  = "UNKNOWN_UNIT_TYPE"
+	CodeNotBuildable     = "NOT_BUILDABLE"
+	CodeCarried          = "CARRIED"
+	CodeTransportFull    = "TRANSPORT_FULL"
+	CodeNotAdjacent      = "NOT_ADJACENT"
+	CodeQueueFull        = "QUEUE_FULL"
+	CodeAbilityUnavailable = "ABILITY_UNAVAILABLE"
+	CodeNotStarted       = "NOT_STARTED"
+	CodeInternal         = "INTERNAL"
+)
+
+// ErrorCode maps an error from the game systems to its stable JS error code
+func ErrorCode(err error) string {
+	switch {
+	case errors.Is(err, units.ErrOutOfBounds):
+		return CodeOutOfBounds
+	case errors.Is(err, units.ErrOccupied):
+		return CodeOccupied
+	case errors.Is(err, units.ErrNotWalkable):
+		return CodeNotWalkable
+	case errors.Is(err, units.ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, units.ErrUnitDead):
+		return CodeUnitDead
+	case errors.Is(err, units.ErrUnknownUnitType):
+		return CodeUnknownUnitType
+	case errors.Is(err, units.ErrNotBuildable):
+		return CodeNotBuildable
+	case errors.Is(err, units.ErrCarried):
+		return CodeCarried
+	case errors.Is(err, units.ErrTransportFull):
+		return CodeTransportFull
+	case errors.Is(err, units.ErrNotAdjacent):
+		return CodeNotAdjacent
+	case errors.Is(err, units.ErrQueueFull):
+		return CodeQueueFull
+	case errors.Is(err, units.ErrAbilityUnavailable):
+		return CodeAbilityUnavailable
+	case errors.Is(err, ErrUnknownCommand), errors.Is(err, ErrConsoleSyntax), errors.Is(err, units.ErrInvalidSpec):
+		return CodeInvalidArguments
+	default:
+		return CodeInternal
+	}
+}
+
+// jsError creates a standardized error response with a stable error code
+func jsError(code, message string) interface{} {
+	return map[string]interface{}{
+		"success": false,
+		"code":    code,
+		"error":   message,
+	}
+}
+
+// jsSuccess creates a standardized success response
+func jsSuccess(data interface{}) interface{} {
+	if data == nil {
+		return map[string]interface{}{"success": true}
+	}
+	return map[string]interface{}{
+		"success": true,
+		"data":    data,
+	}
+}
+
+// jsErrorFrom creates a standardized error response from a Go error
+func jsErrorFrom(err error) interface{} {
+	return jsError(ErrorCode(err), err.Error())
+}
@@ -3,50 +3,32 @@ package game
 import (
 	"syscall/js"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
 )
 
 // JavaScript interface functions for unit management
 
-var createUnitFunc js.Func
-var getUnitsFunc js.Func
-var moveUnitFunc js.Func
-var removeUnitFunc js.Func
-
-// jsError creates a standardized error response
-func jsError(message string) interface{} {
-	return map[string]interface{}{
-		"success": false,
-		"error":   message,
-	}
-}
-
-// jsSuccess creates a standardized success response
-func jsSuccess(data interface{}) interface{} {
-	if data == nil {
-		return map[string]interface{}{"success": true}
-	}
-	return map[string]interface{}{
-		"success": true,
-		"data":    data,
-	}
-}
-
+// createUnit spawns a unit: createUnit(unitType, tileX, tileY, name?, options?)
+// options may set {level, experience, health, maxHealth, team, kills, damageDealt, damageTaken}, e.g. when restoring a saved unit
 func createUnit(this js.Value, args []js.Value) interface{} {
 	if len(args) < 3 {
-		return jsError("createUnit requires unitType, tileX, tileY")
+		return jsError(CodeInvalidArguments, "createUnit requires unitType, tileX, tileY")
 	}
 
-	unitType := entities.UnitType(args[0].Int())
-	tileX := args[1].Int()
-	tileY := args[2].Int()
-	name := ""
-	if len(args) > 3 {
-		name = args[3].String()
+	spec := units.UnitSpec{Type: entities.UnitType(args[0].Int()), TileX: args[1].Int(), TileY: args[2].Int()}
+	if len(args) > 3 && args[3].Type() == js.TypeString {
+		spec.Name = args[3].String()
+	}
+	if len(args) > 4 && args[4].Type() == js.TypeObject {
+		options := commandFromJS(args[4])
+		spec.Level, spec.Experience, spec.Team = options.Level, options.Experience, options.Team
+		spec.Health, spec.MaxHealth = options.Health, options.MaxHealth
+		spec.Kills, spec.DamageDealt, spec.DamageTaken = options.Kills, options.DamageDealt, options.DamageTaken
 	}
 
-	unit, err := State.UnitManager.CreateUnit(unitType, tileX, tileY, name)
+	unit, err := State.SpawnUnitFull(spec)
 	if err != nil {
-		return jsError(err.Error())
+		return jsErrorFrom(err)
 	}
 
 	return jsSuccess(map[string]interface{}{
@@ -56,12 +38,14 @@ func createUnit(this js.Value, args []js.Value) interface{} {
 		"tileX":  unit.TileX,
 		"tileY":  unit.TileY,
 		"health": unit.CurrentStats.Health,
+		"maxHealth": unit.MaxStats.Health,
 		"level":  unit.Level,
+		"team":   unit.Team,
 	})
 }
 
 func getUnits(this js.Value, args []js.Value) interface{} {
-	units := State.UnitManager.GetAllUnits()
+	units := State.UnitManager.OrderedUnits()
 	result := make([]interface{}, 0, len(units))
 
 	for _, unit := range units {
@@ -78,7 +62,15 @@ func getUnits(this js.Value, args []js.Value) interface{} {
 			"health": unit.CurrentStats.Health,
 			"maxHealth": unit.MaxStats.Health,
 			"level":  unit.Level,
+			"experience": unit.Experience,
+			"kills":  unit.Kills,
+			"damageDealt": unit.DamageDealt,
+			"damageTaken": unit.DamageTaken,
 			"status": unit.Status,
+			"team":   unit.Team,
+			"stance": unit.Stance.String(),
+			"carriedBy": unit.CarriedBy(),
+			"progress": unit.PathProgress(),
 		})
 	}
 
@@ -87,12 +79,12 @@ func getUnits(this js.Value, args []js.Value) interface{} {
 
 func moveUnit(this js.Value, args []js.Value) interface{} {
 	if len(args) < 3 {
-		return jsError("moveUnit requires unitId, tileX, tileY")
+		return jsError(CodeInvalidArguments, "moveUnit requires unitId, tileX, tileY")
 	}
 
-	err := State.UnitManager.MoveUnit(args[0].String(), args[1].Int(), args[2].Int())
+	err := State.MoveUnit(args[0].String(), args[1].Int(), args[2].Int())
 	if err != nil {
-		return jsError(err.Error())
+		return jsErrorFrom(err)
 	}
 
 	return jsSuccess(nil)
@@ -100,29 +92,103 @@ func moveUnit(this js.Value, args []js.Value) interface{} {
 
 func removeUnit(this js.Value, args []js.Value) interface{} {
 	if len(args) < 1 {
-		return jsError("removeUnit requires unitId")
+		return jsError(CodeInvalidArguments, "removeUnit requires unitId")
 	}
 
-	err := State.UnitManager.RemoveUnit(args[0].String())
+	err := State.RemoveUnit(args[0].String())
 	if err != nil {
-		return jsError(err.Error())
+		return jsErrorFrom(err)
 	}
 
 	return jsSuccess(nil)
 }
 
+func setLayerVisible(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return jsError(CodeInvalidArguments, "setLayerVisible requires name, visible")
+	}
+
+	if !State.GameMap.Layers.SetLayerVisibility(args[0].String(), args[1].Bool()) {
+		return jsError(CodeNotFound, "layer not found: "+args[0].String())
+	}
+
+	return jsSuccess(nil)
+}
+
+func setLayerOpacity(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return jsError(CodeInvalidArguments, "setLayerOpacity requires name, opacity")
+	}
+
+	if !State.GameMap.Layers.SetLayerOpacity(args[0].String(), args[1].Float()) {
+		return jsError(CodeNotFound, "layer not found: "+args[0].String())
+	}
+
+	return jsSuccess(nil)
+}
+
+// getWalkableGrid returns a flat row-major 0/1 walkability grid, optionally treating unit-occupied tiles as blocked
+func getWalkableGrid(this js.Value, args []js.Value) interface{} {
+	includeOccupancy := len(args) > 0 && args[0].Truthy()
+	grid := State.GameMap.WalkableGrid()
+	cells := make([]interface{}, len(grid))
+	for i, walkable := range grid {
+		if includeOccupancy && walkable == 1 && State.UnitManager.IsPositionOccupied(i%State.GameMap.Width, i/State.GameMap.Width) {
+			walkable = 0
+		}
+		cells[i] = int(walkable)
+	}
+
+	return jsSuccess(map[string]interface{}{
+		"width":  State.GameMap.Width,
+		"height": State.GameMap.Height,
+		"cells":  cells,
+	})
+}
+
 // initializeJSInterface sets up JavaScript function bindings
 func InitializeJSInterface() {
 	// Expose unit management functions to JavaScript
-	createUnitFunc = js.FuncOf(createUnit)
-	js.Global().Set("createUnit", createUnitFunc)
+	exposeFunc("createUnit", createUnit)
+	
+	exposeFunc("getUnits", getUnits)
+	initializeUnitDetailInterface()
+	
+	exposeFunc("moveUnit", moveUnit)
+	
+	exposeFunc("removeUnit", removeUnit)
+	
+	exposeFunc("setGlobalSeed", setGlobalSeed)
+	exposeFunc("stepGame", stepGame)
+	exposeFunc("setPaused", setPaused)
+	
+	// Expose unit event callbacks, the command dispatcher and command replays
+	initializeUnitCallbackInterface()
+	initializeCommandInterface()
+	initializeReplayInterface()
+	
+	// Expose player scripting, camera follow and zoom, and saved views
+	exposeFunc("movePlayerToTile", movePlayerToTile)
+	initializeCameraInterface()
+	exposeFunc("saveView", saveView)
+	exposeFunc("restoreView", restoreView)
+	
+	// Expose layer controls to JavaScript
+	exposeFunc("setLayerVisible", setLayerVisible)
 	
-	getUnitsFunc = js.FuncOf(getUnits)
-	js.Global().Set("getUnits", getUnitsFunc)
+	exposeFunc("setLayerOpacity", setLayerOpacity)
 	
-	moveUnitFunc = js.FuncOf(moveUnit)
-	js.Global().Set("moveUnit", moveUnitFunc)
+	// Expose the walkability grid for external pathfinding and debug overlays
+	exposeFunc("getWalkableGrid", getWalkableGrid)
 	
-	removeUnitFunc = js.FuncOf(removeUnit)
-	js.Global().Set("removeUnit", removeUnitFunc)
+	// Expose unit placement, area effects, teams, stances, transports, construction orders and the rally point, map info/editing and debug helpers
+	initializePlacementInterface()
+	initializeAreaInterface()
+	initializeTeamInterface()
+	initializeStanceInterface()
+	initializeTransportInterface()
+	initializeBuildInterface()
+	initializeEditorInterface()
+	initializeMapInterface()
+	initializeDebugInterface()
 }
\ No newline at end of file
@@ -0,0 +1,319 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"syscall/js"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+func noopRender(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {}
+
+// Test that toggling layer visibility and opacity through the JS bindings updates the layer
+func TestLayerControlsViaJS(t *testing.T) {
+	state := newTestState(10, 10)
+	state.GameMap.Layers = world.NewLayers()
+	state.GameMap.Layers.AddLayer("terrain", 0, true, noopRender)
+	game.InitializeJSInterface()
+	
+	result := js.Global().Call("setLayerVisible", "terrain", false)
+	if !result.Get("success").Bool() {
+		t.Fatalf("setLayerVisible failed: %v", result.Get("error"))
+	}
+	if state.GameMap.Layers.GetLayer("terrain").Visible {
+		t.Error("layer should be hidden after setLayerVisible(false)")
+	}
+	
+	result = js.Global().Call("setLayerOpacity", "terrain", 1.7)
+	if !result.Get("success").Bool() {
+		t.Fatalf("setLayerOpacity failed: %v", result.Get("error"))
+	}
+	if opacity := state.GameMap.Layers.GetLayer("terrain").Opacity; opacity != 1.0 {
+		t.Errorf("Opacity = %v, want clamped 1.0", opacity)
+	}
+	
+	result = js.Global().Call("setLayerVisible", "missing", true)
+	if result.Get("success").Bool() {
+		t.Error("setLayerVisible should fail for an unknown layer")
+	}
+}
+
+// Test that each unit-management failure path reports the expected error code
+func TestJSErrorCodes(t *testing.T) {
+	state := newTestState(10, 10)
+	state.GameMap.SetTile(5, 5, world.TileWater)
+	state.GameMap.Layers = world.NewLayers()
+	game.InitializeJSInterface()
+	
+	if result := js.Global().Call("createUnit", 0, 2, 2); !result.Get("success").Bool() {
+		t.Fatalf("setup createUnit failed: %v", result.Get("error"))
+	}
+	
+	tests := []struct {
+		name     string
+		function string
+		args     []interface{}
+		code     string
+	}{
+		{"Missing arguments", "createUnit", []interface{}{0}, game.CodeInvalidArguments},
+		{"Out of bounds", "createUnit", []interface{}{0, 50, 2}, game.CodeOutOfBounds},
+		{"Occupied tile", "createUnit", []interface{}{0, 2, 2}, game.CodeOccupied},
+		{"Water tile", "createUnit", []interface{}{0, 5, 5}, game.CodeNotWalkable},
+		{"Unknown unit type", "createUnit", []interface{}{99, 3, 3}, game.CodeUnknownUnitType},
+		{"Move missing unit", "moveUnit", []interface{}{"unit_404", 1, 1}, game.CodeNotFound},
+		{"Remove missing unit", "removeUnit", []interface{}{"unit_404"}, game.CodeNotFound},
+		{"Player move out of bounds", "movePlayerToTile", []interface{}{-1, 3}, game.CodeOutOfBounds},
+		{"Player move past map edge", "movePlayerToTile", []interface{}{3, 10}, game.CodeOutOfBounds},
+		{"Player move missing arguments", "movePlayerToTile", []interface{}{3}, game.CodeInvalidArguments},
+		{"Build on grass bridge", "orderBuild", []interface{}{"unit_1", 3, 3, int(world.TileBridge)}, game.CodeNotBuildable},
+		{"Missing layer", "setLayerVisible", []interface{}{"missing", true}, game.CodeNotFound},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := js.Global().Call(tt.function, tt.args...)
+			if result.Get("success").Bool() {
+				t.Fatalf("%s(%v) succeeded, want failure", tt.function, tt.args)
+			}
+			if code := result.Get("code").String(); code != tt.code {
+				t.Errorf("code = %q, want %q", code, tt.code)
+			}
+			if result.Get("error").String() == "" {
+				t.Error("error message should be kept alongside the code")
+			}
+		})
+	}
+}
+
+// Test that a valid movePlayerToTile call starts pathfinding movement toward the tile
+func TestMovePlayerToTileViaJS(t *testing.T) {
+	state := newTestState(10, 10)
+	state.GameMap.Layers = world.NewLayers()
+	game.InitializeJSInterface()
+	
+	result := js.Global().Call("movePlayerToTile", 6, 4)
+	if !result.Get("success").Bool() {
+		t.Fatalf("movePlayerToTile failed: %v", result.Get("error"))
+	}
+	if !result.Get("data").Get("moving").Bool() {
+		t.Error("response should report the player as moving")
+	}
+	
+	path := state.Player.GetPath()
+	if !state.Player.IsMoving() || len(path) == 0 {
+		t.Fatal("player should be moving along a path after movePlayerToTile")
+	}
+	last := path[len(path)-1]
+	if last.X != 6 || last.Y != 4 {
+		t.Errorf("path ends at (%d, %d), want (6, 4)", last.X, last.Y)
+	}
+}
+
+// Test that getUnits reports each unit's path progress, from 0 when ordered to 1 once it stops
+func TestGetUnitsReportsProgress(t *testing.T) {
+	state := newTestState(10, 3)
+	game.InitializeJSInterface()
+	unit, err := state.SpawnUnit(0, 0, 1, "")
+	if err != nil {
+		t.Fatalf("SpawnUnit failed: %v", err)
+	}
+	progressOf := func() float64 {
+		return js.Global().Call("getUnits").Index(0).Get("progress").Float()
+	}
+	if got := progressOf(); got != 1 {
+		t.Errorf("idle progress = %v, want 1", got)
+	}
+	
+	if err := state.MoveUnit(unit.ID, 9, 1); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	for frame := 0; frame < 20; frame++ {
+		state.Step()
+	}
+	if got := progressOf(); got <= 0 || got >= 1 || got != unit.PathProgress() {
+		t.Errorf("mid-path progress = %v, want %v strictly between 0 and 1", got, unit.PathProgress())
+	}
+}
+
+// Test that the tile legend lists every tile type, in order, with its display properties
+func TestTileLegendSerialization(t *testing.T) {
+	state := newTestState(12, 8)
+	state.GameMap.Layers = world.NewLayers()
+	game.InitializeJSInterface()
+	
+	result := js.Global().Call("getTileLegend")
+	if !result.Get("success").Bool() {
+		t.Fatalf("getTileLegend failed: %v", result.Get("error"))
+	}
+	legend := result.Get("data")
+	if legend.Length() != len(world.TileDefinitions) {
+		t.Fatalf("legend has %d entries, want %d", legend.Length(), len(world.TileDefinitions))
+	}
+	
+	for i := 0; i < legend.Length(); i++ {
+		entry := legend.Index(i)
+		tileType := world.TileType(entry.Get("type").Int())
+		tile, exists := world.TileDefinitions[tileType]
+		if !exists {
+			t.Fatalf("legend entry %d has unknown type %d", i, tileType)
+		}
+		if i > 0 && entry.Get("type").Int() <= legend.Index(i-1).Get("type").Int() {
+			t.Error("legend entries should be ordered by tile type")
+		}
+		if entry.Get("name").String() != tile.Name || tile.Name == "" {
+			t.Errorf("type %d name = %q, want non-empty %q", tileType, entry.Get("name").String(), tile.Name)
+		}
+		if entry.Get("color").String() != tile.Color ||
+			entry.Get("walkable").Bool() != tile.Walkable ||
+			entry.Get("walkSpeed").Float() != tile.WalkSpeed {
+			t.Errorf("type %d legend entry does not match its tile definition", tileType)
+		}
+	}
+}
+
+// Test that map info reports the tile dimensions alongside the tile size, world size and wrap mode
+func TestGetMapInfoViaJS(t *testing.T) {
+	state := newTestState(12, 8)
+	state.GameMap.Layers = world.NewLayers()
+	game.InitializeJSInterface()
+	
+	result := js.Global().Call("getMapInfo")
+	if !result.Get("success").Bool() {
+		t.Fatalf("getMapInfo failed: %v", result.Get("error"))
+	}
+	info := result.Get("data")
+	if info.Get("width").Int() != 12 || info.Get("height").Int() != 8 {
+		t.Errorf("getMapInfo size = %dx%d, want 12x8", info.Get("width").Int(), info.Get("height").Int())
+	}
+	if info.Get("tileSize").Float() != 32 {
+		t.Errorf("getMapInfo tileSize = %v, want 32", info.Get("tileSize").Float())
+	}
+	if info.Get("worldWidth").Float() != 12*32 || info.Get("worldHeight").Float() != 8*32 {
+		t.Errorf("getMapInfo world size = %vx%v, want 384x256", info.Get("worldWidth").Float(), info.Get("worldHeight").Float())
+	}
+	if info.Get("tileWidth").Float() != 32 || info.Get("tileHeight").Float() != 32 {
+		t.Errorf("getMapInfo tile dimensions = %vx%v, want 32x32", info.Get("tileWidth").Float(), info.Get("tileHeight").Float())
+	}
+	if info.Get("wrap").Bool() {
+		t.Error("getMapInfo wrap = true, want false for a bounded map")
+	}
+	
+	// Non-square tiles report their own width and height
+	state.GameMap.TileWidth, state.GameMap.TileHeight = 32, 16
+	info = js.Global().Call("getMapInfo").Get("data")
+	if info.Get("tileWidth").Float() != 32 || info.Get("tileHeight").Float() != 16 {
+		t.Errorf("getMapInfo tile dimensions = %vx%v, want 32x16", info.Get("tileWidth").Float(), info.Get("tileHeight").Float())
+	}
+	if info.Get("worldWidth").Float() != 12*32 || info.Get("worldHeight").Float() != 8*16 {
+		t.Errorf("getMapInfo world size = %vx%v, want 384x128", info.Get("worldWidth").Float(), info.Get("worldHeight").Float())
+	}
+}
+
+// Test that the walkable grid is flat row-major and can merge in unit occupancy
+func TestGetWalkableGridViaJS(t *testing.T) {
+	state := newTestState(3, 2)
+	state.GameMap.SetTile(1, 0, world.TileWater)
+	state.GameMap.Layers = world.NewLayers()
+	game.InitializeJSInterface()
+	if result := js.Global().Call("createUnit", 0, 2, 1); !result.Get("success").Bool() {
+		t.Fatalf("setup createUnit failed: %v", result.Get("error"))
+	}
+	
+	readCells := func(result js.Value) []int {
+		if !result.Get("success").Bool() {
+			t.Fatalf("getWalkableGrid failed: %v", result.Get("error"))
+		}
+		data := result.Get("data")
+		if data.Get("width").Int() != 3 || data.Get("height").Int() != 2 {
+			t.Fatalf("grid size = %dx%d, want 3x2", data.Get("width").Int(), data.Get("height").Int())
+		}
+		cells := make([]int, data.Get("cells").Length())
+		for i := range cells {
+			cells[i] = data.Get("cells").Index(i).Int()
+		}
+		return cells
+	}
+	
+	terrain := readCells(js.Global().Call("getWalkableGrid"))
+	merged := readCells(js.Global().Call("getWalkableGrid", true))
+	wantTerrain := []int{1, 0, 1, 1, 1, 1}
+	wantMerged := []int{1, 0, 1, 1, 1, 0}
+	for i := range wantTerrain {
+		if terrain[i] != wantTerrain[i] {
+			t.Errorf("terrain cell %d = %d, want %d", i, terrain[i], wantTerrain[i])
+		}
+		if merged[i] != wantMerged[i] {
+			t.Errorf("occupancy cell %d = %d, want %d", i, merged[i], wantMerged[i])
+		}
+	}
+}
+
+// Test that the cost grid is flat row-major, cheaper on dirt and flags water with ImpassableCost
+func TestGetCostGridViaJS(t *testing.T) {
+	state := newTestState(3, 1)
+	state.GameMap.SetTile(1, 0, world.TileDirtPath)
+	state.GameMap.SetTile(2, 0, world.TileWater)
+	game.InitializeJSInterface()
+	
+	result := js.Global().Call("getCostGrid")
+	if !result.Get("success").Bool() {
+		t.Fatalf("getCostGrid failed: %v", result.Get("error"))
+	}
+	cells := result.Get("data").Get("cells")
+	if cells.Length() != 3 {
+		t.Fatalf("len(cells) = %d, want 3", cells.Length())
+	}
+	grass, dirt, water := cells.Index(0).Float(), cells.Index(1).Float(), cells.Index(2).Float()
+	if dirt >= grass {
+		t.Errorf("dirt cost %.3f, want less than grass cost %.3f", dirt, grass)
+	}
+	if water != game.ImpassableCost {
+		t.Errorf("water cost = %v, want ImpassableCost %v", water, game.ImpassableCost)
+	}
+}
+
+// Test that toggleTileWalkable flips walkability both ways and re-plans a unit whose path crossed the tile
+func TestToggleTileWalkableViaJS(t *testing.T) {
+	state := newTestState(10, 3)
+	game.InitializeJSInterface()
+	unit, err := state.SpawnUnit(0, 0, 1, "")
+	if err != nil {
+		t.Fatalf("SpawnUnit failed: %v", err)
+	}
+	if err := state.MoveUnit(unit.ID, 9, 1); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	crosses := func(x, y int) bool {
+		for _, step := range unit.GetPath() {
+			if step.X == x && step.Y == y {
+				return true
+			}
+		}
+		return false
+	}
+	if !crosses(5, 1) {
+		t.Fatalf("setup: path %v does not cross (5, 1)", unit.GetPath())
+	}
+	
+	result := js.Global().Call("toggleTileWalkable", 5, 1)
+	if !result.Get("success").Bool() || result.Get("data").Get("walkable").Bool() {
+		t.Fatalf("toggleTileWalkable(5, 1) = %v, want success with walkable false", result)
+	}
+	if state.GameMap.TileDefAt(5, 1).Walkable {
+		t.Errorf("tile (5, 1) still walkable after the toggle")
+	}
+	if !unit.IsMoving() || crosses(5, 1) {
+		t.Errorf("path after blocking (5, 1) = %v, want a re-planned route around it", unit.GetPath())
+	}
+	
+	result = js.Global().Call("toggleTileWalkable", 5, 1)
+	if !result.Get("success").Bool() || !result.Get("data").Get("walkable").Bool() {
+		t.Errorf("second toggleTileWalkable(5, 1) = %v, want walkable true", result)
+	}
+	if result := js.Global().Call("toggleTileWalkable", 10, 1); result.Get("code").String() != game.CodeOutOfBounds {
+		t.Errorf("toggleTileWalkable(10, 1) code = %v, want %s", result.Get("code"), game.CodeOutOfBounds)
+	}
+}
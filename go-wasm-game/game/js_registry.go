@@ -0,0 +1,106 @@
+package game
+
+import (
+	"syscall/js"
+)
+
+// Registry of every js.Func the game creates, so they can be released on shutdown
+
+// registeredFunc is a callback plus where it was bound: a global name or an event listener
+type registeredFunc struct {
+	fn     js.Func
+	global string   // Global binding name, "" for listeners
+	target js.Value // Event target for listeners
+	event  string
+}
+
+var registeredFuncs []registeredFunc
+var shutdownRequested bool
+var done = make(chan struct{})
+
+// exposeFunc wraps fn and binds it as a global JS function, replacing (and releasing) any earlier binding of the same name
+func exposeFunc(name string, fn func(this js.Value, args []js.Value) interface{}) {
+	for i, reg := range registeredFuncs {
+		if reg.global == name {
+			reg.fn.Release()
+			registeredFuncs = append(registeredFuncs[:i], registeredFuncs[i+1:]...)
+			break
+		}
+	}
+	
+	f := js.FuncOf(fn)
+	js.Global().Set(name, f)
+	registeredFuncs = append(registeredFuncs, registeredFunc{fn: f, global: name})
+}
+
+// addEventListener wraps fn and attaches it to target, tracking it so Cleanup can detach it
+func addEventListener(target js.Value, event string, fn func(this js.Value, args []js.Value) interface{}) {
+	f := js.FuncOf(fn)
+	target.Call("addEventListener", event, f)
+	registeredFuncs = append(registeredFuncs, registeredFunc{fn: f, target: target, event: event})
+}
+
+// TrackFunc registers a callback created outside this package (e.g. the draw loop) for release by Cleanup
+func TrackFunc(f js.Func) js.Func {
+	registeredFuncs = append(registeredFuncs, registeredFunc{fn: f})
+	return f
+}
+
+// RegisteredGlobals returns the names of the global JS functions currently bound by the game
+func RegisteredGlobals() []string {
+	names := make([]string, 0, len(registeredFuncs))
+	for _, reg := range registeredFuncs {
+		if reg.global != "" {
+			names = append(names, reg.global)
+		}
+	}
+	return names
+}
+
+// RegisteredFuncCount returns how many callbacks are awaiting release
+func RegisteredFuncCount() int {
+	return len(registeredFuncs)
+}
+
+// Cleanup detaches listeners, removes global bindings and releases every registered callback
+// The game can be initialized again afterwards
+func Cleanup() {
+	for _, reg := range registeredFuncs {
+		if reg.global != "" {
+			js.Global().Delete(reg.global)
+		}
+		if reg.event != "" {
+			reg.target.Call("removeEventListener", reg.event, reg.fn)
+		}
+		reg.fn.Release()
+	}
+	registeredFuncs = nil
+}
+
+// ShutdownRequested reports whether JS has called destroyGame
+// The draw loop checks this so nothing is released while a frame callback is still pending
+func ShutdownRequested() bool {
+	return shutdownRequested
+}
+
+// Shutdown cleans up and lets main return, ending the WASM program
+func Shutdown() {
+	Cleanup()
+	shutdownRequested = false
+	select {
+	case <-done:
+	default:
+		close(done)
+	}
+}
+
+// Done is closed once the game has shut down
+func Done() <-chan struct{} {
+	return done
+}
+
+// destroyGame asks the game to stop; cleanup happens at the start of the next frame
+func destroyGame(this js.Value, args []js.Value) interface{} {
+	shutdownRequested = true
+	return jsSuccess(nil)
+}
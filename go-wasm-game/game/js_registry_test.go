@@ -0,0 +1,50 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"syscall/js"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that every exposed function is tracked and that Cleanup unbinds and releases them all
+func TestCleanupReleasesRegisteredFuncs(t *testing.T) {
+	game.Cleanup()
+	state := newTestState(10, 10)
+	state.GameMap.Layers = world.NewLayers()
+	game.InitializeJSInterface()
+	
+	registered := map[string]bool{}
+	for _, name := range game.RegisteredGlobals() {
+		registered[name] = true
+	}
+	for _, name := range []string{"createUnit", "getUnits", "moveUnit", "removeUnit", "movePlayerToTile", "startRecording"} {
+		if !registered[name] {
+			t.Errorf("%s was bound without being registered for release", name)
+		}
+	}
+	
+	// Reinitializing replaces the old bindings instead of leaking them
+	count := game.RegisteredFuncCount()
+	game.InitializeJSInterface()
+	if game.RegisteredFuncCount() != count {
+		t.Errorf("RegisteredFuncCount() = %d after reinitializing, want %d", game.RegisteredFuncCount(), count)
+	}
+	
+	game.Cleanup()
+	if game.RegisteredFuncCount() != 0 {
+		t.Errorf("RegisteredFuncCount() = %d after Cleanup, want 0", game.RegisteredFuncCount())
+	}
+	if !js.Global().Get("createUnit").IsUndefined() {
+		t.Error("createUnit should be removed from the global scope")
+	}
+	
+	// The interface can be brought back up after cleanup
+	game.InitializeJSInterface()
+	if js.Global().Get("createUnit").Type() != js.TypeFunction {
+		t.Error("createUnit should be bound again after reinitializing")
+	}
+}
@@ -0,0 +1,185 @@
+package game
+
+import (
+	"math"
+	"sort"
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Map information and editing functions exposed to JavaScript
+
+// ImpassableCost stands in for an infinite tile cost in getCostGrid, which JSON-style results can't carry
+const ImpassableCost = -1.0
+
+func getMapInfo(this js.Value, args []js.Value) interface{} {
+	worldWidth, worldHeight := State.GameMap.WorldSize()
+	tileWidth, tileHeight := State.GameMap.TileDimensions()
+	return jsSuccess(map[string]interface{}{
+		"width":       State.GameMap.Width,
+		"height":      State.GameMap.Height,
+		"tileSize":    State.GameMap.TileSize,
+		"tileWidth":   tileWidth,
+		"tileHeight":  tileHeight,
+		"worldWidth":  worldWidth,
+		"worldHeight": worldHeight,
+		"wrap":        State.GameMap.Wrap,
+	})
+}
+
+// TileLegend describes every tile type for JS legends and minimaps, ordered by type
+func TileLegend() []interface{} {
+	types := make([]int, 0, len(world.TileDefinitions))
+	for tileType := range world.TileDefinitions {
+		types = append(types, int(tileType))
+	}
+	sort.Ints(types)
+
+	legend := make([]interface{}, 0, len(types))
+	for _, tileType := range types {
+		tile := world.TileDefinitions[world.TileType(tileType)]
+		legend = append(legend, map[string]interface{}{
+			"type":      tileType,
+			"name":      tile.Name,
+			"color":     tile.Color,
+			"walkable":  tile.Walkable,
+			"walkSpeed": tile.WalkSpeed,
+			"overlay":   tile.Overlay,
+		})
+	}
+	return legend
+}
+
+func getTileLegend(this js.Value, args []js.Value) interface{} {
+	return jsSuccess(TileLegend())
+}
+
+// setOverlay places an overlay tile (e.g. snow) over a cell, or clears it with 0, leaving the base tile intact
+func setOverlay(this js.Value, args []js.Value) interface{} {
+	if len(args) < 3 {
+		return jsError(CodeInvalidArguments, "setOverlay requires x, y, overlayType")
+	}
+
+	if !State.GameMap.SetOverlay(args[0].Int(), args[1].Int(), world.TileType(args[2].Int())) {
+		return jsError(CodeInvalidArguments, "setOverlay requires an in-bounds cell and an overlay tile type")
+	}
+	return jsSuccess(nil)
+}
+
+// ToggleTileWalkable flips a tile between grass and water like an editor stroke, drops cached preview
+// paths and re-plans units whose routes the change affects. Returns the new walkability, ok false out of bounds
+func (gs *GameState) ToggleTileWalkable(tileX, tileY int) (walkable, ok bool) {
+	walkable, ok = gs.GameMap.ToggleWalkable(tileX, tileY)
+	if !ok {
+		return false, false
+	}
+	gs.Preview.Clear()
+	gs.AttackPreview.Clear()
+	gs.UnitManager.RepathAfterTileChange(tileX, tileY)
+	return walkable, true
+}
+
+// toggleTileWalkable flips a tile between walkable grass and unwalkable water so pathing reacts live
+func toggleTileWalkable(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return jsError(CodeInvalidArguments, "toggleTileWalkable requires tileX, tileY")
+	}
+	if State == nil || State.UnitManager == nil {
+		return jsError(CodeNotStarted, "game has not started")
+	}
+
+	tileX, tileY := args[0].Int(), args[1].Int()
+	walkable, ok := State.ToggleTileWalkable(tileX, tileY)
+	if !ok {
+		return jsError(CodeOutOfBounds, "tile coordinates out of bounds")
+	}
+	return jsSuccess(map[string]interface{}{
+		"tileX":    tileX,
+		"tileY":    tileY,
+		"tileType": int(State.GameMap.GetTile(tileX, tileY)),
+		"walkable": walkable,
+	})
+}
+
+// toggleDecorations shows or hides the cosmetic decoration layer
+func toggleDecorations(this js.Value, args []js.Value) interface{} {
+	layer := State.GameMap.Layers.GetLayer("decorations")
+	if layer == nil {
+		return jsError(CodeNotFound, "layer not found: decorations")
+	}
+	visible := !layer.Visible
+	State.GameMap.Layers.SetLayerVisibility("decorations", visible)
+	return jsSuccess(map[string]interface{}{
+		"visible": visible,
+	})
+}
+
+// SetMapWrap turns toroidal wrapping on or off, switching the camera edge mode to match
+// Turning wrap off falls back to clamping the camera
+func (gs *GameState) SetMapWrap(wrap bool) {
+	gs.GameMap.Wrap = wrap
+	if wrap {
+		gs.CameraEdgeMode = systems.CameraEdgeWrap
+	} else if gs.CameraEdgeMode == systems.CameraEdgeWrap {
+		gs.CameraEdgeMode = systems.CameraEdgeClamp
+	}
+}
+
+// setMapWrap turns toroidal wrapping on or off; the camera stops clamping to the edges while it is on
+func setMapWrap(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return jsError(CodeInvalidArguments, "setMapWrap requires wrap")
+	}
+	State.SetMapWrap(args[0].Truthy())
+	return jsSuccess(nil)
+}
+
+// setPalette switches the tile color palette, e.g. "colorblind"; tile definitions are left untouched
+func setPalette(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return jsError(CodeInvalidArguments, "setPalette requires name")
+	}
+	if !State.GameMap.SetPalette(args[0].String()) {
+		return jsError(CodeNotFound, "palette not found: "+args[0].String())
+	}
+	return jsSuccess(nil)
+}
+
+// getCostGrid returns the flat row-major terrain cost per tile, with ImpassableCost for tiles that can't be entered
+func getCostGrid(this js.Value, args []js.Value) interface{} {
+	grid := State.GameMap.CostGrid(nil)
+	cells := make([]interface{}, 0, State.GameMap.Width*State.GameMap.Height)
+	for _, row := range grid {
+		for _, cost := range row {
+			if math.IsInf(cost, 1) {
+				cost = ImpassableCost
+			}
+			cells = append(cells, cost)
+		}
+	}
+
+	return jsSuccess(map[string]interface{}{
+		"width":  State.GameMap.Width,
+		"height": State.GameMap.Height,
+		"cells":  cells,
+	})
+}
+
+// initializeMapInterface sets up JavaScript bindings for map information and editing
+func initializeMapInterface() {
+	exposeFunc("getMapInfo", getMapInfo)
+	
+	exposeFunc("getCostGrid", getCostGrid)
+	
+	exposeFunc("getTileLegend", getTileLegend)
+	
+	exposeFunc("setOverlay", setOverlay)
+	exposeFunc("toggleTileWalkable", toggleTileWalkable)
+	
+	exposeFunc("toggleDecorations", toggleDecorations)
+	
+	exposeFunc("setMapWrap", setMapWrap)
+	
+	exposeFunc("setPalette", setPalette)
+}
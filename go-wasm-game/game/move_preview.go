@@ -0,0 +1,99 @@
+package game
+
+import (
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// MovePreview caches the path shown for the selected unit while the preview modifier (Shift) is held.
+// The path is keyed by unit, its tile and the hovered tile, so mouse movement within one tile never repaths
+type MovePreview struct {
+	active       bool
+	unitID       string
+	fromX, fromY int // Unit tile the cached path starts from
+	tileX, tileY int // Hovered tile the cached path leads to
+	path         systems.Path
+	computations int // Paths planned so far, for throttling checks
+}
+
+// Update returns the preview path for a unit and hovered tile, calling plan only when the key changed
+func (p *MovePreview) Update(unitID string, fromX, fromY, tileX, tileY int, plan func() systems.Path) systems.Path {
+	if p.active && p.unitID == unitID && p.fromX == fromX && p.fromY == fromY && p.tileX == tileX && p.tileY == tileY {
+		return p.path
+	}
+	p.active = true
+	p.unitID = unitID
+	p.fromX, p.fromY = fromX, fromY
+	p.tileX, p.tileY = tileX, tileY
+	p.path = plan()
+	p.computations++
+	return p.path
+}
+
+// Active reports whether a preview is being shown
+func (p *MovePreview) Active() bool {
+	return p.active
+}
+
+// Path returns the previewed path, nil when inactive or unreachable
+func (p *MovePreview) Path() systems.Path {
+	if !p.active {
+		return nil
+	}
+	return p.path
+}
+
+// Computations returns how many preview paths have been planned
+func (p *MovePreview) Computations() int {
+	return p.computations
+}
+
+// Clear hides the preview; the next Update always plans a fresh path
+func (p *MovePreview) Clear() {
+	p.active = false
+	p.path = nil
+}
+
+// PreviewMove shows the path the selected unit would take to a tile, returning it (nil if none)
+func (gs *GameState) PreviewMove(tileX, tileY int) systems.Path {
+	unit := gs.UnitManager.GetSelectedUnit()
+	if unit == nil || !unit.IsAlive || unit.IsCarried() {
+		gs.Preview.Clear()
+		return nil
+	}
+	return gs.Preview.Update(unit.ID, unit.TileX, unit.TileY, tileX, tileY, func() systems.Path {
+		return unit.PreviewPath(tileX, tileY)
+	})
+}
+
+// CommitPreview orders the previewed unit to the previewed tile and hides the preview
+func (gs *GameState) CommitPreview() error {
+	if !gs.Preview.Active() {
+		return nil
+	}
+	unitID, tileX, tileY := gs.Preview.unitID, gs.Preview.tileX, gs.Preview.tileY
+	gs.Preview.Clear()
+	return gs.MoveUnit(unitID, tileX, tileY)
+}
+
+// RenderMovePreview draws the previewed path as a dotted line from the selected unit
+func RenderMovePreview(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
+	path := State.Preview.Path()
+	if len(path) < 2 {
+		return
+	}
+	
+	ctx.Call("save")
+	ctx.Set("strokeStyle", "rgba(255, 255, 255, 0.8)")
+	ctx.Set("lineWidth", 2)
+	ctx.Call("setLineDash", []interface{}{2, 6})
+	ctx.Call("beginPath")
+	startX, startY := systems.TileToScreen(State.GameMap, path[0].X, path[0].Y, cameraX, cameraY)
+	ctx.Call("moveTo", startX, startY)
+	for _, step := range path[1:] {
+		stepX, stepY := systems.TileToScreen(State.GameMap, step.X, step.Y, cameraX, cameraY)
+		ctx.Call("lineTo", stepX, stepY)
+	}
+	ctx.Call("stroke")
+	ctx.Call("restore")
+}
@@ -0,0 +1,86 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// Test that the preview path is cached per hovered tile and replanned only when the key changes
+func TestMovePreviewCachesByHoveredTile(t *testing.T) {
+	var preview game.MovePreview
+	plans := 0
+	plan := func() systems.Path {
+		plans++
+		return systems.Path{{X: 0, Y: 0}, {X: plans, Y: 0}}
+	}
+
+	tests := []struct {
+		name         string
+		unitID       string
+		fromX, tileX int
+		wantPlans    int
+	}{
+		{"first hover", "unit_1", 0, 5, 1},
+		{"same tile", "unit_1", 0, 5, 1},
+		{"hovered tile changed", "unit_1", 0, 6, 2},
+		{"unit moved", "unit_1", 1, 6, 3},
+		{"other unit", "unit_2", 1, 6, 4},
+	}
+
+	for _, tt := range tests {
+		path := preview.Update(tt.unitID, tt.fromX, 0, tt.tileX, 0, plan)
+		if plans != tt.wantPlans || preview.Computations() != tt.wantPlans {
+			t.Fatalf("%s: planned %d paths (Computations %d), want %d", tt.name, plans, preview.Computations(), tt.wantPlans)
+		}
+		if last := path[len(path)-1]; last.X != plans {
+			t.Errorf("%s: Update() returned a stale path ending at %d, want %d", tt.name, last.X, plans)
+		}
+	}
+
+	preview.Clear()
+	if preview.Active() || preview.Path() != nil {
+		t.Errorf("after Clear() Active = %v, Path = %v, want an empty preview", preview.Active(), preview.Path())
+	}
+	preview.Update("unit_2", 1, 0, 6, 0, plan)
+	if plans != 5 {
+		t.Errorf("Update() after Clear() reused the old path, want a fresh plan")
+	}
+}
+
+// Test that previewing shows the selected unit's path without moving it, and committing issues the move
+func TestPreviewMoveAndCommit(t *testing.T) {
+	state := newTestState(10, 10)
+	if path := state.PreviewMove(5, 5); path != nil || state.Preview.Active() {
+		t.Fatalf("PreviewMove() with nothing selected = %v, want no preview", path)
+	}
+
+	unit, err := state.UnitManager.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	state.UnitManager.SelectUnit(unit.ID)
+
+	path := state.PreviewMove(5, 5)
+	if len(path) == 0 || path[len(path)-1].X != 5 || path[len(path)-1].Y != 5 {
+		t.Fatalf("PreviewMove(5, 5) = %v, want a path ending at (5, 5)", path)
+	}
+	if unit.IsMoving() {
+		t.Errorf("unit started moving from a preview, want it to wait for the commit")
+	}
+	state.PreviewMove(5, 5)
+	if state.Preview.Computations() != 1 {
+		t.Errorf("hovering the same tile planned %d paths, want 1", state.Preview.Computations())
+	}
+
+	if err := state.CommitPreview(); err != nil {
+		t.Fatalf("CommitPreview() error = %v", err)
+	}
+	if !unit.IsMoving() || state.Preview.Active() {
+		t.Errorf("after CommitPreview() moving = %v, preview active = %v, want the unit moving and the preview hidden", unit.IsMoving(), state.Preview.Active())
+	}
+}
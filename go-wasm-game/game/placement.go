@@ -0,0 +1,56 @@
+package game
+
+import (
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Click-to-place units, optionally snapped to a coarser grid for tidy formations
+
+// PlaceUnit spawns a unit on the tile nearest (tileX, tileY) that lies on the placement grid
+// The snapped tile goes through normal placement validation (bounds, walkable, unoccupied).
+// Placed units come from the build queue, so they walk to the rally point once grown in
+func (gs *GameState) PlaceUnit(unitType entities.UnitType, tileX, tileY int) (*units.Unit, error) {
+	snappedX, snappedY := systems.SnapTile(tileX, tileY, gs.PlacementGrid)
+	unit, err := gs.UnitManager.CreateUnit(unitType, snappedX, snappedY, "")
+	if err != nil {
+		return nil, err
+	}
+	gs.UnitManager.RallyOnceSpawned(unit.ID)
+	gs.RecordSpawn(unit)
+	return unit, nil
+}
+
+// setPlacementMode makes game clicks place units of the given type; no argument (or -1) goes back to moving the player
+func setPlacementMode(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 || args[0].Int() < 0 {
+		State.PlacingUnits = false
+		return jsSuccess(map[string]interface{}{"placing": false})
+	}
+	
+	unitType := entities.UnitType(args[0].Int())
+	if _, exists := entities.UnitTypeDefinitions[unitType]; !exists {
+		return jsError(CodeUnknownUnitType, "unknown unit type")
+	}
+	State.PlacingUnits = true
+	State.PlacementUnitType = unitType
+	return jsSuccess(map[string]interface{}{"placing": true})
+}
+
+// setPlacementGrid sets the snapping grid for placed units (1 places on the clicked tile)
+func setPlacementGrid(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 || args[0].Int() < 1 {
+		return jsError(CodeInvalidArguments, "setPlacementGrid requires a grid size of at least 1")
+	}
+	
+	State.PlacementGrid = args[0].Int()
+	return jsSuccess(nil)
+}
+
+// initializePlacementInterface sets up JavaScript bindings for unit placement
+func initializePlacementInterface() {
+	exposeFunc("setPlacementMode", setPlacementMode)
+	exposeFunc("setPlacementGrid", setPlacementGrid)
+}
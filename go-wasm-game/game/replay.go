@@ -0,0 +1,73 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CommandRecorder logs commands with the tick they were issued on, relative to when recording started
+type CommandRecorder struct {
+	startTick int
+	commands  []Command
+}
+
+// NewCommandRecorder starts a recording at the given tick
+func NewCommandRecorder(startTick int) *CommandRecorder {
+	return &CommandRecorder{startTick: startTick}
+}
+
+// Record logs a command issued at the given absolute tick
+// Every command is kept, including repeats on the same tick, so replays issue exactly what was dispatched
+func (r *CommandRecorder) Record(tick int, cmd Command) {
+	cmd.Tick = tick - r.startTick
+	r.commands = append(r.commands, cmd)
+}
+
+// Commands returns the recorded commands in the order they were issued
+func (r *CommandRecorder) Commands() []Command {
+	return r.commands
+}
+
+// ExportReplay serializes the recording to JSON
+func (r *CommandRecorder) ExportReplay() (string, error) {
+	data, err := json.Marshal(r.commands)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ReplayPlayer re-issues recorded commands when the game reaches their recorded tick
+type ReplayPlayer struct {
+	startTick int
+	commands  []Command
+	next      int // Index of the next command to issue
+}
+
+// NewReplayPlayer parses a replay exported by CommandRecorder, starting playback at the given tick
+func NewReplayPlayer(replayJSON string, startTick int) (*ReplayPlayer, error) {
+	var commands []Command
+	if err := json.Unmarshal([]byte(replayJSON), &commands); err != nil {
+		return nil, fmt.Errorf("invalid replay: %w", err)
+	}
+	for i := 1; i < len(commands); i++ {
+		if commands[i].Tick < commands[i-1].Tick {
+			return nil, fmt.Errorf("invalid replay: command %d is out of tick order", i)
+		}
+	}
+	return &ReplayPlayer{startTick: startTick, commands: commands}, nil
+}
+
+// Done returns true once every command has been issued
+func (p *ReplayPlayer) Done() bool {
+	return p.next >= len(p.commands)
+}
+
+// issueDue executes, in order, every command scheduled at or before the given absolute tick
+// Failed commands are skipped so one bad entry doesn't stall the rest of the replay
+func (p *ReplayPlayer) issueDue(gs *GameState, tick int) {
+	for !p.Done() && p.commands[p.next].Tick <= tick-p.startTick {
+		gs.executeCommand(p.commands[p.next])
+		p.next++
+	}
+}
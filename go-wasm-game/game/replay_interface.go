@@ -0,0 +1,54 @@
+package game
+
+import (
+	"syscall/js"
+)
+
+// JavaScript bindings for recording and replaying commands
+
+// startRecording begins logging commands from the current tick, discarding any previous recording
+func startRecording(this js.Value, args []js.Value) interface{} {
+	State.Recorder = NewCommandRecorder(State.Tick)
+	return jsSuccess(nil)
+}
+
+// stopRecording ends the active recording and returns it as replay JSON
+func stopRecording(this js.Value, args []js.Value) interface{} {
+	if State.Recorder == nil {
+		return jsError(CodeNotFound, "no recording in progress")
+	}
+	
+	replay, err := State.Recorder.ExportReplay()
+	State.Recorder = nil
+	if err != nil {
+		return jsErrorFrom(err)
+	}
+	return jsSuccess(map[string]interface{}{
+		"replay": replay,
+	})
+}
+
+// playReplay schedules a replay's commands relative to the current tick
+func playReplay(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return jsError(CodeInvalidArguments, "playReplay requires replay JSON")
+	}
+	
+	replay, err := NewReplayPlayer(args[0].String(), State.Tick)
+	if err != nil {
+		return jsError(CodeInvalidArguments, err.Error())
+	}
+	State.Replay = replay
+	return jsSuccess(map[string]interface{}{
+		"commands": len(replay.commands),
+	})
+}
+
+// initializeReplayInterface sets up JavaScript bindings for command replays
+func initializeReplayInterface() {
+	exposeFunc("startRecording", startRecording)
+	
+	exposeFunc("stopRecording", stopRecording)
+	
+	exposeFunc("playReplay", playReplay)
+}
@@ -0,0 +1,162 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+)
+
+// stepTo advances the state until it reaches the given tick
+func stepTo(state *game.GameState, tick int) {
+	for state.Tick < tick {
+		state.Step()
+	}
+}
+
+// Test that a recorded spawn and move replay in order on the ticks they were issued
+func TestReplayIssuesCommandsAtRecordedTicks(t *testing.T) {
+	state := newTestState(10, 10)
+	stepTo(state, 3)
+	state.Recorder = game.NewCommandRecorder(state.Tick)
+	
+	stepTo(state, 5)
+	unit, err := state.SpawnUnit(entities.UnitWarrior, 2, 2, "")
+	if err != nil {
+		t.Fatalf("SpawnUnit() error = %v", err)
+	}
+	stepTo(state, 8)
+	if err := state.MoveUnit(unit.ID, 7, 2); err != nil {
+		t.Fatalf("MoveUnit() error = %v", err)
+	}
+	
+	commands := state.Recorder.Commands()
+	if len(commands) != 2 || commands[0].Tick != 2 || commands[1].Tick != 5 {
+		t.Fatalf("recorded %+v, want spawn at tick 2 and move at tick 5", commands)
+	}
+	replayJSON, err := state.Recorder.ExportReplay()
+	if err != nil {
+		t.Fatalf("ExportReplay() error = %v", err)
+	}
+	
+	// Play back on a fresh state starting from tick 0
+	state = newTestState(10, 10)
+	replay, err := game.NewReplayPlayer(replayJSON, state.Tick)
+	if err != nil {
+		t.Fatalf("NewReplayPlayer() error = %v", err)
+	}
+	state.Replay = replay
+	
+	stepTo(state, 2)
+	if state.UnitManager.GetTotalUnitCount() != 0 {
+		t.Fatal("unit spawned before its recorded tick")
+	}
+	state.Step()
+	replayed := state.UnitManager.GetUnit(unit.ID)
+	if replayed == nil || replayed.TileX != 2 || replayed.TileY != 2 {
+		t.Fatalf("after tick 2 unit = %+v, want %s spawned at (2, 2)", replayed, unit.ID)
+	}
+	
+	stepTo(state, 5)
+	if replayed.IsMoving() {
+		t.Fatal("unit started moving before its recorded tick")
+	}
+	state.Step()
+	if !replayed.IsMoving() {
+		t.Fatal("unit should be moving after the move command's tick")
+	}
+	if state.Replay != nil {
+		t.Error("replay should be cleared once every command has been issued")
+	}
+}
+
+// Test that repeated same-tick commands and unit removals are all recorded and replayed
+func TestReplayRecordsRepeatsAndRemovals(t *testing.T) {
+	state := newTestState(10, 10)
+	state.Recorder = game.NewCommandRecorder(state.Tick)
+	unit, err := state.SpawnUnit(entities.UnitWarrior, 2, 2, "")
+	if err != nil {
+		t.Fatalf("SpawnUnit() error = %v", err)
+	}
+	state.MovePlayer(5, 5)
+	state.MovePlayer(5, 5)
+	stepTo(state, 3)
+	if err := state.RemoveUnit(unit.ID); err != nil {
+		t.Fatalf("RemoveUnit() error = %v", err)
+	}
+	
+	commands := state.Recorder.Commands()
+	want := []game.CommandType{game.CommandSpawnUnit, game.CommandMovePlayer, game.CommandMovePlayer, game.CommandRemoveUnit}
+	if len(commands) != len(want) {
+		t.Fatalf("recorded %+v, want %v", commands, want)
+	}
+	for i, cmdType := range want {
+		if commands[i].Type != cmdType {
+			t.Errorf("command %d type = %q, want %q", i, commands[i].Type, cmdType)
+		}
+	}
+	replayJSON, err := state.Recorder.ExportReplay()
+	if err != nil {
+		t.Fatalf("ExportReplay() error = %v", err)
+	}
+	
+	state = newTestState(10, 10)
+	if state.Replay, err = game.NewReplayPlayer(replayJSON, state.Tick); err != nil {
+		t.Fatalf("NewReplayPlayer() error = %v", err)
+	}
+	state.Step()
+	if state.UnitManager.GetUnit(unit.ID) == nil {
+		t.Fatal("replayed unit should exist before its recorded removal")
+	}
+	stepTo(state, 5)
+	if state.UnitManager.GetUnit(unit.ID) != nil {
+		t.Error("replayed unit should be removed at its recorded tick")
+	}
+}
+
+// Test that placed units rally while scripted spawns don't, and a replay rallies the same units
+func TestRallyOnlyForPlacedUnits(t *testing.T) {
+	state := newTestState(10, 10)
+	state.UnitManager.SetRallyPoint(8, 8)
+	state.Recorder = game.NewCommandRecorder(state.Tick)
+	placed, err := state.PlaceUnit(entities.UnitWarrior, 1, 1)
+	if err != nil {
+		t.Fatalf("PlaceUnit() error = %v", err)
+	}
+	scripted, err := state.SpawnUnit(entities.UnitWarrior, 3, 1, "")
+	if err != nil {
+		t.Fatalf("SpawnUnit() error = %v", err)
+	}
+	if !placed.AwaitingRally() || scripted.AwaitingRally() {
+		t.Fatalf("AwaitingRally() placed = %v, scripted = %v, want only the placed unit", placed.AwaitingRally(), scripted.AwaitingRally())
+	}
+	replayJSON, err := state.Recorder.ExportReplay()
+	if err != nil {
+		t.Fatalf("ExportReplay() error = %v", err)
+	}
+	
+	state = newTestState(10, 10)
+	state.UnitManager.SetRallyPoint(8, 8)
+	if state.Replay, err = game.NewReplayPlayer(replayJSON, state.Tick); err != nil {
+		t.Fatalf("NewReplayPlayer() error = %v", err)
+	}
+	stepTo(state, 20)
+	if unit := state.UnitManager.GetUnit(placed.ID); unit == nil || !unit.IsMoving() {
+		t.Error("replayed placed unit should be walking to the rally point")
+	}
+	if unit := state.UnitManager.GetUnit(scripted.ID); unit == nil || unit.IsMoving() {
+		t.Error("replayed scripted unit should stay where it spawned")
+	}
+}
+
+// Test that malformed replay JSON is rejected
+func TestNewReplayPlayerRejectsInvalidJSON(t *testing.T) {
+	if _, err := game.NewReplayPlayer("not json", 0); err == nil {
+		t.Error("NewReplayPlayer() should fail on invalid JSON")
+	}
+	if _, err := game.NewReplayPlayer(`[{"tick":5,"type":"movePlayer"},{"tick":2,"type":"movePlayer"}]`, 0); err == nil {
+		t.Error("NewReplayPlayer() should fail on out-of-order ticks")
+	}
+}
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"syscall/js"
+	"time"
+)
+
+// JavaScript interface functions for scripting the simulation: seeding, stepping, pausing and moving the player.
+// InitializeJSInterface registers them
+
+// setGlobalSeed reseeds spawning, combat and particle randomness from one master seed for reproducible demos
+func setGlobalSeed(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return jsError(CodeInvalidArguments, "setGlobalSeed requires seed")
+	}
+	State.SetGlobalSeed(int64(args[0].Int()))
+	return jsSuccess(nil)
+}
+
+// movePlayerToTile paths the player to a tile exactly like a canvas click would
+func movePlayerToTile(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return jsError(CodeInvalidArguments, "movePlayerToTile requires tileX, tileY")
+	}
+
+	tileX, tileY := args[0].Int(), args[1].Int()
+	if tileX < 0 || tileX >= State.GameMap.Width || tileY < 0 || tileY >= State.GameMap.Height {
+		return jsError(CodeOutOfBounds, "tile coordinates out of bounds")
+	}
+
+	State.MovePlayer(tileX, tileY)
+	return jsSuccess(map[string]interface{}{
+		"moving": State.Player.IsMoving(),
+	})
+}
+
+// stepGame advances the simulation by dtMillis of fixed ticks without rendering, e.g. while the draw loop is paused
+func stepGame(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 || args[0].Type() != js.TypeNumber || args[0].Float() < 0 {
+		return jsError(CodeInvalidArguments, "stepGame requires a non-negative dtMillis")
+	}
+	if State == nil || State.UnitManager == nil {
+		return jsError(CodeNotStarted, "game has not started")
+	}
+	ticks := State.StepFor(time.Duration(args[0].Float() * float64(time.Millisecond)))
+	return jsSuccess(map[string]interface{}{"ticks": ticks, "tick": State.Tick})
+}
+
+// setPaused freezes or resumes the simulation while the draw loop keeps rendering
+func setPaused(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 || args[0].Type() != js.TypeBoolean {
+		return jsError(CodeInvalidArguments, "setPaused requires paused")
+	}
+	if State == nil {
+		return jsError(CodeNotStarted, "game has not started")
+	}
+	State.Paused = args[0].Bool()
+	return jsSuccess(map[string]interface{}{"paused": State.Paused})
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Unit stances exposed to JavaScript
+
+// setStance sets a unit's stance by name: "aggressive", "guard" or "passive"
+func setStance(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return jsError(CodeInvalidArguments, "setStance requires unitId, stance")
+	}
+
+	stance, ok := units.ParseStance(args[1].String())
+	if !ok {
+		return jsError(CodeInvalidArguments, "unknown stance: "+args[1].String())
+	}
+	if err := State.UnitManager.SetStance(args[0].String(), stance); err != nil {
+		return jsErrorFrom(err)
+	}
+
+	return jsSuccess(nil)
+}
+
+// taunt has a warrior pull the aggro of nearby enemies, returning how many were taunted
+func taunt(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return jsError(CodeInvalidArguments, "taunt requires unitId")
+	}
+
+	taunted, err := State.UnitManager.Taunt(args[0].String())
+	if err != nil {
+		return jsErrorFrom(err)
+	}
+
+	return jsSuccess(map[string]interface{}{
+		"taunted": taunted,
+	})
+}
+
+// initializeStanceInterface sets up JavaScript bindings for unit stances and the warrior taunt
+func initializeStanceInterface() {
+	exposeFunc("setStance", setStance)
+	
+	exposeFunc("taunt", taunt)
+}
@@ -1,8 +1,11 @@
 package game
 
 import (
+	"math/rand"
 	"syscall/js"
+	"time"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
 )
@@ -19,8 +22,32 @@ type GameState struct {
 	Environment  *world.Environment
 	CameraX      float64
 	CameraY      float64
+	DebugTeleport bool // When enabled, game clicks teleport the player instead of pathfinding
+	CameraEdgeMode systems.CameraEdgeMode // Camera behavior at map edges (defaults to clamp)
+	CameraFollowTarget string      // Unit ID the camera follows; "" follows the player
+	heldView     *heldView         // Camera position set by RestoreView, kept until the player moves; nil when following
+	FogOfWar     *systems.FogOfWar // Tile visibility; nil disables fog of war
+	Pet          *entities.Pet     // Cosmetic companion trailing the player; nil if none
+	Particles    *systems.ParticleSystem // Footstep effects; nil disables them
+	Tick         int               // Simulation ticks advanced by Step
+	Paused       bool              // When set, StepFrame keeps measuring frames but simulates none of them
+	Recorder     *CommandRecorder  // Active command recording; nil when not recording
+	Replay       *ReplayPlayer     // Replay being played back; nil when idle
+	PlacementGrid int              // Placed units snap to multiples of this many tiles (1 = no snapping)
+	PlacingUnits bool              // When enabled, game clicks place units instead of moving the player
+	PlacementUnitType entities.UnitType // Unit type placed by clicks while PlacingUnits is set
+	Drag         DragMove          // Click-and-drag player movement while the mouse button is held
+	Preview      MovePreview       // Shift-hover path preview for the selected unit
+	AttackPreview AttackPreview    // Hover range preview for attacking an enemy with the selected unit
+	Console      DebugConsole      // In-game debug command console, toggled with ConsoleToggleKey
+	Shake        CameraShake       // Camera shake from unit deaths and big hits, faded by the draw loop and applied to the drawn view
+	stepRemainder time.Duration    // Time passed to StepFor not yet covered by a whole tick
+	frames       FrameTimer        // Draw loop timestamps that StepFrame derives frame times from
 }
 
+// TickDuration is the simulated time one Step covers, matching the 60 Hz frame move speeds are tuned for
+const TickDuration = systems.FrameDuration
+
 // Global game state instance
 var State *GameState
 
@@ -33,6 +60,58 @@ func InitializeState(ctx js.Value, canvas js.Value, player *entities.Player, gam
 		GameMap:     gameMap,
 		UnitManager: unitManager,
 		Environment: environment,
+		PlacementGrid: 1,
+	}
+	if unitManager != nil {
+		unitManager.SetDamageCallback(State.shakeOnDamage)
+	}
+}
+
+// Step advances the simulation by one TickDuration tick without rendering; see StepDT
+func (gs *GameState) Step() {
+	gs.StepDT(TickDuration)
+}
+
+// StepDT advances the simulation by one tick covering dt of simulated time, without rendering
+// Replayed commands due this tick are issued first, then the player and units move
+func (gs *GameState) StepDT(dt time.Duration) {
+	if gs.Replay != nil {
+		gs.Replay.issueDue(gs, gs.Tick)
+		if gs.Replay.Done() {
+			gs.Replay = nil
+		}
+	}
+	
+	if gs.Player != nil {
+		gs.Player.Update(dt)
+	}
+	if gs.UnitManager != nil {
+		gs.UnitManager.UpdateDT(dt)
+	}
+	gs.Tick++
+}
+
+// StepFor advances the simulation by as many whole ticks as fit in dt and returns how many ran
+// Leftover time carries over to the next call, so many small steps add up to the same ticks
+func (gs *GameState) StepFor(dt time.Duration) int {
+	gs.stepRemainder += dt
+	ticks := 0
+	for ; gs.stepRemainder >= TickDuration; ticks++ {
+		gs.stepRemainder -= TickDuration
+		gs.Step()
+	}
+	return ticks
+}
+
+// SetGlobalSeed reseeds every random source from one master seed, each subsystem on its own derived stream
+// Random spawns, combat damage variance and footstep particle scatter become reproducible;
+// unit IDs and the replay tick counter are already deterministic and are left as they are
+func (gs *GameState) SetGlobalSeed(seed int64) {
+	if gs.UnitManager != nil {
+		gs.UnitManager.SetSeed(seed)
+	}
+	if gs.Particles != nil {
+		gs.Particles.SetRand(rand.New(rand.NewSource(systems.DeriveSeed(seed, systems.SeedStreamParticles))))
 	}
 }
 
@@ -0,0 +1,75 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"syscall/js"
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that stepGame advances a moving unit by MoveSpeed per whole tick, carrying leftover time over
+func TestStepGameAdvancesUnits(t *testing.T) {
+	state := newTestState(20, 10)
+	state.GameMap.Layers = world.NewLayers()
+	game.InitializeJSInterface()
+	unit, err := state.UnitManager.CreateUnit(entities.UnitWarrior, 0, 5, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	if err := state.MoveUnit(unit.ID, 15, 5); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	tickMillis := float64(game.TickDuration) / float64(time.Millisecond)
+	startX := unit.X
+
+	tests := []struct {
+		name      string
+		dtMillis  float64
+		wantTicks int
+		wantTick  int
+	}{
+		{"four ticks", 4 * tickMillis, 4, 4},
+		{"less than a tick", tickMillis / 2, 0, 4},
+		{"leftover completes a tick", tickMillis / 2, 1, 5},
+		{"zero", 0, 0, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := js.Global().Call("stepGame", tt.dtMillis)
+			if !result.Get("success").Bool() {
+				t.Fatalf("stepGame(%v) failed: %v", tt.dtMillis, result.Get("error"))
+			}
+			if got := result.Get("data").Get("ticks").Int(); got != tt.wantTicks {
+				t.Errorf("ticks = %d, want %d", got, tt.wantTicks)
+			}
+			if state.Tick != tt.wantTick {
+				t.Errorf("Tick = %d, want %d", state.Tick, tt.wantTick)
+			}
+			if want := startX + float64(tt.wantTick)*unit.MoveSpeed; unit.X != want || unit.Y != unit.TargetY {
+				t.Errorf("unit at (%.1f, %.1f), want x %.1f on a straight line east", unit.X, unit.Y, want)
+			}
+		})
+	}
+}
+
+// Test that stepGame rejects bad deltas and errors cleanly before the game has started
+func TestStepGameErrors(t *testing.T) {
+	newTestState(10, 10)
+	game.InitializeJSInterface()
+
+	if code := js.Global().Call("stepGame", -5).Get("code").String(); code != game.CodeInvalidArguments {
+		t.Errorf("stepGame(-5) code = %s, want %s", code, game.CodeInvalidArguments)
+	}
+
+	saved := game.State
+	game.State = nil
+	defer func() { game.State = saved }()
+	if code := js.Global().Call("stepGame", 16).Get("code").String(); code != game.CodeNotStarted {
+		t.Errorf("stepGame() before start code = %s, want %s", code, game.CodeNotStarted)
+	}
+}
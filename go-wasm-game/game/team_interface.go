@@ -0,0 +1,38 @@
+package game
+
+import (
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Team colors and membership exposed to JavaScript
+
+func setTeamColor(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return jsError(CodeInvalidArguments, "setTeamColor requires team, color")
+	}
+
+	if err := units.SetTeamColor(args[0].Int(), args[1].String()); err != nil {
+		return jsError(CodeInvalidArguments, err.Error())
+	}
+
+	return jsSuccess(nil)
+}
+
+func setUnitTeam(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return jsError(CodeInvalidArguments, "setUnitTeam requires unitId, team")
+	}
+
+	if err := State.UnitManager.SetUnitTeam(args[0].String(), args[1].Int()); err != nil {
+		return jsErrorFrom(err)
+	}
+
+	return jsSuccess(nil)
+}
+
+// initializeTeamInterface sets up JavaScript bindings for teams
+func initializeTeamInterface() {
+	exposeFunc("setTeamColor", setTeamColor)
+	exposeFunc("setUnitTeam", setUnitTeam)
+}
@@ -0,0 +1,48 @@
+package game
+
+import "syscall/js"
+
+// Unit transports exposed to JavaScript
+
+// loadUnit puts an adjacent passenger inside a transport unit
+func loadUnit(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return jsError(CodeInvalidArguments, "loadUnit requires transportId, passengerId")
+	}
+
+	if err := State.UnitManager.LoadUnit(args[0].String(), args[1].String()); err != nil {
+		return jsErrorFrom(err)
+	}
+
+	return jsSuccess(nil)
+}
+
+// unloadUnit drops a passenger next to its transport, on the given tile if tileX, tileY are passed
+func unloadUnit(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return jsError(CodeInvalidArguments, "unloadUnit requires transportId, passengerId")
+	}
+
+	var err error
+	if len(args) >= 4 {
+		err = State.UnitManager.UnloadUnitAt(args[0].String(), args[1].String(), args[2].Int(), args[3].Int())
+	} else {
+		err = State.UnitManager.UnloadUnit(args[0].String(), args[1].String())
+	}
+	if err != nil {
+		return jsErrorFrom(err)
+	}
+
+	passenger := State.UnitManager.GetUnit(args[1].String())
+	return jsSuccess(map[string]interface{}{
+		"tileX": passenger.TileX,
+		"tileY": passenger.TileY,
+	})
+}
+
+// initializeTransportInterface sets up JavaScript bindings for loading and unloading transports
+func initializeTransportInterface() {
+	exposeFunc("loadUnit", loadUnit)
+	
+	exposeFunc("unloadUnit", unloadUnit)
+}
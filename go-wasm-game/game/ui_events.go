@@ -5,7 +5,7 @@ import "syscall/js"
 // setupUIEventHandlers sets up UI-specific event handlers
 func SetupUIEventHandlers(canvas js.Value, uiSystem interface{}) {
 	// Add mouse move handler for UI hover effects
-	canvas.Call("addEventListener", "mousemove", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+	addEventListener(canvas, "mousemove", func(this js.Value, args []js.Value) interface{} {
 		event := args[0]
 		rect := canvas.Call("getBoundingClientRect")
 		x := event.Get("clientX").Float() - rect.Get("left").Float()
@@ -16,10 +16,10 @@ func SetupUIEventHandlers(canvas js.Value, uiSystem interface{}) {
 			ui.HandleMouseMove(x, y)
 		}
 		return nil
-	}))
+	})
 	
 	// Add custom click handler that checks UI first
-	canvas.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+	addEventListener(canvas, "click", func(this js.Value, args []js.Value) interface{} {
 		event := args[0]
 		rect := canvas.Call("getBoundingClientRect")
 		x := event.Get("clientX").Float() - rect.Get("left").Float()
@@ -35,7 +35,7 @@ func SetupUIEventHandlers(canvas js.Value, uiSystem interface{}) {
 		// Pass click to game logic
 		HandleGameClick(event)
 		return nil
-	}))
+	})
 }
 
 // HandleGameClick processes game area clicks
@@ -52,6 +52,11 @@ func HandleGameClick(event js.Value) {
 		return // Click was in UI area, ignore
 	}
 	
+	// Shift-clicks confirming a move preview are handled by the game click handler
+	if previewing(event) {
+		return
+	}
+	
 	// Convert screen coordinates to world coordinates
 	worldX := mouseX + State.CameraX
 	worldY := mouseY + State.CameraY
@@ -61,8 +66,19 @@ func HandleGameClick(event js.Value) {
 	
 	// Check if the tile is within map bounds
 	if tileX >= 0 && tileX < State.GameMap.Width && tileY >= 0 && tileY < State.GameMap.Height {
-		// Move player to the clicked tile
-		State.Player.MoveToTile(tileX, tileY)
+		// In debug mode, jump straight to the tile (non-walkable tiles are ignored)
+		if State.DebugTeleport {
+			State.TeleportPlayerToTile(tileX, tileY)
+			return
+		}
+		
+		// In placement mode the click drops a unit (snapped to the placement grid) instead
+		if State.PlacingUnits {
+			State.PlaceUnit(State.PlacementUnitType, tileX, tileY)
+			return
+		}
+		
+		// Plain clicks are left to the game's mousedown and click handlers, which already moved the player
 	}
 }
 
@@ -0,0 +1,53 @@
+package game
+
+import (
+	"syscall/js"
+)
+
+// Unit event callbacks exposed to JavaScript
+
+// onUnitArrived calls a JS callback with the unit ID once the unit stops on its move destination
+func onUnitArrived(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 || args[1].Type() != js.TypeFunction {
+		return jsError(CodeInvalidArguments, "onUnitArrived requires unitId, callback")
+	}
+
+	unitID, callback := args[0].String(), args[1]
+	err := State.UnitManager.SetArrivalCallback(unitID, func() {
+		callback.Invoke(unitID)
+	})
+	if err != nil {
+		return jsErrorFrom(err)
+	}
+
+	return jsSuccess(nil)
+}
+
+// onGroupArrived calls a JS callback with the unit IDs once every listed unit has arrived, died or been removed
+func onGroupArrived(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 || args[0].Type() != js.TypeObject || args[1].Type() != js.TypeFunction {
+		return jsError(CodeInvalidArguments, "onGroupArrived requires unitIds array, callback")
+	}
+
+	length := args[0].Length()
+	unitIDs := make([]string, length)
+	for i := 0; i < length; i++ {
+		unitIDs[i] = args[0].Index(i).String()
+	}
+	callback := args[1]
+	State.UnitManager.OnGroupArrived(unitIDs, func() {
+		ids := make([]interface{}, len(unitIDs))
+		for i, id := range unitIDs {
+			ids[i] = id
+		}
+		callback.Invoke(ids)
+	})
+
+	return jsSuccess(nil)
+}
+
+// initializeUnitCallbackInterface sets up JavaScript bindings for unit event callbacks
+func initializeUnitCallbackInterface() {
+	exposeFunc("onUnitArrived", onUnitArrived)
+	exposeFunc("onGroupArrived", onGroupArrived)
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Unit detail panel data exposed to JavaScript
+
+// getUnit returns the full detail of one unit: getUnit(unitId)
+func getUnit(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return jsError(CodeInvalidArguments, "getUnit requires unitId")
+	}
+
+	detail, err := State.UnitManager.UnitDetail(args[0].String())
+	if err != nil {
+		return jsErrorFrom(err)
+	}
+
+	return jsSuccess(unitDetailToJS(detail))
+}
+
+// unitDetailToJS converts a unit detail snapshot into plain values js.ValueOf accepts
+func unitDetailToJS(detail units.UnitDetail) map[string]interface{} {
+	effects := make([]interface{}, 0, len(detail.Effects))
+	for _, effect := range detail.Effects {
+		effects = append(effects, map[string]interface{}{
+			"source":           effect.Source,
+			"stat":             effect.Stat.String(),
+			"add":              effect.Add,
+			"multiply":         effect.Multiply,
+			"remainingSeconds": effect.Remaining.Seconds(),
+			"permanent":        effect.Remaining == 0,
+		})
+	}
+
+	return map[string]interface{}{
+		"id":             detail.ID,
+		"name":           detail.Name,
+		"typeId":         int(detail.TypeID),
+		"team":           detail.Team,
+		"level":          detail.Level,
+		"experience":     detail.Experience,
+		"kills":          detail.Kills,
+		"damageDealt":    detail.DamageDealt,
+		"damageTaken":    detail.DamageTaken,
+		"status":         detail.Status,
+		"stance":         detail.Stance.String(),
+		"alive":          detail.IsAlive,
+		"tileX":          detail.TileX,
+		"tileY":          detail.TileY,
+		"x":              detail.X,
+		"y":              detail.Y,
+		"stats":          statsToJS(detail.Stats),
+		"maxStats":       statsToJS(detail.MaxStats),
+		"effectiveStats": statsToJS(detail.EffectiveStats),
+		"moving":         detail.Moving,
+		"pathLength":     detail.PathLength,
+		"effects":        effects,
+		"carriedBy":      detail.CarriedBy,
+	}
+}
+
+// statsToJS converts unit stats into a JS-friendly map
+func statsToJS(stats entities.UnitStats) map[string]interface{} {
+	return map[string]interface{}{
+		"health":  stats.Health,
+		"damage":  stats.Damage,
+		"speed":   stats.Speed,
+		"defense": stats.Defense,
+		"range":   stats.Range,
+	}
+}
+
+// initializeUnitDetailInterface sets up the JavaScript binding for unit details
+func initializeUnitDetailInterface() {
+	exposeFunc("getUnit", getUnit)
+}
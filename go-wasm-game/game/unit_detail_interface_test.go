@@ -0,0 +1,78 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"syscall/js"
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that getUnit serializes stats, effects and the active path of a unit
+func TestGetUnitSerializesDetail(t *testing.T) {
+	state := newTestState(10, 10)
+	game.InitializeJSInterface()
+	
+	created := js.Global().Call("createUnit", 0, 1, 1, "Scout")
+	if !created.Get("success").Bool() {
+		t.Fatalf("setup createUnit failed: %v", created.Get("error"))
+	}
+	unitID := created.Get("data").Get("id").String()
+	unit := state.UnitManager.GetUnit(unitID)
+	unit.Kills, unit.DamageDealt, unit.DamageTaken = 2, 45, 30
+	unit.AddModifier(units.StatModifier{Source: "sword", Stat: units.StatDamage, Add: 5})
+	unit.AddTimedModifier(units.StatModifier{Source: "haste", Stat: units.StatSpeed, Multiply: 1.5}, time.Minute)
+	if err := state.UnitManager.MoveUnit(unitID, 5, 1); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	
+	result := js.Global().Call("getUnit", unitID)
+	if !result.Get("success").Bool() {
+		t.Fatalf("getUnit failed: %v", result.Get("error"))
+	}
+	data := result.Get("data")
+	if data.Get("name").String() != "Scout" || data.Get("tileX").Int() != 1 || data.Get("stance").String() != "aggressive" {
+		t.Errorf("name, tileX, stance = %v, %v, %v, want Scout, 1, aggressive", data.Get("name"), data.Get("tileX"), data.Get("stance"))
+	}
+	if data.Get("kills").Int() != 2 || data.Get("damageDealt").Int() != 45 || data.Get("damageTaken").Int() != 30 {
+		t.Errorf("kills, damageDealt, damageTaken = %v, %v, %v, want 2, 45, 30", data.Get("kills"), data.Get("damageDealt"), data.Get("damageTaken"))
+	}
+	if !data.Get("moving").Bool() || data.Get("pathLength").Int() != 4 {
+		t.Errorf("moving, pathLength = %v, %v, want true, 4", data.Get("moving"), data.Get("pathLength"))
+	}
+	if got, want := data.Get("effectiveStats").Get("damage").Int(), unit.MaxStats.Damage+5; got != want {
+		t.Errorf("effectiveStats.damage = %d, want %d", got, want)
+	}
+	if got := data.Get("maxStats").Get("health").Int(); got != unit.MaxStats.Health {
+		t.Errorf("maxStats.health = %d, want %d", got, unit.MaxStats.Health)
+	}
+	
+	effects := data.Get("effects")
+	if effects.Length() != 2 {
+		t.Fatalf("effects length = %d, want 2", effects.Length())
+	}
+	sword, haste := effects.Index(0), effects.Index(1)
+	if sword.Get("stat").String() != "damage" || sword.Get("add").Float() != 5 || !sword.Get("permanent").Bool() {
+		t.Errorf("effects[0] = %v %v permanent %v, want a permanent +5 damage", sword.Get("stat"), sword.Get("add"), sword.Get("permanent"))
+	}
+	if remaining := haste.Get("remainingSeconds").Float(); haste.Get("stat").String() != "speed" || haste.Get("permanent").Bool() || remaining <= 0 || remaining > 60 {
+		t.Errorf("effects[1] = %v with %v seconds left, want a timed speed effect", haste.Get("stat"), remaining)
+	}
+}
+
+// Test that getUnit reports a missing unit with the not-found code
+func TestGetUnitNotFound(t *testing.T) {
+	newTestState(10, 10)
+	game.InitializeJSInterface()
+	
+	result := js.Global().Call("getUnit", "unit_404")
+	if result.Get("success").Bool() {
+		t.Fatal("getUnit(unit_404) succeeded, want failure")
+	}
+	if code := result.Get("code").String(); code != game.CodeNotFound {
+		t.Errorf("code = %q, want %q", code, game.CodeNotFound)
+	}
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"encoding/json"
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// ViewStorageKey is the localStorage key saveView and restoreView use
+const ViewStorageKey = "goWasmGameView"
+
+// ViewSnapshot is the camera part of a saved session: what the player was looking at and how zoomed in
+type ViewSnapshot struct {
+	CameraX float64 `json:"cameraX"`
+	CameraY float64 `json:"cameraY"`
+	Zoom    float64 `json:"zoom"`
+}
+
+// SaveSnapshot is the saved session saveView stores and restoreView loads between page loads
+type SaveSnapshot struct {
+	View ViewSnapshot `json:"view"`
+}
+
+// View captures the current camera position and unit zoom
+func (gs *GameState) View() ViewSnapshot {
+	return ViewSnapshot{CameraX: gs.CameraX, CameraY: gs.CameraY, Zoom: gs.UnitManager.Renderer().Zoom}
+}
+
+// RestoreView applies a saved view to a game area of the given size and returns the view actually applied.
+// The camera is clamped with the current edge mode, so a view saved on a larger map or canvas can't end up
+// off the map. Only the camera and zoom change: the view is held in place instead of following the player
+// until the player moves or a follow target is chosen; a non-positive zoom keeps the current one
+func (gs *GameState) RestoreView(view ViewSnapshot, viewWidth, viewHeight float64) ViewSnapshot {
+	mapWidth, mapHeight := gs.GameMap.WorldSize()
+	view.CameraX, view.CameraY = systems.ClampCameraPure([2]float64{view.CameraX, view.CameraY},
+		[2]float64{viewWidth, viewHeight}, [2]float64{mapWidth, mapHeight}, gs.CameraEdgeMode)
+	if view.Zoom <= 0 {
+		view.Zoom = gs.UnitManager.Renderer().Zoom
+	}
+	
+	gs.UpdateCamera(view.CameraX, view.CameraY)
+	gs.UnitManager.Renderer().Zoom = view.Zoom
+	gs.CameraFollowTarget = ""
+	playerX, playerY := gs.Player.GetPosition()
+	gs.heldView = &heldView{centerX: view.CameraX + viewWidth/2, centerY: view.CameraY + viewHeight/2, playerX: playerX, playerY: playerY}
+	return view
+}
+
+// Snapshot captures the session state that survives a page load
+func (gs *GameState) Snapshot() SaveSnapshot {
+	return SaveSnapshot{View: gs.View()}
+}
+
+// RestoreSnapshot applies a saved session to a game area of the given size, the view through RestoreView
+// so it is clamped to the current map; returns the snapshot actually applied
+func (gs *GameState) RestoreSnapshot(snapshot SaveSnapshot, viewWidth, viewHeight float64) SaveSnapshot {
+	snapshot.View = gs.RestoreView(snapshot.View, viewWidth, viewHeight)
+	return snapshot
+}
+
+// viewJS converts a view to the object returned to JavaScript
+func viewJS(view ViewSnapshot) map[string]interface{} {
+	return map[string]interface{}{
+		"cameraX": view.CameraX,
+		"cameraY": view.CameraY,
+		"zoom":    view.Zoom,
+	}
+}
+
+// saveView stores the session snapshot, with the current camera and zoom, in localStorage and returns the view
+func saveView(this js.Value, args []js.Value) interface{} {
+	snapshot := State.Snapshot()
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return jsErrorFrom(err)
+	}
+	storage := js.Global().Get("localStorage")
+	if !storage.Truthy() {
+		return jsError(CodeInternal, "localStorage is not available")
+	}
+	storage.Call("setItem", ViewStorageKey, string(data))
+	return jsSuccess(viewJS(snapshot.View))
+}
+
+// restoreView applies the snapshot saved by saveView, or one passed as JSON, and returns its view: restoreView(json?)
+func restoreView(this js.Value, args []js.Value) interface{} {
+	var saved js.Value
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		saved = args[0]
+	} else if storage := js.Global().Get("localStorage"); storage.Truthy() {
+		saved = storage.Call("getItem", ViewStorageKey)
+	}
+	if saved.Type() != js.TypeString {
+		return jsError(CodeNotFound, "no saved view")
+	}
+	
+	var snapshot SaveSnapshot
+	if err := json.Unmarshal([]byte(saved.String()), &snapshot); err != nil {
+		return jsError(CodeInvalidArguments, "invalid saved view: "+err.Error())
+	}
+	canvasWidth, canvasHeight := State.Canvas.Get("width").Float(), State.Canvas.Get("height").Float()
+	return jsSuccess(viewJS(State.RestoreSnapshot(snapshot, canvasWidth, canvasHeight-GetUIAreaHeight()).View))
+}
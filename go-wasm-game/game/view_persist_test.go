@@ -0,0 +1,148 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game_test
+
+import (
+	"encoding/json"
+	"syscall/js"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// Test that restoring a saved view clamps a camera that lies beyond the current map
+func TestRestoreViewClampsToMap(t *testing.T) {
+	// The 20x10 map is 640x320 world units; the game area shows 200x100 of it
+	tests := []struct {
+		name         string
+		view         game.ViewSnapshot
+		mode         systems.CameraEdgeMode
+		wantX, wantY float64
+	}{
+		{"inside the map", game.ViewSnapshot{CameraX: 100, CameraY: 50, Zoom: 1.5}, systems.CameraEdgeClamp, 100, 50},
+		{"past the far edges", game.ViewSnapshot{CameraX: 5000, CameraY: 900, Zoom: 1.5}, systems.CameraEdgeClamp, 440, 220},
+		{"before the near edges", game.ViewSnapshot{CameraX: -300, CameraY: -40, Zoom: 1.5}, systems.CameraEdgeClamp, 0, 0},
+		{"wrapping maps are not clamped", game.ViewSnapshot{CameraX: 5000, CameraY: -40, Zoom: 1.5}, systems.CameraEdgeWrap, 5000, -40},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newTestState(20, 10)
+			state.CameraEdgeMode = tt.mode
+			applied := state.RestoreView(tt.view, 200, 100)
+			if applied.CameraX != tt.wantX || applied.CameraY != tt.wantY {
+				t.Errorf("RestoreView() camera = (%v, %v), want (%v, %v)", applied.CameraX, applied.CameraY, tt.wantX, tt.wantY)
+			}
+			if view := state.View(); view != applied || view.Zoom != 1.5 {
+				t.Errorf("View() after restore = %+v, want %+v", view, applied)
+			}
+		})
+	}
+}
+
+// Test that a save snapshot carries the view and restoring it clamps the camera like RestoreView
+func TestRestoreSnapshotAppliesView(t *testing.T) {
+	state := newTestState(20, 10)
+	state.UpdateCamera(300, 120)
+	state.UnitManager.Renderer().Zoom = 2
+	snapshot := state.Snapshot()
+	if snapshot.View != state.View() {
+		t.Fatalf("Snapshot().View = %+v, want the current view %+v", snapshot.View, state.View())
+	}
+	
+	// Restored onto a smaller map, the camera comes back inside it
+	state = newTestState(10, 5)
+	applied := state.RestoreSnapshot(snapshot, 200, 100)
+	if applied.View.CameraX != 120 || applied.View.CameraY != 60 || applied.View.Zoom != 2 {
+		t.Errorf("RestoreSnapshot() view = %+v, want camera clamped to (120, 60) at zoom 2", applied.View)
+	}
+	if view := state.View(); view != applied.View {
+		t.Errorf("View() after restore = %+v, want %+v", view, applied.View)
+	}
+}
+
+// Test that restoring a view leaves the player alone and holds the camera there until the player moves
+func TestRestoreViewHoldsCamera(t *testing.T) {
+	state := newTestState(20, 10)
+	state.TeleportPlayerToTile(2, 2)
+	playerX, playerY := state.Player.GetPosition()
+	state.RestoreView(game.ViewSnapshot{CameraX: 5000, CameraY: 5000}, 200, 100)
+	
+	if x, y := state.Player.GetPosition(); x != playerX || y != playerY {
+		t.Errorf("player moved to (%v, %v) by RestoreView, want it left at (%v, %v)", x, y, playerX, playerY)
+	}
+	if zoom := state.View().Zoom; zoom != 1.0 {
+		t.Errorf("zoom = %v, want the current zoom kept for a snapshot without one", zoom)
+	}
+	
+	// The follow camera centers on the focus, so a held view reports its own center, (540, 270)
+	for frame := 0; frame < 3; frame++ {
+		state.Step()
+		if x, y, width, height := state.CameraFocus(); x != 540 || y != 270 || width != 0 || height != 0 {
+			t.Fatalf("CameraFocus() = (%v, %v, %v, %v) on frame %d, want the held view center (540, 270)", x, y, width, height, frame)
+		}
+	}
+	
+	state.MovePlayer(4, 2)
+	for frame := 0; frame < 5; frame++ {
+		state.Step()
+	}
+	x, y := state.Player.GetPosition()
+	if focusX, focusY, _, _ := state.CameraFocus(); focusX != x || focusY != y {
+		t.Errorf("CameraFocus() = (%v, %v) after the player moved, want the player at (%v, %v)", focusX, focusY, x, y)
+	}
+}
+
+// Test that saveView and restoreView round-trip through localStorage
+func TestSaveAndRestoreViewViaJS(t *testing.T) {
+	state := newTestState(20, 10)
+	state.Canvas = js.Global().Get("Object").New()
+	state.Canvas.Set("width", 200)
+	state.Canvas.Set("height", 160) // 100 of game area above the UI area
+	game.InitializeJSInterface()
+	
+	items := map[string]string{}
+	storage := js.Global().Get("Object").New()
+	setItem := js.FuncOf(func(this js.Value, args []js.Value) interface{} { items[args[0].String()] = args[1].String(); return nil })
+	getItem := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if value, ok := items[args[0].String()]; ok {
+			return value
+		}
+		return nil
+	})
+	defer setItem.Release()
+	defer getItem.Release()
+	storage.Set("setItem", setItem)
+	storage.Set("getItem", getItem)
+	js.Global().Set("localStorage", storage)
+	defer js.Global().Delete("localStorage")
+	
+	if code := js.Global().Call("restoreView").Get("code").String(); code != game.CodeNotFound {
+		t.Errorf("restoreView() with nothing saved code = %s, want %s", code, game.CodeNotFound)
+	}
+	
+	state.UpdateCamera(5000, 5000) // As if saved on a bigger map
+	state.UnitManager.Renderer().Zoom = 2
+	if result := js.Global().Call("saveView"); !result.Get("success").Bool() {
+		t.Fatalf("saveView() failed: %v", result.Get("error"))
+	}
+	var saved game.SaveSnapshot
+	if err := json.Unmarshal([]byte(items[game.ViewStorageKey]), &saved); err != nil || saved.View.Zoom != 2 {
+		t.Fatalf("stored snapshot %q (error %v), want its view at zoom 2", items[game.ViewStorageKey], err)
+	}
+	state.UpdateCamera(0, 0)
+	state.UnitManager.Renderer().Zoom = 1
+	
+	result := js.Global().Call("restoreView")
+	if !result.Get("success").Bool() {
+		t.Fatalf("restoreView() failed: %v", result.Get("error"))
+	}
+	if view := state.View(); view.CameraX != 440 || view.CameraY != 220 || view.Zoom != 2 {
+		t.Errorf("restored view = %+v, want camera clamped to (440, 220) at zoom 2", view)
+	}
+	
+	if code := js.Global().Call("restoreView", "{not json").Get("code").String(); code != game.CodeInvalidArguments {
+		t.Errorf("restoreView(bad JSON) code = %s, want %s", code, game.CodeInvalidArguments)
+	}
+}
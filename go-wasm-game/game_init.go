@@ -2,26 +2,37 @@ package main
 
 import (
 	"syscall/js"
+	"time"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/ui"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
 )
 
+// decorationSeed fixes the cosmetic flower/rock scatter so the map looks the same on every load
+const decorationSeed = 2024
+
+// spatialReconcileInterval is how often units left sharing a tile get separated
+const spatialReconcileInterval = 2 * time.Second
+
 // initializeGameEntities creates and initializes player and unit manager
 func initializeGameEntities(gameMap *world.Map) (*entities.Player, *units.UnitManager, *ui.UISystem) {
 	// Create unit manager
 	um := units.NewUnitManager(gameMap)
+	um.SetSpatialReconcile(spatialReconcileInterval)
 	
 	// Calculate world dimensions and create player at center
-	mapWorldWidth := float64(gameMap.Width) * gameMap.TileSize
-	mapWorldHeight := float64(gameMap.Height) * gameMap.TileSize
+	mapWorldWidth, mapWorldHeight := gameMap.WorldSize()
 
 	// Create player at center of map
 	centerX := (mapWorldWidth - 20) / 2
 	centerY := (mapWorldHeight - 20) / 2
 	p := entities.NewPlayer(centerX, centerY, gameMap)
+	
+	// Route the player around units instead of through them
+	p.SetOccupancyCheck(um.IsPositionOccupied)
 
 	// Initialize UI system
 	uiSys := ui.NewUISystem()
@@ -39,6 +50,13 @@ func initializeGameSystems(ctx, canvas js.Value, player *entities.Player, gameMa
 
 	// Initialize game state for shared access
 	game.InitializeState(ctx, canvas, player, gameMap, unitManager, environment)
+	game.State.FogOfWar = systems.NewFogOfWar(gameMap.Width, gameMap.Height)
+	game.State.FogOfWar.SetSightBlocker(gameMap.BlocksSight)
+	gameMap.SetTrafficDiscount(0.2) // Mildly prefer routes units use often
+	gameMap.GenerateDecorations(decorationSeed, 0.06)
+	playerX, playerY := player.GetPosition()
+	game.State.Pet = entities.NewPet(playerX, playerY, gameMap)
+	game.State.Particles = systems.NewParticleSystem(systems.DefaultMaxParticles)
 
 	// Initialize game layers
 	initializeGameLayers()
@@ -47,15 +65,152 @@ func initializeGameSystems(ctx, canvas js.Value, player *entities.Player, gameMa
 	game.SetupUIEventHandlers(canvas, uiSystem)
 }
 
-// renderObjectsLayer renders objects (units) on the game map
-func renderObjectsLayer(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
-	unitManager.Render(ctx, cameraX, cameraY)
+// renderPathDebugLayer draws the player's remaining path colored by terrain cost
+func renderPathDebugLayer(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
+	path := player.GetPath()
+	if len(path) < 2 {
+		return
+	}
+	
+	colors := systems.PathStepColors(path, gameMap)
+	ctx.Set("lineWidth", 4)
+	
+	// Only draw segments the player hasn't finished walking yet
+	start := player.GetPathStep()
+	if start < 1 {
+		start = 1
+	}
+	for i := start; i < len(path); i++ {
+		fromX, fromY := gameMap.GridToWorld(path[i-1].X, path[i-1].Y)
+		toX, toY := gameMap.GridToWorld(path[i].X, path[i].Y)
+		
+		ctx.Set("strokeStyle", colors[i])
+		ctx.Call("beginPath")
+		ctx.Call("moveTo", fromX-cameraX, fromY-cameraY)
+		ctx.Call("lineTo", toX-cameraX, toY-cameraY)
+		ctx.Call("stroke")
+	}
+}
+
+// updateCosmetics advances purely visual extras that don't belong in the simulation step by dt
+func updateCosmetics(dt time.Duration) {
+	game.State.Shake.Update(dt)
+	updatePet(dt)
+	updateParticles()
+}
+
+// updateParticles spawns footstep particles under the moving player and units and ages existing ones
+func updateParticles() {
+	particles := game.State.Particles
+	if particles == nil {
+		return
+	}
+	
+	x, y := player.GetPosition()
+	width, height := player.MovableEntity.GetSize()
+	particles.TrackMovement("player", x+width/2, y+height, player.IsMoving(), gameMap)
+	for _, unit := range unitManager.OrderedUnits() {
+		ux, uy := unit.GetPosition()
+		particles.TrackMovement(unit.ID, ux+unit.Width/2, uy+unit.Height, unit.IsAlive && unit.IsMoving(), gameMap)
+	}
+	particles.Update()
+}
+
+// renderEffectsLayer draws footstep particles between the terrain and the units
+func renderEffectsLayer(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
+	particles := game.State.Particles
+	if particles == nil {
+		return
+	}
+	
+	ctx.Call("save")
+	for _, p := range particles.Particles() {
+		ctx.Set("globalAlpha", p.Alpha())
+		ctx.Set("fillStyle", p.Color)
+		ctx.Call("fillRect", p.X-cameraX-1.5, p.Y-cameraY-1.5, 3, 3)
+	}
+	ctx.Call("restore")
+}
+
+// updatePet moves the pet toward a spot behind the player
+func updatePet(dt time.Duration) {
+	pet := game.State.Pet
+	if pet == nil {
+		return
+	}
+	
+	x, y := player.GetPosition()
+	width, height := player.MovableEntity.GetSize()
+	pet.Follow(gameMap.WorldToGrid(x+width/2, y+height/2))
+	pet.Update(dt)
+}
+
+// visionRadius is how many tiles the player and units can see
+const visionRadius = 8
+
+// updateFogOfWar recomputes vision around the player and units and advances reveal fades
+func updateFogOfWar() {
+	fog := game.State.FogOfWar
+	if fog == nil {
+		return
+	}
+	
+	x, y := player.GetPosition()
+	width, height := player.MovableEntity.GetSize()
+	playerTileX, playerTileY := gameMap.WorldToGrid(x+width/2, y+height/2)
+	sources := [][2]int{{playerTileX, playerTileY}}
+	for _, unit := range unitManager.OrderedUnits() {
+		if unit.IsAlive {
+			sources = append(sources, [2]int{unit.TileX, unit.TileY})
+		}
+	}
+	
+	fog.UpdateVision(sources, visionRadius)
+	fog.Advance()
+}
+
+// renderFogOfWar darkens tiles by how hidden they are, so newly revealed tiles fade in
+func renderFogOfWar(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
+	fog := game.State.FogOfWar
+	if fog == nil {
+		return
+	}
+	
+	tileWidth, tileHeight := gameMap.TileDimensions()
+	startX, startY := int(cameraX/tileWidth), int(cameraY/tileHeight)
+	endX, endY := int((cameraX+canvasWidth)/tileWidth)+1, int((cameraY+canvasHeight)/tileHeight)+1
+	
+	ctx.Call("save")
+	ctx.Set("fillStyle", "#000000")
+	for tileY := startY; tileY <= endY; tileY++ {
+		for tileX := startX; tileX <= endX; tileX++ {
+			hidden := 1 - fog.RevealAlpha(tileX, tileY)
+			if hidden <= 0 {
+				continue
+			}
+			ctx.Set("globalAlpha", hidden)
+			ctx.Call("fillRect", float64(tileX)*tileWidth-cameraX, float64(tileY)*tileHeight-cameraY, tileWidth, tileHeight)
+		}
+	}
+	ctx.Call("restore")
 }
 
 // initializeGameLayers sets up all game layers after game objects are created
 func initializeGameLayers() {
-	// Add objects layer (priority 10 - foreground)
+	// Add footstep effects below the units (priority 5)
+	gameMap.Layers.AddLayer("effects", 5, true, renderEffectsLayer)
+	
+	// Add objects layer (priority 10 - foreground): trees and bushes, then the depth-sorted units, pet and player
 	gameMap.Layers.AddLayer("objects", 10, true, renderObjectsLayer)
+	
+	// Add path debug overlay (priority 20, hidden until enabled via setLayerVisible)
+	gameMap.Layers.AddLayer("path-debug", 20, false, renderPathDebugLayer)
+	
+	// Add the shift-hover move preview for the selected unit (priority 25)
+	gameMap.Layers.AddLayer("move-preview", 25, true, game.RenderMovePreview)
+	
+	// Add the hover attack range preview for the selected unit (priority 26)
+	gameMap.Layers.AddLayer("attack-preview", 26, true, game.RenderAttackPreview)
 }
 
 // setupUIHandlers sets up UI button handlers
@@ -64,6 +219,10 @@ func setupUIHandlers(um *units.UnitManager, uiSys *ui.UISystem) {
 	ui.SpawnUnitCallback = func() {
 		err := um.SpawnRandomUnit()
 		if err == nil {
+			// Record the spawn by its outcome so replays don't depend on the random roll
+			ids := um.GetUnitIDsInOrder()
+			game.State.RecordSpawn(um.GetUnit(ids[len(ids)-1]))
+			
 			// Update UI with new unit count
 			uiSys.SetUnitCount(um.GetTotalUnitCount())
 		}
@@ -4,6 +4,7 @@ import (
 	"syscall/js"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/game"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/ui"
@@ -26,6 +27,12 @@ var (
 var drawFunc js.Func
 
 func draw(this js.Value, args []js.Value) interface{} {
+	// Tear down between frames once destroyGame has been called
+	if game.ShutdownRequested() {
+		game.Shutdown()
+		return nil
+	}
+	
 	// Get current canvas dimensions
 	canvasWidth = canvas.Get("width").Float()
 	canvasHeight = canvas.Get("height").Float()
@@ -33,45 +40,33 @@ func draw(this js.Value, args []js.Value) interface{} {
 	// Update UI system with current canvas size
 	uiSystem.UpdateCanvasSize(canvasWidth, canvasHeight)
 	
-	// Update player (handles movement animations with pathfinding and tile-based speed)
-	player.Update()
+	// Advance the simulation in fixed ticks by the time since the last frame (replayed commands, player and unit movement)
+	dt := game.State.StepFrame(args[0].Float())
+	updateCosmetics(dt)
 	
-	// Update all units using the unified movement system
-	unitManager.Update()
+	// Recompute vision and advance fog reveal fades
+	updateFogOfWar()
 	
 	// Keep player within world bounds (map bounds)
 	player.ClampToMapBounds(float64(gameMap.Width), float64(gameMap.Height), gameMap.TileSize)
 	
-	// Get player position
-	playerX, playerY := player.GetPosition()
-	
 	// Calculate game area height (full canvas minus UI area)
 	gameAreaHeight := canvasHeight - uiSystem.GetUIAreaHeight()
 	
-	// Update camera to follow player (center player on screen)
-	width, height := player.MovableEntity.GetSize()
-	cameraX = playerX - canvasWidth/2 + width/2
-	cameraY = playerY - gameAreaHeight/2 + height/2
+	// Update camera to follow the player or the followed unit (centered on screen)
+	focusX, focusY, width, height := game.State.CameraFocus()
+	cameraX = focusX - canvasWidth/2 + width/2
+	cameraY = focusY - gameAreaHeight/2 + height/2
 	
-	// Clamp camera to map bounds
-	mapWorldWidth := float64(gameMap.Width) * gameMap.TileSize
-	mapWorldHeight := float64(gameMap.Height) * gameMap.TileSize
+	// Clamp camera to map bounds (or center small maps, depending on edge mode)
+	mapWorldWidth, mapWorldHeight := gameMap.WorldSize()
+	cameraX, cameraY = systems.ClampCameraPure([2]float64{cameraX, cameraY},
+		[2]float64{canvasWidth, gameAreaHeight}, [2]float64{mapWorldWidth, mapWorldHeight}, game.State.CameraEdgeMode)
 	
-	if cameraX < 0 {
-		cameraX = 0
-	}
-	if cameraY < 0 {
-		cameraY = 0
-	}
-	if cameraX > mapWorldWidth-canvasWidth {
-		cameraX = mapWorldWidth - canvasWidth
-	}
-	if cameraY > mapWorldHeight-gameAreaHeight {
-		cameraY = mapWorldHeight - gameAreaHeight
-	}
-	
-	// Update the game state with current camera position
+	// Update the game state with current camera position, then shake only the drawn view
 	game.State.UpdateCamera(cameraX, cameraY)
+	shakeX, shakeY := game.State.ShakeOffset()
+	cameraX, cameraY = cameraX+shakeX, cameraY+shakeY
 	
 	// Clear canvas
 	ctx.Call("clearRect", 0, 0, canvasWidth, canvasHeight)
@@ -81,27 +76,33 @@ func draw(this js.Value, args []js.Value) interface{} {
 	ctx.Call("rect", 0, 0, canvasWidth, gameAreaHeight)
 	ctx.Call("clip")
 	
+	// Terrain and effects, then the objects layer (trees, units, pet, player), then the overlays above it
 	gameMap.RenderWithLayers(ctx, cameraX, cameraY, canvasWidth, gameAreaHeight)
-
-	// Draw environment objects (trees and bushes)
-	environment.Render(ctx, cameraX, cameraY, canvasWidth, gameAreaHeight)
-	
-	// Draw units
-	unitManager.Render(ctx, cameraX, cameraY)
 	
-	// Draw player
-	player.Draw(ctx, cameraX, cameraY)
+	// Cover everything not currently in vision
+	renderFogOfWar(ctx, cameraX, cameraY, canvasWidth, gameAreaHeight)
 	
 	ctx.Call("restore")
 	
 	// Draw UI system (always on top)
 	uiSystem.Render(ctx)
+	game.RenderDebugConsole(ctx, canvasWidth, canvasHeight)
 	
 	js.Global().Call("requestAnimationFrame", drawFunc)
 	return nil
 }
 
-
+// renderObjectsLayer draws environment objects (trees and bushes), then units, the pet and the player
+// sorted by depth, so whichever is lower on screen overlaps the other
+func renderObjectsLayer(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
+	environment.Render(ctx, cameraX, cameraY, canvasWidth, canvasHeight)
+	
+	characters := []entities.Renderable{player}
+	if game.State.Pet != nil {
+		characters = append(characters, game.State.Pet)
+	}
+	unitManager.Render(ctx, cameraX, cameraY, characters...)
+}
 
 func main() {
 	doc := js.Global().Get("document")
@@ -139,8 +140,9 @@ func main() {
 	// Set flag to indicate WASM is loaded
 	js.Global().Set("wasmLoaded", true)
 
-	drawFunc = js.FuncOf(draw)
+	drawFunc = game.TrackFunc(js.FuncOf(draw))
 	js.Global().Call("requestAnimationFrame", drawFunc)
 
-	select {}
+	// Block until destroyGame has released everything
+	<-game.Done()
 }
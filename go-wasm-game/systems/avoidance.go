@@ -0,0 +1,75 @@
+package systems
+
+import "math"
+
+const (
+	// DefaultAvoidanceRadius is how close, in world units between centers, another unit must be to push an entity aside
+	DefaultAvoidanceRadius = 24.0
+	// DefaultAvoidanceWeight scales the repulsion against the unit-length heading toward the target
+	DefaultAvoidanceWeight = 0.6
+)
+
+// NeighborFunc returns the world-space centers of other entities near a moving entity, e.g. from a spatial query
+type NeighborFunc func(entity Movable, radius float64) [][2]float64
+
+// RepulsionVector sums a push away from each neighbor within radius of center, strongest when touching
+// and fading linearly to zero at radius. Neighbors exactly on the center give no direction and are skipped
+func RepulsionVector(center [2]float64, neighbors [][2]float64, radius float64) (float64, float64) {
+	pushX, pushY := 0.0, 0.0
+	if radius <= 0 {
+		return pushX, pushY
+	}
+	for _, neighbor := range neighbors {
+		dx, dy := center[0]-neighbor[0], center[1]-neighbor[1]
+		distance := math.Sqrt(dx*dx + dy*dy)
+		if distance == 0 || distance >= radius {
+			continue
+		}
+		strength := 1 - distance/radius
+		pushX += dx / distance * strength
+		pushY += dy / distance * strength
+	}
+	return pushX, pushY
+}
+
+// SteerMovementPure moves like ExecuteMovementPure, but along the heading toward the target blended with a
+// repulsion vector. The final step onto the target is never steered, so entities still arrive exactly
+func SteerMovementPure(currentPos, targetPos [2]float64, moveSpeed float64, repulsion [2]float64) (float64, float64) {
+	dx := targetPos[0] - currentPos[0]
+	dy := targetPos[1] - currentPos[1]
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance <= moveSpeed || (repulsion[0] == 0 && repulsion[1] == 0) {
+		return ExecuteMovementPure(currentPos, targetPos, moveSpeed)
+	}
+	
+	headingX, headingY := dx/distance+repulsion[0], dy/distance+repulsion[1]
+	length := math.Sqrt(headingX*headingX + headingY*headingY)
+	if length < 1e-9 {
+		// Pushed straight back as hard as it is pulled: keep going rather than stall head-on
+		return ExecuteMovementPure(currentPos, targetPos, moveSpeed)
+	}
+	return currentPos[0] + headingX/length*moveSpeed, currentPos[1] + headingY/length*moveSpeed
+}
+
+// SetAvoidance turns on local steering around the neighbors reported by neighbors, within radius and blended
+// in with weight; a nil neighbors func (the default) turns it off
+func (ms *MovementSystem) SetAvoidance(neighbors NeighborFunc, radius, weight float64) {
+	ms.neighbors = neighbors
+	ms.avoidanceRadius = radius
+	ms.avoidanceWeight = weight
+}
+
+// steer nudges a movement step away from nearby entities, keeping the original step if the nudge
+// would put the entity's center on an unwalkable tile
+func (ms *MovementSystem) steer(entity Movable, current, target [2]float64, moveSpeed, newX, newY float64) (float64, float64) {
+	width, height := entity.GetSize()
+	center := [2]float64{current[0] + width/2, current[1] + height/2}
+	pushX, pushY := RepulsionVector(center, ms.neighbors(entity, ms.avoidanceRadius), ms.avoidanceRadius)
+	
+	steeredX, steeredY := SteerMovementPure(current, target, moveSpeed, [2]float64{pushX * ms.avoidanceWeight, pushY * ms.avoidanceWeight})
+	tileX, tileY := ms.gameMap.WorldToGrid(steeredX+width/2, steeredY+height/2)
+	if !ms.gameMap.TileDefAt(tileX, tileY).Walkable {
+		return newX, newY
+	}
+	return steeredX, steeredY
+}
@@ -0,0 +1,83 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"math"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that the repulsion points away from close neighbors, fades with distance and is zero with none near
+func TestRepulsionVector(t *testing.T) {
+	center := [2]float64{100, 100}
+	tests := []struct {
+		name         string
+		neighbors    [][2]float64
+		wantX, wantY float64
+	}{
+		{"no neighbors", nil, 0, 0},
+		{"neighbor outside the radius", [][2]float64{{130, 100}}, 0, 0},
+		{"neighbor on the center", [][2]float64{{100, 100}}, 0, 0},
+		{"close neighbor to the east", [][2]float64{{106, 100}}, -0.75, 0},
+		{"farther neighbor to the north", [][2]float64{{100, 88}}, 0, 0.5},
+		{"neighbors on both sides cancel", [][2]float64{{94, 100}, {106, 100}}, 0, 0},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := systems.RepulsionVector(center, tt.neighbors, 24)
+			if math.Abs(x-tt.wantX) > 1e-9 || math.Abs(y-tt.wantY) > 1e-9 {
+				t.Errorf("RepulsionVector() = (%v, %v), want (%v, %v)", x, y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+// Test that steering bends the step sideways at full speed, but leaves plain and final steps alone
+func TestSteerMovementPure(t *testing.T) {
+	current, target := [2]float64{0, 0}, [2]float64{100, 0}
+	
+	if x, y := systems.SteerMovementPure(current, target, 2, [2]float64{0, 0}); x != 2 || y != 0 {
+		t.Errorf("unsteered step = (%v, %v), want the straight (2, 0)", x, y)
+	}
+	
+	x, y := systems.SteerMovementPure(current, target, 2, [2]float64{0, 1})
+	if y <= 0 || x <= 0 || math.Abs(math.Hypot(x, y)-2) > 1e-9 {
+		t.Errorf("steered step = (%v, %v), want a forward sidestep of length 2", x, y)
+	}
+	
+	// The last step lands exactly on the target however hard it is pushed
+	if x, y := systems.SteerMovementPure([2]float64{99, 0}, target, 2, [2]float64{0, 1}); x != 100 || y != 0 {
+		t.Errorf("final step = (%v, %v), want the target (100, 0)", x, y)
+	}
+}
+
+// Test that a movement system with avoidance on sidesteps a neighbor in its way, and moves straight with it off
+func TestMovementSystemAvoidance(t *testing.T) {
+	gameMap := world.NewMap(10, 10, 32.0)
+	neighbor := [2]float64{16 + 3*32 + 10, 16 + 3*32 + 4} // Just ahead of, and slightly below, the path
+	
+	for _, enabled := range []bool{false, true} {
+		ms := systems.NewMovementSystem(gameMap)
+		if enabled {
+			ms.SetAvoidance(func(entity systems.Movable, radius float64) [][2]float64 {
+				return [][2]float64{neighbor}
+			}, systems.DefaultAvoidanceRadius, systems.DefaultAvoidanceWeight)
+		}
+		entity := newEntityAtTile(gameMap, 3, 3)
+		ms.MoveToTile(entity, 8, 3)
+		for i := 0; i < 5; i++ {
+			ms.Update(entity, systems.FrameDuration)
+		}
+		
+		startY := 3*32.0 + 16 - entity.Height/2
+		if sidestep := entity.Y - startY; enabled && sidestep >= 0 {
+			t.Errorf("with avoidance, y moved by %v, want it pushed up away from the neighbor", sidestep)
+		} else if !enabled && sidestep != 0 {
+			t.Errorf("without avoidance, y moved by %v, want a straight line", sidestep)
+		}
+	}
+}
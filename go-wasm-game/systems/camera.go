@@ -0,0 +1,52 @@
+package systems
+
+// CameraEdgeMode controls how the camera behaves at the edges of the world
+type CameraEdgeMode int
+
+const (
+	// CameraEdgeClamp keeps the camera inside the map bounds
+	CameraEdgeClamp CameraEdgeMode = iota
+	// CameraEdgeCenter centers maps smaller than the viewport (letterboxing the rest)
+	CameraEdgeCenter
+	// CameraEdgeWrap leaves the camera unclamped for wrapping maps, which draw tiles past every edge
+	CameraEdgeWrap
+)
+
+// ClampCameraPure applies the edge mode to a desired camera position
+// viewSize is the visible game area and worldSize the map size, both in world units
+func ClampCameraPure(camera, viewSize, worldSize [2]float64, mode CameraEdgeMode) (float64, float64) {
+	x := clampCameraAxis(camera[0], viewSize[0], worldSize[0], mode)
+	y := clampCameraAxis(camera[1], viewSize[1], worldSize[1], mode)
+	return x, y
+}
+
+// clampCameraAxis clamps the camera along a single axis
+func clampCameraAxis(camera, viewSize, worldSize float64, mode CameraEdgeMode) float64 {
+	if mode == CameraEdgeWrap {
+		return camera
+	}
+	
+	// A map narrower than the view has no valid clamp range, so center it
+	if mode == CameraEdgeCenter && worldSize < viewSize {
+		return (worldSize - viewSize) / 2
+	}
+	
+	if camera < 0 {
+		camera = 0
+	}
+	if camera > worldSize-viewSize {
+		camera = worldSize - viewSize
+	}
+	return camera
+}
+
+// ZoomToCursor returns the camera position that keeps the world point under the cursor fixed
+// across a zoom change. The camera is the world position of the view's top-left corner and the
+// cursor is in screen pixels, so a screen point maps to camera + cursor/zoom in world units.
+// Non-positive zoom levels leave the camera where it is.
+func ZoomToCursor(camX, camY, cursorX, cursorY, oldZoom, newZoom float64) (float64, float64) {
+	if oldZoom <= 0 || newZoom <= 0 {
+		return camX, camY
+	}
+	return camX + cursorX/oldZoom - cursorX/newZoom, camY + cursorY/oldZoom - cursorY/newZoom
+}
@@ -0,0 +1,120 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"math"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// Test the camera clamp helper in both edge modes
+func TestClampCameraPure(t *testing.T) {
+	tests := []struct {
+		name                 string
+		camera               [2]float64
+		viewSize             [2]float64
+		worldSize            [2]float64
+		mode                 systems.CameraEdgeMode
+		expectedX, expectedY float64
+	}{
+		{
+			name:      "Clamp inside large map",
+			camera:    [2]float64{100, 200},
+			viewSize:  [2]float64{800, 600},
+			worldSize: [2]float64{6400, 6400},
+			mode:      systems.CameraEdgeClamp,
+			expectedX: 100, expectedY: 200,
+		},
+		{
+			name:      "Clamp negative camera",
+			camera:    [2]float64{-50, -10},
+			viewSize:  [2]float64{800, 600},
+			worldSize: [2]float64{6400, 6400},
+			mode:      systems.CameraEdgeClamp,
+			expectedX: 0, expectedY: 0,
+		},
+		{
+			name:      "Clamp beyond far edge",
+			camera:    [2]float64{6000, 6000},
+			viewSize:  [2]float64{800, 600},
+			worldSize: [2]float64{6400, 6400},
+			mode:      systems.CameraEdgeClamp,
+			expectedX: 5600, expectedY: 5800,
+		},
+		{
+			name:      "Clamp keeps legacy corner behavior for small maps",
+			camera:    [2]float64{10, 10},
+			viewSize:  [2]float64{800, 600},
+			worldSize: [2]float64{320, 320},
+			mode:      systems.CameraEdgeClamp,
+			expectedX: -480, expectedY: -280,
+		},
+		{
+			name:      "Center small map",
+			camera:    [2]float64{10, 10},
+			viewSize:  [2]float64{800, 600},
+			worldSize: [2]float64{320, 320},
+			mode:      systems.CameraEdgeCenter,
+			expectedX: -240, expectedY: -140,
+		},
+		{
+			name:      "Center only the axis that is smaller than the view",
+			camera:    [2]float64{-20, 900},
+			viewSize:  [2]float64{800, 600},
+			worldSize: [2]float64{320, 1000},
+			mode:      systems.CameraEdgeCenter,
+			expectedX: -240, expectedY: 400,
+		},
+		{
+			name:      "Wrap leaves the camera past the edges",
+			camera:    [2]float64{-50, 6000},
+			viewSize:  [2]float64{800, 600},
+			worldSize: [2]float64{6400, 6400},
+			mode:      systems.CameraEdgeWrap,
+			expectedX: -50, expectedY: 6000,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := systems.ClampCameraPure(tt.camera, tt.viewSize, tt.worldSize, tt.mode)
+			if x != tt.expectedX || y != tt.expectedY {
+				t.Errorf("ClampCameraPure() = (%v, %v), want (%v, %v)", x, y, tt.expectedX, tt.expectedY)
+			}
+		})
+	}
+}
+
+// Test that the world point under the cursor stays put across a zoom step
+func TestZoomToCursorKeepsCursorPoint(t *testing.T) {
+	tests := []struct {
+		name               string
+		camX, camY         float64
+		cursorX, cursorY   float64
+		oldZoom, newZoom   float64
+	}{
+		{"zoom in at center", 100, 200, 400, 300, 1, 2},
+		{"zoom out at corner", 0, 0, 0, 0, 2, 1},
+		{"zoom in off-center", -50, 75, 733, 12, 1.25, 1.5},
+		{"zoom out bottom right", 640, 480, 800, 600, 3, 0.5},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			worldX := tt.camX + tt.cursorX/tt.oldZoom
+			worldY := tt.camY + tt.cursorY/tt.oldZoom
+			
+			camX, camY := systems.ZoomToCursor(tt.camX, tt.camY, tt.cursorX, tt.cursorY, tt.oldZoom, tt.newZoom)
+			gotX, gotY := camX+tt.cursorX/tt.newZoom, camY+tt.cursorY/tt.newZoom
+			if math.Abs(gotX-worldX) > 1e-9 || math.Abs(gotY-worldY) > 1e-9 {
+				t.Errorf("world point under cursor moved from (%v, %v) to (%v, %v)", worldX, worldY, gotX, gotY)
+			}
+		})
+	}
+	
+	if camX, camY := systems.ZoomToCursor(10, 20, 5, 5, 1, 0); camX != 10 || camY != 20 {
+		t.Errorf("ZoomToCursor(newZoom=0) = (%v, %v), want camera unchanged", camX, camY)
+	}
+}
@@ -19,13 +19,7 @@ func IsPositionWalkable(x, y, width, height float64, gameMap *world.Map) bool {
 	// Check if any corner of the player would be on a non-walkable tile
 	for _, corner := range corners {
 		tileX, tileY := gameMap.WorldToGrid(corner.px, corner.py)
-		tileType := gameMap.GetTile(tileX, tileY)
-		tileDef, exists := world.TileDefinitions[tileType]
-		if !exists {
-			// If tile definition not found, assume it's walkable (fallback to grass)
-			tileDef = world.TileDefinitions[world.TileGrass]
-		}
-		if !tileDef.Walkable {
+		if !gameMap.TileDefAt(tileX, tileY).Walkable {
 			return false
 		}
 	}
@@ -33,24 +27,22 @@ func IsPositionWalkable(x, y, width, height float64, gameMap *world.Map) bool {
 	return true
 }
 
-// FindNearestWalkableTile finds the closest walkable tile to the target coordinates
-// This is used when the player clicks on water - we find the nearest grass tile
-func FindNearestWalkableTile(targetX, targetY int, gameMap *world.Map) (int, int) {
-	// If the target tile is already walkable, return it
-	tileType := gameMap.GetTile(targetX, targetY)
-	tileDef, exists := world.TileDefinitions[tileType]
-	if !exists {
-		// If tile definition not found, assume it's walkable (fallback to grass)
-		tileDef = world.TileDefinitions[world.TileGrass]
-	}
-	if tileDef.Walkable {
-		return targetX, targetY
+// DefaultWalkableSearchRadius is how far pathfinding looks for a walkable tile to stand in for an unwalkable start or goal
+const DefaultWalkableSearchRadius = 20
+
+// FindNearestWalkableTile finds the closest walkable tile within maxRadius of the target coordinates
+// This is used when the player clicks on water - we find the nearest grass tile. ok is false when
+// nothing within maxRadius is walkable, e.g. deep in a large lake
+func FindNearestWalkableTile(targetX, targetY, maxRadius int, gameMap *world.Map) (x, y int, ok bool) {
+	// If the target tile is already walkable, return it; the exterior is never a destination,
+	// even when the map's OutOfBoundsTile is walkable
+	inBounds := targetX >= 0 && targetX < gameMap.Width && targetY >= 0 && targetY < gameMap.Height
+	if inBounds && gameMap.TileDefAt(targetX, targetY).Walkable {
+		return targetX, targetY, true
 	}
 	
 	// Use a spiral search pattern to find the nearest walkable tile
-	maxSearchRadius := 20 // Limit search to avoid infinite loops
-	
-	for radius := 1; radius <= maxSearchRadius; radius++ {
+	for radius := 1; radius <= maxRadius; radius++ {
 		// Check all tiles within this radius, prioritizing closer tiles
 		// Use a circular search pattern to find the truly closest walkable tile
 		for dx := -radius; dx <= radius; dx++ {
@@ -69,22 +61,15 @@ func FindNearestWalkableTile(targetX, targetY int, gameMap *world.Map) (int, int
 				// Check if this tile is within map bounds and walkable
 				if checkX >= 0 && checkX < gameMap.Width && 
 				   checkY >= 0 && checkY < gameMap.Height {
-					tileType := gameMap.GetTile(checkX, checkY)
-					tileDef, exists := world.TileDefinitions[tileType]
-					if !exists {
-						// If tile definition not found, assume it's walkable (fallback to grass)
-						tileDef = world.TileDefinitions[world.TileGrass]
-					}
-					if tileDef.Walkable {
-						return checkX, checkY
+					if gameMap.TileDefAt(checkX, checkY).Walkable {
+						return checkX, checkY, true
 					}
 				}
 			}
 		}
 	}
 	
-	// If no walkable tile found within search radius, return the center of the map
-	return gameMap.Width / 2, gameMap.Height / 2
+	return 0, 0, false
 }
 
 // abs returns the absolute value of an integer
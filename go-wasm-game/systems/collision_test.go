@@ -0,0 +1,69 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newLakeMap builds a 60x60 lake whose only shore is a grass column along x = 0
+func newLakeMap() *world.Map {
+	gameMap := world.NewMap(60, 60, 32.0)
+	for y := 0; y < gameMap.Height; y++ {
+		for x := 1; x < gameMap.Width; x++ {
+			gameMap.SetTile(x, y, world.TileWater)
+		}
+	}
+	return gameMap
+}
+
+// Test that the nearest walkable tile is found when the radius reaches the shore, and reported missing otherwise
+func TestFindNearestWalkableTileRadius(t *testing.T) {
+	tests := []struct {
+		name         string
+		targetX      int
+		maxRadius    int
+		wantOK       bool
+		wantX, wantY int
+	}{
+		{"already walkable", 0, 0, true, 0, 30},
+		{"shore within radius", 30, 40, true, 0, 30},
+		{"radius exactly reaches shore", 30, 30, true, 0, 30},
+		{"radius too small", 30, 5, false, 0, 0},
+	}
+
+	gameMap := newLakeMap()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y, ok := systems.FindNearestWalkableTile(tt.targetX, 30, tt.maxRadius, gameMap)
+			if ok != tt.wantOK || (ok && (x != tt.wantX || y != tt.wantY)) {
+				t.Errorf("FindNearestWalkableTile(%d, 30, %d) = (%d, %d, %v), want (%d, %d, %v)",
+					tt.targetX, tt.maxRadius, x, y, ok, tt.wantX, tt.wantY, tt.wantOK)
+			}
+		})
+	}
+}
+
+// Test that a move order deep into a lake, beyond the search radius of any shore, leaves the entity in place
+func TestMoveToTileDeepInLakeDoesNotMove(t *testing.T) {
+	gameMap := newLakeMap()
+	if path := systems.FindPath(0, 30, 45, 30, gameMap); path != nil {
+		t.Errorf("FindPath() into the lake = %v, want nil", path)
+	}
+
+	ms := systems.NewMovementSystem(gameMap)
+	entity := newEntityAtTile(gameMap, 0, 30)
+	ms.MoveToTile(entity, 45, 30)
+	if entity.IsMoving() || entity.GetPath() != nil {
+		t.Errorf("entity moving = %v along %v, want it left in place", entity.IsMoving(), entity.GetPath())
+	}
+
+	// Near the shore the order still lands on the closest grass
+	ms.MoveToTile(entity, 10, 50)
+	if path := entity.GetPath(); len(path) == 0 || path[len(path)-1].X != 0 || path[len(path)-1].Y != 50 {
+		t.Errorf("path toward (10, 50) = %v, want it to end on the shore at (0, 50)", path)
+	}
+}
@@ -0,0 +1,25 @@
+package systems
+
+import "math"
+
+// RangeMetric chooses how tile distances are measured for reach checks
+type RangeMetric int
+
+const (
+	RangeChebyshev RangeMetric = iota // Diagonal steps count as 1, so all 8 neighbors are at distance 1
+	RangeManhattan                    // Only orthogonal steps, so diagonal neighbors are at distance 2
+	RangeEuclidean                    // Straight-line distance, so diagonal neighbors are at about 1.414
+)
+
+// TileDistance returns the distance between two tiles under a metric, in tiles
+func TileDistance(x1, y1, x2, y2 int, metric RangeMetric) float64 {
+	dx, dy := float64(absInt(x2-x1)), float64(absInt(y2-y1))
+	switch metric {
+	case RangeManhattan:
+		return dx + dy
+	case RangeEuclidean:
+		return math.Sqrt(dx*dx + dy*dy)
+	default:
+		return math.Max(dx, dy)
+	}
+}
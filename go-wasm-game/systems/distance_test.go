@@ -0,0 +1,42 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"math"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// Test tile distances under every metric for cardinal, diagonal and mixed offsets
+func TestTileDistance(t *testing.T) {
+	tests := []struct {
+		name           string
+		x1, y1, x2, y2 int
+		chebyshev      float64
+		manhattan      float64
+		euclidean      float64
+	}{
+		{"same tile", 3, 3, 3, 3, 0, 0, 0},
+		{"cardinal neighbor", 3, 3, 4, 3, 1, 1, 1},
+		{"diagonal neighbor", 3, 3, 2, 2, 1, 2, math.Sqrt2},
+		{"two tiles straight", 0, 0, 0, -2, 2, 2, 2},
+		{"knight's move", 0, 0, 2, 1, 2, 3, math.Sqrt(5)},
+		{"far diagonal", 5, 1, 1, 5, 4, 8, 4 * math.Sqrt2},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for metric, want := range map[systems.RangeMetric]float64{
+				systems.RangeChebyshev: tt.chebyshev,
+				systems.RangeManhattan: tt.manhattan,
+				systems.RangeEuclidean: tt.euclidean,
+			} {
+				if got := systems.TileDistance(tt.x1, tt.y1, tt.x2, tt.y2, metric); math.Abs(got-want) > 1e-9 {
+					t.Errorf("TileDistance(metric %d) = %v, want %v", metric, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package systems
+
+import (
+	"container/heap"
+	"math"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// dstarKey is a D* Lite priority: compared on the first value, ties broken by the second
+type dstarKey [2]float64
+
+func (k dstarKey) less(other dstarKey) bool {
+	if k[0] != other[0] {
+		return k[0] < other[0]
+	}
+	return k[1] < other[1]
+}
+
+// dstarEntry is a tile waiting in the D* Lite open list
+type dstarEntry struct {
+	tile      int
+	key       dstarKey
+	heapIndex int
+}
+
+// dstarHeap implements heap.Interface ordered by key
+type dstarHeap []*dstarEntry
+
+func (h dstarHeap) Len() int           { return len(h) }
+func (h dstarHeap) Less(i, j int) bool { return h[i].key.less(h[j].key) }
+func (h dstarHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heapIndex = i
+	h[j].heapIndex = j
+}
+
+func (h *dstarHeap) Push(x interface{}) {
+	entry := x.(*dstarEntry)
+	entry.heapIndex = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *dstarHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	entry.heapIndex = -1
+	*h = old[0 : n-1]
+	return entry
+}
+
+// DStarLite is an incremental planner for one goal tile. It searches backward from the goal
+// and keeps its search state, so after a few tiles change (walls built, bridges opened) it only
+// repairs the affected part of the search instead of starting over like FindPath.
+// Step costs match FindPath without traffic discounts, which change every frame, so its paths only
+// match FindPath's on maps with no discount set; on wrapping maps routes cross the seam like FindPath's
+type DStarLite struct {
+	gameMap      *world.Map
+	goalX, goalY int
+	aspect       float64
+
+	g, rhs  []float64     // Per-tile cost-to-goal estimates, row-major
+	open    dstarHeap
+	entries []*dstarEntry // Per-tile open list entry, nil when not queued
+	km      float64       // Key modifier accumulated as the start moves
+
+	started        bool
+	startX, startY int // Start of the most recent search
+	expansions     int // Tiles expanded by the most recent PathFrom
+}
+
+// NewDStarLite creates a planner for routes to (goalX, goalY); nothing is searched until PathFrom
+func NewDStarLite(goalX, goalY int, gameMap *world.Map) *DStarLite {
+	goalX, goalY = gameMap.WrapTile(goalX, goalY)
+	size := gameMap.Width * gameMap.Height
+	d := &DStarLite{
+		gameMap: gameMap,
+		goalX:   goalX,
+		goalY:   goalY,
+		aspect:  gameMap.TileAspectRatio(),
+		g:       make([]float64, size),
+		rhs:     make([]float64, size),
+		entries: make([]*dstarEntry, size),
+	}
+	for i := range d.g {
+		d.g[i] = math.Inf(1)
+		d.rhs[i] = math.Inf(1)
+	}
+	return d
+}
+
+// Goal returns the tile this planner routes to
+func (d *DStarLite) Goal() (int, int) {
+	return d.goalX, d.goalY
+}
+
+// Expansions returns how many tiles the most recent PathFrom expanded
+func (d *DStarLite) Expansions() int {
+	return d.expansions
+}
+
+// PathFrom returns the cheapest path from (startX, startY) to the goal, or nil if there is none.
+// Any start may be used, so units heading to the same goal can share one planner
+func (d *DStarLite) PathFrom(startX, startY int) Path {
+	d.expansions = 0
+	startX, startY = d.gameMap.WrapTile(startX, startY)
+	if !d.inBounds(startX, startY) || !d.inBounds(d.goalX, d.goalY) {
+		return nil
+	}
+
+	// Like FindPath, start from the nearest walkable tile
+	startX, startY, found := FindNearestWalkableTile(startX, startY, DefaultWalkableSearchRadius, d.gameMap)
+	if !found {
+		return nil
+	}
+	if startX == d.goalX && startY == d.goalY {
+		return Path{{X: d.goalX, Y: d.goalY}}
+	}
+
+	if !d.started {
+		d.started = true
+		d.startX, d.startY = startX, startY
+		goal := d.index(d.goalX, d.goalY)
+		d.rhs[goal] = 0
+		d.queue(goal)
+	} else if startX != d.startX || startY != d.startY {
+		// Existing keys stay valid lower bounds once km absorbs how far the start moved
+		d.km += d.heuristic(d.startX, d.startY, startX, startY)
+		d.startX, d.startY = startX, startY
+	}
+
+	d.computeShortestPath()
+	return d.extractPath()
+}
+
+// TileChanged tells the planner that a tile's walkability, speed or edge walls changed.
+// Only the tile and its neighbors are updated; the next PathFrom repairs the rest
+func (d *DStarLite) TileChanged(x, y int) {
+	x, y = d.gameMap.WrapTile(x, y)
+	if !d.started || !d.inBounds(x, y) {
+		return
+	}
+	d.updateVertex(d.index(x, y))
+	for _, step := range FlowDirections {
+		if nx, ny, ok := d.neighbor(x, y, step.DX, step.DY); ok {
+			d.updateVertex(d.index(nx, ny))
+		}
+	}
+}
+
+// PathValid reports whether every step of a path can still be taken at the planner's step costs,
+// catching paths over edits the planner never heard about through TileChanged
+func (d *DStarLite) PathValid(path Path) bool {
+	for i := 1; i < len(path); i++ {
+		dx, dy := d.gameMap.WrapTileDelta(path[i].X-path[i-1].X, path[i].Y-path[i-1].Y)
+		if math.IsInf(d.cost(path[i-1].X, path[i-1].Y, dx, dy), 1) {
+			return false
+		}
+	}
+	return true
+}
+
+// computeShortestPath expands tiles until the start's cost is settled
+func (d *DStarLite) computeShortestPath() {
+	start := d.index(d.startX, d.startY)
+	for d.open.Len() > 0 && (d.open[0].key.less(d.calculateKey(start)) || d.rhs[start] != d.g[start]) {
+		entry := d.open[0]
+		tile := entry.tile
+		oldKey := entry.key
+		newKey := d.calculateKey(tile)
+		d.expansions++
+
+		if oldKey.less(newKey) {
+			// The key went stale as the start moved; requeue with the current one
+			entry.key = newKey
+			heap.Fix(&d.open, 0)
+			continue
+		}
+
+		heap.Pop(&d.open)
+		d.entries[tile] = nil
+		x, y := tile%d.gameMap.Width, tile/d.gameMap.Width
+		if d.g[tile] > d.rhs[tile] {
+			d.g[tile] = d.rhs[tile]
+		} else {
+			d.g[tile] = math.Inf(1)
+			d.updateVertex(tile)
+		}
+		for _, step := range FlowDirections {
+			if nx, ny, ok := d.neighbor(x, y, step.DX, step.DY); ok {
+				d.updateVertex(d.index(nx, ny))
+			}
+		}
+	}
+}
+
+// updateVertex recomputes a tile's one-step lookahead cost and queues it while inconsistent
+func (d *DStarLite) updateVertex(tile int) {
+	x, y := tile%d.gameMap.Width, tile/d.gameMap.Width
+	if x != d.goalX || y != d.goalY {
+		best := math.Inf(1)
+		for _, step := range FlowDirections {
+			nx, ny, ok := d.neighbor(x, y, step.DX, step.DY)
+			if !ok {
+				continue
+			}
+			if cost := d.cost(x, y, step.DX, step.DY) + d.g[d.index(nx, ny)]; cost < best {
+				best = cost
+			}
+		}
+		d.rhs[tile] = best
+	}
+
+	if entry := d.entries[tile]; entry != nil {
+		heap.Remove(&d.open, entry.heapIndex)
+		d.entries[tile] = nil
+	}
+	if d.g[tile] != d.rhs[tile] {
+		d.queue(tile)
+	}
+}
+
+// queue adds a tile to the open list with its current key
+func (d *DStarLite) queue(tile int) {
+	entry := &dstarEntry{tile: tile, key: d.calculateKey(tile)}
+	d.entries[tile] = entry
+	heap.Push(&d.open, entry)
+}
+
+// calculateKey orders tiles by estimated total cost through them, like A*'s F cost
+func (d *DStarLite) calculateKey(tile int) dstarKey {
+	best := math.Min(d.g[tile], d.rhs[tile])
+	x, y := tile%d.gameMap.Width, tile/d.gameMap.Width
+	return dstarKey{best + d.heuristic(d.startX, d.startY, x, y) + d.km, best}
+}
+
+// cost returns what FindPath charges to step from a tile in direction (dx, dy), +Inf if it can't
+func (d *DStarLite) cost(fromX, fromY, dx, dy int) float64 {
+	if !d.gameMap.TileDefAt(fromX, fromY).Walkable {
+		return math.Inf(1)
+	}
+	// Like FindPath, edge and corner checks take the unwrapped step, which GetTile wraps
+	toX, toY := fromX+dx, fromY+dy
+	neighborX, neighborY := d.gameMap.WrapTile(toX, toY)
+	tileDef := d.gameMap.TileDefAt(neighborX, neighborY)
+	if !tileDef.Walkable || !d.gameMap.CanCrossEdge(fromX, fromY, toX, toY) || cutsCorner(fromX, fromY, toX, toY, d.gameMap) {
+		return math.Inf(1)
+	}
+	return stepCost(dx, dy, d.aspect) / tileDef.WalkSpeed
+}
+
+// heuristic is FindPath's Euclidean estimate between two tiles, the short way around on wrapping maps
+func (d *DStarLite) heuristic(x1, y1, x2, y2 int) float64 {
+	return wrappedHeuristic(x1, y1, x2, y2, d.aspect, d.gameMap, HeuristicEuclidean)
+}
+
+// extractPath walks greedily from the start, always stepping to the neighbor with the lowest cost to goal
+func (d *DStarLite) extractPath() Path {
+	if math.IsInf(d.g[d.index(d.startX, d.startY)], 1) {
+		return nil
+	}
+
+	path := Path{{X: d.startX, Y: d.startY}}
+	x, y := d.startX, d.startY
+	for steps := 0; (x != d.goalX || y != d.goalY) && steps < len(d.g); steps++ {
+		bestX, bestY, best := 0, 0, math.Inf(1)
+		for _, step := range FlowDirections {
+			nx, ny, ok := d.neighbor(x, y, step.DX, step.DY)
+			if !ok {
+				continue
+			}
+			if cost := d.cost(x, y, step.DX, step.DY) + d.g[d.index(nx, ny)]; cost < best {
+				bestX, bestY, best = nx, ny, cost
+			}
+		}
+		if math.IsInf(best, 1) {
+			return nil
+		}
+		x, y = bestX, bestY
+		path = append(path, struct{ X, Y int }{X: x, Y: y})
+	}
+	return path
+}
+
+// neighbor returns the tile one step from (x, y), across the seam on wrapping maps, or false off the map
+func (d *DStarLite) neighbor(x, y, dx, dy int) (int, int, bool) {
+	nx, ny := d.gameMap.WrapTile(x+dx, y+dy)
+	return nx, ny, d.inBounds(nx, ny)
+}
+
+func (d *DStarLite) inBounds(x, y int) bool {
+	return x >= 0 && x < d.gameMap.Width && y >= 0 && y < d.gameMap.Height
+}
+
+func (d *DStarLite) index(x, y int) int {
+	return y*d.gameMap.Width + x
+}
+
+// DStarPlanners hands out one shared DStarLite per goal tile and forwards map changes to all of them
+type DStarPlanners struct {
+	gameMap  *world.Map
+	planners map[[2]int]*DStarLite
+}
+
+// NewDStarPlanners creates an empty planner cache for a map
+func NewDStarPlanners(gameMap *world.Map) *DStarPlanners {
+	return &DStarPlanners{
+		gameMap:  gameMap,
+		planners: make(map[[2]int]*DStarLite),
+	}
+}
+
+// Planner returns the planner for a goal, creating it on first use
+func (p *DStarPlanners) Planner(goalX, goalY int) *DStarLite {
+	goalX, goalY = p.gameMap.WrapTile(goalX, goalY)
+	key := [2]int{goalX, goalY}
+	planner, exists := p.planners[key]
+	if !exists {
+		planner = NewDStarLite(goalX, goalY, p.gameMap)
+		p.planners[key] = planner
+	}
+	return planner
+}
+
+// Release drops the planner for a goal once no unit is heading there
+func (p *DStarPlanners) Release(goalX, goalY int) {
+	goalX, goalY = p.gameMap.WrapTile(goalX, goalY)
+	delete(p.planners, [2]int{goalX, goalY})
+}
+
+// Retain drops every planner whose goal is not in goals, e.g. once the units heading there have arrived
+func (p *DStarPlanners) Retain(goals map[[2]int]bool) {
+	for key := range p.planners {
+		if !goals[key] {
+			delete(p.planners, key)
+		}
+	}
+}
+
+// TileChanged notifies every planner that a tile changed
+func (p *DStarPlanners) TileChanged(x, y int) {
+	for _, planner := range p.planners {
+		planner.TileChanged(x, y)
+	}
+}
@@ -0,0 +1,188 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"math"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// pathCost totals FindPath's step costs along a path on a map of square tiles
+func pathCost(path systems.Path, gameMap *world.Map) float64 {
+	total := 0.0
+	for i := 1; i < len(path); i++ {
+		step := 1.0
+		if path[i].X != path[i-1].X && path[i].Y != path[i-1].Y {
+			step = 1.414
+		}
+		total += step / gameMap.TileDefAt(path[i].X, path[i].Y).WalkSpeed
+	}
+	return total
+}
+
+// newWalledMap builds a 30x30 grass map split by a vertical wall with gaps at rows 3 and 26
+func newWalledMap() *world.Map {
+	gameMap := world.NewMap(30, 30, 32.0)
+	for y := 0; y < gameMap.Height; y++ {
+		if y != 3 && y != 26 {
+			gameMap.SetTile(15, y, world.TileWater)
+		}
+	}
+	return gameMap
+}
+
+// assertMatchesAStar checks a D* Lite path against a fresh A* search over the same map
+func assertMatchesAStar(t *testing.T, path systems.Path, startX, startY, goalX, goalY int, gameMap *world.Map) int {
+	t.Helper()
+	want, expansions := systems.FindPathExpansions(startX, startY, goalX, goalY, gameMap)
+	if want == nil {
+		t.Fatalf("A* found no path from (%d, %d) to (%d, %d)", startX, startY, goalX, goalY)
+	}
+	if len(path) == 0 || path[0].X != startX || path[0].Y != startY || path[len(path)-1].X != goalX || path[len(path)-1].Y != goalY {
+		t.Fatalf("D* Lite path = %v, want it to run from (%d, %d) to (%d, %d)", path, startX, startY, goalX, goalY)
+	}
+	for _, step := range path {
+		if !gameMap.TileDefAt(step.X, step.Y).Walkable {
+			t.Fatalf("D* Lite path crosses unwalkable tile (%d, %d)", step.X, step.Y)
+		}
+	}
+	if got, wantCost := pathCost(path, gameMap), pathCost(want, gameMap); math.Abs(got-wantCost) > 1e-9 {
+		t.Errorf("D* Lite path cost = %.3f, want A*'s %.3f", got, wantCost)
+	}
+	return expansions
+}
+
+// Test that the first D* Lite search finds a path as cheap as A*
+func TestDStarLiteMatchesAStar(t *testing.T) {
+	gameMap := newWalledMap()
+	planner := systems.NewDStarLite(27, 20, gameMap)
+
+	path := planner.PathFrom(2, 20)
+	assertMatchesAStar(t, path, 2, 20, 27, 20, gameMap)
+
+	if got := planner.PathFrom(27, 20); len(got) != 1 {
+		t.Errorf("PathFrom(goal) = %v, want a single-point path", got)
+	}
+}
+
+// Test that replanning after a single tile change matches A* while expanding fewer nodes
+func TestDStarLiteReplansIncrementally(t *testing.T) {
+	tests := []struct {
+		name         string
+		tileX, tileY int
+		tileType     world.TileType
+	}{
+		{"gap closed", 15, 26, world.TileWater},
+		{"wall opened", 15, 18, world.TileGrass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := newWalledMap()
+			planner := systems.NewDStarLite(27, 20, gameMap)
+			planner.PathFrom(2, 22)
+
+			gameMap.SetTile(tt.tileX, tt.tileY, tt.tileType)
+			planner.TileChanged(tt.tileX, tt.tileY)
+
+			path := planner.PathFrom(2, 22)
+			aStarExpansions := assertMatchesAStar(t, path, 2, 22, 27, 20, gameMap)
+			if planner.Expansions() >= aStarExpansions {
+				t.Errorf("replan expanded %d nodes, want fewer than A*'s %d", planner.Expansions(), aStarExpansions)
+			}
+		})
+	}
+}
+
+// Test that units heading to one goal share a planner that stays correct from any start
+func TestDStarPlannersShareByGoal(t *testing.T) {
+	gameMap := newWalledMap()
+	planners := systems.NewDStarPlanners(gameMap)
+
+	planner := planners.Planner(27, 20)
+	if planners.Planner(27, 20) != planner {
+		t.Fatalf("Planner() returned different planners for the same goal")
+	}
+	if planners.Planner(5, 5) == planner {
+		t.Fatalf("Planner() shared a planner across different goals")
+	}
+
+	planner.PathFrom(2, 22)
+	gameMap.SetTile(15, 26, world.TileWater)
+	planners.TileChanged(15, 26)
+
+	for _, start := range [][2]int{{2, 22}, {10, 1}, {20, 28}} {
+		path := planner.PathFrom(start[0], start[1])
+		assertMatchesAStar(t, path, start[0], start[1], 27, 20, gameMap)
+	}
+
+	// Sealing the last gap leaves no route, just like A*
+	gameMap.SetTile(15, 3, world.TileWater)
+	planners.TileChanged(15, 3)
+	if path := planner.PathFrom(2, 22); path != nil {
+		t.Errorf("PathFrom() with the wall sealed = %v, want nil", path)
+	}
+
+	planners.Release(27, 20)
+	if planners.Planner(27, 20) == planner {
+		t.Errorf("Planner() after Release returned the released planner")
+	}
+}
+
+// Test that D* Lite routes across the seam of a wrapping map like A*, also after repairing its search
+func TestDStarLiteAcrossWrapSeam(t *testing.T) {
+	gameMap := newSplitMap(true)
+	planner := systems.NewDStarLite(17, 2, gameMap)
+
+	path := planner.PathFrom(2, 2)
+	assertMatchesAStar(t, path, 2, 2, 17, 2, gameMap)
+	if len(path) != 6 {
+		t.Errorf("PathFrom() took %d tiles, want the 6-tile route across the seam: %v", len(path), path)
+	}
+
+	// Closing most of the seam column leaves a detour through its last open tile
+	for y := 0; y < 4; y++ {
+		gameMap.SetTile(0, y, world.TileWater)
+		planner.TileChanged(0, y)
+	}
+	path = planner.PathFrom(2, 2)
+	assertMatchesAStar(t, path, 2, 2, 17, 2, gameMap)
+	for i := 1; i < len(path); i++ {
+		if dx, dy := gameMap.WrapTileDelta(path[i].X-path[i-1].X, path[i].Y-path[i-1].Y); dx*dx > 1 || dy*dy > 1 {
+			t.Fatalf("path jumps from %v to %v", path[i-1], path[i])
+		}
+	}
+}
+
+// Test that PathValid rejects a path once an edit the planner never heard about blocks one of its steps
+func TestDStarLitePathValid(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(gameMap *world.Map, path systems.Path)
+	}{
+		{"tile turned to water", func(gameMap *world.Map, path systems.Path) {
+			gameMap.Tiles[path[3].Y][path[3].X] = world.TileWater
+		}},
+		{"edge wall across a step", func(gameMap *world.Map, path systems.Path) {
+			gameMap.AddEdgeWall(path[2].X, path[2].Y, path[2].X+1, path[2].Y)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := world.NewMap(12, 3, 32.0)
+			planner := systems.NewDStarLite(11, 1, gameMap)
+			path := planner.PathFrom(0, 1)
+			if !planner.PathValid(path) {
+				t.Fatalf("PathValid(%v) = false on the unchanged map", path)
+			}
+			tt.edit(gameMap, path)
+			if planner.PathValid(path) {
+				t.Errorf("PathValid(%v) = true after the edit, want false", path)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package systems
+
+import (
+	"container/heap"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Flow field cell values; any other value is an index into FlowDirections
+const (
+	FlowUnreachable = -1 // No walkable route to the goal
+	FlowAtGoal      = -2 // The goal tile itself
+)
+
+// FlowDirections maps flow field cell values to grid steps, in the same order pathfinding explores them
+var FlowDirections = [8]struct{ DX, DY int }{
+	{0, 1}, {1, 0}, {0, -1}, {-1, 0},
+	{1, 1}, {-1, -1}, {1, -1}, {-1, 1},
+}
+
+// ComputeFlowField runs a single Dijkstra search outward from the goal and returns, per tile ([y][x]),
+// the direction to step to get closer to it. Costs match FindPath, so following the field gives
+// the same quality of route as A* while letting any number of units share one search.
+// On wrapping maps the field routes across the seam like FindPath does
+func ComputeFlowField(goalX, goalY int, gameMap *world.Map) [][]int {
+	goalX, goalY = gameMap.WrapTile(goalX, goalY)
+	field := make([][]int, gameMap.Height)
+	cost := make([][]float64, gameMap.Height)
+	for y := range field {
+		field[y] = make([]int, gameMap.Width)
+		cost[y] = make([]float64, gameMap.Width)
+		for x := range field[y] {
+			field[y][x] = FlowUnreachable
+		}
+	}
+	
+	if goalX < 0 || goalX >= gameMap.Width || goalY < 0 || goalY >= gameMap.Height ||
+	   !gameMap.TileDefAt(goalX, goalY).Walkable {
+		return field
+	}
+	
+	aspect := gameMap.TileAspectRatio()
+	settled := make([][]bool, gameMap.Height)
+	for y := range settled {
+		settled[y] = make([]bool, gameMap.Width)
+	}
+	
+	// Reuse the A* node heap; with a zero heuristic it orders purely by distance to the goal
+	openSet := &PathNodeHeap{}
+	heap.Push(openSet, &PathNode{X: goalX, Y: goalY})
+	field[goalY][goalX] = FlowAtGoal
+	
+	for openSet.Len() > 0 {
+		current := heap.Pop(openSet).(*PathNode)
+		if settled[current.Y][current.X] {
+			continue
+		}
+		settled[current.Y][current.X] = true
+		
+		// Cost for a neighbor to step onto the current tile, which is what the field will tell it to do
+		enterCost := gameMap.TrafficCostMultiplier(current.X, current.Y) / gameMap.TileDefAt(current.X, current.Y).WalkSpeed
+		
+		for dir, step := range FlowDirections {
+			// The neighbor sits opposite the direction it would step to reach the current tile,
+			// across the seam on wrapping maps
+			neighborX, neighborY := gameMap.WrapTile(current.X-step.DX, current.Y-step.DY)
+			if neighborX < 0 || neighborX >= gameMap.Width || neighborY < 0 || neighborY >= gameMap.Height {
+				continue
+			}
+			if settled[neighborY][neighborX] {
+				continue
+			}
+			if !gameMap.TileDefAt(neighborX, neighborY).Walkable {
+				continue
+			}
+			if !gameMap.CanCrossEdge(current.X-step.DX, current.Y-step.DY, current.X, current.Y) || cutsCorner(neighborX, neighborY, current.X, current.Y, gameMap) {
+				continue
+			}
+			
+			tentative := current.GCost + stepCost(step.DX, step.DY, aspect)*enterCost
+			if field[neighborY][neighborX] == FlowUnreachable || tentative < cost[neighborY][neighborX] {
+				field[neighborY][neighborX] = dir
+				cost[neighborY][neighborX] = tentative
+				heap.Push(openSet, &PathNode{X: neighborX, Y: neighborY, GCost: tentative, FCost: tentative})
+			}
+		}
+	}
+	
+	return field
+}
+
+// FlowFieldStep returns the tile to move to from (tileX, tileY)
+// Returns false at the goal, on unreachable tiles and outside the field. Steps across a wrapping
+// map's seam land one tile past the edge; WrapTile maps them back onto the map
+func FlowFieldStep(field [][]int, tileX, tileY int) (int, int, bool) {
+	if tileY < 0 || tileY >= len(field) || tileX < 0 || tileX >= len(field[tileY]) {
+		return tileX, tileY, false
+	}
+	dir := field[tileY][tileX]
+	if dir < 0 {
+		return tileX, tileY, false
+	}
+	step := FlowDirections[dir]
+	return tileX + step.DX, tileY + step.DY, true
+}
+
+// StepAlongFlowField starts a one-tile move following the field if the entity is idle
+// Returns false once the entity is at the goal or has no route to it
+func (ms *MovementSystem) StepAlongFlowField(entity Movable, field [][]int) bool {
+	if entity.IsMoving() {
+		return true
+	}
+	
+	x, y := entity.GetPosition()
+	width, height := entity.GetSize()
+	currentX, currentY := ms.gameMap.WorldToGrid(x+width/2, y+height/2)
+	nextX, nextY, ok := FlowFieldStep(field, currentX, currentY)
+	if !ok {
+		return false
+	}
+	nextX, nextY = ms.gameMap.WrapTile(nextX, nextY)
+	
+	// A single-step path lets the normal movement update handle speed and arrival
+	worldX, worldY := ms.gameMap.GridToWorld(nextX, nextY)
+	entity.SetPath(Path{{X: nextX, Y: nextY}})
+	entity.SetPathStep(0)
+	entity.SetTarget(worldX-width/2, worldY-height/2)
+	entity.SetMoving(true)
+	return true
+}
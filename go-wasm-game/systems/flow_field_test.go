@@ -0,0 +1,91 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that following the flow field from several start tiles reaches the goal without touching water
+func TestFlowFieldLeadsToGoalAroundWater(t *testing.T) {
+	// A river splits the map at x=5 with a single crossing at y=8
+	gameMap := world.NewMap(10, 10, 32.0)
+	for y := 0; y < 10; y++ {
+		if y != 8 {
+			gameMap.SetTile(5, y, world.TileWater)
+		}
+	}
+	goalX, goalY := 8, 1
+	field := systems.ComputeFlowField(goalX, goalY, gameMap)
+	
+	if field[goalY][goalX] != systems.FlowAtGoal {
+		t.Errorf("goal cell = %d, want FlowAtGoal", field[goalY][goalX])
+	}
+	if field[0][5] != systems.FlowUnreachable {
+		t.Errorf("water cell = %d, want FlowUnreachable", field[0][5])
+	}
+	
+	starts := [][2]int{{0, 0}, {1, 9}, {3, 4}, {9, 9}, {6, 5}}
+	for _, start := range starts {
+		x, y := start[0], start[1]
+		crossed := false
+		for steps := 0; !(x == goalX && y == goalY); steps++ {
+			if steps > 100 {
+				t.Fatalf("from %v: did not reach the goal, stuck around (%d, %d)", start, x, y)
+			}
+			nextX, nextY, ok := systems.FlowFieldStep(field, x, y)
+			if !ok {
+				t.Fatalf("from %v: no flow direction at (%d, %d)", start, x, y)
+			}
+			if dx, dy := nextX-x, nextY-y; dx < -1 || dx > 1 || dy < -1 || dy > 1 {
+				t.Fatalf("from %v: step (%d, %d) -> (%d, %d) is not to a neighbor", start, x, y, nextX, nextY)
+			}
+			x, y = nextX, nextY
+			if gameMap.GetTile(x, y) == world.TileWater {
+				t.Fatalf("from %v: flow field stepped onto water at (%d, %d)", start, x, y)
+			}
+			if x == 5 {
+				crossed = true
+			}
+		}
+		if start[0] < 5 && !crossed {
+			t.Errorf("from %v: reached the goal without using the crossing", start)
+		}
+	}
+}
+
+// Test that tiles cut off from the goal are marked unreachable
+func TestFlowFieldUnreachableRegion(t *testing.T) {
+	gameMap := world.NewMap(6, 6, 32.0)
+	for y := 0; y < 6; y++ {
+		gameMap.SetTile(2, y, world.TileWater)
+	}
+	field := systems.ComputeFlowField(4, 4, gameMap)
+	
+	if _, _, ok := systems.FlowFieldStep(field, 0, 0); ok {
+		t.Errorf("field[0][0] = %d, want no step across an uncrossable river", field[0][0])
+	}
+	if _, _, ok := systems.FlowFieldStep(field, 5, 0); !ok {
+		t.Error("tiles on the goal's side of the river should have a direction")
+	}
+}
+
+// Test that an entity driven by StepAlongFlowField walks to the goal
+func TestStepAlongFlowField(t *testing.T) {
+	gameMap := world.NewMap(8, 8, 32.0)
+	ms := systems.NewMovementSystem(gameMap)
+	entity := newEntityAtTile(gameMap, 1, 1)
+	field := systems.ComputeFlowField(6, 4, gameMap)
+	
+	for frame := 0; frame < 2000 && ms.StepAlongFlowField(entity, field); frame++ {
+		ms.Update(entity, systems.FrameDuration)
+	}
+	
+	tileX, tileY := gameMap.WorldToGrid(entity.X+entity.Width/2, entity.Y+entity.Height/2)
+	if tileX != 6 || tileY != 4 {
+		t.Errorf("entity ended at (%d, %d), want (6, 4)", tileX, tileY)
+	}
+}
@@ -0,0 +1,122 @@
+package systems
+
+import "math"
+
+// Visibility is the fog-of-war state of a single tile
+type Visibility int
+
+const (
+	TileUnseen   Visibility = iota // Never seen, fully hidden
+	TileExplored                   // Seen before but not currently in vision
+	TileVisible                    // Currently inside a vision radius
+)
+
+const (
+	// ExploredRevealAlpha is how visible an explored tile is shown (0 hidden, 1 fully visible)
+	ExploredRevealAlpha = 0.4
+	// RevealRate is the fraction of the remaining alpha gained each frame while a tile fades in
+	RevealRate = 0.25
+	// revealSnapThreshold snaps the fade to its target once it is visually indistinguishable
+	revealSnapThreshold = 0.01
+)
+
+// FogOfWar tracks per-tile visibility and the fade-in of newly revealed tiles
+type FogOfWar struct {
+	Width        int
+	Height       int
+	visibility   [][]Visibility
+	revealAlpha  [][]float64 // How visible each tile is currently drawn, eased toward its target
+	visibleTiles [][2]int    // Tiles marked visible by the last UpdateVision call
+	blocksSight  BlockedFunc // Optional sight check; nil means vision is a plain radius
+}
+
+// NewFogOfWar creates a fog of war with every tile unseen
+func NewFogOfWar(width, height int) *FogOfWar {
+	fog := &FogOfWar{
+		Width:       width,
+		Height:      height,
+		visibility:  make([][]Visibility, height),
+		revealAlpha: make([][]float64, height),
+	}
+	for y := 0; y < height; y++ {
+		fog.visibility[y] = make([]Visibility, width)
+		fog.revealAlpha[y] = make([]float64, width)
+	}
+	return fog
+}
+
+// GetVisibility returns the visibility of a tile (out of bounds tiles are unseen)
+func (f *FogOfWar) GetVisibility(tileX, tileY int) Visibility {
+	if !f.inBounds(tileX, tileY) {
+		return TileUnseen
+	}
+	return f.visibility[tileY][tileX]
+}
+
+// RevealAlpha returns how visible a tile should be drawn, from 0 (hidden) to 1 (fully visible)
+func (f *FogOfWar) RevealAlpha(tileX, tileY int) float64 {
+	if !f.inBounds(tileX, tileY) {
+		return 0
+	}
+	return f.revealAlpha[tileY][tileX]
+}
+
+// SetSightBlocker makes vision require a clear line of sight, e.g. Map.BlocksSight; nil turns it off
+func (f *FogOfWar) SetSightBlocker(blocksSight BlockedFunc) {
+	f.blocksSight = blocksSight
+}
+
+// UpdateVision marks every tile within radius of a source (and in its line of sight, if a blocker is set) as visible
+// Tiles that drop out of vision become explored; newly visible tiles start fading in
+func (f *FogOfWar) UpdateVision(sources [][2]int, radius int) {
+	previous := f.visibleTiles
+	for _, tile := range previous {
+		f.visibility[tile[1]][tile[0]] = TileExplored
+	}
+	f.visibleTiles = make([][2]int, 0, len(previous))
+	
+	for _, source := range sources {
+		for dy := -radius; dy <= radius; dy++ {
+			for dx := -radius; dx <= radius; dx++ {
+				x, y := source[0]+dx, source[1]+dy
+				if dx*dx+dy*dy > radius*radius || !f.inBounds(x, y) || f.visibility[y][x] == TileVisible {
+					continue
+				}
+				if f.blocksSight != nil && !lineOfSightClear(source[0], source[1], x, y, f.blocksSight) {
+					continue
+				}
+				f.visibility[y][x] = TileVisible
+				f.visibleTiles = append(f.visibleTiles, [2]int{x, y})
+			}
+		}
+	}
+	
+	// Tiles that left vision drop straight back to the explored level
+	for _, tile := range previous {
+		x, y := tile[0], tile[1]
+		if f.visibility[y][x] == TileExplored {
+			f.revealAlpha[y][x] = ExploredRevealAlpha
+		}
+	}
+}
+
+// Advance eases every visible tile one frame closer to full visibility
+func (f *FogOfWar) Advance() {
+	for _, tile := range f.visibleTiles {
+		x, y := tile[0], tile[1]
+		f.revealAlpha[y][x] = easeReveal(f.revealAlpha[y][x], 1.0, RevealRate)
+	}
+}
+
+func (f *FogOfWar) inBounds(tileX, tileY int) bool {
+	return tileX >= 0 && tileX < f.Width && tileY >= 0 && tileY < f.Height
+}
+
+// easeReveal moves current a fraction rate of the way toward target, snapping once close enough
+func easeReveal(current, target, rate float64) float64 {
+	next := current + (target-current)*rate
+	if math.Abs(target-next) < revealSnapThreshold {
+		return target
+	}
+	return next
+}
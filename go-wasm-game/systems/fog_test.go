@@ -0,0 +1,69 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// Test that a newly visible tile starts below full alpha and eases monotonically to 1
+func TestFogRevealEasesToFullVisibility(t *testing.T) {
+	fog := systems.NewFogOfWar(10, 10)
+	fog.UpdateVision([][2]int{{5, 5}}, 2)
+	
+	if fog.GetVisibility(5, 5) != systems.TileVisible {
+		t.Fatalf("GetVisibility(5, 5) = %v, want visible", fog.GetVisibility(5, 5))
+	}
+	if alpha := fog.RevealAlpha(5, 5); alpha >= 1 {
+		t.Fatalf("newly visible tile alpha = %v, want below 1", alpha)
+	}
+	
+	previous := fog.RevealAlpha(5, 5)
+	frames := 0
+	for ; frames < 100 && fog.RevealAlpha(5, 5) < 1; frames++ {
+		fog.Advance()
+		if alpha := fog.RevealAlpha(5, 5); alpha <= previous {
+			t.Fatalf("alpha did not increase on frame %d: %v -> %v", frames, previous, alpha)
+		}
+		previous = fog.RevealAlpha(5, 5)
+	}
+	if fog.RevealAlpha(5, 5) != 1 {
+		t.Fatalf("alpha = %v after 100 frames, want converged to 1", fog.RevealAlpha(5, 5))
+	}
+	if frames < 3 {
+		t.Errorf("reveal finished in %d frames, want a gradual fade", frames)
+	}
+	
+	// Tiles outside the vision radius stay hidden
+	if fog.GetVisibility(9, 9) != systems.TileUnseen || fog.RevealAlpha(9, 9) != 0 {
+		t.Error("tile outside vision should remain unseen and hidden")
+	}
+}
+
+// Test that tiles leaving vision become explored and fade in again from the explored level
+func TestFogExploredTilesRevealAgain(t *testing.T) {
+	fog := systems.NewFogOfWar(20, 5)
+	fog.UpdateVision([][2]int{{2, 2}}, 1)
+	for i := 0; i < 50; i++ {
+		fog.Advance()
+	}
+	
+	fog.UpdateVision([][2]int{{15, 2}}, 1)
+	if fog.GetVisibility(2, 2) != systems.TileExplored {
+		t.Fatalf("GetVisibility(2, 2) = %v, want explored", fog.GetVisibility(2, 2))
+	}
+	if alpha := fog.RevealAlpha(2, 2); alpha != systems.ExploredRevealAlpha {
+		t.Errorf("explored tile alpha = %v, want %v", alpha, systems.ExploredRevealAlpha)
+	}
+	
+	fog.UpdateVision([][2]int{{2, 2}}, 1)
+	if alpha := fog.RevealAlpha(2, 2); alpha != systems.ExploredRevealAlpha {
+		t.Errorf("re-revealed tile alpha = %v, want to start from %v", alpha, systems.ExploredRevealAlpha)
+	}
+	fog.Advance()
+	if alpha := fog.RevealAlpha(2, 2); alpha <= systems.ExploredRevealAlpha || alpha >= 1 {
+		t.Errorf("alpha after one frame = %v, want between explored level and 1", alpha)
+	}
+}
@@ -0,0 +1,29 @@
+package systems
+
+// ShouldRepositionFollower reports whether a leader has moved far enough from its follower
+// (more than threshold tiles in either axis) that the follower should walk to a new spot
+func ShouldRepositionFollower(followerX, followerY, leaderX, leaderY, threshold int) bool {
+	dx, dy := absInt(leaderX-followerX), absInt(leaderY-followerY)
+	return dx > threshold || dy > threshold
+}
+
+// TrailingTile returns the tile distance steps behind a leader moving in direction (dirX, dirY)
+// Only the sign of the direction matters; a leader with no direction is trailed from below
+func TrailingTile(leaderX, leaderY, dirX, dirY, distance int) (int, int) {
+	stepX, stepY := sign(dirX), sign(dirY)
+	if stepX == 0 && stepY == 0 {
+		return leaderX, leaderY + distance
+	}
+	return leaderX - stepX*distance, leaderY - stepY*distance
+}
+
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,59 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// Test the decision of whether the leader is far enough away to reposition the follower
+func TestShouldRepositionFollower(t *testing.T) {
+	tests := []struct {
+		name     string
+		follower [2]int
+		leader   [2]int
+		expected bool
+	}{
+		{"Same tile", [2]int{5, 5}, [2]int{5, 5}, false},
+		{"Within threshold", [2]int{5, 5}, [2]int{7, 3}, false},
+		{"Beyond threshold horizontally", [2]int{5, 5}, [2]int{8, 5}, true},
+		{"Beyond threshold vertically", [2]int{5, 5}, [2]int{5, 2}, true},
+		{"Beyond threshold diagonally", [2]int{5, 5}, [2]int{8, 8}, true},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := systems.ShouldRepositionFollower(tt.follower[0], tt.follower[1], tt.leader[0], tt.leader[1], 2)
+			if result != tt.expected {
+				t.Errorf("ShouldRepositionFollower(%v, %v) = %v, want %v", tt.follower, tt.leader, result, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that the trailing tile sits behind the leader relative to its movement direction
+func TestTrailingTile(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction [2]int
+		distance  int
+		expected  [2]int
+	}{
+		{"Moving right", [2]int{1, 0}, 1, [2]int{9, 10}},
+		{"Moving up", [2]int{0, -1}, 2, [2]int{10, 12}},
+		{"Moving diagonally", [2]int{1, 1}, 1, [2]int{9, 9}},
+		{"Large direction uses only the sign", [2]int{-4, 3}, 1, [2]int{11, 9}},
+		{"No direction trails from below", [2]int{0, 0}, 1, [2]int{10, 11}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := systems.TrailingTile(10, 10, tt.direction[0], tt.direction[1], tt.distance)
+			if x != tt.expected[0] || y != tt.expected[1] {
+				t.Errorf("TrailingTile(dir %v) = (%d, %d), want %v", tt.direction, x, y, tt.expected)
+			}
+		})
+	}
+}
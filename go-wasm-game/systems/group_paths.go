@@ -0,0 +1,103 @@
+package systems
+
+import "github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+
+// maxGroupGoalRadius caps how far from the target GroupGoalTiles looks for free tiles
+const maxGroupGoalRadius = 10
+
+// GroupPath is one member's route from SharedGroupPaths
+// Path[SharedStart:SharedEnd] is the trunk shared with the rest of the group; both are 0 for a fallback path
+type GroupPath struct {
+	Path                   Path
+	SharedStart, SharedEnd int
+}
+
+// GroupGoalTiles returns up to count distinct walkable tiles around (tileX, tileY), closest rings first,
+// skipping tiles reported by blocked. Members of a group moving together each take one
+func GroupGoalTiles(tileX, tileY, count int, gameMap *world.Map, blocked BlockedFunc) [][2]int {
+	goals := make([][2]int, 0, count)
+	for radius := 0; radius <= maxGroupGoalRadius && len(goals) < count; radius++ {
+		for y := tileY - radius; y <= tileY+radius; y++ {
+			for x := tileX - radius; x <= tileX+radius; x++ {
+				// Only the ring at this radius; inner tiles were taken already
+				if absInt(x-tileX) != radius && absInt(y-tileY) != radius {
+					continue
+				}
+				if x < 0 || x >= gameMap.Width || y < 0 || y >= gameMap.Height || !gameMap.TileDefAt(x, y).Walkable {
+					continue
+				}
+				if blocked != nil && blocked(x, y) {
+					continue
+				}
+				if len(goals) < count {
+					goals = append(goals, [2]int{x, y})
+				}
+			}
+		}
+	}
+	return goals
+}
+
+// SharedGroupPaths plans routes for a group going from nearby starts to nearby goals with one long search.
+// A single trunk path runs from the tile nearest the starts' centroid to the tile nearest the goals' centroid;
+// each member joins it at the trunk tile nearest its start and leaves at the tile nearest its goal, so only
+// short heads and tails are searched and nobody walks back to the centroid first.
+// A member whose head or tail can't be found gets its own full path instead
+func SharedGroupPaths(starts, goals [][2]int, gameMap *world.Map, opts PathOptions) []GroupPath {
+	results := make([]GroupPath, len(starts))
+	if len(starts) == 0 || len(goals) < len(starts) {
+		return results
+	}
+
+	var trunk Path
+	startX, startY, startOK := centroidTile(starts, gameMap)
+	goalX, goalY, goalOK := centroidTile(goals[:len(starts)], gameMap)
+	if startOK && goalOK {
+		trunk = FindPathWithOptions(startX, startY, goalX, goalY, gameMap, opts)
+	}
+
+	for i, start := range starts {
+		goal := goals[i]
+		if trunk != nil {
+			join := nearestTrunkTile(trunk, 0, start, gameMap)
+			leave := nearestTrunkTile(trunk, join, goal, gameMap)
+			head := FindPathWithOptions(start[0], start[1], trunk[join].X, trunk[join].Y, gameMap, opts)
+			tail := FindPathWithOptions(trunk[leave].X, trunk[leave].Y, goal[0], goal[1], gameMap, opts)
+			if head != nil && tail != nil {
+				// The head ends where the member joins the trunk and the tail starts where it leaves, so drop the duplicates
+				path := append(Path{}, head[:len(head)-1]...)
+				sharedStart := len(path)
+				path = append(path, trunk[join:leave+1]...)
+				sharedEnd := len(path)
+				path = append(path, tail[1:]...)
+				results[i] = GroupPath{Path: path, SharedStart: sharedStart, SharedEnd: sharedEnd}
+				continue
+			}
+		}
+		results[i] = GroupPath{Path: FindPathWithOptions(start[0], start[1], goal[0], goal[1], gameMap, opts)}
+	}
+	return results
+}
+
+// nearestTrunkTile returns the index of the trunk tile, at or after from, closest to a tile
+func nearestTrunkTile(trunk Path, from int, tile [2]int, gameMap *world.Map) int {
+	aspect := gameMap.TileAspectRatio()
+	best, bestDistance := from, 0.0
+	for i := from; i < len(trunk); i++ {
+		distance := wrappedHeuristic(tile[0], tile[1], trunk[i].X, trunk[i].Y, aspect, gameMap, HeuristicEuclidean)
+		if i == from || distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+	return best
+}
+
+// centroidTile returns the walkable tile nearest the average of a set of tiles, false if none is nearby
+func centroidTile(tiles [][2]int, gameMap *world.Map) (int, int, bool) {
+	sumX, sumY := 0, 0
+	for _, tile := range tiles {
+		sumX += tile[0]
+		sumY += tile[1]
+	}
+	return FindNearestWalkableTile(sumX/len(tiles), sumY/len(tiles), DefaultWalkableSearchRadius, gameMap)
+}
@@ -0,0 +1,100 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"reflect"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that group paths end at each member's goal, step only between neighbors and share one trunk
+func TestSharedGroupPaths(t *testing.T) {
+	gameMap := newWalledMap()
+	starts := [][2]int{{2, 20}, {3, 20}, {2, 21}, {3, 21}}
+	goals := systems.GroupGoalTiles(26, 20, len(starts), gameMap, nil)
+	if len(goals) != len(starts) {
+		t.Fatalf("GroupGoalTiles() = %v, want %d tiles", goals, len(starts))
+	}
+
+	paths := systems.SharedGroupPaths(starts, goals, gameMap, systems.PathOptions{})
+	var trunk systems.Path
+	for i, groupPath := range paths {
+		path := groupPath.Path
+		if len(path) == 0 || path[0].X != starts[i][0] || path[0].Y != starts[i][1] {
+			t.Fatalf("member %d path = %v, want it to start at %v", i, path, starts[i])
+		}
+		if last := path[len(path)-1]; last.X != goals[i][0] || last.Y != goals[i][1] {
+			t.Errorf("member %d path ends at (%d, %d), want its goal %v", i, last.X, last.Y, goals[i])
+		}
+		for j := 1; j < len(path); j++ {
+			dx, dy := path[j].X-path[j-1].X, path[j].Y-path[j-1].Y
+			if dx*dx > 1 || dy*dy > 1 || !gameMap.TileDefAt(path[j].X, path[j].Y).Walkable {
+				t.Fatalf("member %d path makes an invalid step from %v to %v", i, path[j-1], path[j])
+			}
+		}
+
+		shared := path[groupPath.SharedStart:groupPath.SharedEnd]
+		if len(shared) < 10 {
+			t.Fatalf("member %d shares only %d tiles, want the long trunk through the wall gap", i, len(shared))
+		}
+		if len(shared) > len(trunk) {
+			trunk = shared
+		}
+	}
+
+	// Members join and leave at different tiles, but all share runs of the same trunk
+	for i, groupPath := range paths {
+		if shared := groupPath.Path[groupPath.SharedStart:groupPath.SharedEnd]; !containsRun(trunk, shared) {
+			t.Errorf("member %d shared segment = %v, want a run of the trunk %v", i, shared, trunk)
+		}
+	}
+}
+
+// Test that members spread along the route join the trunk near their starts instead of walking back to the centroid
+func TestSharedGroupPathsJoinNearestTrunkTile(t *testing.T) {
+	gameMap := world.NewMap(30, 5, 32.0)
+	starts := [][2]int{{0, 2}, {10, 2}}
+	goals := [][2]int{{28, 2}, {29, 2}}
+
+	for i, groupPath := range systems.SharedGroupPaths(starts, goals, gameMap, systems.PathOptions{}) {
+		direct := systems.FindPath(starts[i][0], starts[i][1], goals[i][0], goals[i][1], gameMap)
+		if len(groupPath.Path) != len(direct) {
+			t.Errorf("member %d path has %d tiles, want %d like its direct path: %v", i, len(groupPath.Path), len(direct), groupPath.Path)
+		}
+		if groupPath.SharedEnd-groupPath.SharedStart < 2 {
+			t.Errorf("member %d shares %d tiles, want it on the trunk", i, groupPath.SharedEnd-groupPath.SharedStart)
+		}
+	}
+}
+
+// containsRun reports whether run appears as consecutive tiles of path
+func containsRun(path, run systems.Path) bool {
+	for i := 0; i+len(run) <= len(path); i++ {
+		if reflect.DeepEqual(path[i:i+len(run)], run) {
+			return true
+		}
+	}
+	return false
+}
+
+// Test that goal tiles are distinct, closest first, and skip blocked or unwalkable tiles
+func TestGroupGoalTiles(t *testing.T) {
+	gameMap := world.NewMap(10, 10, 32.0)
+	gameMap.SetTile(5, 4, world.TileWater)
+	blocked := func(x, y int) bool { return x == 4 && y == 4 }
+
+	goals := systems.GroupGoalTiles(5, 5, 9, gameMap, blocked)
+	if len(goals) != 9 || goals[0] != [2]int{5, 5} {
+		t.Fatalf("GroupGoalTiles() = %v, want 9 tiles starting at the target", goals)
+	}
+	seen := make(map[[2]int]bool)
+	for _, goal := range goals {
+		if seen[goal] || goal == [2]int{5, 4} || goal == [2]int{4, 4} {
+			t.Errorf("GroupGoalTiles() returned duplicate, blocked or unwalkable tile %v", goal)
+		}
+		seen[goal] = true
+	}
+}
@@ -0,0 +1,32 @@
+package systems
+
+import "math"
+
+// Heuristic selects how A* estimates the remaining distance to the goal
+type Heuristic int
+
+const (
+	// HeuristicEuclidean is straight-line distance, the default; it never overestimates 8-directional moves
+	HeuristicEuclidean Heuristic = iota
+	// HeuristicManhattan sums the horizontal and vertical distance. It matches 4-directional movement
+	// (PathOptions.FourDirectional); with diagonals allowed it overestimates, so the search is greedier
+	// and no longer guaranteed shortest
+	HeuristicManhattan
+	// HeuristicChebyshev is the larger of the horizontal and vertical distance, exact for movement
+	// where a diagonal step costs the same as a cardinal one
+	HeuristicChebyshev
+)
+
+// estimate returns the heuristic distance for a tile offset, in tile widths, with dy scaled by the tile aspect ratio
+func (h Heuristic) estimate(dx, dy int, aspect float64) float64 {
+	fx := float64(absInt(dx))
+	fy := float64(absInt(dy)) * aspect
+	switch h {
+	case HeuristicManhattan:
+		return fx + fy
+	case HeuristicChebyshev:
+		return math.Max(fx, fy)
+	default:
+		return math.Sqrt(fx*fx + fy*fy)
+	}
+}
@@ -0,0 +1,359 @@
+package systems
+
+import (
+	"container/heap"
+	"math"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// jpsRegionPadding is how many tiles past the bounding box of the start and goal a Jump Point Search explores
+const jpsRegionPadding = 16
+
+// Walkability grid states, filled in the first time the search asks about each tile
+const (
+	jpsUnknown uint8 = iota
+	jpsOpen
+	jpsBlocked
+)
+
+// FindPathJPS finds the same shortest path as FindPath using Jump Point Search, which skips over runs of
+// open tiles instead of expanding them one by one, so wide grass fields cost a handful of expansions.
+// It only explores the tiles within jpsRegionPadding of the start and goal's bounding box, taking the
+// tiles outside to be no faster than the ones it reached. JPS needs every step of a kind to cost the same,
+// so it falls back to FindPath when a tile it reaches has a different walk speed or is one-way, when the
+// map has traffic discounts, edge walls, non-square tiles, wrapping or chunks, and when a path leaving
+// the region could be cheaper than the one found inside it
+func FindPathJPS(startX, startY, endX, endY int, gameMap *world.Map) Path {
+	path, _ := FindPathJPSExpansions(startX, startY, endX, endY, gameMap)
+	return path
+}
+
+// FindPathJPSExpansions works like FindPathJPS and also reports how many nodes were expanded,
+// for comparing against FindPathExpansions
+func FindPathJPSExpansions(startX, startY, endX, endY int, gameMap *world.Map) (Path, int) {
+	if !jpsSupported(gameMap) {
+		return FindPathExpansions(startX, startY, endX, endY, gameMap)
+	}
+
+	// Same endpoint handling as FindPath
+	if startX < 0 || startX >= gameMap.Width || startY < 0 || startY >= gameMap.Height ||
+	   endX < 0 || endX >= gameMap.Width || endY < 0 || endY >= gameMap.Height {
+		return nil, 0
+	}
+	originalStartX, originalStartY, originalEndX, originalEndY := startX, startY, endX, endY
+	var startOK, endOK bool
+	startX, startY, startOK = FindNearestWalkableTile(startX, startY, DefaultWalkableSearchRadius, gameMap)
+	endX, endY, endOK = FindNearestWalkableTile(endX, endY, DefaultWalkableSearchRadius, gameMap)
+	if !startOK || !endOK {
+		return nil, 0
+	}
+	if startX == endX && startY == endY {
+		return Path{{X: endX, Y: endY}}, 0
+	}
+
+	search := newJPSSearch(startX, startY, endX, endY, gameMap)
+	path, cost, expansions := search.run(startX, startY)
+	if search.nonUniform || cost > search.exitCost(startX, startY) {
+		return FindPathExpansions(originalStartX, originalStartY, originalEndX, originalEndY, gameMap)
+	}
+	return path, expansions
+}
+
+// jpsSupported reports whether the map as a whole leaves every tile costed by its walk speed alone
+func jpsSupported(gameMap *world.Map) bool {
+	return !gameMap.Wrap && !gameMap.IsChunked() && gameMap.TileAspectRatio() == 1 &&
+		gameMap.MinTrafficCostMultiplier() == 1 && len(gameMap.EdgeWalls()) == 0
+}
+
+// jpsSearch is the state of one Jump Point Search
+type jpsSearch struct {
+	gameMap    *world.Map
+	minX, minY int     // Top-left corner of the region the search stays within
+	maxX, maxY int     // Bottom-right corner of the region, inclusive
+	grid       []uint8 // Row-major walkability over the region, so jumps don't look up tile definitions twice
+	endX, endY int
+	speed      float64 // Walk speed of the start tile, which every walkable tile reached must share
+	nonUniform bool    // Set once a tile JPS can't cost like the start turns up, abandoning the search
+}
+
+// newJPSSearch sets up a search between two walkable tiles over their padded bounding box
+func newJPSSearch(startX, startY, endX, endY int, gameMap *world.Map) *jpsSearch {
+	s := &jpsSearch{gameMap: gameMap, minX: startX, minY: startY, maxX: startX, maxY: startY,
+		endX: endX, endY: endY, speed: gameMap.TileDefAt(startX, startY).WalkSpeed}
+	if endX < s.minX {
+		s.minX = endX
+	} else {
+		s.maxX = endX
+	}
+	if endY < s.minY {
+		s.minY = endY
+	} else {
+		s.maxY = endY
+	}
+	s.minX, s.minY = s.minX-jpsRegionPadding, s.minY-jpsRegionPadding
+	s.maxX, s.maxY = s.maxX+jpsRegionPadding, s.maxY+jpsRegionPadding
+	if s.minX < 0 {
+		s.minX = 0
+	}
+	if s.minY < 0 {
+		s.minY = 0
+	}
+	if s.maxX >= gameMap.Width {
+		s.maxX = gameMap.Width - 1
+	}
+	if s.maxY >= gameMap.Height {
+		s.maxY = gameMap.Height - 1
+	}
+	s.grid = make([]uint8, (s.maxX-s.minX+1)*(s.maxY-s.minY+1))
+	return s
+}
+
+// exitCost is the least any path leaving the region could cost, with the tiles outside costed like those
+// inside: it must cover the distance between the endpoints plus the way out past a side and back.
+// Sides on the map edge can't be left, so a region covering the whole map can't be left at all
+func (s *jpsSearch) exitCost(startX, startY int) float64 {
+	dx, dy := absInt(s.endX-startX), absInt(s.endY-startY)
+	lowX, lowY, highX, highY := startX, startY, startX, startY
+	if s.endX < lowX {
+		lowX = s.endX
+	} else {
+		highX = s.endX
+	}
+	if s.endY < lowY {
+		lowY = s.endY
+	} else {
+		highY = s.endY
+	}
+
+	cheapest := math.Inf(1)
+	detour := func(extraX, extraY int) {
+		straight, diagonal := absInt(dx+extraX-dy-extraY), dx+extraX
+		if dy+extraY < diagonal {
+			diagonal = dy + extraY
+		}
+		if cost := (float64(straight)*stepCost(1, 0, 1) + float64(diagonal)*stepCost(1, 1, 1)) / s.speed; cost < cheapest {
+			cheapest = cost
+		}
+	}
+	if s.minX > 0 {
+		detour(2*(lowX-s.minX+1), 0)
+	}
+	if s.maxX < s.gameMap.Width-1 {
+		detour(2*(s.maxX-highX+1), 0)
+	}
+	if s.minY > 0 {
+		detour(0, 2*(lowY-s.minY+1))
+	}
+	if s.maxY < s.gameMap.Height-1 {
+		detour(0, 2*(s.maxY-highY+1))
+	}
+	return cheapest
+}
+
+// run searches from a walkable start to the goal within the region, returning the tile-by-tile path,
+// its cost (infinite when there is none) and the expansions made
+func (s *jpsSearch) run(startX, startY int) (Path, float64, int) {
+	openSet := &PathNodeHeap{}
+	heap.Init(openSet)
+	allNodes := make(map[int]*PathNode)
+	closedSet := make(map[int]bool)
+	getKey := func(x, y int) int {
+		return y*s.gameMap.Width + x
+	}
+
+	startNode := &PathNode{X: startX, Y: startY, HCost: heuristic(startX, startY, s.endX, s.endY, 1) / s.speed}
+	startNode.FCost = startNode.HCost
+	heap.Push(openSet, startNode)
+	allNodes[getKey(startX, startY)] = startNode
+
+	expansions := 0
+	for openSet.Len() > 0 && expansions < DefaultMaxIterations && !s.nonUniform {
+		expansions++
+		current := heap.Pop(openSet).(*PathNode)
+		closedSet[getKey(current.X, current.Y)] = true
+		if current.X == s.endX && current.Y == s.endY {
+			return expandJumpPath(reconstructPath(current)), current.GCost, expansions
+		}
+
+		for _, dir := range s.neighborDirections(current) {
+			jumpX, jumpY, found := s.jump(current.X+dir[0], current.Y+dir[1], dir[0], dir[1])
+			jumpKey := getKey(jumpX, jumpY)
+			if !found || closedSet[jumpKey] {
+				continue
+			}
+
+			// Jumps run in a straight line, so their cost is the step count times one step's cost
+			steps := absInt(jumpX - current.X)
+			if dy := absInt(jumpY - current.Y); dy > steps {
+				steps = dy
+			}
+			tentativeGCost := current.GCost + float64(steps)*stepCost(dir[0], dir[1], 1)/s.speed
+
+			node, exists := allNodes[jumpKey]
+			if !exists {
+				node = &PathNode{X: jumpX, Y: jumpY, Parent: current, GCost: tentativeGCost,
+					HCost: heuristic(jumpX, jumpY, s.endX, s.endY, 1) / s.speed}
+				node.FCost = node.GCost + node.HCost
+				allNodes[jumpKey] = node
+				heap.Push(openSet, node)
+			} else if tentativeGCost < node.GCost {
+				node.Parent = current
+				node.GCost = tentativeGCost
+				node.FCost = node.GCost + node.HCost
+				heap.Fix(openSet, node.HeapIndex)
+			}
+		}
+	}
+	return nil, math.Inf(1), expansions
+}
+
+// walkable reports whether a tile is inside the region and can be walked on, looking it up the first
+// time it is asked about. A walkable tile that doesn't cost what the start does ends the search
+func (s *jpsSearch) walkable(x, y int) bool {
+	if s.nonUniform || x < s.minX || x > s.maxX || y < s.minY || y > s.maxY {
+		return false
+	}
+	cell := &s.grid[(y-s.minY)*(s.maxX-s.minX+1)+x-s.minX]
+	if *cell == jpsUnknown {
+		*cell = jpsBlocked
+		if tileDef := s.gameMap.TileDefAt(x, y); tileDef.Walkable {
+			if tileDef.WalkSpeed != s.speed || s.gameMap.TileEntryMask(x, y) != world.EntryFromAll {
+				s.nonUniform = true
+				return false
+			}
+			*cell = jpsOpen
+		}
+	}
+	return *cell == jpsOpen
+}
+
+// neighborDirections prunes the directions worth jumping in from a node, given the direction it was
+// reached from. Diagonals need both tiles beside the corner open, matching FindPath's corner rule
+func (s *jpsSearch) neighborDirections(node *PathNode) [][2]int {
+	x, y := node.X, node.Y
+	if node.Parent == nil {
+		dirs := make([][2]int, 0, 8)
+		for _, step := range FlowDirections {
+			if s.walkable(x+step.DX, y+step.DY) && (step.DX == 0 || step.DY == 0 || (s.walkable(x+step.DX, y) && s.walkable(x, y+step.DY))) {
+				dirs = append(dirs, [2]int{step.DX, step.DY})
+			}
+		}
+		return dirs
+	}
+
+	dx, dy := signInt(x-node.Parent.X), signInt(y-node.Parent.Y)
+	dirs := make([][2]int, 0, 5)
+	switch {
+	case dx != 0 && dy != 0:
+		walkX, walkY := s.walkable(x+dx, y), s.walkable(x, y+dy)
+		if walkY {
+			dirs = append(dirs, [2]int{0, dy})
+		}
+		if walkX {
+			dirs = append(dirs, [2]int{dx, 0})
+		}
+		if walkX && walkY {
+			dirs = append(dirs, [2]int{dx, dy})
+		}
+	case dx != 0:
+		next, up, down := s.walkable(x+dx, y), s.walkable(x, y-1), s.walkable(x, y+1)
+		if next {
+			dirs = append(dirs, [2]int{dx, 0})
+			if up {
+				dirs = append(dirs, [2]int{dx, -1})
+			}
+			if down {
+				dirs = append(dirs, [2]int{dx, 1})
+			}
+		}
+		if up {
+			dirs = append(dirs, [2]int{0, -1})
+		}
+		if down {
+			dirs = append(dirs, [2]int{0, 1})
+		}
+	default:
+		next, left, right := s.walkable(x, y+dy), s.walkable(x-1, y), s.walkable(x+1, y)
+		if next {
+			dirs = append(dirs, [2]int{0, dy})
+			if left {
+				dirs = append(dirs, [2]int{-1, dy})
+			}
+			if right {
+				dirs = append(dirs, [2]int{1, dy})
+			}
+		}
+		if left {
+			dirs = append(dirs, [2]int{-1, 0})
+		}
+		if right {
+			dirs = append(dirs, [2]int{1, 0})
+		}
+	}
+	return dirs
+}
+
+// jump walks from (x, y) in direction (dx, dy) until it reaches the goal or a tile with a forced
+// neighbor, the only tiles an optimal path could turn at. Returns false when it runs into a wall
+func (s *jpsSearch) jump(x, y, dx, dy int) (int, int, bool) {
+	for {
+		if !s.walkable(x, y) {
+			return 0, 0, false
+		}
+		if x == s.endX && y == s.endY {
+			return x, y, true
+		}
+
+		switch {
+		case dx != 0 && dy != 0:
+			// A diagonal stops wherever a straight jump from it would find something
+			if _, _, found := s.jump(x+dx, y, dx, 0); found {
+				return x, y, true
+			}
+			if _, _, found := s.jump(x, y+dy, 0, dy); found {
+				return x, y, true
+			}
+			if !s.walkable(x+dx, y) || !s.walkable(x, y+dy) {
+				return 0, 0, false
+			}
+		case dx != 0:
+			if (s.walkable(x, y-1) && !s.walkable(x-dx, y-1)) || (s.walkable(x, y+1) && !s.walkable(x-dx, y+1)) {
+				return x, y, true
+			}
+		default:
+			if (s.walkable(x-1, y) && !s.walkable(x-1, y-dy)) || (s.walkable(x+1, y) && !s.walkable(x+1, y-dy)) {
+				return x, y, true
+			}
+		}
+		x, y = x+dx, y+dy
+	}
+}
+
+// expandJumpPath fills in the tiles between consecutive jump points, which always lie on a straight
+// or diagonal line, so movement can follow the path one tile at a time
+func expandJumpPath(jumpPoints Path) Path {
+	if len(jumpPoints) == 0 {
+		return nil
+	}
+	path := Path{jumpPoints[0]}
+	for i := 1; i < len(jumpPoints); i++ {
+		x, y := jumpPoints[i-1].X, jumpPoints[i-1].Y
+		dx, dy := signInt(jumpPoints[i].X-x), signInt(jumpPoints[i].Y-y)
+		for x != jumpPoints[i].X || y != jumpPoints[i].Y {
+			x, y = x+dx, y+dy
+			path = append(path, struct{ X, Y int }{X: x, Y: y})
+		}
+	}
+	return path
+}
+
+// signInt returns -1, 0 or 1 matching the sign of v
+func signInt(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,160 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newScatteredMap builds a grass map with roughly a fifth of its tiles turned to water, reproducibly
+func newScatteredMap(width, height int, seed int64) *world.Map {
+	gameMap := world.NewMap(width, height, 32.0)
+	rng := rand.New(rand.NewSource(seed))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if rng.Intn(5) == 0 {
+				gameMap.SetTile(x, y, world.TileWater)
+			}
+		}
+	}
+	return gameMap
+}
+
+// Test that JPS finds paths exactly as cheap as A* on uniform maps, obeying the same corner rule
+func TestFindPathJPSMatchesFindPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		gameMap *world.Map
+		routes  [][4]int
+	}{
+		{"open field", world.NewMap(40, 40, 32.0), [][4]int{{2, 2, 37, 30}, {0, 39, 39, 0}, {5, 5, 5, 6}}},
+		{"walled", newWalledMap(), [][4]int{{2, 20, 27, 20}, {10, 1, 20, 28}, {14, 14, 16, 14}}},
+		{"diagonal gap", newDiagonalGapMap(), [][4]int{{0, 0, 5, 5}, {5, 0, 0, 5}, {0, 5, 4, 0}}},
+		{"scattered", newScatteredMap(40, 40, 7), [][4]int{{0, 0, 39, 39}, {3, 35, 36, 4}, {20, 0, 20, 39}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, route := range tt.routes {
+				want := systems.FindPath(route[0], route[1], route[2], route[3], tt.gameMap)
+				got := systems.FindPathJPS(route[0], route[1], route[2], route[3], tt.gameMap)
+				if want == nil {
+					if got != nil {
+						t.Errorf("route %v: JPS path = %v, want nil like FindPath", route, got)
+					}
+					continue
+				}
+				assertValidPath(t, got, want[0].X, want[0].Y, route[2], route[3], tt.gameMap)
+				if cutsAnyCorner(got, tt.gameMap) {
+					t.Errorf("route %v: JPS path %v cuts a corner", route, got)
+				}
+				if gotCost, wantCost := pathCost(got, tt.gameMap), pathCost(want, tt.gameMap); math.Abs(gotCost-wantCost) > 1e-9 {
+					t.Errorf("route %v: JPS cost = %.3f, want FindPath's %.3f", route, gotCost, wantCost)
+				}
+			}
+		})
+	}
+}
+
+// Test that JPS expands far fewer nodes than A* across an open field
+func TestFindPathJPSExpandsFewerNodes(t *testing.T) {
+	gameMap := newWalledMap()
+	_, aStarExpansions := systems.FindPathExpansions(2, 20, 27, 20, gameMap)
+	_, jpsExpansions := systems.FindPathJPSExpansions(2, 20, 27, 20, gameMap)
+	if jpsExpansions*4 > aStarExpansions {
+		t.Errorf("JPS expanded %d nodes, want under a quarter of A*'s %d", jpsExpansions, aStarExpansions)
+	}
+}
+
+// Test that mixed walk speeds and other map features JPS can't model fall back to FindPath
+func TestFindPathJPSFallsBack(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(gameMap *world.Map)
+	}{
+		{"dirt road", func(gameMap *world.Map) {
+			for x := 0; x < gameMap.Width; x++ {
+				gameMap.SetTile(x, 12, world.TileDirtPath)
+			}
+		}},
+		{"edge wall", func(gameMap *world.Map) { gameMap.AddEdgeWall(10, 10, 11, 10) }},
+		{"one-way tile", func(gameMap *world.Map) { gameMap.SetTileEntryMask(10, 10, world.EntryFromWest) }},
+		{"traffic discount", func(gameMap *world.Map) { gameMap.SetTrafficDiscount(0.3) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := world.NewMap(30, 30, 32.0)
+			tt.setup(gameMap)
+			want, wantExpansions := systems.FindPathExpansions(2, 10, 27, 14, gameMap)
+			got, expansions := systems.FindPathJPSExpansions(2, 10, 27, 14, gameMap)
+			if len(got) != len(want) || expansions != wantExpansions {
+				t.Errorf("JPS = %d steps after %d expansions, want FindPath's %d steps after %d", len(got), expansions, len(want), wantExpansions)
+			}
+		})
+	}
+}
+
+// Test that JPS searches the padded region between the endpoints: terrain far outside doesn't stop it,
+// and a shortest path that has to leave the region falls back to FindPath
+func TestFindPathJPSRegion(t *testing.T) {
+	tests := []struct {
+		name         string
+		setup        func(gameMap *world.Map)
+		wantFallback bool
+	}{
+		{"distant dirt road", func(gameMap *world.Map) {
+			for x := 0; x < gameMap.Width; x++ {
+				gameMap.SetTile(x, 70, world.TileDirtPath)
+			}
+		}, false},
+		{"detour past the padding", func(gameMap *world.Map) {
+			for y := 0; y < gameMap.Height-1; y++ {
+				gameMap.SetTile(40, y, world.TileWater)
+			}
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := world.NewMap(80, 80, 32.0)
+			tt.setup(gameMap)
+			want, wantExpansions := systems.FindPathExpansions(30, 10, 50, 12, gameMap)
+			got, expansions := systems.FindPathJPSExpansions(30, 10, 50, 12, gameMap)
+			assertValidPath(t, got, 30, 10, 50, 12, gameMap)
+			if gotCost, wantCost := pathCost(got, gameMap), pathCost(want, gameMap); math.Abs(gotCost-wantCost) > 1e-9 {
+				t.Errorf("JPS cost = %.3f, want FindPath's %.3f", gotCost, wantCost)
+			}
+			if fellBack := expansions == wantExpansions; fellBack != tt.wantFallback {
+				t.Errorf("JPS expanded %d nodes against A*'s %d, want fallback %v", expansions, wantExpansions, tt.wantFallback)
+			}
+		})
+	}
+}
+
+// BenchmarkOpenMapSearch compares A* and JPS across a 200x200 open grass map, reporting nodes expanded
+func BenchmarkOpenMapSearch(b *testing.B) {
+	gameMap := world.NewMap(200, 200, 32.0)
+	planners := []struct {
+		name string
+		find func(startX, startY, endX, endY int, gameMap *world.Map) (systems.Path, int)
+	}{
+		{"AStar", systems.FindPathExpansions},
+		{"JPS", systems.FindPathJPSExpansions},
+	}
+
+	for _, planner := range planners {
+		b.Run(planner.name, func(b *testing.B) {
+			expansions := 0
+			for i := 0; i < b.N; i++ {
+				_, expansions = planner.find(5, 10, 190, 180, gameMap)
+			}
+			b.ReportMetric(float64(expansions), "nodes/op")
+		})
+	}
+}
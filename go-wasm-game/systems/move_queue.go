@@ -0,0 +1,41 @@
+package systems
+
+// DefaultMaxQueueLength is how many move orders an entity can have waiting when MaxQueueLength is unset
+const DefaultMaxQueueLength = 8
+
+// QueuedMove is a destination tile waiting for the current move to finish
+type QueuedMove struct {
+	X, Y int
+}
+
+// QueueLimit returns the most move orders the entity may have waiting
+func (me *MovableEntity) QueueLimit() int {
+	if me.MaxQueueLength > 0 {
+		return me.MaxQueueLength
+	}
+	return DefaultMaxQueueLength
+}
+
+// EnqueueMove appends a destination to the move queue, returning false if the queue is full
+func (me *MovableEntity) EnqueueMove(tileX, tileY int) bool {
+	if len(me.MoveQueue) >= me.QueueLimit() {
+		return false
+	}
+	me.MoveQueue = append(me.MoveQueue, QueuedMove{X: tileX, Y: tileY})
+	return true
+}
+
+// NextQueuedMove removes and returns the oldest waiting destination
+func (me *MovableEntity) NextQueuedMove() (QueuedMove, bool) {
+	if len(me.MoveQueue) == 0 {
+		return QueuedMove{}, false
+	}
+	next := me.MoveQueue[0]
+	me.MoveQueue = me.MoveQueue[1:]
+	return next, true
+}
+
+// ClearMoveQueue drops every waiting destination
+func (me *MovableEntity) ClearMoveQueue() {
+	me.MoveQueue = nil
+}
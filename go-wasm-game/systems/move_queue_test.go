@@ -0,0 +1,48 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// Test that the move queue is first-in first-out and refuses orders past its limit
+func TestMoveQueueLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxLength int
+		wantLimit int
+	}{
+		{"default limit", 0, systems.DefaultMaxQueueLength},
+		{"custom limit", 3, 3},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := &systems.MovableEntity{MaxQueueLength: tt.maxLength}
+			for i := 0; i < tt.wantLimit; i++ {
+				if !entity.EnqueueMove(i, i) {
+					t.Fatalf("EnqueueMove #%d refused, want room for %d", i, tt.wantLimit)
+				}
+			}
+			if entity.EnqueueMove(99, 99) {
+				t.Errorf("EnqueueMove past the limit accepted, want it refused")
+			}
+			if len(entity.MoveQueue) != tt.wantLimit {
+				t.Errorf("queue length = %d, want %d", len(entity.MoveQueue), tt.wantLimit)
+			}
+			
+			for i := 0; i < tt.wantLimit; i++ {
+				next, ok := entity.NextQueuedMove()
+				if !ok || next.X != i || next.Y != i {
+					t.Errorf("NextQueuedMove() = %+v, %v, want (%d, %d)", next, ok, i, i)
+				}
+			}
+			if _, ok := entity.NextQueuedMove(); ok {
+				t.Errorf("NextQueuedMove() on an empty queue returned a move")
+			}
+		})
+	}
+}
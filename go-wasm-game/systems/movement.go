@@ -2,6 +2,7 @@ package systems
 
 import (
 	"math"
+	"time"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
 )
 
@@ -24,7 +25,14 @@ type Movable interface {
 // MovementSystem handles unified movement logic for both players and units
 // Redesigned from scratch to eliminate dead zones and complex threshold logic
 type MovementSystem struct {
-	gameMap *world.Map
+	gameMap   *world.Map
+	isBlocked BlockedFunc // Optional occupancy check, e.g. tiles held by units
+	extraCost CostModifier // Optional per-tile path penalty, e.g. enemy threat
+	heuristicWeight float64 // Weighted A* factor for planned paths, see PathOptions.HeuristicWeight
+	smoothPaths bool        // Whether planned paths are string-pulled with SmoothPath
+	neighbors NeighborFunc  // Optional nearby entities to steer around; nil disables local avoidance
+	avoidanceRadius float64 // Reach of the neighbor repulsion, in world units
+	avoidanceWeight float64 // Strength of the repulsion relative to the heading toward the target
 }
 
 // NewMovementSystem creates a new movement system
@@ -39,9 +47,39 @@ func (ms *MovementSystem) GetGameMap() *world.Map {
 	return ms.gameMap
 }
 
-// Update handles movement logic with simplified, robust movement execution
-// Redesigned to eliminate dead zones and ensure smooth movement to targets
-func (ms *MovementSystem) Update(entity Movable) {
+// SetBlockedCheck injects an occupancy check that pathing and movement treat as impassable
+func (ms *MovementSystem) SetBlockedCheck(isBlocked BlockedFunc) {
+	ms.isBlocked = isBlocked
+}
+
+// SetCostModifier injects extra per-tile path cost, so routes prefer cheaper tiles; nil removes it
+func (ms *MovementSystem) SetCostModifier(extraCost CostModifier) {
+	ms.extraCost = extraCost
+}
+
+// SetHeuristicWeight plans paths with weighted A*: weights above 1 search faster but may return
+// somewhat longer paths, while 1 (the default) always finds the shortest
+func (ms *MovementSystem) SetHeuristicWeight(weight float64) {
+	ms.heuristicWeight = weight
+}
+
+// SetPathSmoothing makes MoveToTile follow smoothed paths (see SmoothPath), walking straight
+// lines across open ground rather than tile-by-tile zig-zags; off by default
+func (ms *MovementSystem) SetPathSmoothing(enabled bool) {
+	ms.smoothPaths = enabled
+}
+
+// FrameDuration is the frame length move speeds are expressed against: MoveSpeed is world units per 60 Hz frame
+const FrameDuration = time.Second / 60
+
+// maxWaypointsPerUpdate bounds how many path steps one Update may pass, however long its dt
+const maxWaypointsPerUpdate = 64
+
+// Update moves an entity along its path for dt of simulated time, scaling its per-frame speed by
+// dt / FrameDuration; dt <= 0 keeps the old behavior of one FrameDuration per call.
+// Distance left over on reaching a waypoint carries on toward the next one, so the distance covered
+// over a stretch of time doesn't depend on how it is split into updates
+func (ms *MovementSystem) Update(entity Movable, dt time.Duration) {
 	if !entity.IsMoving() {
 		return
 	}
@@ -51,31 +89,59 @@ func (ms *MovementSystem) Update(entity Movable) {
 		entity.SetMoving(false)
 		return
 	}
+	if dt <= 0 {
+		dt = FrameDuration
+	}
 
-	// Check if we've reached the current target
-	if ms.hasReachedTarget(entity) {
-		// Move to next step in path
-		if !ms.advanceToNextPathStep(entity) {
-			// Path completed or no more steps
-			entity.SetMoving(false)
-			entity.SetPath(nil)
-			entity.SetPathStep(0)
-			return
+	frames := float64(dt) / float64(FrameDuration)
+	for waypoints := 0; frames > 0 && waypoints < maxWaypointsPerUpdate; waypoints++ {
+		// Check if we've reached the current target
+		if ms.hasReachedTarget(entity) {
+			// Walk the last fraction of a pixel first, so a waypoint costs the same time however updates fall
+			if ms.neighbors == nil && !ms.atTarget(entity) {
+				if frames = ms.executeMovement(entity, frames); frames <= 0 {
+					return
+				}
+			}
+			
+			// Move to next step in path
+			if !ms.advanceToNextPathStep(entity) {
+				// Path completed or no more steps
+				entity.SetMoving(false)
+				entity.SetPath(nil)
+				entity.SetPathStep(0)
+				return
+			}
 		}
+		
+		// Execute movement towards current target, keeping whatever time is left after reaching it
+		frames = ms.executeMovement(entity, frames)
 	}
-	
-	// Execute movement towards current target
-	ms.executeMovement(entity)
 }
 
 // hasReachedTarget checks if entity has reached the current target
 // Uses a simple, small threshold to avoid any dead zones
 func (ms *MovementSystem) hasReachedTarget(entity Movable) bool {
 	x, y := entity.GetPosition()
-	targetX, targetY := entity.GetTarget()
+	targetX, targetY := ms.nearestTarget(entity)
 	return HasReachedTargetPure([2]float64{x, y}, [2]float64{targetX, targetY})
 }
 
+// atTarget reports whether the entity stands exactly on its current target
+func (ms *MovementSystem) atTarget(entity Movable) bool {
+	x, y := entity.GetPosition()
+	targetX, targetY := ms.nearestTarget(entity)
+	return math.Abs(targetX-x) < 1e-9 && math.Abs(targetY-y) < 1e-9
+}
+
+// nearestTarget returns the entity's target, moved across the seam on wrapping maps when that is closer
+func (ms *MovementSystem) nearestTarget(entity Movable) (float64, float64) {
+	x, y := entity.GetPosition()
+	targetX, targetY := entity.GetTarget()
+	dx, dy := ms.gameMap.WrapWorldDelta(targetX-x, targetY-y)
+	return x + dx, y + dy
+}
+
 // HasReachedTargetPure is a pure function version for testing
 func HasReachedTargetPure(currentPos, targetPos [2]float64) bool {
 	dx := targetPos[0] - currentPos[0]
@@ -105,6 +171,17 @@ func (ms *MovementSystem) advanceToNextPathStep(entity Movable) bool {
 		return false // No next step available
 	}
 	
+	// Something moved onto the route since it was planned, so plan a new one around it
+	// A smoothed path's waypoints can be several tiles apart, so every tile up to the next one is checked
+	if ms.isBlocked != nil && ms.segmentBlocked(path[currentStep].X, path[currentStep].Y, stepX, stepY) {
+		destination := path[len(path)-1]
+		entity.SetPath(nil)
+		entity.SetPathStep(0)
+		entity.SetMoving(false)
+		ms.MoveToTile(entity, destination.X, destination.Y)
+		return entity.IsMoving()
+	}
+	
 	// Set new target and advance path step
 	worldX, worldY := ms.gameMap.GridToWorld(stepX, stepY)
 	width, height := entity.GetSize()
@@ -116,15 +193,47 @@ func (ms *MovementSystem) advanceToNextPathStep(entity Movable) bool {
 	return true
 }
 
-// executeMovement performs the actual movement towards the target
-// Simplified logic that ensures smooth movement without dead zones
-func (ms *MovementSystem) executeMovement(entity Movable) {
+// segmentBlocked reports whether isBlocked holds for any tile walked between two path waypoints
+func (ms *MovementSystem) segmentBlocked(fromX, fromY, toX, toY int) bool {
+	for _, tile := range SegmentTiles(fromX, fromY, toX, toY, ms.gameMap) {
+		if ms.isBlocked(tile.X, tile.Y) {
+			return true
+		}
+	}
+	return false
+}
+
+// executeMovement moves the entity toward its target for the given number of frames and returns the
+// frames left unused when it arrives early. Steering around neighbors always uses up the whole budget
+func (ms *MovementSystem) executeMovement(entity Movable, frames float64) float64 {
 	x, y := entity.GetPosition()
-	targetX, targetY := entity.GetTarget()
-	moveSpeed := ms.getTerrainAdjustedSpeed(entity)
+	targetX, targetY := ms.nearestTarget(entity)
+	speed := ms.getTerrainAdjustedSpeed(entity)
+	if speed <= 0 {
+		return 0
+	}
+	moveSpeed := speed * frames
 	
 	newX, newY := ExecuteMovementPure([2]float64{x, y}, [2]float64{targetX, targetY}, moveSpeed)
+	leftover := 0.0
+	if distance := math.Hypot(targetX-x, targetY-y); distance < moveSpeed {
+		leftover = frames - distance/speed
+	}
+	
+	// With local avoidance on, sidestep nearby units instead of walking into them
+	if ms.neighbors != nil {
+		newX, newY = ms.steer(entity, [2]float64{x, y}, [2]float64{targetX, targetY}, moveSpeed, newX, newY)
+		leftover = 0
+	}
+	
+	// On wrapping maps an entity whose center crosses the seam reappears on the opposite edge
+	if ms.gameMap.Wrap {
+		width, height := entity.GetSize()
+		centerX, centerY := ms.gameMap.WrapWorld(newX+width/2, newY+height/2)
+		newX, newY = centerX-width/2, centerY-height/2
+	}
 	entity.SetPosition(newX, newY)
+	return leftover
 }
 
 // ExecuteMovementPure is a pure function version for testing
@@ -150,21 +259,26 @@ func ExecuteMovementPure(currentPos, targetPos [2]float64, moveSpeed float64) (f
 	}
 }
 
+// TerrainSpeedIgnorer is implemented by entities that can opt out of terrain speed effects
+type TerrainSpeedIgnorer interface {
+	IgnoresTerrainSpeed() bool
+}
+
 // getTerrainAdjustedSpeed calculates movement speed based on current terrain
 func (ms *MovementSystem) getTerrainAdjustedSpeed(entity Movable) float64 {
+	// Constant-speed entities (e.g. vehicle mode) skip the terrain lookup entirely
+	if ignorer, ok := entity.(TerrainSpeedIgnorer); ok && ignorer.IgnoresTerrainSpeed() {
+		return entity.GetMoveSpeed()
+	}
+	
 	x, y := entity.GetPosition()
 	width, height := entity.GetSize()
 	
 	// Get current tile based on entity center
 	currentTileX, currentTileY := ms.gameMap.WorldToGrid(x + width/2, y + height/2)
-	currentTileType := ms.gameMap.GetTile(currentTileX, currentTileY)
 	
-	// Get tile definition for speed multiplier
-	tileDef, exists := world.TileDefinitions[currentTileType]
-	if !exists {
-		// Default to grass if tile definition not found
-		tileDef = world.TileDefinitions[world.TileGrass]
-	}
+	// Get tile definition for speed multiplier (an overlay such as snow may override it)
+	tileDef := ms.gameMap.TileDefAt(currentTileX, currentTileY)
 	
 	// Apply terrain speed multiplier to base movement speed
 	return entity.GetMoveSpeed() * tileDef.WalkSpeed
@@ -173,6 +287,20 @@ func (ms *MovementSystem) getTerrainAdjustedSpeed(entity Movable) float64 {
 // MoveToTile initiates pathfinding-based movement to a specific tile
 // Uses existing pathfinding but with simplified movement execution
 func (ms *MovementSystem) MoveToTile(entity Movable, tileX, tileY int) {
+	ms.moveToTile(entity, tileX, tileY, nil)
+}
+
+// MoveToTileAvoiding works like MoveToTile but routes around the tiles avoid reports too, e.g. ones other
+// units stand on right now. When that leaves no route it falls back to the path MoveToTile would take
+func (ms *MovementSystem) MoveToTileAvoiding(entity Movable, tileX, tileY int, avoid BlockedFunc) {
+	if !ms.moveToTile(entity, tileX, tileY, avoid) && avoid != nil {
+		ms.moveToTile(entity, tileX, tileY, nil)
+	}
+}
+
+// moveToTile starts the entity along a planned path to a tile, also avoiding the tiles avoid reports when non-nil
+// Returns false when no path was found, leaving whatever the entity was doing untouched
+func (ms *MovementSystem) moveToTile(entity Movable, tileX, tileY int, avoid BlockedFunc) bool {
 	// Get current entity position in grid coordinates
 	x, y := entity.GetPosition()
 	width, height := entity.GetSize()
@@ -183,24 +311,28 @@ func (ms *MovementSystem) MoveToTile(entity Movable, tileX, tileY int) {
 		entity.SetMoving(false)
 		entity.SetPath(nil)
 		entity.SetPathStep(0)
-		return
-	}
-	
-	// Ensure the destination is walkable - if not, find nearest walkable tile
-	endTileType := ms.gameMap.GetTile(tileX, tileY)
-	tileDef, exists := world.TileDefinitions[endTileType]
-	if !exists || !tileDef.Walkable {
-		// Find nearest walkable tile
-		tileX, tileY = FindNearestWalkableTile(tileX, tileY, ms.gameMap)
+		return true
 	}
 	
 	// Find path from current position to target using existing pathfinding
-	path := FindPath(currentX, currentY, tileX, tileY, ms.gameMap)
+	path := ms.planPath(currentX, currentY, tileX, tileY, avoid)
 	
 	if path == nil || len(path) == 0 {
 		// No path found, don't move
+		return false
+	}
+	
+	ms.FollowPath(entity, path)
+	return true
+}
+
+// FollowPath starts the entity along an already planned path, e.g. one from SharedGroupPaths
+// The path should start on the entity's current tile
+func (ms *MovementSystem) FollowPath(entity Movable, path Path) {
+	if len(path) == 0 {
 		return
 	}
+	width, height := entity.GetSize()
 	
 	// Set up pathfinding movement with simplified system
 	entity.SetPath(path)
@@ -219,10 +351,43 @@ func (ms *MovementSystem) MoveToTile(entity Movable, tileX, tileY int) {
 	}
 }
 
+// PathOptions returns the blocked check, extra cost and heuristic weight this system plans paths with
+func (ms *MovementSystem) PathOptions() PathOptions {
+	return PathOptions{Blocked: ms.isBlocked, ExtraCost: ms.extraCost, HeuristicWeight: ms.heuristicWeight}
+}
+
+// PlanPath returns the path MoveToTile would follow between two tiles, without moving anything
+// An unwalkable destination is swapped for the nearest walkable tile; with none nearby there is no path
+func (ms *MovementSystem) PlanPath(fromX, fromY, tileX, tileY int) Path {
+	return ms.planPath(fromX, fromY, tileX, tileY, nil)
+}
+
+// planPath is PlanPath with the tiles avoid reports blocked on top of the system's own check
+func (ms *MovementSystem) planPath(fromX, fromY, tileX, tileY int, avoid BlockedFunc) Path {
+	tileX, tileY, found := FindNearestWalkableTile(tileX, tileY, DefaultWalkableSearchRadius, ms.gameMap)
+	if !found {
+		return nil
+	}
+	opts := ms.PathOptions()
+	if own := opts.Blocked; avoid != nil && own != nil {
+		opts.Blocked = func(x, y int) bool { return own(x, y) || avoid(x, y) }
+	} else if avoid != nil {
+		opts.Blocked = avoid
+	}
+	path := FindPathWithOptions(fromX, fromY, tileX, tileY, ms.gameMap, opts)
+	if ms.smoothPaths {
+		path = smoothPath(path, ms.gameMap, opts.Blocked)
+	}
+	return path
+}
+
 // ClampToMapBounds ensures the entity stays within map boundaries
 func (ms *MovementSystem) ClampToMapBounds(entity Movable) {
-	mapWorldWidth := float64(ms.gameMap.Width) * ms.gameMap.TileSize
-	mapWorldHeight := float64(ms.gameMap.Height) * ms.gameMap.TileSize
+	// Wrapping maps have no bounds to clamp to
+	if ms.gameMap.Wrap {
+		return
+	}
+	mapWorldWidth, mapWorldHeight := ms.gameMap.WorldSize()
 	
 	x, y := entity.GetPosition()
 	targetX, targetY := entity.GetTarget()
@@ -288,6 +453,9 @@ type MovableEntity struct {
 	MoveSpeed  float64
 	Path       Path
 	PathStep   int
+	IgnoreTerrainSpeed bool // Move at MoveSpeed on every tile, ignoring terrain multipliers
+	MoveQueue  []QueuedMove // Destinations to path to, in order, once the current move finishes
+	MaxQueueLength int     // Cap on MoveQueue; 0 uses DefaultMaxQueueLength
 }
 
 // Implement Movable interface for MovableEntity
@@ -302,4 +470,8 @@ func (me *MovableEntity) SetMoving(moving bool) { me.IsMovingFlag = moving }
 func (me *MovableEntity) GetPath() Path { return me.Path }
 func (me *MovableEntity) SetPath(path Path) { me.Path = path }
 func (me *MovableEntity) GetPathStep() int { return me.PathStep }
-func (me *MovableEntity) SetPathStep(step int) { me.PathStep = step }
\ No newline at end of file
+func (me *MovableEntity) SetPathStep(step int) { me.PathStep = step }
+func (me *MovableEntity) IgnoresTerrainSpeed() bool { return me.IgnoreTerrainSpeed }
+
+// DepthY returns the world Y of the entity's bottom edge, used to depth-sort drawing
+func (me *MovableEntity) DepthY() float64 { return me.Y + me.Height }
\ No newline at end of file
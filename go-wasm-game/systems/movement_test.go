@@ -10,6 +10,7 @@ import (
 	"math"
 	"testing"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
 )
 
 // Test the actual HasReachedTargetPure function from movement.go
@@ -295,4 +296,120 @@ func TestMovableEntityInterface(t *testing.T) {
 	if len(retrievedPath) != 2 || retrievedPath[0].X != 1 || retrievedPath[0].Y != 2 {
 		t.Errorf("Path operations failed, got %v", retrievedPath)
 	}
-}
\ No newline at end of file
+}
+// newEntityAtTile creates a player-sized entity centered on a tile
+func newEntityAtTile(gameMap *world.Map, tileX, tileY int) *systems.MovableEntity {
+	worldX, worldY := gameMap.GridToWorld(tileX, tileY)
+	return &systems.MovableEntity{
+		X: worldX - 10, Y: worldY - 10,
+		Width: 20, Height: 20,
+		TargetX: worldX - 10, TargetY: worldY - 10,
+		MoveSpeed: 3,
+	}
+}
+
+// Test that movement routes around a unit blocking the direct route, ignoring the mover's own tile
+func TestMoveToTileAvoidsOccupiedTiles(t *testing.T) {
+	gameMap := world.NewMap(7, 3, 32.0)
+	occupied := map[[2]int]bool{
+		{1, 1}: true, // The mover's own tile must not block it
+		{3, 1}: true, // A unit sitting on the straight line to the target
+	}
+	
+	ms := systems.NewMovementSystem(gameMap)
+	ms.SetBlockedCheck(func(tileX, tileY int) bool { return occupied[[2]int{tileX, tileY}] })
+	entity := newEntityAtTile(gameMap, 1, 1)
+	
+	ms.MoveToTile(entity, 5, 1)
+	path := entity.GetPath()
+	if !entity.IsMoving() || len(path) == 0 {
+		t.Fatal("entity should find a path around the occupied tile")
+	}
+	for _, step := range path[1:] {
+		if occupied[[2]int{step.X, step.Y}] {
+			t.Errorf("path steps onto occupied tile (%d, %d)", step.X, step.Y)
+		}
+	}
+	if last := path[len(path)-1]; last.X != 5 || last.Y != 1 {
+		t.Errorf("path ends at (%d, %d), want (5, 1)", last.X, last.Y)
+	}
+	
+	// Without the occupancy check the direct route goes straight through the unit
+	direct := systems.FindPath(1, 1, 5, 1, gameMap)
+	if systems.PathLength(direct) != 5 || direct[2].X != 3 || direct[2].Y != 1 {
+		t.Errorf("FindPath without occupancy = %v, want straight line through (3, 1)", direct)
+	}
+}
+
+// Test that a unit stepping onto the planned route causes a re-route, and an occupied target isn't pathed to
+func TestMovementReroutesWhenRouteBecomesBlocked(t *testing.T) {
+	gameMap := world.NewMap(7, 3, 32.0)
+	occupied := map[[2]int]bool{}
+	
+	ms := systems.NewMovementSystem(gameMap)
+	ms.SetBlockedCheck(func(tileX, tileY int) bool { return occupied[[2]int{tileX, tileY}] })
+	entity := newEntityAtTile(gameMap, 1, 1)
+	ms.MoveToTile(entity, 5, 1)
+	
+	// A unit walks into the middle of the planned straight line
+	occupied[[2]int{3, 1}] = true
+	for frame := 0; frame < 500 && entity.IsMoving(); frame++ {
+		ms.Update(entity, systems.FrameDuration)
+		x, y := entity.GetPosition()
+		tileX, tileY := gameMap.WorldToGrid(x+10, y+10)
+		if occupied[[2]int{tileX, tileY}] {
+			t.Fatalf("entity walked onto occupied tile (%d, %d)", tileX, tileY)
+		}
+	}
+	x, y := entity.GetPosition()
+	if tileX, tileY := gameMap.WorldToGrid(x+10, y+10); tileX != 5 || tileY != 1 {
+		t.Errorf("entity stopped at (%d, %d), want (5, 1)", tileX, tileY)
+	}
+	
+	if path := systems.FindPathAvoiding(5, 1, 3, 1, gameMap, func(x, y int) bool { return occupied[[2]int{x, y}] }); path != nil {
+		t.Errorf("FindPathAvoiding to an occupied tile = %v, want nil", path)
+	}
+}
+
+// stepDistanceOn measures how far an entity moves in one update while standing on the given tile type
+func stepDistanceOn(t *testing.T, tileType world.TileType, ignoreTerrain bool) float64 {
+	t.Helper()
+	gameMap := world.NewMap(10, 3, 32.0)
+	for x := 0; x < 10; x++ {
+		gameMap.SetTile(x, 1, tileType)
+	}
+	
+	ms := systems.NewMovementSystem(gameMap)
+	entity := newEntityAtTile(gameMap, 1, 1)
+	entity.IgnoreTerrainSpeed = ignoreTerrain
+	ms.MoveToTile(entity, 8, 1)
+	
+	// The first step of a path is the current tile, so advance until moving along the row
+	startX, _ := entity.GetPosition()
+	for frame := 0; frame < 50; frame++ {
+		ms.Update(entity, systems.FrameDuration)
+		if x, _ := entity.GetPosition(); x != startX {
+			return x - startX
+		}
+	}
+	t.Fatal("entity never moved")
+	return 0
+}
+
+// Test that the terrain override makes dirt and grass equally fast, while the default keeps the dirt boost
+func TestIgnoreTerrainSpeedOverride(t *testing.T) {
+	grass := stepDistanceOn(t, world.TileGrass, false)
+	dirt := stepDistanceOn(t, world.TileDirtPath, false)
+	if math.Abs(dirt-grass*1.5) > 1e-9 {
+		t.Errorf("dirt step = %v, want 1.5x grass step %v", dirt, grass)
+	}
+	
+	grassOverride := stepDistanceOn(t, world.TileGrass, true)
+	dirtOverride := stepDistanceOn(t, world.TileDirtPath, true)
+	if dirtOverride != grassOverride {
+		t.Errorf("with override, dirt step = %v, grass step = %v; want equal", dirtOverride, grassOverride)
+	}
+	if grassOverride != 3 {
+		t.Errorf("override step = %v, want the base MoveSpeed 3", grassOverride)
+	}
+}
@@ -0,0 +1,157 @@
+package systems
+
+import (
+	"math"
+	"math/rand"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+const (
+	// DefaultMaxParticles caps how many footstep particles can be alive at once
+	DefaultMaxParticles = 200
+	// FootstepDistance is how far (in pixels) an entity travels between particle bursts
+	FootstepDistance = 12.0
+	// ParticleLifetime is how many frames a particle lives before it expires
+	ParticleLifetime = 20
+	// particlesPerFootstep is how many particles each footstep spawns
+	particlesPerFootstep = 3
+)
+
+// Particle is a short-lived cosmetic speck spawned under a moving entity
+type Particle struct {
+	X, Y     float64
+	VX, VY   float64
+	Color    string
+	Age      int // Frames since spawn
+	Lifetime int
+}
+
+// Alpha returns the particle's opacity, fading from 1 at spawn to 0 at expiry
+func (p Particle) Alpha() float64 {
+	return 1 - float64(p.Age)/float64(p.Lifetime)
+}
+
+// ParticleSystem spawns terrain-colored footstep particles and ages them out
+type ParticleSystem struct {
+	particles    []Particle
+	maxParticles int
+	emitters     map[string]particleEmitter // Entity ID -> movement since its last footstep
+	rng          *rand.Rand
+}
+
+// particleEmitter tracks how far an entity has moved since it last left a footstep
+type particleEmitter struct {
+	lastX, lastY float64
+	travelled    float64
+}
+
+// NewParticleSystem creates a particle system holding at most maxParticles live particles
+func NewParticleSystem(maxParticles int) *ParticleSystem {
+	return &ParticleSystem{
+		maxParticles: maxParticles,
+		emitters:     make(map[string]particleEmitter),
+		rng:          rand.New(rand.NewSource(1)),
+	}
+}
+
+// SetRand replaces the random source used to scatter footstep particles
+func (ps *ParticleSystem) SetRand(rng *rand.Rand) {
+	ps.rng = rng
+}
+
+// TrackMovement records an entity's feet position (x, y in world pixels) this frame
+// Every FootstepDistance travelled spawns a burst colored by the tile underfoot; stopping resets the count
+func (ps *ParticleSystem) TrackMovement(id string, x, y float64, moving bool, gameMap *world.Map) {
+	if !moving {
+		delete(ps.emitters, id)
+		return
+	}
+	
+	emitter, exists := ps.emitters[id]
+	if !exists {
+		ps.emitters[id] = particleEmitter{lastX: x, lastY: y}
+		return
+	}
+	
+	emitter.travelled += math.Hypot(x-emitter.lastX, y-emitter.lastY)
+	emitter.lastX, emitter.lastY = x, y
+	for emitter.travelled >= FootstepDistance {
+		emitter.travelled -= FootstepDistance
+		tileX, tileY := gameMap.WorldToGrid(x, y)
+		ps.SpawnFootstep(x, y, FootstepColor(gameMap, tileX, tileY))
+	}
+	ps.emitters[id] = emitter
+}
+
+// SpawnFootstep adds a small burst of particles at a point, evicting the oldest particles at the cap
+func (ps *ParticleSystem) SpawnFootstep(x, y float64, color string) {
+	for i := 0; i < particlesPerFootstep; i++ {
+		if len(ps.particles) >= ps.maxParticles {
+			if ps.maxParticles <= 0 {
+				return
+			}
+			ps.particles = ps.particles[1:]
+		}
+		ps.particles = append(ps.particles, Particle{
+			X:        x,
+			Y:        y,
+			VX:       (ps.rng.Float64() - 0.5) * 0.8,
+			VY:       -ps.rng.Float64() * 0.6,
+			Color:    color,
+			Lifetime: ParticleLifetime,
+		})
+	}
+}
+
+// Update moves and ages every particle, dropping the ones that have expired
+func (ps *ParticleSystem) Update() {
+	alive := ps.particles[:0]
+	for _, p := range ps.particles {
+		p.Age++
+		if p.Age >= p.Lifetime {
+			continue
+		}
+		p.X += p.VX
+		p.Y += p.VY
+		alive = append(alive, p)
+	}
+	ps.particles = alive
+}
+
+// Particles returns the live particles for rendering
+func (ps *ParticleSystem) Particles() []Particle {
+	return ps.particles
+}
+
+// Count returns the number of live particles
+func (ps *ParticleSystem) Count() int {
+	return len(ps.particles)
+}
+
+// FootstepColor picks the particle color for a tile: splashes beside water, otherwise terrain-tinted dust
+func FootstepColor(gameMap *world.Map, tileX, tileY int) string {
+	tileType := gameMap.GetTile(tileX, tileY)
+	if tileType == world.TileWater || tileType == world.TileBridge {
+		return splashColor
+	}
+	for _, dir := range [4][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+		x, y := tileX+dir[0], tileY+dir[1]
+		if x >= 0 && x < gameMap.Width && y >= 0 && y < gameMap.Height && gameMap.GetTile(x, y) == world.TileWater {
+			return splashColor
+		}
+	}
+	if color, exists := dustColors[tileType]; exists {
+		return color
+	}
+	return dustColors[world.TileGrass]
+}
+
+// splashColor is used for footsteps on or next to water
+const splashColor = "#CFE8FF"
+
+// dustColors are footstep particle colors per terrain type
+var dustColors = map[world.TileType]string{
+	world.TileGrass:    "#7A9A4A",
+	world.TileDirtPath: "#B08D5B",
+	world.TileWall:     "#9E9E9E",
+}
@@ -0,0 +1,93 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that particles fade out and are removed once their lifetime has elapsed
+func TestParticleLifetimeExpiry(t *testing.T) {
+	ps := systems.NewParticleSystem(systems.DefaultMaxParticles)
+	ps.SpawnFootstep(10, 10, "#FFFFFF")
+	spawned := ps.Count()
+	if spawned == 0 {
+		t.Fatal("SpawnFootstep() spawned no particles")
+	}
+	
+	for frame := 1; frame < systems.ParticleLifetime; frame++ {
+		ps.Update()
+		if ps.Count() != spawned {
+			t.Fatalf("frame %d: Count() = %d, want %d before expiry", frame, ps.Count(), spawned)
+		}
+	}
+	if alpha := ps.Particles()[0].Alpha(); alpha <= 0 || alpha >= 1 {
+		t.Errorf("Alpha() = %v near the end of life, want between 0 and 1", alpha)
+	}
+	
+	ps.Update()
+	if ps.Count() != 0 {
+		t.Errorf("Count() = %d after %d frames, want 0", ps.Count(), systems.ParticleLifetime)
+	}
+}
+
+// Test that continuous movement never grows the particle pool past its cap
+func TestParticleCap(t *testing.T) {
+	gameMap := world.NewMap(50, 5, 32.0)
+	ps := systems.NewParticleSystem(30)
+	
+	for frame := 0; frame < 1000; frame++ {
+		ps.TrackMovement("runner", float64(frame), 50, true, gameMap)
+		if ps.Count() > 30 {
+			t.Fatalf("frame %d: Count() = %d, exceeds cap of 30", frame, ps.Count())
+		}
+	}
+	if ps.Count() != 30 {
+		t.Errorf("Count() = %d, want the pool full at 30 without per-frame expiry", ps.Count())
+	}
+}
+
+// Test that spawns are driven by distance travelled, not by frames
+func TestFootstepsFollowDistance(t *testing.T) {
+	gameMap := world.NewMap(10, 10, 32.0)
+	ps := systems.NewParticleSystem(systems.DefaultMaxParticles)
+	
+	// Standing still (or reported as not moving) never spawns
+	for frame := 0; frame < 50; frame++ {
+		ps.TrackMovement("idle", 40, 40, frame%2 == 0, gameMap)
+	}
+	if ps.Count() != 0 {
+		t.Fatalf("Count() = %d for an entity that never travelled, want 0", ps.Count())
+	}
+	
+	// Just short of one footstep, then across the threshold
+	ps.TrackMovement("walker", 0, 40, true, gameMap)
+	ps.TrackMovement("walker", systems.FootstepDistance-1, 40, true, gameMap)
+	if ps.Count() != 0 {
+		t.Fatalf("Count() = %d before a full footstep, want 0", ps.Count())
+	}
+	ps.TrackMovement("walker", systems.FootstepDistance+1, 40, true, gameMap)
+	if ps.Count() == 0 {
+		t.Error("crossing FootstepDistance should spawn particles")
+	}
+}
+
+// Test that footstep colors follow the terrain, with splashes on tiles beside water
+func TestFootstepColor(t *testing.T) {
+	gameMap := world.NewMap(5, 5, 32.0)
+	gameMap.SetTile(0, 0, world.TileDirtPath)
+	gameMap.SetTile(4, 4, world.TileWater)
+	
+	dirt := systems.FootstepColor(gameMap, 0, 0)
+	grass := systems.FootstepColor(gameMap, 2, 2)
+	shore := systems.FootstepColor(gameMap, 3, 4)
+	if dirt == grass {
+		t.Errorf("dirt and grass share color %s, want distinct dust", dirt)
+	}
+	if shore == grass || shore == dirt {
+		t.Errorf("shore color = %s, want a splash distinct from dust", shore)
+	}
+}
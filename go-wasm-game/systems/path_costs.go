@@ -0,0 +1,47 @@
+package systems
+
+import "github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+
+// CombineCosts returns a cost modifier that sums every non-nil modifier, or nil if there are none
+func CombineCosts(modifiers ...CostModifier) CostModifier {
+	active := make([]CostModifier, 0, len(modifiers))
+	for _, modifier := range modifiers {
+		if modifier != nil {
+			active = append(active, modifier)
+		}
+	}
+	switch len(active) {
+	case 0:
+		return nil
+	case 1:
+		return active[0]
+	}
+	return func(tileX, tileY int) float64 {
+		total := 0.0
+		for _, modifier := range active {
+			total += modifier(tileX, tileY)
+		}
+		return total
+	}
+}
+
+// WaterMarginCost charges penalty for entering any tile within margin tiles (diagonals count as 1)
+// of water, so paths keep clear of shorelines when there is room to
+func WaterMarginCost(gameMap *world.Map, margin int, penalty float64) CostModifier {
+	if margin <= 0 || penalty <= 0 {
+		return nil
+	}
+	return func(tileX, tileY int) float64 {
+		for y := tileY - margin; y <= tileY+margin; y++ {
+			for x := tileX - margin; x <= tileX+margin; x++ {
+				if x < 0 || x >= gameMap.Width || y < 0 || y >= gameMap.Height {
+					continue
+				}
+				if gameMap.GetTile(x, y) == world.TileWater {
+					return penalty
+				}
+			}
+		}
+		return 0
+	}
+}
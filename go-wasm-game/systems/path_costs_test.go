@@ -0,0 +1,85 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// pathVisits reports whether any step of the path satisfies match
+func pathVisits(path systems.Path, match func(x, y int) bool) bool {
+	for _, step := range path {
+		if match(step.X, step.Y) {
+			return true
+		}
+	}
+	return false
+}
+
+// Test that a hook penalizing a column reroutes the path through the column's one cheap tile
+func TestExtraCostHookReroutesAroundColumn(t *testing.T) {
+	gameMap := world.NewMap(10, 6, 32.0)
+	inColumn := func(x, y int) bool { return x == 4 && y < 5 }
+	penalty := func(x, y int) float64 {
+		if inColumn(x, y) {
+			return 100
+		}
+		return 0
+	}
+	
+	if path := systems.FindPathWithOptions(0, 0, 9, 0, gameMap, systems.PathOptions{}); !pathVisits(path, inColumn) {
+		t.Fatalf("path without hooks = %v, want it straight through the column", path)
+	}
+	
+	path := systems.FindPathWithOptions(0, 0, 9, 0, gameMap, systems.PathOptions{ExtraCost: penalty})
+	if len(path) == 0 {
+		t.Fatalf("FindPathWithOptions() returned no path")
+	}
+	if pathVisits(path, inColumn) {
+		t.Errorf("path %v crossed the penalized column", path)
+	}
+	if !pathVisits(path, func(x, y int) bool { return x == 4 && y == 5 }) {
+		t.Errorf("path %v, want it through the unpenalized tile (4, 5)", path)
+	}
+}
+
+// Test that combined hooks add up and nil hooks drop out
+func TestCombineCosts(t *testing.T) {
+	flat := func(x, y int) float64 { return 1.5 }
+	byColumn := func(x, y int) float64 { return float64(x) }
+	
+	if systems.CombineCosts(nil, nil) != nil {
+		t.Errorf("CombineCosts(nil, nil) should be nil")
+	}
+	if got := systems.CombineCosts(nil, flat)(3, 0); got != 1.5 {
+		t.Errorf("CombineCosts(nil, flat)(3, 0) = %v, want 1.5", got)
+	}
+	if got := systems.CombineCosts(flat, byColumn, nil)(3, 0); got != 4.5 {
+		t.Errorf("CombineCosts(flat, byColumn)(3, 0) = %v, want 4.5", got)
+	}
+}
+
+// Test that the water margin hook keeps paths off the shoreline when there is room
+func TestWaterMarginCostAvoidsShore(t *testing.T) {
+	gameMap := world.NewMap(10, 7, 32.0)
+	for x := 3; x <= 6; x++ {
+		gameMap.SetTile(x, 0, world.TileWater)
+	}
+	nearWater := func(x, y int) bool { return x >= 2 && x <= 7 && y <= 1 }
+	
+	if path := systems.FindPath(0, 1, 9, 1, gameMap); !pathVisits(path, nearWater) {
+		t.Fatalf("path without margin = %v, want it along the shore", path)
+	}
+	
+	margin := systems.WaterMarginCost(gameMap, 1, 5)
+	path := systems.FindPathWithOptions(0, 1, 9, 1, gameMap, systems.PathOptions{ExtraCost: margin})
+	if pathVisits(path, nearWater) {
+		t.Errorf("path %v hugged the shore despite the water margin hook", path)
+	}
+	if systems.WaterMarginCost(gameMap, 0, 5) != nil {
+		t.Errorf("WaterMarginCost with no margin should be nil")
+	}
+}
@@ -0,0 +1,38 @@
+package systems
+
+import (
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Debug overlay colors for path segments, keyed to terrain walk speed
+const (
+	PathColorFast   = "#32CD32" // Faster than grass (e.g. dirt paths)
+	PathColorNormal = "#FFD700" // Normal walking speed (grass)
+	PathColorSlow   = "#FF4500" // Slower than grass or not walkable
+)
+
+// PathCostColor returns the debug color for a tile based on its terrain cost
+func PathCostColor(tileType world.TileType) string {
+	tileDef, exists := world.TileDefinitions[tileType]
+	if !exists || !tileDef.Walkable {
+		return PathColorSlow
+	}
+	
+	switch {
+	case tileDef.WalkSpeed > 1.0:
+		return PathColorFast
+	case tileDef.WalkSpeed == 1.0:
+		return PathColorNormal
+	default:
+		return PathColorSlow
+	}
+}
+
+// PathStepColors returns one debug color per path step based on the tile under that step
+func PathStepColors(path Path, gameMap *world.Map) []string {
+	colors := make([]string, len(path))
+	for i, step := range path {
+		colors[i] = PathCostColor(gameMap.GetTile(step.X, step.Y))
+	}
+	return colors
+}
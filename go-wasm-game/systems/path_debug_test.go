@@ -0,0 +1,42 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that a path crossing dirt and grass maps to the expected color sequence
+func TestPathStepColors(t *testing.T) {
+	gameMap := world.NewMap(6, 3, 32.0)
+	gameMap.SetTile(2, 1, world.TileDirtPath)
+	gameMap.SetTile(3, 1, world.TileDirtPath)
+	gameMap.SetTile(5, 1, world.TileWater)
+	
+	path := systems.Path{{X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1}, {X: 4, Y: 1}, {X: 5, Y: 1}}
+	expected := []string{
+		systems.PathColorNormal,
+		systems.PathColorNormal,
+		systems.PathColorFast,
+		systems.PathColorFast,
+		systems.PathColorNormal,
+		systems.PathColorSlow,
+	}
+	
+	colors := systems.PathStepColors(path, gameMap)
+	if len(colors) != len(expected) {
+		t.Fatalf("PathStepColors() returned %d colors, want %d", len(colors), len(expected))
+	}
+	for i := range expected {
+		if colors[i] != expected[i] {
+			t.Errorf("PathStepColors()[%d] = %s, want %s", i, colors[i], expected[i])
+		}
+	}
+	
+	if colors := systems.PathStepColors(nil, gameMap); len(colors) != 0 {
+		t.Errorf("PathStepColors(nil) = %v, want empty", colors)
+	}
+}
@@ -0,0 +1,30 @@
+package systems
+
+import (
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// PathDestination returns the final tile of a path, or false for an empty path
+func PathDestination(path Path) (int, int, bool) {
+	if len(path) == 0 {
+		return 0, 0, false
+	}
+	last := path[len(path)-1]
+	return last.X, last.Y, true
+}
+
+// TileToScreen converts a tile to the screen position of its center for the given camera
+func TileToScreen(gameMap *world.Map, tileX, tileY int, cameraX, cameraY float64) (float64, float64) {
+	worldX, worldY := gameMap.GridToWorld(tileX, tileY)
+	return worldX - cameraX, worldY - cameraY
+}
+
+// DestinationScreenPoint returns where a path's destination marker should be drawn on screen
+func DestinationScreenPoint(path Path, gameMap *world.Map, cameraX, cameraY float64) (float64, float64, bool) {
+	tileX, tileY, ok := PathDestination(path)
+	if !ok {
+		return 0, 0, false
+	}
+	screenX, screenY := TileToScreen(gameMap, tileX, tileY, cameraX, cameraY)
+	return screenX, screenY, true
+}
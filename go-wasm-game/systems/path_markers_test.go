@@ -0,0 +1,53 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that the destination is the last step of a multi-step path
+func TestPathDestination(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   systems.Path
+		wantX  int
+		wantY  int
+		wantOK bool
+	}{
+		{"Multi-step path", systems.Path{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 2}, {X: 7, Y: 4}}, 7, 4, true},
+		{"Single step", systems.Path{{X: 5, Y: 6}}, 5, 6, true},
+		{"Empty path", nil, 0, 0, false},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y, ok := systems.PathDestination(tt.path)
+			if x != tt.wantX || y != tt.wantY || ok != tt.wantOK {
+				t.Errorf("PathDestination() = (%d, %d, %v), want (%d, %d, %v)", x, y, ok, tt.wantX, tt.wantY, tt.wantOK)
+			}
+		})
+	}
+}
+
+// Test that the destination marker lands on the center of the final tile relative to the camera
+func TestDestinationScreenPoint(t *testing.T) {
+	gameMap := world.NewMapWithTileDimensions(20, 20, 32.0, 16.0)
+	path := systems.Path{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 4, Y: 3}}
+	
+	x, y, ok := systems.DestinationScreenPoint(path, gameMap, 50, 10)
+	if !ok {
+		t.Fatal("DestinationScreenPoint() returned false for a non-empty path")
+	}
+	// Tile (4, 3) center is (4*32+16, 3*16+8) = (144, 56) in world space
+	if x != 94 || y != 46 {
+		t.Errorf("DestinationScreenPoint() = (%v, %v), want (94, 46)", x, y)
+	}
+	
+	if _, _, ok := systems.DestinationScreenPoint(nil, gameMap, 0, 0); ok {
+		t.Error("DestinationScreenPoint(nil) should report no destination")
+	}
+}
@@ -0,0 +1,43 @@
+package systems
+
+import (
+	"math"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// PathProgress returns how much of a path has been walked, from 0 at the first tile to 1 at the last.
+// step is the path index currently being walked toward (as in Movable.GetPathStep) and (x, y) the
+// entity's center in world coordinates, which interpolates progress within the current step.
+// Progress counts tiles, so the long segments of a smoothed path weigh as much as the tiles they skip.
+// Paths with fewer than two tiles have nothing left to walk and report 1
+func PathProgress(path Path, step int, x, y float64, gameMap *world.Map) float64 {
+	if len(path) < 2 || step >= len(path) {
+		return 1.0
+	}
+	if step < 1 {
+		return 0.0 // Still settling onto the first tile
+	}
+	
+	walked, total := 0, 0
+	for i := 1; i < len(path); i++ {
+		length := SegmentLength(path[i-1].X, path[i-1].Y, path[i].X, path[i].Y, gameMap)
+		if i < step {
+			walked += length
+		}
+		total += length
+	}
+	if total == 0 {
+		return 1.0
+	}
+	
+	// Fraction of the current segment covered, measured by the distance left to its end
+	fromX, fromY := gameMap.GridToWorld(path[step-1].X, path[step-1].Y)
+	toX, toY := gameMap.GridToWorld(path[step].X, path[step].Y)
+	segmentLength := math.Hypot(toX-fromX, toY-fromY)
+	within := 1.0
+	if segmentLength > 0 {
+		within = 1 - math.Min(math.Hypot(toX-x, toY-y)/segmentLength, 1)
+	}
+	current := SegmentLength(path[step-1].X, path[step-1].Y, path[step].X, path[step].Y, gameMap)
+	return (float64(walked) + within*float64(current)) / float64(total)
+}
@@ -0,0 +1,67 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"math"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test progress at the start, middle and end of a multi-step path, including within a step
+func TestPathProgress(t *testing.T) {
+	gameMap := world.NewMap(10, 10, 32.0)
+	path := systems.Path{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0}, {X: 4, Y: 0}}
+	center := func(tileX, tileY int) (float64, float64) { return gameMap.GridToWorld(tileX, tileY) }
+
+	startX, startY := center(0, 0)
+	midX, midY := center(2, 0)
+	endX, endY := center(4, 0)
+
+	tests := []struct {
+		name string
+		step int
+		x, y float64
+		want float64
+	}{
+		{"settling on the first tile", 0, startX, startY, 0},
+		{"leaving the first tile", 1, startX, startY, 0},
+		{"halfway through the second step", 2, midX - 16, midY, 0.375},
+		{"middle of the path", 3, midX, midY, 0.5},
+		{"arriving at the last tile", 4, endX, endY, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := systems.PathProgress(path, tt.step, tt.x, tt.y, gameMap); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("PathProgress(step %d) = %v, want %v", tt.step, got, tt.want)
+			}
+		})
+	}
+}
+
+// Test that progress along a smoothed path counts the tiles its long segments skip
+func TestPathProgressSmoothedPath(t *testing.T) {
+	gameMap := world.NewMap(10, 10, 32.0)
+	path := systems.Path{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 5, Y: 0}}
+	x, y := gameMap.GridToWorld(4, 0)
+	if got := systems.PathProgress(path, 2, x, y, gameMap); math.Abs(got-0.8) > 1e-9 {
+		t.Errorf("PathProgress() at the second waypoint = %v, want 0.8 after 4 of 5 tiles", got)
+	}
+	x, y = gameMap.GridToWorld(2, 0)
+	if got := systems.PathProgress(path, 1, x, y, gameMap); math.Abs(got-0.4) > 1e-9 {
+		t.Errorf("PathProgress() halfway along the long segment = %v, want 0.4", got)
+	}
+}
+
+// Test that having no path left to walk counts as complete
+func TestPathProgressWithoutPath(t *testing.T) {
+	gameMap := world.NewMap(10, 10, 32.0)
+	for _, path := range []systems.Path{nil, {{X: 3, Y: 3}}} {
+		if got := systems.PathProgress(path, 0, 0, 0, gameMap); got != 1 {
+			t.Errorf("PathProgress(%v) = %v, want 1", path, got)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package systems
+
+import "github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+
+// SmoothPath string-pulls a grid path: every waypoint that a straight walk from an earlier kept
+// waypoint can skip is dropped, so entities head diagonally across open ground instead of zig-zagging.
+// A straight walk follows the Bresenham line and must stay on walkable tiles, without crossing edge
+// walls or clipping corners. Only walkability is checked, so a smoothed route may leave a faster road
+func SmoothPath(path Path, gameMap *world.Map) Path {
+	return smoothPath(path, gameMap, nil)
+}
+
+// smoothPath is SmoothPath that also keeps straight walks off tiles reported by blocked
+func smoothPath(path Path, gameMap *world.Map, blocked BlockedFunc) Path {
+	if len(path) <= 2 {
+		return path
+	}
+	
+	smoothed := Path{path[0]}
+	for anchor := 0; anchor < len(path)-1; {
+		// Jump to the farthest waypoint in a straight line; the next one always qualifies
+		next := anchor + 1
+		for candidate := len(path) - 1; candidate > next; candidate-- {
+			if walkableLine(path[anchor].X, path[anchor].Y, path[candidate].X, path[candidate].Y, gameMap, blocked) {
+				next = candidate
+				break
+			}
+		}
+		smoothed = append(smoothed, path[next])
+		anchor = next
+	}
+	return smoothed
+}
+
+// walkableLine reports whether an entity can walk the straight tile line between two tiles,
+// measured the short way around on wrapping maps
+func walkableLine(x0, y0, x1, y1 int, gameMap *world.Map, blocked BlockedFunc) bool {
+	dx, dy := gameMap.WrapTileDelta(x1-x0, y1-y0)
+	return walkLine(x0, y0, x0+dx, y0+dy, func(fromX, fromY, x, y int) bool {
+		tileX, tileY := gameMap.WrapTile(x, y)
+		return gameMap.TileDefAt(tileX, tileY).Walkable &&
+			gameMap.CanCrossEdge(fromX, fromY, x, y) &&
+			!cutsCorner(fromX, fromY, x, y, gameMap) &&
+			(blocked == nil || !blocked(tileX, tileY))
+	})
+}
+
+// SegmentTiles returns the tiles a straight walk from one path waypoint to the next steps onto, ending
+// on the second and measured the short way around on wrapping maps. Neighboring waypoints give just the
+// second, but smoothed paths skip tiles between waypoints, and this fills them back in
+func SegmentTiles(fromX, fromY, toX, toY int, gameMap *world.Map) Path {
+	dx, dy := gameMap.WrapTileDelta(toX-fromX, toY-fromY)
+	var tiles Path
+	walkLine(fromX, fromY, fromX+dx, fromY+dy, func(_, _, x, y int) bool {
+		tileX, tileY := gameMap.WrapTile(x, y)
+		tiles = append(tiles, struct{ X, Y int }{X: tileX, Y: tileY})
+		return true
+	})
+	return tiles
+}
+
+// SegmentLength is how many tiles a straight walk from one waypoint to the next steps onto, 1 for neighbors
+func SegmentLength(fromX, fromY, toX, toY int, gameMap *world.Map) int {
+	dx, dy := gameMap.WrapTileDelta(toX-fromX, toY-fromY)
+	if absInt(dx) > absInt(dy) {
+		return absInt(dx)
+	}
+	return absInt(dy)
+}
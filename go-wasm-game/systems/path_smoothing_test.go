@@ -0,0 +1,164 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"math"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// segmentStaysWalkable samples the straight line between two tile centers and checks every tile it touches
+func segmentStaysWalkable(fromX, fromY, toX, toY int, gameMap *world.Map) bool {
+	steps := 100 * (absDiff(fromX, toX) + absDiff(fromY, toY) + 1)
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x := float64(fromX) + 0.5 + t*float64(toX-fromX)
+		y := float64(fromY) + 0.5 + t*float64(toY-fromY)
+		if !gameMap.TileDefAt(int(math.Floor(x)), int(math.Floor(y))).Walkable {
+			return false
+		}
+	}
+	return true
+}
+
+// Test that a path across an open field collapses to its two endpoints
+func TestSmoothPathOpenField(t *testing.T) {
+	gameMap := world.NewMap(20, 20, 32.0)
+	tests := []struct {
+		name  string
+		route [4]int
+	}{
+		{"straight", [4]int{2, 5, 12, 5}},
+		{"diagonal", [4]int{2, 2, 12, 12}},
+		{"mixed", [4]int{1, 3, 11, 7}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := systems.FindPath(tt.route[0], tt.route[1], tt.route[2], tt.route[3], gameMap)
+			if len(path) != 11 {
+				t.Fatalf("FindPath returned %d waypoints, want the 10-step grid path", len(path))
+			}
+			smoothed := systems.SmoothPath(path, gameMap)
+			if len(smoothed) != 2 || smoothed[0] != path[0] || smoothed[1] != path[len(path)-1] {
+				t.Errorf("SmoothPath() = %v, want just %v and %v", smoothed, path[0], path[len(path)-1])
+			}
+		})
+	}
+}
+
+// Test that a wall in the way keeps the turning points and no smoothed segment crosses it
+func TestSmoothPathKeepsCornersAroundObstacles(t *testing.T) {
+	gameMap := world.NewMap(14, 14, 32.0)
+	for y := 0; y < 9; y++ {
+		gameMap.SetTile(6, y, world.TileWater)
+	}
+	
+	path := systems.FindPath(2, 2, 10, 2, gameMap)
+	smoothed := systems.SmoothPath(path, gameMap)
+	if len(smoothed) <= 2 {
+		t.Fatalf("SmoothPath() = %v, want intermediate waypoints to route around the water", smoothed)
+	}
+	if len(smoothed) >= len(path) {
+		t.Errorf("SmoothPath() kept %d of %d waypoints, want fewer", len(smoothed), len(path))
+	}
+	start, end := smoothed[0], smoothed[len(smoothed)-1]
+	if start.X != 2 || start.Y != 2 || end.X != 10 || end.Y != 2 {
+		t.Fatalf("SmoothPath() runs %v to %v, want (2, 2) to (10, 2)", start, end)
+	}
+	for i := 1; i < len(smoothed); i++ {
+		if !segmentStaysWalkable(smoothed[i-1].X, smoothed[i-1].Y, smoothed[i].X, smoothed[i].Y, gameMap) {
+			t.Errorf("segment %v -> %v crosses water", smoothed[i-1], smoothed[i])
+		}
+	}
+}
+
+// Test that the movement system only smooths planned paths once opted in, and never through blocked tiles
+func TestMovementSystemPathSmoothing(t *testing.T) {
+	gameMap := world.NewMap(20, 20, 32.0)
+	ms := systems.NewMovementSystem(gameMap)
+	if path := ms.PlanPath(2, 2, 12, 12); len(path) != 11 {
+		t.Fatalf("PlanPath() without smoothing has %d waypoints, want 11", len(path))
+	}
+	
+	ms.SetPathSmoothing(true)
+	if path := ms.PlanPath(2, 2, 12, 12); len(path) != 2 {
+		t.Errorf("PlanPath() with smoothing = %v, want 2 waypoints", path)
+	}
+	
+	// An occupied tile off the grid path still stops the straight shortcut through it
+	ms.SetBlockedCheck(func(x, y int) bool { return x == 7 && y == 7 })
+	path := ms.PlanPath(2, 2, 12, 12)
+	if len(path) <= 2 {
+		t.Errorf("PlanPath() around an occupied tile = %v, want a kept turning point", path)
+	}
+	for i := 1; i < len(path); i++ {
+		from, to := path[i-1], path[i]
+		if from.X-from.Y == 0 && to.X-to.Y == 0 && from.X < 7 && to.X > 7 {
+			t.Errorf("segment %v -> %v cuts straight through the occupied (7, 7)", from, to)
+		}
+	}
+}
+
+// Test that the tiles between two waypoints step one neighbor at a time, end on the second and wrap the short way
+func TestSegmentTiles(t *testing.T) {
+	tests := []struct {
+		name         string
+		wrap         bool
+		fromX, fromY int
+		toX, toY     int
+		wantLength   int
+	}{
+		{"neighbors", false, 2, 2, 3, 3, 1},
+		{"long diagonal line", false, 2, 2, 8, 5, 6},
+		{"same tile", false, 4, 4, 4, 4, 0},
+		{"across the seam", true, 18, 2, 1, 2, 3},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := world.NewMap(20, 10, 32.0)
+			gameMap.Wrap = tt.wrap
+			tiles := systems.SegmentTiles(tt.fromX, tt.fromY, tt.toX, tt.toY, gameMap)
+			if len(tiles) != tt.wantLength || systems.SegmentLength(tt.fromX, tt.fromY, tt.toX, tt.toY, gameMap) != tt.wantLength {
+				t.Fatalf("SegmentTiles() = %v, want %d tiles matching SegmentLength", tiles, tt.wantLength)
+			}
+			x, y := tt.fromX, tt.fromY
+			for _, tile := range tiles {
+				if dx, dy := gameMap.WrapTileDelta(tile.X-x, tile.Y-y); dx*dx > 1 || dy*dy > 1 {
+					t.Fatalf("SegmentTiles() jumps from (%d, %d) to %v", x, y, tile)
+				}
+				x, y = tile.X, tile.Y
+			}
+			if x != tt.toX || y != tt.toY {
+				t.Errorf("SegmentTiles() ends at (%d, %d), want (%d, %d)", x, y, tt.toX, tt.toY)
+			}
+		})
+	}
+}
+
+// Test that a tile blocked partway along a long smoothed segment still makes the entity reroute around it
+func TestSmoothedPathReroutesAroundBlockedSegment(t *testing.T) {
+	gameMap := world.NewMap(20, 5, 32.0)
+	ms := systems.NewMovementSystem(gameMap)
+	ms.SetPathSmoothing(true)
+	entity := newEntityAtTile(gameMap, 2, 2)
+	ms.MoveToTile(entity, 12, 2)
+	if path := entity.GetPath(); len(path) != 2 {
+		t.Fatalf("setup: smoothed path = %v, want a single straight segment", path)
+	}
+	
+	ms.SetBlockedCheck(func(x, y int) bool { return x == 7 && y == 2 })
+	for frame := 0; frame < 500 && entity.IsMoving(); frame++ {
+		ms.Update(entity, systems.FrameDuration)
+		if tileX, tileY := gameMap.WorldToGrid(entity.X+entity.Width/2, entity.Y+entity.Height/2); tileX == 7 && tileY == 2 {
+			t.Fatal("entity walked onto the blocked (7, 2)")
+		}
+	}
+	if tileX, tileY := gameMap.WorldToGrid(entity.X+entity.Width/2, entity.Y+entity.Height/2); tileX != 12 || tileY != 2 {
+		t.Errorf("entity stopped at (%d, %d), want (12, 2)", tileX, tileY)
+	}
+}
@@ -53,31 +53,152 @@ type Path []struct {
 	X, Y int
 }
 
+// BlockedFunc reports whether a tile is temporarily blocked (e.g. occupied by a unit)
+type BlockedFunc func(tileX, tileY int) bool
+
+// CostModifier returns extra, non-negative cost for stepping onto a tile (e.g. danger from a threat map)
+type CostModifier func(tileX, tileY int) float64
+
 // FindPath uses A* algorithm to find the shortest walkable path between two grid points
 func FindPath(startX, startY, endX, endY int, gameMap *world.Map) Path {
+	return FindPathAvoiding(startX, startY, endX, endY, gameMap, nil)
+}
+
+// FindPathAvoiding finds a path like FindPath but also routes around tiles reported by blocked
+// The start tile is never checked, so an entity isn't blocked by its own occupancy
+func FindPathAvoiding(startX, startY, endX, endY int, gameMap *world.Map, blocked BlockedFunc) Path {
+	return FindPathWithOptions(startX, startY, endX, endY, gameMap, PathOptions{Blocked: blocked})
+}
+
+// FindPathWithExtraCost finds a path like FindPathAvoiding, adding extraCost for each tile entered
+// A nil extraCost behaves exactly like FindPathAvoiding
+func FindPathWithExtraCost(startX, startY, endX, endY int, gameMap *world.Map, blocked BlockedFunc, extraCost CostModifier) Path {
+	return FindPathWithOptions(startX, startY, endX, endY, gameMap, PathOptions{Blocked: blocked, ExtraCost: extraCost})
+}
+
+// PathOptions are the optional hooks FindPathWithOptions applies on top of terrain and traffic costs
+// The zero value finds exactly the same path as FindPath
+type PathOptions struct {
+	Blocked   BlockedFunc  // Tiles to treat as impassable, e.g. occupied by units
+	ExtraCost CostModifier // Cost added for each tile entered; combine several with CombineCosts
+	
+	// HeuristicWeight inflates the heuristic (FCost = GCost + weight*HCost) for weighted A*.
+	// Above 1 the search heads for the goal more greedily and expands far fewer nodes, but the
+	// path is no longer guaranteed shortest: it can cost up to weight times the optimum.
+	// 1 or below, including the zero value, keeps the search optimal
+	HeuristicWeight float64
+	
+	// Heuristic picks the distance estimate; the zero value is HeuristicEuclidean, as FindPath uses
+	Heuristic Heuristic
+	
+	// FourDirectional drops diagonal steps, so paths only move across and up or down.
+	// HeuristicManhattan is exact for this movement and keeps the search optimal with it
+	FourDirectional bool
+	
+	// AllowCornerCutting lets diagonal steps squeeze past an unwalkable tile beside the corner,
+	// the old behavior where units clip across lake and wall corners. Off by default
+	AllowCornerCutting bool
+	
+	// MaxNodes bounds how many nodes the search may expand. When it runs out before reaching the goal,
+	// the search returns a partial path to the expanded tile closest to the goal instead of nil.
+	// 0 keeps only the search limit below, which still gives up with no path
+	MaxNodes int
+	
+	// MaxIterations is the search limit: past this many expansions the search gives up with no path,
+	// which FindPathLimited reports as truncated rather than impossible. 0 uses DefaultMaxIterations
+	MaxIterations int
+}
+
+// DefaultMaxIterations is the search limit FindPath and PathOptions use unless MaxIterations says otherwise
+// It is high enough for long detours around rivers and complex terrain on the 200x200 map
+const DefaultMaxIterations = 50000
+
+// searchResult is everything one A* search found out
+type searchResult struct {
+	path       Path
+	expansions int
+	cost       float64 // Cost of path, +Inf when there is none
+	truncated  bool    // The search hit MaxIterations before it could tell whether a path exists
+}
+
+// FindPathLimited finds a path like FindPathWithOptions and reports whether the search gave up at
+// opts.MaxIterations. A nil path with truncated false means no path exists at all; with truncated true
+// the search simply ran out of iterations, and a larger limit or a later retry may still find one
+func FindPathLimited(startX, startY, endX, endY int, gameMap *world.Map, opts PathOptions) (path Path, truncated bool) {
+	result := findPath(startX, startY, endX, endY, gameMap, opts)
+	return result.path, result.truncated
+}
+
+// FindPathWithOptions is the general A* entry point; the other FindPath variants are shorthands for it
+func FindPathWithOptions(startX, startY, endX, endY int, gameMap *world.Map, opts PathOptions) Path {
+	return findPath(startX, startY, endX, endY, gameMap, opts).path
+}
+
+// FindPathWithCost works like FindPath and also returns the path's cost: the sum of each step's base cost
+// (1 across, 1.414 diagonally) divided by the walk speed of the tile entered, after traffic discounts.
+// Comparing costs ranks routes by travel time. With no path the cost is +Inf
+func FindPathWithCost(startX, startY, endX, endY int, gameMap *world.Map) (Path, float64) {
+	return FindPathCostWithOptions(startX, startY, endX, endY, gameMap, PathOptions{})
+}
+
+// FindPathCostWithOptions works like FindPathWithOptions and also returns the path's cost, including
+// any ExtraCost. A partial path cut short by MaxNodes reports the cost of the part it covers
+func FindPathCostWithOptions(startX, startY, endX, endY int, gameMap *world.Map, opts PathOptions) (Path, float64) {
+	result := findPath(startX, startY, endX, endY, gameMap, opts)
+	return result.path, result.cost
+}
+
+// FindPathWithDeadline finds a path like FindPath but expands at most maxNodes nodes, bounding the frame time
+// a pathological search can take. When the budget runs out it returns the best partial path toward the goal,
+// ending short of it, so the caller can move along it and plan the rest later
+func FindPathWithDeadline(startX, startY, endX, endY int, gameMap *world.Map, maxNodes int) Path {
+	return FindPathWithOptions(startX, startY, endX, endY, gameMap, PathOptions{MaxNodes: maxNodes})
+}
+
+// FindPathExpansions works like FindPath and also reports how many nodes A* expanded,
+// for comparing search effort against other planners such as DStarLite
+func FindPathExpansions(startX, startY, endX, endY int, gameMap *world.Map) (Path, int) {
+	result := findPath(startX, startY, endX, endY, gameMap, PathOptions{})
+	return result.path, result.expansions
+}
+
+// FindPathExpansionsWithOptions works like FindPathWithOptions and also reports how many nodes A* expanded,
+// e.g. to measure how much a HeuristicWeight saves
+func FindPathExpansionsWithOptions(startX, startY, endX, endY int, gameMap *world.Map, opts PathOptions) (Path, int) {
+	result := findPath(startX, startY, endX, endY, gameMap, opts)
+	return result.path, result.expansions
+}
+
+// findPath runs the A* search
+func findPath(startX, startY, endX, endY int, gameMap *world.Map, opts PathOptions) searchResult {
+	blocked, extraCost := opts.Blocked, opts.ExtraCost
+	
+	// On wrapping maps, off-map endpoints name the same tiles on the opposite edge
+	startX, startY = gameMap.WrapTile(startX, startY)
+	endX, endY = gameMap.WrapTile(endX, endY)
+	
 	// Check if start and end are within bounds
 	if startX < 0 || startX >= gameMap.Width || startY < 0 || startY >= gameMap.Height ||
 	   endX < 0 || endX >= gameMap.Width || endY < 0 || endY >= gameMap.Height {
-		return nil
-	}
-	
-	// Check if start is walkable
-	startTile := gameMap.GetTile(startX, startY)
-	if !world.TileDefinitions[startTile].Walkable {
-		// Find nearest walkable tile to start from
-		startX, startY = FindNearestWalkableTile(startX, startY, gameMap)
+		return searchResult{cost: math.Inf(1)}
 	}
 	
-	// Check if end is walkable
-	endTile := gameMap.GetTile(endX, endY)
-	if !world.TileDefinitions[endTile].Walkable {
-		// Find nearest walkable tile to end at
-		endX, endY = FindNearestWalkableTile(endX, endY, gameMap)
+	// Unwalkable start and end tiles are swapped for the nearest walkable ones; with none nearby there is no path
+	var startOK, endOK bool
+	startX, startY, startOK = FindNearestWalkableTile(startX, startY, DefaultWalkableSearchRadius, gameMap)
+	endX, endY, endOK = FindNearestWalkableTile(endX, endY, DefaultWalkableSearchRadius, gameMap)
+	if !startOK || !endOK {
+		return searchResult{cost: math.Inf(1)}
 	}
 	
 	// If start and end are the same, return single-point path
 	if startX == endX && startY == endY {
-		return Path{{X: endX, Y: endY}}
+		return searchResult{path: Path{{X: endX, Y: endY}}}
+	}
+	
+	// A blocked destination can never be reached, so skip the search entirely
+	if blocked != nil && blocked(endX, endY) {
+		return searchResult{cost: math.Inf(1)}
 	}
 	
 	// Initialize data structures
@@ -89,10 +210,29 @@ func FindPath(startX, startY, endX, endY int, gameMap *world.Map) Path {
 	closedSet := make(map[int]bool)
 	
 	// Add search limit to prevent infinite loops in extreme cases
-	// Increased limit to handle larger rivers and complex terrain
-	const maxSearchIterations = 50000
+	maxSearchIterations := opts.MaxIterations
+	if maxSearchIterations <= 0 {
+		maxSearchIterations = DefaultMaxIterations
+	}
 	searchIterations := 0
 	
+	// A caller's node budget stops the search early with a partial path rather than none
+	budgeted := opts.MaxNodes > 0 && opts.MaxNodes < maxSearchIterations
+	iterationLimit := maxSearchIterations
+	if budgeted {
+		iterationLimit = opts.MaxNodes
+	}
+	
+	// Non-square tiles make vertical steps cost more (or less) than horizontal ones
+	aspect := gameMap.TileAspectRatio()
+	
+	// Traffic discounts can make steps cheaper than their base cost; shrink the heuristic to match
+	// Weighted A* then inflates it again, trading optimality for fewer expansions
+	heuristicScale := gameMap.MinTrafficCostMultiplier()
+	if opts.HeuristicWeight > 1 {
+		heuristicScale *= opts.HeuristicWeight
+	}
+	
 	// Helper function to get unique key for coordinates
 	getKey := func(x, y int) int {
 		return y*gameMap.Width + x
@@ -103,21 +243,27 @@ func FindPath(startX, startY, endX, endY int, gameMap *world.Map) Path {
 		X:     startX,
 		Y:     startY,
 		GCost: 0,
-		HCost: heuristic(startX, startY, endX, endY),
+		HCost: wrappedHeuristic(startX, startY, endX, endY, aspect, gameMap, opts.Heuristic) * heuristicScale,
 	}
 	startNode.FCost = startNode.GCost + startNode.HCost
 	
 	heap.Push(openSet, startNode)
 	allNodes[getKey(startX, startY)] = startNode
 	
-	// Define movement directions (8-directional movement)
+	// Expanded node nearest the goal, where a partial path ends if the budget runs out
+	closest := startNode
+	
+	// Define movement directions (8-directional movement, or only the cardinal ones)
 	directions := []struct{ dx, dy int }{
 		{0, 1}, {1, 0}, {0, -1}, {-1, 0},     // Cardinal directions
 		{1, 1}, {-1, -1}, {1, -1}, {-1, 1},   // Diagonal directions
 	}
+	if opts.FourDirectional {
+		directions = directions[:4]
+	}
 	
 	// A* main loop
-	for openSet.Len() > 0 && searchIterations < maxSearchIterations {
+	for openSet.Len() > 0 && searchIterations < iterationLimit {
 		searchIterations++
 		
 		// Get node with lowest F cost
@@ -129,13 +275,16 @@ func FindPath(startX, startY, endX, endY int, gameMap *world.Map) Path {
 		
 		// Check if we reached the goal
 		if current.X == endX && current.Y == endY {
-			return reconstructPath(current)
+			return searchResult{path: reconstructPath(current), expansions: searchIterations, cost: current.GCost}
+		}
+		if current.HCost < closest.HCost || (current.HCost == closest.HCost && current.GCost < closest.GCost) {
+			closest = current
 		}
 		
 		// Explore neighbors
 		for _, dir := range directions {
-			neighborX := current.X + dir.dx
-			neighborY := current.Y + dir.dy
+			// On wrapping maps, stepping off one edge enters the tile on the opposite edge
+			neighborX, neighborY := gameMap.WrapTile(current.X+dir.dx, current.Y+dir.dy)
 			neighborKey := getKey(neighborX, neighborY)
 			
 			// Skip if out of bounds
@@ -150,22 +299,41 @@ func FindPath(startX, startY, endX, endY int, gameMap *world.Map) Path {
 			}
 			
 			// Skip if not walkable
-			neighborTile := gameMap.GetTile(neighborX, neighborY)
-			if !world.TileDefinitions[neighborTile].Walkable {
+			tileDef := gameMap.TileDefAt(neighborX, neighborY)
+			if !tileDef.Walkable {
 				continue
 			}
 			
-			// Calculate movement cost (diagonal moves cost more + terrain cost)
-			baseCost := 1.0
-			if dir.dx != 0 && dir.dy != 0 {
-				baseCost = 1.414 // sqrt(2) for diagonal movement
+			// Skip if a thin wall sits between the two tiles
+			if !gameMap.CanCrossEdge(current.X, current.Y, current.X+dir.dx, current.Y+dir.dy) {
+				continue
+			}
+			
+			// Skip diagonals that would clip the corner of water or a wall
+			if !opts.AllowCornerCutting && cutsCorner(current.X, current.Y, current.X+dir.dx, current.Y+dir.dy, gameMap) {
+				continue
+			}
+			
+			// Skip if blocked by another entity
+			if blocked != nil && blocked(neighborX, neighborY) {
+				continue
 			}
 			
+			// Calculate movement cost (diagonal moves cost more + terrain cost)
+			baseCost := stepCost(dir.dx, dir.dy, aspect)
+			
 			// Factor in terrain movement cost (slower terrain = higher pathfinding cost)
 			// This encourages pathfinding through faster terrain when available
-			tileDef := world.TileDefinitions[neighborTile]
 			terrainCost := baseCost / tileDef.WalkSpeed // Invert speed to get cost
 			
+			// Well-traveled tiles are mildly cheaper, so popular routes reinforce themselves
+			terrainCost *= gameMap.TrafficCostMultiplier(neighborX, neighborY)
+			
+			// Caller-supplied penalties (e.g. threat) only ever add cost, so the heuristic stays admissible
+			if extraCost != nil {
+				terrainCost += extraCost(neighborX, neighborY)
+			}
+			
 			tentativeGCost := current.GCost + terrainCost
 			
 			// Check if we found a better path to this neighbor
@@ -177,7 +345,7 @@ func FindPath(startX, startY, endX, endY int, gameMap *world.Map) Path {
 					Y:      neighborY,
 					Parent: current,
 					GCost:  tentativeGCost,
-					HCost:  heuristic(neighborX, neighborY, endX, endY),
+					HCost:  wrappedHeuristic(neighborX, neighborY, endX, endY, aspect, gameMap, opts.Heuristic) * heuristicScale,
 				}
 				neighbor.FCost = neighbor.GCost + neighbor.HCost
 				
@@ -195,18 +363,49 @@ func FindPath(startX, startY, endX, endY int, gameMap *world.Map) Path {
 		}
 	}
 	
+	// Out of budget with tiles still to explore: head toward the goal as far as the search got
+	outOfIterations := openSet.Len() > 0 && searchIterations >= iterationLimit
+	if budgeted && outOfIterations {
+		return searchResult{path: reconstructPath(closest), expansions: searchIterations, cost: closest.GCost}
+	}
+	
 	// No path found - return nil to indicate no valid path exists
 	// This prevents the player from getting stuck trying to follow an impossible path
-	return nil
+	return searchResult{expansions: searchIterations, cost: math.Inf(1), truncated: outOfIterations}
 }
 
 // heuristic calculates the Euclidean distance heuristic for A*
 // This provides better pathfinding accuracy for diagonal movement compared to Manhattan distance
-func heuristic(x1, y1, x2, y2 int) float64 {
-	dx := float64(absInt(x2 - x1))
-	dy := float64(absInt(y2 - y1))
-	// Use Euclidean distance for more accurate pathfinding with diagonal movement
-	return math.Sqrt(dx*dx + dy*dy)
+// Distances are measured in tile widths, with vertical offsets scaled by the tile aspect ratio
+func heuristic(x1, y1, x2, y2 int, aspect float64) float64 {
+	return HeuristicEuclidean.estimate(x2-x1, y2-y1, aspect)
+}
+
+// wrappedHeuristic is the chosen heuristic measured the short way around, across the seam on wrapping maps
+func wrappedHeuristic(x1, y1, x2, y2 int, aspect float64, gameMap *world.Map, mode Heuristic) float64 {
+	dx, dy := gameMap.WrapTileDelta(x2-x1, y2-y1)
+	return mode.estimate(dx, dy, aspect)
+}
+
+// cutsCorner reports whether a diagonal step passes an unwalkable tile on either side of the corner it crosses
+// Coordinates may run off the edge of wrapping maps, where GetTile wraps them
+func cutsCorner(fromX, fromY, toX, toY int, gameMap *world.Map) bool {
+	if fromX == toX || fromY == toY {
+		return false
+	}
+	return !gameMap.TileDefAt(toX, fromY).Walkable || !gameMap.TileDefAt(fromX, toY).Walkable
+}
+
+// stepCost returns the base cost of a single grid step, measured in tile widths
+// Square tiles keep the classic 1 / 1.414 costs; other aspect ratios scale them
+func stepCost(dx, dy int, aspect float64) float64 {
+	if dx != 0 && dy != 0 {
+		return 1.414 * math.Sqrt((1+aspect*aspect)/2) // sqrt(2) for diagonal movement
+	}
+	if dy != 0 {
+		return aspect
+	}
+	return 1.0
 }
 
 // reconstructPath builds the final path by following parent pointers backwards
@@ -0,0 +1,51 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that collision, nearest-tile search and pathfinding all follow the map's exterior tile at the border
+func TestOutOfBoundsTileAtBorder(t *testing.T) {
+	tests := []struct {
+		name             string
+		exterior         world.TileType
+		overhangWalkable bool
+	}{
+		{"water exterior", world.TileWater, false},
+		{"wall exterior", world.TileWall, false},
+		{"grass exterior", world.TileGrass, true},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := world.NewMap(6, 6, 32.0)
+			gameMap.SetOutOfBoundsTile(tt.exterior)
+			
+			// A box hanging 4px past the left edge touches the exterior
+			if got := systems.IsPositionWalkable(-4, 40, 20, 20, gameMap); got != tt.overhangWalkable {
+				t.Errorf("IsPositionWalkable(overhang) = %v, want %v", got, tt.overhangWalkable)
+			}
+			
+			// Clicking outside the map still targets the nearest tile inside it
+			if x, y, ok := systems.FindNearestWalkableTile(-1, 2, systems.DefaultWalkableSearchRadius, gameMap); !ok || x != 0 || y != 2 {
+				t.Errorf("FindNearestWalkableTile(-1, 2) = (%d, %d, %v), want (0, 2, true)", x, y, ok)
+			}
+			
+			// Paths along the border stay on the map whatever lies outside it
+			path := systems.FindPath(0, 0, 0, 5, gameMap)
+			if len(path) == 0 {
+				t.Fatalf("FindPath along the border returned no path")
+			}
+			for _, step := range path {
+				if step.X < 0 || step.X >= gameMap.Width || step.Y < 0 || step.Y >= gameMap.Height {
+					t.Errorf("path step (%d, %d) leaves the map", step.X, step.Y)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"reflect"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that a tiny node budget returns a valid partial path that ends closer to the goal than it started
+func TestFindPathWithDeadlinePartial(t *testing.T) {
+	gameMap := world.NewMap(40, 10, 32.0)
+	
+	for _, budget := range []int{1, 5, 20} {
+		path := systems.FindPathWithDeadline(2, 5, 37, 5, gameMap, budget)
+		if len(path) == 0 {
+			t.Fatalf("budget %d: got no path, want a partial one", budget)
+		}
+		end := path[len(path)-1]
+		assertValidPath(t, path, 2, 5, end.X, end.Y, gameMap)
+		if end.X == 37 && end.Y == 5 {
+			t.Errorf("budget %d: path reached the goal, want it cut short", budget)
+		}
+		if budget > 1 && end.X <= 2 {
+			t.Errorf("budget %d: path ends at %v, want progress toward x = 37", budget, end)
+		}
+	}
+	
+	// An unreachable goal still reports no path once the search runs dry within its budget
+	for y := 0; y < gameMap.Height; y++ {
+		gameMap.SetTile(4, y, world.TileWater)
+	}
+	if path := systems.FindPathWithDeadline(2, 5, 37, 5, gameMap, 1000); path != nil {
+		t.Errorf("path to a walled-off goal = %v, want nil", path)
+	}
+}
+
+// Test that a generous node budget finds the same optimal path as unbudgeted A*
+func TestFindPathWithDeadlineGenerous(t *testing.T) {
+	gameMap := newWalledMap()
+	want, expansions := systems.FindPathExpansions(2, 20, 27, 20, gameMap)
+	
+	for _, budget := range []int{expansions, expansions * 10, 0} {
+		if got := systems.FindPathWithDeadline(2, 20, 27, 20, gameMap, budget); !reflect.DeepEqual(got, want) {
+			t.Errorf("budget %d: path = %v, want the optimal %v", budget, got, want)
+		}
+	}
+}
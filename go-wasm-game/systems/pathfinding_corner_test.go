@@ -0,0 +1,68 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newDiagonalGapMap builds a 6x6 grass map split by a diagonal water line that only pairs of
+// corner-touching water tiles close, plus a real one-tile gap at (0, 3)
+func newDiagonalGapMap() *world.Map {
+	gameMap := world.NewMap(6, 6, 32.0)
+	for _, tile := range [][2]int{{1, 2}, {2, 1}, {3, 0}} {
+		gameMap.SetTile(tile[0], tile[1], world.TileWater)
+	}
+	return gameMap
+}
+
+// cutsAnyCorner reports whether some diagonal step in a path passes an unwalkable tile beside its corner
+func cutsAnyCorner(path systems.Path, gameMap *world.Map) bool {
+	for i := 1; i < len(path); i++ {
+		from, to := path[i-1], path[i]
+		if from.X != to.X && from.Y != to.Y &&
+			(!gameMap.TileDefAt(to.X, from.Y).Walkable || !gameMap.TileDefAt(from.X, to.Y).Walkable) {
+			return true
+		}
+	}
+	return false
+}
+
+// Test that paths route around a diagonal water gap instead of squeezing through its corner
+func TestFindPathNoCornerCutting(t *testing.T) {
+	gameMap := newDiagonalGapMap()
+	
+	path := systems.FindPath(1, 1, 2, 2, gameMap)
+	assertValidPath(t, path, 1, 1, 2, 2, gameMap)
+	if cutsAnyCorner(path, gameMap) {
+		t.Errorf("path %v cuts across a water corner", path)
+	}
+	if !pathVisits(path, atTile(0, 3)) {
+		t.Errorf("path %v does not go around through the gap at (0, 3)", path)
+	}
+	
+	// D* Lite and flow fields follow the same rule, so they agree on the detour
+	if dstar := systems.NewDStarLite(2, 2, gameMap).PathFrom(1, 1); cutsAnyCorner(dstar, gameMap) || !pathVisits(dstar, atTile(0, 3)) {
+		t.Errorf("D* Lite path %v, want it around through (0, 3)", dstar)
+	}
+	field := systems.ComputeFlowField(2, 2, gameMap)
+	if dir := field[1][1]; dir < 0 {
+		t.Errorf("flow field at (1, 1) = %d, want a direction toward the detour", dir)
+	} else if step := systems.FlowDirections[dir]; step.DX == 1 && step.DY == 1 {
+		t.Errorf("flow field at (1, 1) points diagonally through the water corner")
+	}
+}
+
+// Test that AllowCornerCutting restores the direct diagonal step through the gap
+func TestFindPathAllowCornerCutting(t *testing.T) {
+	gameMap := newDiagonalGapMap()
+	
+	path := systems.FindPathWithOptions(1, 1, 2, 2, gameMap, systems.PathOptions{AllowCornerCutting: true})
+	assertValidPath(t, path, 1, 1, 2, 2, gameMap)
+	if len(path) != 2 {
+		t.Errorf("path = %v, want the single diagonal step", path)
+	}
+}
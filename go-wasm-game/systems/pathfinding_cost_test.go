@@ -0,0 +1,67 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that the reported cost weights each step by its direction and the speed of the tile entered
+func TestFindPathWithCost(t *testing.T) {
+	tests := []struct {
+		name      string
+		route     [4]int
+		dirt      [][2]int
+		wantSteps int
+		wantCost  float64
+	}{
+		{"straight grass", [4]int{1, 1, 4, 1}, nil, 3, 3.0},
+		{"diagonal grass", [4]int{1, 1, 3, 3}, nil, 2, 2 * 1.414},
+		{"straight dirt", [4]int{1, 5, 4, 5}, [][2]int{{2, 5}, {3, 5}, {4, 5}}, 3, 3 / 1.5},
+		{"same tile", [4]int{2, 2, 2, 2}, nil, 0, 0},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := world.NewMap(8, 8, 32.0)
+			for _, tile := range tt.dirt {
+				gameMap.SetTile(tile[0], tile[1], world.TileDirtPath)
+			}
+			
+			path, cost := systems.FindPathWithCost(tt.route[0], tt.route[1], tt.route[2], tt.route[3], gameMap)
+			if len(path) != tt.wantSteps+1 {
+				t.Fatalf("path = %v, want %d steps", path, tt.wantSteps)
+			}
+			if math.Abs(cost-tt.wantCost) > 1e-9 {
+				t.Errorf("cost = %.4f, want %.4f", cost, tt.wantCost)
+			}
+			if want := systems.FindPath(tt.route[0], tt.route[1], tt.route[2], tt.route[3], gameMap); !reflect.DeepEqual(path, want) {
+				t.Errorf("path = %v, want FindPath's %v", path, want)
+			}
+		})
+	}
+}
+
+// Test that an unreachable goal costs +Inf and extra costs are included
+func TestFindPathCostWithOptions(t *testing.T) {
+	gameMap := world.NewMap(8, 8, 32.0)
+	_, plain := systems.FindPathWithCost(1, 1, 4, 1, gameMap)
+	_, penalized := systems.FindPathCostWithOptions(1, 1, 4, 1, gameMap, systems.PathOptions{
+		ExtraCost: func(x, y int) float64 { return 0.5 },
+	})
+	if math.Abs(penalized-(plain+3*0.5)) > 1e-9 {
+		t.Errorf("cost with 0.5 per tile = %.3f, want %.3f", penalized, plain+1.5)
+	}
+	
+	for y := 0; y < gameMap.Height; y++ {
+		gameMap.SetTile(3, y, world.TileWater)
+	}
+	if path, cost := systems.FindPathWithCost(1, 1, 5, 1, gameMap); path != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unreachable goal = %v at cost %v, want nil at +Inf", path, cost)
+	}
+}
@@ -0,0 +1,82 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// pathCrossesEdge reports whether consecutive path steps move directly between the two tiles
+func pathCrossesEdge(path systems.Path, x1, y1, x2, y2 int) bool {
+	for i := 1; i < len(path); i++ {
+		from, to := path[i-1], path[i]
+		if (from.X == x1 && from.Y == y1 && to.X == x2 && to.Y == y2) ||
+			(from.X == x2 && from.Y == y2 && to.X == x1 && to.Y == y1) {
+			return true
+		}
+	}
+	return false
+}
+
+// Test that an edge wall blocks the direct step and the path reroutes around its end
+func TestPathReroutesAroundEdgeWall(t *testing.T) {
+	gameMap := world.NewMap(5, 5, 32.0)
+	
+	direct := systems.FindPath(1, 2, 2, 2, gameMap)
+	if systems.PathLength(direct) != 2 {
+		t.Fatalf("path without walls = %v, want a single step", direct)
+	}
+	
+	// Wall off the edge between columns 1 and 2 for rows 1-3
+	for y := 1; y <= 3; y++ {
+		if !gameMap.AddEdgeWall(1, y, 2, y) {
+			t.Fatalf("AddEdgeWall(1, %d, 2, %d) = false for neighbors", y, y)
+		}
+	}
+	
+	path := systems.FindPath(1, 2, 2, 2, gameMap)
+	if path == nil {
+		t.Fatal("path should reroute around the edge wall")
+	}
+	for y := 1; y <= 3; y++ {
+		if pathCrossesEdge(path, 1, y, 2, y) {
+			t.Errorf("path %v crosses the wall between (1, %d) and (2, %d)", path, y, y)
+		}
+	}
+	for i := 1; i < len(path); i++ {
+		if !gameMap.CanCrossEdge(path[i-1].X, path[i-1].Y, path[i].X, path[i].Y) {
+			t.Errorf("path step %v -> %v slips past a wall corner", path[i-1], path[i])
+		}
+	}
+	if systems.PathLength(path) <= 2 {
+		t.Errorf("rerouted path %v should be longer than the direct step", path)
+	}
+}
+
+// Test that a fully enclosing ring of edge walls makes the inside unreachable without using tiles
+func TestEdgeWallsEnclosure(t *testing.T) {
+	gameMap := world.NewMap(5, 5, 32.0)
+	gameMap.AddEdgeWall(2, 2, 1, 2)
+	gameMap.AddEdgeWall(2, 2, 3, 2)
+	gameMap.AddEdgeWall(2, 2, 2, 1)
+	gameMap.AddEdgeWall(2, 2, 2, 3)
+	
+	if path := systems.FindPath(0, 0, 2, 2, gameMap); path != nil {
+		t.Errorf("FindPath into an enclosure = %v, want nil", path)
+	}
+	if gameMap.GetTile(2, 2) != world.TileGrass {
+		t.Error("edge walls should not change the enclosed tile")
+	}
+	
+	if gameMap.AddEdgeWall(0, 0, 1, 1) {
+		t.Error("AddEdgeWall should reject tiles that only touch diagonally")
+	}
+	
+	gameMap.RemoveEdgeWall(2, 1, 2, 2)
+	if path := systems.FindPath(0, 0, 2, 2, gameMap); path == nil {
+		t.Error("removing one wall should open the enclosure")
+	}
+}
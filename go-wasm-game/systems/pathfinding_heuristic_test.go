@@ -0,0 +1,72 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that FindPath is the Euclidean default of FindPathWithOptions
+func TestEuclideanHeuristicIsDefault(t *testing.T) {
+	gameMap := newWalledMap()
+	want, wantExpansions := systems.FindPathExpansions(2, 20, 27, 20, gameMap)
+	got, expansions := systems.FindPathExpansionsWithOptions(2, 20, 27, 20, gameMap, systems.PathOptions{Heuristic: systems.HeuristicEuclidean})
+	if !reflect.DeepEqual(got, want) || expansions != wantExpansions {
+		t.Errorf("Euclidean path = %v (%d expansions), want FindPath's %v (%d)", got, expansions, want, wantExpansions)
+	}
+}
+
+// Test how much each heuristic narrows the search on an open map: the tighter the estimate,
+// the fewer nodes expanded, with the admissible ones still finding the cheapest route
+func TestHeuristicExpansions(t *testing.T) {
+	gameMap := world.NewMap(30, 30, 32.0)
+	startX, startY, goalX, goalY := 2, 3, 26, 21
+	optimal := pathCost(systems.FindPath(startX, startY, goalX, goalY, gameMap), gameMap)
+	
+	expansions := map[systems.Heuristic]int{}
+	for _, mode := range []systems.Heuristic{systems.HeuristicEuclidean, systems.HeuristicManhattan, systems.HeuristicChebyshev} {
+		path, count := systems.FindPathExpansionsWithOptions(startX, startY, goalX, goalY, gameMap, systems.PathOptions{Heuristic: mode})
+		assertValidPath(t, path, startX, startY, goalX, goalY, gameMap)
+		expansions[mode] = count
+		
+		// Manhattan overestimates diagonal moves, so only the other two promise the optimum
+		if cost := pathCost(path, gameMap); mode != systems.HeuristicManhattan && math.Abs(cost-optimal) > 1e-9 {
+			t.Errorf("heuristic %d path cost %.3f, want optimal %.3f", mode, cost, optimal)
+		}
+	}
+	
+	if !(expansions[systems.HeuristicManhattan] < expansions[systems.HeuristicEuclidean] && expansions[systems.HeuristicEuclidean] < expansions[systems.HeuristicChebyshev]) {
+		t.Errorf("expansions Manhattan %d, Euclidean %d, Chebyshev %d, want strictly increasing",
+			expansions[systems.HeuristicManhattan], expansions[systems.HeuristicEuclidean], expansions[systems.HeuristicChebyshev])
+	}
+}
+
+// Test that with diagonals turned off paths only take cardinal steps and Manhattan still finds the shortest one
+func TestFourDirectionalManhattan(t *testing.T) {
+	gameMap := newWalledMap()
+	startX, startY, goalX, goalY := 2, 20, 27, 20
+	
+	euclidean, euclideanExpansions := systems.FindPathExpansionsWithOptions(startX, startY, goalX, goalY, gameMap,
+		systems.PathOptions{FourDirectional: true})
+	manhattan, manhattanExpansions := systems.FindPathExpansionsWithOptions(startX, startY, goalX, goalY, gameMap,
+		systems.PathOptions{FourDirectional: true, Heuristic: systems.HeuristicManhattan})
+	assertValidPath(t, manhattan, startX, startY, goalX, goalY, gameMap)
+	for i := 1; i < len(manhattan); i++ {
+		if manhattan[i].X != manhattan[i-1].X && manhattan[i].Y != manhattan[i-1].Y {
+			t.Fatalf("path steps diagonally from %v to %v", manhattan[i-1], manhattan[i])
+		}
+	}
+	
+	// Euclidean never overestimates, so its path is the 4-directional optimum to compare against
+	if got, want := pathCost(manhattan, gameMap), pathCost(euclidean, gameMap); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Manhattan path cost %.3f, want the optimal %.3f", got, want)
+	}
+	if manhattanExpansions >= euclideanExpansions {
+		t.Errorf("Manhattan expanded %d nodes, want fewer than Euclidean's %d", manhattanExpansions, euclideanExpansions)
+	}
+}
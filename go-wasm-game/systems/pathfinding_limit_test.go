@@ -0,0 +1,67 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newSerpentineMap builds a 40x40 maze of horizontal walls with gaps alternating between the left and right edges
+func newSerpentineMap() *world.Map {
+	gameMap := world.NewMap(40, 40, 32.0)
+	for y := 2; y < gameMap.Height; y += 2 {
+		gap := gameMap.Width - 1
+		if y%4 == 0 {
+			gap = 0
+		}
+		for x := 0; x < gameMap.Width; x++ {
+			if x != gap {
+				gameMap.SetTile(x, y, world.TileWater)
+			}
+		}
+	}
+	return gameMap
+}
+
+// Test that hitting MaxIterations is reported as truncated, unlike a goal that is truly unreachable
+func TestFindPathLimited(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxIterations int
+		sealGoal      bool
+		wantPath      bool
+		wantTruncated bool
+	}{
+		{"tiny limit", 10, false, false, true},
+		{"default limit", 0, false, true, false},
+		{"unreachable goal", 0, true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := newSerpentineMap()
+			if tt.sealGoal {
+				gameMap.SetTile(0, 36, world.TileWater)
+			}
+
+			path, truncated := systems.FindPathLimited(1, 1, 38, 39, gameMap, systems.PathOptions{MaxIterations: tt.maxIterations})
+			if (path != nil) != tt.wantPath {
+				t.Errorf("FindPathLimited() path = %v, want found = %v", path, tt.wantPath)
+			}
+			if truncated != tt.wantTruncated {
+				t.Errorf("FindPathLimited() truncated = %v, want %v", truncated, tt.wantTruncated)
+			}
+			if tt.wantPath {
+				assertValidPath(t, path, 1, 1, 38, 39, gameMap)
+			}
+		})
+	}
+
+	// FindPath keeps the default limit, so the maze is still solved
+	if path := systems.FindPath(1, 1, 38, 39, newSerpentineMap()); path == nil {
+		t.Errorf("FindPath() on the maze = nil, want a path")
+	}
+}
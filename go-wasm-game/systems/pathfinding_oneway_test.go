@@ -0,0 +1,90 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newGapMap builds a 5x5 grass map split by a water column at x=2 with gaps at rows 0 and 4
+func newGapMap() *world.Map {
+	gameMap := world.NewMap(5, 5, 32.0)
+	for y := 1; y <= 3; y++ {
+		gameMap.SetTile(2, y, world.TileWater)
+	}
+	return gameMap
+}
+
+// atTile matches a single tile for pathVisits
+func atTile(tileX, tileY int) func(x, y int) bool {
+	return func(x, y int) bool { return x == tileX && y == tileY }
+}
+
+// Test that a one-way tile can be entered from its allowed side but not from the others
+func TestOneWayTileEntry(t *testing.T) {
+	gameMap := world.NewMap(5, 5, 32.0)
+	gameMap.SetTileEntryMask(2, 2, world.EntryFromWest)
+	
+	tests := []struct {
+		name         string
+		fromX, fromY int
+		want         bool
+	}{
+		{"from the west", 1, 2, true},
+		{"from the east", 3, 2, false},
+		{"from the north", 2, 1, false},
+		{"from the south", 2, 3, false},
+		{"diagonally past an allowed side", 1, 1, false},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gameMap.CanCrossEdge(tt.fromX, tt.fromY, 2, 2); got != tt.want {
+				t.Errorf("CanCrossEdge(%d, %d -> 2, 2) = %v, want %v", tt.fromX, tt.fromY, got, tt.want)
+			}
+		})
+	}
+	
+	if !gameMap.CanCrossEdge(2, 2, 3, 2) {
+		t.Error("leaving a one-way tile should never be restricted")
+	}
+	if gameMap.TileEntryMask(0, 0) != world.EntryFromAll {
+		t.Errorf("TileEntryMask(0, 0) = %v, want EntryFromAll by default", gameMap.TileEntryMask(0, 0))
+	}
+	gameMap.SetTileEntryMask(2, 2, world.EntryFromAll)
+	if !gameMap.CanCrossEdge(3, 2, 2, 2) {
+		t.Error("resetting to EntryFromAll should reopen every side")
+	}
+}
+
+// Test that A* only routes through a one-way gap in its allowed direction
+func TestPathRespectsOneWayTiles(t *testing.T) {
+	gameMap := newGapMap()
+	gameMap.SetTileEntryMask(2, 0, world.EntryFromWest)
+	
+	east := systems.FindPath(0, 0, 4, 0, gameMap)
+	if east == nil || !pathVisits(east, atTile(2, 0)) {
+		t.Errorf("eastward path = %v, want it through the one-way gap at (2, 0)", east)
+	}
+	
+	west := systems.FindPath(4, 0, 0, 0, gameMap)
+	if west == nil {
+		t.Fatal("westward path should detour through the open gap at (2, 4)")
+	}
+	if pathVisits(west, atTile(2, 0)) || !pathVisits(west, atTile(2, 4)) {
+		t.Errorf("westward path = %v, want it through (2, 4) instead of the one-way gap", west)
+	}
+	for i := 1; i < len(west); i++ {
+		if !gameMap.CanCrossEdge(west[i-1].X, west[i-1].Y, west[i].X, west[i].Y) {
+			t.Errorf("path step %v -> %v enters a tile from a blocked side", west[i-1], west[i])
+		}
+	}
+	
+	gameMap.SetTileEntryMask(2, 4, world.EntryFromWest)
+	if path := systems.FindPath(4, 0, 0, 0, gameMap); path != nil {
+		t.Errorf("FindPath with both gaps one-way eastward = %v, want nil", path)
+	}
+}
@@ -0,0 +1,59 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that a threatened corridor is avoided for a slightly longer safe route, and a nil modifier changes nothing
+func TestPathAvoidsHighThreatCorridor(t *testing.T) {
+	gameMap := world.NewMap(10, 5, 32.0)
+	
+	// Danger sits on the direct row between the endpoints
+	threat := make([][]float64, gameMap.Height)
+	for y := range threat {
+		threat[y] = make([]float64, gameMap.Width)
+	}
+	for x := 2; x <= 7; x++ {
+		threat[2][x] = 10
+	}
+	
+	direct := systems.FindPath(0, 2, 9, 2, gameMap)
+	if systems.PathLength(direct) != 10 {
+		t.Fatalf("unmodified path = %v, want the straight 10-tile row", direct)
+	}
+	
+	tests := []struct {
+		name        string
+		weight      float64
+		wantAvoided bool
+	}{
+		{"no weight keeps the direct route", 0, false},
+		{"threat weight detours", 1, true},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := systems.FindPathWithExtraCost(0, 2, 9, 2, gameMap, nil, systems.ThreatCost(threat, tt.weight))
+			if len(path) == 0 {
+				t.Fatalf("FindPathWithExtraCost() returned no path")
+			}
+			entered := 0
+			for _, step := range path {
+				if threat[step.Y][step.X] > 0 {
+					entered++
+				}
+			}
+			if avoided := entered == 0; avoided != tt.wantAvoided {
+				t.Errorf("path %v entered %d threatened tiles, want avoided = %v", path, entered, tt.wantAvoided)
+			}
+			if tt.wantAvoided && systems.PathLength(path) > systems.PathLength(direct)+2 {
+				t.Errorf("safe route has %d steps, want only slightly longer than %d", systems.PathLength(path), systems.PathLength(direct))
+			}
+		})
+	}
+}
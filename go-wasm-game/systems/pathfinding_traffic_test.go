@@ -0,0 +1,51 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// countRow returns how many path steps lie on the given row
+func countRow(path systems.Path, row int) int {
+	count := 0
+	for _, step := range path {
+		if step.Y == row {
+			count++
+		}
+	}
+	return count
+}
+
+// Test that a busy corridor one row off the straight line attracts the path once traffic is discounted
+func TestPathPrefersHighTrafficCorridor(t *testing.T) {
+	gameMap := world.NewMap(12, 5, 32.0)
+	
+	path := systems.FindPath(0, 2, 11, 2, gameMap)
+	if countRow(path, 1) != 0 {
+		t.Fatalf("baseline path %v should stay on the straight row", path)
+	}
+	
+	for x := 1; x < 11; x++ {
+		for i := 0; i < int(world.TrafficSaturation); i++ {
+			gameMap.RecordTraffic(x, 1)
+		}
+	}
+	
+	// Traffic alone changes nothing until a discount is configured
+	if path := systems.FindPath(0, 2, 11, 2, gameMap); countRow(path, 1) != 0 {
+		t.Errorf("path %v used the corridor with no discount configured", path)
+	}
+	
+	gameMap.SetTrafficDiscount(0.3)
+	path = systems.FindPath(0, 2, 11, 2, gameMap)
+	if path == nil {
+		t.Fatal("FindPath() returned nil")
+	}
+	if onCorridor := countRow(path, 1); onCorridor < 8 {
+		t.Errorf("path %v has %d steps on the busy corridor, want most of the route", path, onCorridor)
+	}
+}
@@ -0,0 +1,85 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"reflect"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// assertValidPath checks that a path runs between two tiles in single walkable steps
+func assertValidPath(t *testing.T, path systems.Path, startX, startY, goalX, goalY int, gameMap *world.Map) {
+	t.Helper()
+	if len(path) == 0 || path[0].X != startX || path[0].Y != startY || path[len(path)-1].X != goalX || path[len(path)-1].Y != goalY {
+		t.Fatalf("path = %v, want it to run from (%d, %d) to (%d, %d)", path, startX, startY, goalX, goalY)
+	}
+	for i, step := range path {
+		if !gameMap.TileDefAt(step.X, step.Y).Walkable {
+			t.Fatalf("path crosses unwalkable tile (%d, %d)", step.X, step.Y)
+		}
+		if i > 0 && (absDiff(step.X, path[i-1].X) > 1 || absDiff(step.Y, path[i-1].Y) > 1) {
+			t.Fatalf("path jumps from %v to %v", path[i-1], step)
+		}
+	}
+}
+
+func absDiff(a, b int) int {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// Test that a heuristic weight of 1 (or the zero value) finds exactly the paths plain A* does
+func TestUnitHeuristicWeightMatchesFindPath(t *testing.T) {
+	gameMap := newWalledMap()
+	routes := [][4]int{{2, 20, 27, 20}, {10, 1, 20, 28}, {14, 14, 16, 14}}
+	
+	for _, route := range routes {
+		want, wantExpansions := systems.FindPathExpansions(route[0], route[1], route[2], route[3], gameMap)
+		for _, weight := range []float64{0, 1.0} {
+			got, expansions := systems.FindPathExpansionsWithOptions(route[0], route[1], route[2], route[3], gameMap, systems.PathOptions{HeuristicWeight: weight})
+			if !reflect.DeepEqual(got, want) || expansions != wantExpansions {
+				t.Errorf("route %v weight %v: path = %v (%d expansions), want %v (%d)", route, weight, got, expansions, want, wantExpansions)
+			}
+		}
+	}
+}
+
+// Test that a higher weight expands fewer nodes and still returns a valid path within the weight's cost bound
+func TestWeightedAStarExpandsFewerNodes(t *testing.T) {
+	gameMap := newWalledMap()
+	optimal, optimalExpansions := systems.FindPathExpansions(2, 20, 27, 20, gameMap)
+	
+	for _, weight := range []float64{1.5, 3.0} {
+		path, expansions := systems.FindPathExpansionsWithOptions(2, 20, 27, 20, gameMap, systems.PathOptions{HeuristicWeight: weight})
+		assertValidPath(t, path, 2, 20, 27, 20, gameMap)
+		if expansions >= optimalExpansions {
+			t.Errorf("weight %v expanded %d nodes, want fewer than optimal A*'s %d", weight, expansions, optimalExpansions)
+		}
+		if cost, bound := pathCost(path, gameMap), weight*pathCost(optimal, gameMap); cost > bound {
+			t.Errorf("weight %v path cost %.3f exceeds %.3f", weight, cost, bound)
+		}
+	}
+}
+
+// Test that the movement system's setter feeds the weight into the paths it plans
+func TestMovementSystemHeuristicWeight(t *testing.T) {
+	gameMap := newWalledMap()
+	ms := systems.NewMovementSystem(gameMap)
+	if weight := ms.PathOptions().HeuristicWeight; weight != 0 {
+		t.Fatalf("default HeuristicWeight = %v, want 0 (optimal)", weight)
+	}
+	
+	ms.SetHeuristicWeight(3.0)
+	if weight := ms.PathOptions().HeuristicWeight; weight != 3.0 {
+		t.Errorf("HeuristicWeight after SetHeuristicWeight(3) = %v, want 3", weight)
+	}
+	want := systems.FindPathWithOptions(2, 20, 27, 20, gameMap, systems.PathOptions{HeuristicWeight: 3.0})
+	if got := ms.PlanPath(2, 20, 27, 20); !reflect.DeepEqual(got, want) {
+		t.Errorf("PlanPath() = %v, want the weighted path %v", got, want)
+	}
+}
@@ -0,0 +1,104 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newSplitMap builds a 20x5 grass map cut in two by a solid water wall on column 10
+func newSplitMap(wrap bool) *world.Map {
+	gameMap := world.NewMap(20, 5, 32.0)
+	gameMap.Wrap = wrap
+	for y := 0; y < gameMap.Height; y++ {
+		gameMap.SetTile(10, y, world.TileWater)
+	}
+	return gameMap
+}
+
+// Test that pathfinding routes across the seam of a wrapping map, and not without wrap
+func TestFindPathAcrossWrapSeam(t *testing.T) {
+	if path := systems.FindPath(2, 2, 17, 2, newSplitMap(false)); path != nil {
+		t.Fatalf("FindPath() without wrap = %v, want nil with the wall in the way", path)
+	}
+
+	gameMap := newSplitMap(true)
+	path := systems.FindPath(2, 2, 17, 2, gameMap)
+	if len(path) == 0 || path[len(path)-1].X != 17 {
+		t.Fatalf("FindPath() with wrap = %v, want a path to (17, 2)", path)
+	}
+	// Across the seam: 2 -> 1 -> 0 -> 19 -> 18 -> 17
+	if len(path) != 6 {
+		t.Errorf("FindPath() with wrap took %d tiles, want the 6-tile route across the seam: %v", len(path), path)
+	}
+	for i := 1; i < len(path); i++ {
+		if dx, dy := gameMap.WrapTileDelta(path[i].X-path[i-1].X, path[i].Y-path[i-1].Y); dx*dx > 1 || dy*dy > 1 {
+			t.Fatalf("path jumps from %v to %v", path[i-1], path[i])
+		}
+	}
+}
+
+// Test that an entity following a path across the seam reappears on the opposite edge
+func TestMovementAcrossWrapSeam(t *testing.T) {
+	gameMap := newSplitMap(true)
+	ms := systems.NewMovementSystem(gameMap)
+	entity := newEntityAtTile(gameMap, 1, 2)
+	ms.MoveToTile(entity, 18, 2)
+
+	for frame := 0; frame < 200 && entity.IsMoving(); frame++ {
+		ms.Update(entity, systems.FrameDuration)
+		if worldWidth, _ := gameMap.WorldSize(); entity.X+entity.Width/2 < 0 || entity.X+entity.Width/2 >= worldWidth {
+			t.Fatalf("entity center left the map at x = %v", entity.X+entity.Width/2)
+		}
+	}
+	if entity.IsMoving() {
+		t.Fatal("entity never arrived across the seam")
+	}
+	if tileX, tileY := gameMap.WorldToGrid(entity.X+entity.Width/2, entity.Y+entity.Height/2); tileX != 18 || tileY != 2 {
+		t.Errorf("entity stopped at (%d, %d), want (18, 2)", tileX, tileY)
+	}
+}
+
+// Test that endpoints given off the edge of a wrapping map name the tiles on the opposite edge
+func TestFindPathWrapsEndpoints(t *testing.T) {
+	gameMap := newSplitMap(true)
+	path := systems.FindPath(-2, 2, 22, 2, gameMap)
+	if len(path) == 0 {
+		t.Fatal("FindPath(-2, 2, 22, 2) with wrap = nil, want the path from (18, 2) to (2, 2)")
+	}
+	if first, last := path[0], path[len(path)-1]; first.X != 18 || last.X != 2 {
+		t.Errorf("path runs %v to %v, want (18, 2) to (2, 2)", first, last)
+	}
+	if path := systems.FindPath(-2, 2, 22, 2, newSplitMap(false)); path != nil {
+		t.Errorf("FindPath() off the map without wrap = %v, want nil", path)
+	}
+}
+
+// Test that a flow field on a wrapping map leads units across the seam
+func TestFlowFieldAcrossWrapSeam(t *testing.T) {
+	gameMap := newSplitMap(true)
+	field := systems.ComputeFlowField(17, 2, gameMap)
+	x, y := 2, 2
+	for steps := 0; steps < 10; steps++ {
+		nextX, nextY, ok := systems.FlowFieldStep(field, x, y)
+		if !ok {
+			break
+		}
+		x, y = gameMap.WrapTile(nextX, nextY)
+	}
+	if x != 17 || y != 2 {
+		t.Fatalf("following the field from (2, 2) ended at (%d, %d), want (17, 2)", x, y)
+	}
+	
+	entity := newEntityAtTile(gameMap, 1, 2)
+	ms := systems.NewMovementSystem(gameMap)
+	for frame := 0; frame < 500 && ms.StepAlongFlowField(entity, field); frame++ {
+		ms.Update(entity, systems.FrameDuration)
+	}
+	if tileX, tileY := gameMap.WorldToGrid(entity.X+entity.Width/2, entity.Y+entity.Height/2); tileX != 17 || tileY != 2 {
+		t.Errorf("entity following the field stopped at (%d, %d), want (17, 2)", tileX, tileY)
+	}
+}
@@ -0,0 +1,20 @@
+package systems
+
+// SnapTile rounds a tile coordinate to the nearest multiple of grid on each axis
+// A grid of 1 or less leaves the tile unchanged; halfway points round up
+func SnapTile(x, y, grid int) (int, int) {
+	if grid <= 1 {
+		return x, y
+	}
+	return snapAxis(x, grid), snapAxis(y, grid)
+}
+
+// snapAxis rounds a single coordinate to the nearest multiple of grid, flooring correctly for negatives
+func snapAxis(v, grid int) int {
+	shifted := v + grid/2
+	q := shifted / grid
+	if shifted < 0 && shifted%grid != 0 {
+		q--
+	}
+	return q * grid
+}
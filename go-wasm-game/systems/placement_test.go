@@ -0,0 +1,38 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// Test that tiles snap to the nearest multiple of the placement grid
+func TestSnapTile(t *testing.T) {
+	tests := []struct {
+		name         string
+		x, y, grid   int
+		wantX, wantY int
+	}{
+		{"Grid 1 is a no-op", 7, 3, 1, 7, 3},
+		{"Grid 0 is treated as 1", 7, 3, 0, 7, 3},
+		{"Grid 2 already aligned", 4, 6, 2, 4, 6},
+		{"Grid 2 rounds halfway up", 5, 3, 2, 6, 4},
+		{"Grid 3 rounds down", 4, 7, 3, 3, 6},
+		{"Grid 3 rounds up", 5, 8, 3, 6, 9},
+		{"Grid 4 rounds to nearest", 1, 3, 4, 0, 4},
+		{"Grid 4 halfway rounds up", 2, 6, 4, 4, 8},
+		{"Negative coordinates", -1, -3, 2, 0, -2},
+		{"Negative below halfway", -3, -5, 4, -4, -4},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := systems.SnapTile(tt.x, tt.y, tt.grid)
+			if x != tt.wantX || y != tt.wantY {
+				t.Errorf("SnapTile(%d, %d, %d) = (%d, %d), want (%d, %d)", tt.x, tt.y, tt.grid, x, y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package systems
+
+import "hash/fnv"
+
+// Random streams derived from a master seed; each subsystem draws from its own stream so adding
+// a roll in one (say, combat) never shifts the sequence another (say, spawning) sees
+const (
+	SeedStreamSpawning  = "spawning"
+	SeedStreamCombat    = "combat"
+	SeedStreamParticles = "particles"
+)
+
+// DeriveSeed returns the seed for one named stream of a master seed
+// The same master and stream always give the same seed; different streams give unrelated ones
+func DeriveSeed(master int64, stream string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(stream))
+	
+	// splitmix64 finalizer spreads nearby masters (1, 2, 3...) across the whole seed space
+	z := uint64(master) ^ h.Sum64()
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
@@ -0,0 +1,29 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"fmt"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// Test that derived seeds are stable per master and stream, and distinct across both
+func TestDeriveSeed(t *testing.T) {
+	if systems.DeriveSeed(42, systems.SeedStreamCombat) != systems.DeriveSeed(42, systems.SeedStreamCombat) {
+		t.Errorf("DeriveSeed() is not deterministic")
+	}
+	
+	seen := map[int64]string{}
+	for _, master := range []int64{0, 1, 2, 42} {
+		for _, stream := range []string{systems.SeedStreamSpawning, systems.SeedStreamCombat, systems.SeedStreamParticles} {
+			seed := systems.DeriveSeed(master, stream)
+			key := fmt.Sprintf("(%d, %s)", master, stream)
+			if other, exists := seen[seed]; exists {
+				t.Errorf("DeriveSeed(%d, %s) collides with %s", master, stream, other)
+			}
+			seen[seed] = key
+		}
+	}
+}
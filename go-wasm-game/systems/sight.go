@@ -0,0 +1,49 @@
+package systems
+
+import "github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+
+// HasLineOfSight reports whether nothing that blocks sight (walls, trees) stands between two tiles
+// Only the tiles strictly between them are checked, so a wall itself can be seen and a unit
+// standing next to one still sees past its own tile. Walkability plays no part: water is low
+func HasLineOfSight(x0, y0, x1, y1 int, gameMap *world.Map) bool {
+	return lineOfSightClear(x0, y0, x1, y1, gameMap.BlocksSight)
+}
+
+// lineOfSightClear walks the Bresenham line between two tiles and fails on the first blocked tile in between
+func lineOfSightClear(x0, y0, x1, y1 int, blocksSight BlockedFunc) bool {
+	return walkLine(x0, y0, x1, y1, func(_, _, x, y int) bool {
+		return (x == x1 && y == y1) || !blocksSight(x, y)
+	})
+}
+
+// walkLine steps along the Bresenham line from one tile to another, calling step for each move
+// (including the one onto the end tile) and stopping with false as soon as step does
+func walkLine(x0, y0, x1, y1 int, step func(fromX, fromY, x, y int) bool) bool {
+	dx, dy := absInt(x1-x0), -absInt(y1-y0)
+	stepX, stepY := 1, 1
+	if x0 > x1 {
+		stepX = -1
+	}
+	if y0 > y1 {
+		stepY = -1
+	}
+	
+	err := dx + dy
+	x, y := x0, y0
+	for x != x1 || y != y1 {
+		fromX, fromY := x, y
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += stepX
+		}
+		if e2 <= dx {
+			err += dx
+			y += stepY
+		}
+		if !step(fromX, fromY, x, y) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+//go:build !js
+// +build !js
+
+package systems_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that tall tiles and objects block line of sight while low impassable water does not
+func TestHasLineOfSight(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(gameMap *world.Map)
+		want  bool
+	}{
+		{"open ground", func(gameMap *world.Map) {}, true},
+		{"wall in between", func(gameMap *world.Map) { gameMap.SetTile(3, 2, world.TileWall) }, false},
+		{"water in between", func(gameMap *world.Map) { gameMap.SetTile(3, 2, world.TileWater) }, true},
+		{"walkable tile with a tree", func(gameMap *world.Map) { gameMap.AddSightBlocker(3, 2) }, false},
+		{"tree removed again", func(gameMap *world.Map) {
+			gameMap.AddSightBlocker(3, 2)
+			gameMap.RemoveSightBlocker(3, 2)
+		}, true},
+		{"wall off the line", func(gameMap *world.Map) { gameMap.SetTile(3, 4, world.TileWall) }, true},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := world.NewMap(8, 8, 32.0)
+			tt.setup(gameMap)
+			if got := systems.HasLineOfSight(0, 2, 6, 2, gameMap); got != tt.want {
+				t.Errorf("HasLineOfSight((0, 2), (6, 2)) = %v, want %v", got, tt.want)
+			}
+			if got := systems.HasLineOfSight(6, 2, 0, 2, gameMap); got != tt.want {
+				t.Errorf("HasLineOfSight((6, 2), (0, 2)) = %v, want %v in reverse too", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test that sight-blocking is a separate property from walkability
+func TestSightBlockingIndependentOfWalkability(t *testing.T) {
+	gameMap := world.NewMap(5, 5, 32.0)
+	gameMap.AddSightBlocker(2, 2)
+	gameMap.SetTile(2, 3, world.TileWater)
+	
+	if !gameMap.TileDefAt(2, 2).Walkable || !gameMap.BlocksSight(2, 2) {
+		t.Errorf("tile under a tree: walkable = %v, blocks sight = %v, want both", gameMap.TileDefAt(2, 2).Walkable, gameMap.BlocksSight(2, 2))
+	}
+	if gameMap.TileDefAt(2, 3).Walkable || gameMap.BlocksSight(2, 3) {
+		t.Errorf("water: walkable = %v, blocks sight = %v, want neither", gameMap.TileDefAt(2, 3).Walkable, gameMap.BlocksSight(2, 3))
+	}
+	
+	// A wall next to the viewer can itself be seen, and so can adjacent tiles
+	gameMap.SetTile(1, 0, world.TileWall)
+	if !systems.HasLineOfSight(0, 0, 1, 0, gameMap) || !systems.HasLineOfSight(0, 0, 1, 1, gameMap) {
+		t.Errorf("adjacent tiles should always be in sight")
+	}
+}
+
+// Test that fog of war with a sight blocker leaves tiles behind a wall hidden
+func TestFogVisionBlockedByWalls(t *testing.T) {
+	gameMap := world.NewMap(10, 10, 32.0)
+	for y := 0; y < 10; y++ {
+		gameMap.SetTile(5, y, world.TileWall)
+	}
+	fog := systems.NewFogOfWar(gameMap.Width, gameMap.Height)
+	fog.SetSightBlocker(gameMap.BlocksSight)
+	fog.UpdateVision([][2]int{{3, 5}}, 4)
+	
+	if fog.GetVisibility(5, 5) != systems.TileVisible {
+		t.Errorf("wall tile visibility = %v, want visible", fog.GetVisibility(5, 5))
+	}
+	if fog.GetVisibility(6, 5) != systems.TileUnseen {
+		t.Errorf("tile behind the wall visibility = %v, want unseen", fog.GetVisibility(6, 5))
+	}
+	
+	fog.SetSightBlocker(nil)
+	fog.UpdateVision([][2]int{{3, 5}}, 4)
+	if fog.GetVisibility(6, 5) != systems.TileVisible {
+		t.Errorf("without a blocker tile behind the wall visibility = %v, want visible", fog.GetVisibility(6, 5))
+	}
+}
@@ -0,0 +1,15 @@
+package systems
+
+// ThreatCost turns a [y][x] threat grid into a path cost modifier charging threatWeight per unit of threat
+// Returns nil (no extra cost) for a missing grid or a non-positive weight
+func ThreatCost(threat [][]float64, threatWeight float64) CostModifier {
+	if threat == nil || threatWeight <= 0 {
+		return nil
+	}
+	return func(tileX, tileY int) float64 {
+		if tileY < 0 || tileY >= len(threat) || tileX < 0 || tileX >= len(threat[tileY]) {
+			return 0
+		}
+		return threat[tileY][tileX] * threatWeight
+	}
+}
@@ -22,6 +22,7 @@ type UISystem struct {
 	bottomBarHeight float64
 	unitCount      int
 	maxUnits       int
+	theme          UITheme
 }
 // NewUISystem creates a new UI system
 func NewUISystem() *UISystem {
@@ -30,6 +31,7 @@ func NewUISystem() *UISystem {
 		bottomBarHeight: 60.0,
 		unitCount:       1, // Start with 1 unit
 		maxUnits:        10,
+		theme:           DefaultUITheme(),
 	}
 }
 // UpdateCanvasSize updates the UI system with current canvas dimensions
@@ -65,9 +67,9 @@ func (ui *UISystem) updateUILayout() {
 		Text:            "Spawn Unit",
 		Icon:            "➕",
 		Enabled:         ui.unitCount < ui.maxUnits,
-		Background:      "#555",
-		HoverBackground: "#2d7a2d",
-		TextColor:       "#fff",
+		Background:      ui.theme.ButtonBackground,
+		HoverBackground: ui.theme.SpawnHoverBackground,
+		TextColor:       ui.theme.ButtonText,
 		OnClick:         ui.onSpawnUnit,
 	}
 	
@@ -83,9 +85,9 @@ func (ui *UISystem) updateUILayout() {
 		Text:            "Remove Unit",
 		Icon:            "➖",
 		Enabled:         ui.unitCount > 1,
-		Background:      "#555",
-		HoverBackground: "#c34343",
-		TextColor:       "#fff",
+		Background:      ui.theme.ButtonBackground,
+		HoverBackground: ui.theme.RemoveHoverBackground,
+		TextColor:       ui.theme.ButtonText,
 		OnClick:         ui.onRemoveUnit,
 	}
 	
@@ -113,11 +115,11 @@ func (ui *UISystem) drawBottomBar(ctx js.Value) {
 	barY := ui.canvasHeight - ui.bottomBarHeight
 	
 	// Draw background
-	ctx.Set("fillStyle", "#333")
+	ctx.Set("fillStyle", ui.theme.BarBackground)
 	ctx.Call("fillRect", 0, barY, ui.canvasWidth, ui.bottomBarHeight)
 	
 	// Draw top border
-	ctx.Set("strokeStyle", "#555")
+	ctx.Set("strokeStyle", ui.theme.BarBorder)
 	ctx.Set("lineWidth", 1)
 	ctx.Call("beginPath")
 	ctx.Call("moveTo", 0, barY)
@@ -126,14 +128,8 @@ func (ui *UISystem) drawBottomBar(ctx js.Value) {
 }
 // drawElement draws a single UI element (button)
 func (ui *UISystem) drawElement(ctx js.Value, element UIElement) {
-	// Determine background color
-	bgColor := element.Background
-	if element.IsHovered && element.Enabled {
-		bgColor = element.HoverBackground
-	}
-	if !element.Enabled {
-		bgColor = "#444"
-	}
+	// Determine colors from the element state and theme
+	bgColor, borderColor, textColor := ui.ResolveElementColors(element)
 	
 	// Draw button background
 	ctx.Set("fillStyle", bgColor)
@@ -141,19 +137,14 @@ func (ui *UISystem) drawElement(ctx js.Value, element UIElement) {
 	ctx.Call("fill")
 	
 	// Draw button border
-	ctx.Set("strokeStyle", "#666")
+	ctx.Set("strokeStyle", borderColor)
 	ctx.Set("lineWidth", 1)
 	ui.drawRoundedRect(ctx, element.X, element.Y, element.Width, element.Height, 6)
 	ctx.Call("stroke")
 	
 	// Draw button content
-	textColor := element.TextColor
-	if !element.Enabled {
-		textColor = "#888"
-	}
-	
 	ctx.Set("fillStyle", textColor)
-	ctx.Set("font", "14px -apple-system, BlinkMacSystemFont, 'Segoe UI', system-ui, sans-serif")
+	ctx.Set("font", ui.textFont())
 	ctx.Set("textAlign", "center")
 	ctx.Set("textBaseline", "middle")
 	
@@ -163,28 +154,28 @@ func (ui *UISystem) drawElement(ctx js.Value, element UIElement) {
 	
 	if element.Icon != "" && element.Text != "" {
 		// Icon + text layout
-		ctx.Set("font", "16px -apple-system, BlinkMacSystemFont, 'Segoe UI', system-ui, sans-serif")
+		ctx.Set("font", ui.iconFont())
 		iconWidth := ui.measureText(ctx, element.Icon)
 		
-		ctx.Set("font", "14px -apple-system, BlinkMacSystemFont, 'Segoe UI', system-ui, sans-serif")
+		ctx.Set("font", ui.textFont())
 		textWidth := ui.measureText(ctx, element.Text)
 		
 		totalWidth := iconWidth + 8 + textWidth // 8px spacing
 		startX := centerX - totalWidth/2
 		
 		// Draw icon
-		ctx.Set("font", "16px -apple-system, BlinkMacSystemFont, 'Segoe UI', system-ui, sans-serif")
+		ctx.Set("font", ui.iconFont())
 		ctx.Call("fillText", element.Icon, startX+iconWidth/2, centerY)
 		
 		// Draw text
-		ctx.Set("font", "14px -apple-system, BlinkMacSystemFont, 'Segoe UI', system-ui, sans-serif")
+		ctx.Set("font", ui.textFont())
 		ctx.Call("fillText", element.Text, startX+iconWidth+8+textWidth/2, centerY)
 	} else if element.Text != "" {
 		// Text only
 		ctx.Call("fillText", element.Text, centerX, centerY)
 	} else if element.Icon != "" {
 		// Icon only
-		ctx.Set("font", "16px -apple-system, BlinkMacSystemFont, 'Segoe UI', system-ui, sans-serif")
+		ctx.Set("font", ui.iconFont())
 		ctx.Call("fillText", element.Icon, centerX, centerY)
 	}
 }
@@ -192,8 +183,8 @@ func (ui *UISystem) drawElement(ctx js.Value, element UIElement) {
 func (ui *UISystem) drawUnitCounter(ctx js.Value) {
 	barY := ui.canvasHeight - ui.bottomBarHeight
 	
-	ctx.Set("fillStyle", "#ccc")
-	ctx.Set("font", "14px -apple-system, BlinkMacSystemFont, 'Segoe UI', system-ui, sans-serif")
+	ctx.Set("fillStyle", ui.theme.CounterText)
+	ctx.Set("font", ui.textFont())
 	ctx.Set("textAlign", "right")
 	ctx.Set("textBaseline", "middle")
 	
@@ -0,0 +1,75 @@
+package ui
+
+// UITheme holds the colors and font used to draw the UI, so the page can switch between light and dark looks
+type UITheme struct {
+	BarBackground         string
+	BarBorder             string
+	ButtonBackground      string
+	ButtonBorder          string
+	SpawnHoverBackground  string // Hover color of the spawn button
+	RemoveHoverBackground string // Hover color of the remove button
+	DisabledBackground    string
+	ButtonText            string
+	DisabledText          string
+	CounterText           string
+	FontFamily            string
+	FontSize              int // Text size in pixels
+	IconFontSize          int // Icon size in pixels
+}
+
+// DefaultUITheme returns the standard dark theme
+func DefaultUITheme() UITheme {
+	return UITheme{
+		BarBackground:         "#333",
+		BarBorder:             "#555",
+		ButtonBackground:      "#555",
+		ButtonBorder:          "#666",
+		SpawnHoverBackground:  "#2d7a2d",
+		RemoveHoverBackground: "#c34343",
+		DisabledBackground:    "#444",
+		ButtonText:            "#fff",
+		DisabledText:          "#888",
+		CounterText:           "#ccc",
+		FontFamily:            "-apple-system, BlinkMacSystemFont, 'Segoe UI', system-ui, sans-serif",
+		FontSize:              14,
+		IconFontSize:          16,
+	}
+}
+
+// SetTheme replaces the UI theme and restyles the existing buttons
+func (ui *UISystem) SetTheme(theme UITheme) {
+	ui.theme = theme
+	ui.updateUILayout()
+}
+
+// Theme returns the current UI theme
+func (ui *UISystem) Theme() UITheme {
+	return ui.theme
+}
+
+// ResolveElementColors returns the background, border and text colors drawElement uses for an element in its current state
+func (ui *UISystem) ResolveElementColors(element UIElement) (background, border, text string) {
+	background, text = element.Background, element.TextColor
+	if element.IsHovered && element.Enabled {
+		background = element.HoverBackground
+	}
+	if !element.Enabled {
+		background, text = ui.theme.DisabledBackground, ui.theme.DisabledText
+	}
+	return background, ui.theme.ButtonBorder, text
+}
+
+// textFont returns the canvas font for button and counter text
+func (ui *UISystem) textFont() string {
+	return intToString(ui.theme.FontSize) + "px " + ui.theme.FontFamily
+}
+
+// iconFont returns the canvas font for button icons
+func (ui *UISystem) iconFont() string {
+	return intToString(ui.theme.IconFontSize) + "px " + ui.theme.FontFamily
+}
+
+// Elements returns a copy of the current UI buttons
+func (ui *UISystem) Elements() []UIElement {
+	return append([]UIElement(nil), ui.elements...)
+}
@@ -0,0 +1,63 @@
+//go:build js && wasm
+// +build js,wasm
+
+package ui_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/ui"
+)
+
+// lightTheme is a light variant used to check that theme changes reach the drawn colors
+func lightTheme() ui.UITheme {
+	theme := ui.DefaultUITheme()
+	theme.ButtonBackground = "#eee"
+	theme.ButtonBorder = "#bbb"
+	theme.SpawnHoverBackground = "#8fd18f"
+	theme.DisabledBackground = "#ddd"
+	theme.ButtonText = "#222"
+	theme.DisabledText = "#aaa"
+	return theme
+}
+
+// Test that the default theme keeps the original dark colors
+func TestDefaultUITheme(t *testing.T) {
+	uiSys := ui.NewUISystem()
+	uiSys.UpdateCanvasSize(800, 600)
+	spawn := uiSys.Elements()[0]
+
+	background, border, text := uiSys.ResolveElementColors(spawn)
+	if background != "#555" || border != "#666" || text != "#fff" {
+		t.Errorf("default spawn button colors = (%s, %s, %s), want (#555, #666, #fff)", background, border, text)
+	}
+}
+
+// Test that changing the theme updates the colors drawElement resolves for each button state
+func TestSetThemeUpdatesElementColors(t *testing.T) {
+	uiSys := ui.NewUISystem()
+	uiSys.UpdateCanvasSize(800, 600)
+	uiSys.SetTheme(lightTheme())
+	elements := uiSys.Elements()
+	spawn, remove := elements[0], elements[1] // Remove starts disabled with a single unit
+	hovered := spawn
+	hovered.IsHovered = true
+
+	tests := []struct {
+		name                     string
+		element                  ui.UIElement
+		background, border, text string
+	}{
+		{"normal", spawn, "#eee", "#bbb", "#222"},
+		{"hovered", hovered, "#8fd18f", "#bbb", "#222"},
+		{"disabled", remove, "#ddd", "#bbb", "#aaa"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			background, border, text := uiSys.ResolveElementColors(tt.element)
+			if background != tt.background || border != tt.border || text != tt.text {
+				t.Errorf("colors = (%s, %s, %s), want (%s, %s, %s)", background, border, text, tt.background, tt.border, tt.text)
+			}
+		})
+	}
+}
@@ -16,13 +16,37 @@ type Unit struct {
 	TileY          int
 	CurrentStats   entities.UnitStats
 	MaxStats       entities.UnitStats
+	Modifiers      []StatModifier              // Item and buff modifiers on top of MaxStats; see EffectiveStats
 	Level          int
 	Experience     int
+	Kills          int                         // Enemies this unit has finished off
+	DamageDealt    int                         // Health its attacks have removed from enemies
+	DamageTaken    int                         // Health it has lost to attacks and area effects
 	IsAlive        bool
 	Status         string
+	Team           int                         // Owning team; 0 is the default, untinted team
+	Stance         Stance                      // Reaction to enemies; defaults to aggressive
+	chaseTargetID  string                      // Enemy an aggressive unit is currently chasing, "" if none
+	AggroRadius    int                         // Tiles within which an aggressive unit engages enemies; 0 uses DefaultAggroRadius
+	HomeTileX, HomeTileY int                   // Where an aggressive unit returns after a fight: its spawn tile or latest move destination
+	engaged        bool                        // Left home to fight; cleared once it heads back
+	TauntedBy      string                      // Warrior this unit is forced to target, "" if not taunted
+	TauntUntil     time.Time                   // When the taunt wears off
+	SpawnTicks     int                         // Updates left in the cosmetic grow-in animation, 0 once full size
+	spawnTotal     int                         // Length of the grow-in animation SpawnTicks counts down from
+	awaitingRally  bool                        // Spawned from the build queue and still to be sent to the rally point once grown in
 	CreatedAt      time.Time
 	LastMoved      time.Time
 	movementSystem *systems.MovementSystem
+	clock          Clock                      // Time source for cooldowns and timers
+	abilityCooldowns map[string]abilityCooldown // Ability name -> last use
+	BuildOrder     *BuildOrder                 // Pending construction job, nil when not building
+	minRepathInterval time.Duration            // Minimum time between repaths, 0 uses DefaultRepathInterval
+	lastRepathAt   time.Time                   // When the unit last recomputed its path via RepathToTile
+	onArrival      func()                      // One-shot callback fired when the unit stops on its ordered destination
+	flowField      [][]int                     // Shared flow field being followed, nil for normal pathing
+	CarriedUnitIDs []string                    // Passengers aboard this transport, in load order
+	carriedBy      string                      // Transport this unit is riding in, "" if none
 }
 
 // GetTypeDef returns the type definition for this unit
@@ -64,21 +88,50 @@ func (u *Unit) SetPosition(x, y float64) {
 		u.TileY = tileY
 	}
 }
-// Update handles unit movement using the unified movement system
-func (u *Unit) Update() {
+// Update moves the unit for dt of simulated time using the unified movement system
+func (u *Unit) Update(dt time.Duration) {
+	if u.SpawnTicks > 0 {
+		u.SpawnTicks--
+	}
+	u.pruneModifiers()
 	if u.movementSystem != nil {
-		u.movementSystem.Update(u)
+		wasMoving := u.IsMoving()
+		u.movementSystem.Update(u, dt)
+		u.stepFlowField()
+		u.updateMovementStatus(wasMoving)
 		// Sync tile position with world position
 		x, y := u.MovableEntity.GetPosition()
 		tileX, tileY := u.movementSystem.GetGameMap().WorldToGrid(x + u.Width/2, y + u.Height/2)
 		u.TileX = tileX
 		u.TileY = tileY
+		u.notifyArrival(wasMoving)
+		u.advanceMoveQueue()
+	}
+}
+
+// PathProgress returns the fraction of the unit's current path walked so far, 1 when it has no path
+func (u *Unit) PathProgress() float64 {
+	if u.movementSystem == nil {
+		return 1.0
+	}
+	return systems.PathProgress(u.GetPath(), u.GetPathStep(), u.X+u.Width/2, u.Y+u.Height/2, u.movementSystem.GetGameMap())
+}
+
+// PreviewPath returns the path a move order to a tile would take right now, nil if there is none
+func (u *Unit) PreviewPath(tileX, tileY int) systems.Path {
+	if u.movementSystem == nil {
+		return nil
 	}
+	return u.movementSystem.PlanPath(u.TileX, u.TileY, tileX, tileY)
 }
 
 // MoveToTile initiates pathfinding-based movement to a specific tile
 func (u *Unit) MoveToTile(tileX, tileY int) {
 	if u.movementSystem != nil {
+		u.flowField = nil
 		u.movementSystem.MoveToTile(u, tileX, tileY)
+		if u.IsMoving() {
+			u.SetStatus(StatusMoving)
+		}
 	}
 }
\ No newline at end of file
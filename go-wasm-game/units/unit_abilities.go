@@ -0,0 +1,63 @@
+package units
+
+import (
+	"time"
+)
+
+// Clock returns the current time; injectable so timed behavior can be tested
+type Clock func() time.Time
+
+// abilityCooldown tracks when an ability was last used and how long it takes to recharge
+type abilityCooldown struct {
+	usedAt   time.Time
+	duration time.Duration
+}
+
+// now returns the current time from the unit's clock, falling back to the wall clock
+func (u *Unit) now() time.Time {
+	if u.clock != nil {
+		return u.clock()
+	}
+	return time.Now()
+}
+
+// StartAbilityCooldown marks an ability as just used, putting it on cooldown for the given duration
+func (u *Unit) StartAbilityCooldown(name string, duration time.Duration) {
+	if u.abilityCooldowns == nil {
+		u.abilityCooldowns = make(map[string]abilityCooldown)
+	}
+	u.abilityCooldowns[name] = abilityCooldown{usedAt: u.now(), duration: duration}
+}
+
+// AbilityCooldownFraction returns the remaining cooldown of an ability (0 ready, 1 just used)
+func (u *Unit) AbilityCooldownFraction(name string) float64 {
+	cooldown, exists := u.abilityCooldowns[name]
+	if !exists || cooldown.duration <= 0 {
+		return 0.0
+	}
+	
+	remaining := cooldown.duration - u.now().Sub(cooldown.usedAt)
+	if remaining <= 0 {
+		return 0.0
+	}
+	if remaining >= cooldown.duration {
+		return 1.0
+	}
+	return float64(remaining) / float64(cooldown.duration)
+}
+
+// IsAbilityReady returns true if the ability is not on cooldown
+func (u *Unit) IsAbilityReady(name string) bool {
+	return u.AbilityCooldownFraction(name) == 0.0
+}
+
+// ActiveCooldowns returns the cooldown fractions of all abilities still recharging
+func (u *Unit) ActiveCooldowns() map[string]float64 {
+	result := make(map[string]float64)
+	for name := range u.abilityCooldowns {
+		if fraction := u.AbilityCooldownFraction(name); fraction > 0 {
+			result[name] = fraction
+		}
+	}
+	return result
+}
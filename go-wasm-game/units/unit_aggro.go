@@ -0,0 +1,31 @@
+package units
+
+// DefaultAggroRadius is how many tiles away an aggressive unit notices and chases an enemy when its AggroRadius is 0
+const DefaultAggroRadius = 6
+
+// aggroRange returns the unit's AggroRadius, falling back to DefaultAggroRadius
+func (u *Unit) aggroRange() int {
+	if u.AggroRadius > 0 {
+		return u.AggroRadius
+	}
+	return DefaultAggroRadius
+}
+
+// setHome makes a tile the one the unit returns to after a chase; move orders call this with
+// their destination, which also ends any engagement so the unit doesn't walk back afterwards
+func (u *Unit) setHome(tileX, tileY int) {
+	u.HomeTileX, u.HomeTileY = tileX, tileY
+	u.engaged = false
+}
+
+// returnHome sends a unit that left to fight back to its home tile once no enemy is left within aggro range
+// It won't pick a new fight until it has arrived and is idle again
+func (u *Unit) returnHome() {
+	if !u.engaged {
+		return
+	}
+	u.engaged = false
+	if u.TileX != u.HomeTileX || u.TileY != u.HomeTileY {
+		u.MoveToTile(u.HomeTileX, u.HomeTileY)
+	}
+}
@@ -0,0 +1,87 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// engageEnemy walks a passive enemy into an aggressive unit's aggro radius and runs frames until
+// the unit has chased it down to attack range
+func engageEnemy(t *testing.T) (*units.UnitManager, *units.Unit, *units.Unit) {
+	t.Helper()
+	um := units.NewUnitManager(newTestMap(20, 10))
+	unit, err := um.CreateUnit(entities.UnitWarrior, 2, 5, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	unit.AggroRadius = 4
+	enemy := createEnemy(t, um, 12, 5)
+	enemy.Stance = units.StancePassive
+	
+	// Outside the aggro radius nothing happens
+	for frame := 0; frame < 50; frame++ {
+		um.Update()
+	}
+	if unit.IsMoving() || unit.TileX != 2 {
+		t.Fatalf("unit moved to (%d, %d) with the enemy outside its aggro radius", unit.TileX, unit.TileY)
+	}
+	
+	um.MoveUnit(enemy.ID, 5, 5)
+	for frame := 0; frame < 500 && !um.InAttackRange(unit, enemy); frame++ {
+		um.Update()
+	}
+	if !um.InAttackRange(unit, enemy) || unit.TileX == unit.HomeTileX {
+		t.Fatalf("unit at (%d, %d) never chased the enemy at (%d, %d) into range", unit.TileX, unit.TileY, enemy.TileX, enemy.TileY)
+	}
+	um.Update()
+	if enemy.CurrentStats.Health == enemy.MaxStats.Health {
+		t.Fatalf("unit reached attack range without attacking")
+	}
+	return um, unit, enemy
+}
+
+// Test that an aggressive unit engages an enemy entering its aggro radius and returns home once it is gone
+func TestAggroEngagesAndReturnsHome(t *testing.T) {
+	tests := []struct {
+		name  string
+		leave func(um *units.UnitManager, enemy *units.Unit)
+	}{
+		{"enemy killed", func(um *units.UnitManager, enemy *units.Unit) { um.KillUnit(enemy.ID) }},
+		{"enemy removed", func(um *units.UnitManager, enemy *units.Unit) { um.RemoveUnit(enemy.ID) }},
+		{"enemy outruns the aggro radius", func(um *units.UnitManager, enemy *units.Unit) {
+			enemy.MoveSpeed = 12
+			um.MoveUnit(enemy.ID, 19, 0)
+		}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			um, unit, enemy := engageEnemy(t)
+			if unit.HomeTileX != 2 || unit.HomeTileY != 5 {
+				t.Fatalf("home tile = (%d, %d), want the spawn tile (2, 5)", unit.HomeTileX, unit.HomeTileY)
+			}
+			
+			tt.leave(um, enemy)
+			for frame := 0; frame < 500; frame++ {
+				um.Update()
+			}
+			if unit.TileX != 2 || unit.TileY != 5 || unit.IsMoving() {
+				t.Errorf("unit at (%d, %d), moving %v; want it back home at (2, 5)", unit.TileX, unit.TileY, unit.IsMoving())
+			}
+		})
+	}
+}
+
+// Test that a move order makes its destination the new home, so the unit doesn't walk back to its spawn
+func TestMoveOrderSetsHomeTile(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(20, 10))
+	unit := createUnits(t, um, 1)[0]
+	um.MoveUnit(unit.ID, 8, 3)
+	if unit.HomeTileX != 8 || unit.HomeTileY != 3 {
+		t.Errorf("home tile after MoveUnit = (%d, %d), want (8, 3)", unit.HomeTileX, unit.HomeTileY)
+	}
+}
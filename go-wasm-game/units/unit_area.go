@@ -0,0 +1,40 @@
+package units
+
+// AnyTeam matches units on every team in team-filtered operations such as HealArea
+const AnyTeam = -1
+
+// AreaResult lists the units an area effect touched and the ones it killed, both in creation order
+type AreaResult struct {
+	Affected []string
+	Killed   []string
+}
+
+// DamageArea damages every living unit within radius of a tile, as found by GetUnitsInRadius.
+// Each unit takes the amount through the normal damage rules, so defense still applies
+func (um *UnitManager) DamageArea(tileX, tileY int, radius float64, amount int) AreaResult {
+	result := AreaResult{Affected: make([]string, 0), Killed: make([]string, 0)}
+	for _, unit := range um.GetUnitsInRadius(tileX, tileY, radius) {
+		if um.combatSystem.DamageUnit(unit, amount) != nil {
+			continue
+		}
+		result.Affected = append(result.Affected, unit.ID)
+		if !unit.IsAlive {
+			result.Killed = append(result.Killed, unit.ID)
+		}
+	}
+	return result
+}
+
+// HealArea heals living units on a team (or AnyTeam) within radius of a tile, capped at max health
+func (um *UnitManager) HealArea(tileX, tileY int, radius float64, amount int, team int) AreaResult {
+	result := AreaResult{Affected: make([]string, 0), Killed: make([]string, 0)}
+	for _, unit := range um.GetUnitsInRadius(tileX, tileY, radius) {
+		if team != AnyTeam && unit.Team != team {
+			continue
+		}
+		if um.combatSystem.HealUnit(unit, amount) == nil {
+			result.Affected = append(result.Affected, unit.ID)
+		}
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"reflect"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that area damage hits only units in the radius and reports the ones it killed
+func TestDamageArea(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	all := createUnits(t, um, 5) // Row 0, x = 0..4
+	all[1].CurrentStats.Health = 10
+	healthBefore := all[2].CurrentStats.Health
+
+	result := um.DamageArea(2, 0, 1, 30)
+
+	if want := []string{all[1].ID, all[2].ID, all[3].ID}; !reflect.DeepEqual(result.Affected, want) {
+		t.Errorf("Affected = %v, want %v", result.Affected, want)
+	}
+	if want := []string{all[1].ID}; !reflect.DeepEqual(result.Killed, want) {
+		t.Errorf("Killed = %v, want %v", result.Killed, want)
+	}
+	if all[1].IsAlive || all[2].CurrentStats.Health >= healthBefore {
+		t.Errorf("after DamageArea alive = %v, health = %d, want the weak unit dead and its neighbor hurt", all[1].IsAlive, all[2].CurrentStats.Health)
+	}
+	if all[0].CurrentStats.Health != all[0].MaxStats.Health || all[4].CurrentStats.Health != all[4].MaxStats.Health {
+		t.Errorf("units outside the radius were damaged")
+	}
+
+	// The dead unit is no longer a target
+	if result := um.DamageArea(1, 0, 0, 30); len(result.Affected) != 0 {
+		t.Errorf("DamageArea on a dead unit's tile affected %v, want none", result.Affected)
+	}
+}
+
+// Test that area healing respects the team filter and the max health cap
+func TestHealArea(t *testing.T) {
+	tests := []struct {
+		name string
+		team int
+		want func(ally, enemy *units.Unit) []string
+	}{
+		{"own team only", 0, func(ally, enemy *units.Unit) []string { return []string{ally.ID} }},
+		{"enemy team only", 1, func(ally, enemy *units.Unit) []string { return []string{enemy.ID} }},
+		{"any team", units.AnyTeam, func(ally, enemy *units.Unit) []string { return []string{ally.ID, enemy.ID} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			um := units.NewUnitManager(newTestMap(10, 10))
+			ally := createUnits(t, um, 1)[0]
+			enemy := createEnemy(t, um, 1, 1)
+			far, err := um.CreateUnit(entities.UnitWarrior, 8, 8, "")
+			if err != nil {
+				t.Fatalf("CreateUnit failed: %v", err)
+			}
+			for _, unit := range []*units.Unit{ally, enemy, far} {
+				unit.CurrentStats.Health = 50
+			}
+
+			result := um.HealArea(0, 0, 2, 80, tt.team)
+			if want := tt.want(ally, enemy); !reflect.DeepEqual(result.Affected, want) {
+				t.Errorf("Affected = %v, want %v", result.Affected, want)
+			}
+			if len(result.Killed) != 0 {
+				t.Errorf("Killed = %v, want none from healing", result.Killed)
+			}
+			for _, id := range result.Affected {
+				if unit := um.GetUnit(id); unit.CurrentStats.Health != unit.MaxStats.Health {
+					t.Errorf("healed %s to %d, want it capped at %d", id, unit.CurrentStats.Health, unit.MaxStats.Health)
+				}
+			}
+			if far.CurrentStats.Health != 50 {
+				t.Errorf("unit outside the radius healed to %d", far.CurrentStats.Health)
+			}
+		})
+	}
+}
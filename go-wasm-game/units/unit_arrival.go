@@ -0,0 +1,32 @@
+package units
+
+import (
+	"fmt"
+)
+
+// SetArrivalCallback registers fn to run once when the unit stops on the destination of its move order
+// The callback is cleared after firing, replaced by later calls, and dropped if the unit is removed or
+// stops anywhere else (blocked, loaded onto a transport, left with no route)
+func (um *UnitManager) SetArrivalCallback(unitID string, fn func()) error {
+	unit := um.units[unitID]
+	if unit == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, unitID)
+	}
+	unit.onArrival = fn
+	return nil
+}
+
+// notifyArrival settles the arrival callback once movement that was in progress has finished: it fires
+// if the unit stopped on its ordered destination (the home tile every move order sets) and is dropped otherwise
+func (u *Unit) notifyArrival(wasMoving bool) {
+	if !wasMoving || u.IsMoving() || u.onArrival == nil {
+		return
+	}
+	
+	// Clear first so the callback can safely register a new one (e.g. chain another move)
+	callback := u.onArrival
+	u.onArrival = nil
+	if u.TileX == u.HomeTileX && u.TileY == u.HomeTileY {
+		callback()
+	}
+}
@@ -0,0 +1,61 @@
+package units
+
+import (
+	"fmt"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// AttackPreview describes what an attack order on a target would do right now
+type AttackPreview struct {
+	InRange      bool // The attacker can hit the target from where it stands
+	TileX, TileY int  // Tile the attack would be made from: the attacker's own tile when in range, else the nearest in-range tile
+	CanApproach  bool // False when out of range and no free tile within reach of the target exists
+}
+
+// PreviewAttack classifies an attack from attacker on target without issuing any order
+func (um *UnitManager) PreviewAttack(attackerID, targetID string) (AttackPreview, error) {
+	attacker, target := um.units[attackerID], um.units[targetID]
+	if attacker == nil || target == nil {
+		return AttackPreview{}, fmt.Errorf("%w: %s -> %s", ErrNotFound, attackerID, targetID)
+	}
+	if um.InAttackRange(attacker, target) {
+		return AttackPreview{InRange: true, TileX: attacker.TileX, TileY: attacker.TileY, CanApproach: true}, nil
+	}
+	tileX, tileY, ok := um.NearestInRangeTile(attacker, target)
+	return AttackPreview{TileX: tileX, TileY: tileY, CanApproach: ok}, nil
+}
+
+// NearestInRangeTile picks the walkable, free tile closest to the attacker from which it could hit the
+// target, measuring reach like InAttackRange. Ties go to the first tile in row-major order
+func (um *UnitManager) NearestInRangeTile(attacker, target *Unit) (int, int, bool) {
+	reach := attacker.EffectiveStats().Range
+	if reach < 1 {
+		reach = 1
+	}
+	
+	bestX, bestY, bestDistance := 0, 0, -1.0
+	for y := target.TileY - reach; y <= target.TileY+reach; y++ {
+		for x := target.TileX - reach; x <= target.TileX+reach; x++ {
+			if (x == target.TileX && y == target.TileY) || x < 0 || x >= um.gameMap.Width || y < 0 || y >= um.gameMap.Height {
+				continue
+			}
+			if systems.TileDistance(x, y, target.TileX, target.TileY, um.combatSystem.RangeMetric) > float64(reach) {
+				continue
+			}
+			if !um.gameMap.TileDefAt(x, y).Walkable || !systems.HasLineOfSight(x, y, target.TileX, target.TileY, um.gameMap) {
+				continue
+			}
+			
+			// The attacker's own tile counts as free
+			if um.spatialIndex.IsPositionOccupied(x, y) && (x != attacker.TileX || y != attacker.TileY) {
+				continue
+			}
+			
+			distance := systems.TileDistance(attacker.TileX, attacker.TileY, x, y, systems.RangeEuclidean)
+			if bestDistance < 0 || distance < bestDistance {
+				bestX, bestY, bestDistance = x, y, distance
+			}
+		}
+	}
+	return bestX, bestY, bestDistance >= 0
+}
@@ -0,0 +1,114 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that an attack preview is in range only within the attacker's reach and line of sight
+func TestPreviewAttackClassifiesRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		unitType    entities.UnitType
+		enemyX      int
+		wall        bool
+		wantInRange bool
+	}{
+		{"warrior next to the enemy", entities.UnitWarrior, 2, false, true},
+		{"warrior two tiles away", entities.UnitWarrior, 3, false, false},
+		{"archer at full reach", entities.UnitArcher, 5, false, true},
+		{"archer past its reach", entities.UnitArcher, 6, false, false},
+		{"archer behind a wall", entities.UnitArcher, 4, true, false},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := newTestMap(10, 10)
+			if tt.wall {
+				gameMap.SetTile(2, 5, world.TileWall)
+			}
+			um := units.NewUnitManager(gameMap)
+			attacker, err := um.CreateUnit(tt.unitType, 1, 5, "")
+			if err != nil {
+				t.Fatalf("CreateUnit failed: %v", err)
+			}
+			enemy := createEnemy(t, um, tt.enemyX, 5)
+			
+			preview, err := um.PreviewAttack(attacker.ID, enemy.ID)
+			if err != nil {
+				t.Fatalf("PreviewAttack failed: %v", err)
+			}
+			if preview.InRange != tt.wantInRange {
+				t.Errorf("InRange = %v, want %v", preview.InRange, tt.wantInRange)
+			}
+			if tt.wantInRange && (preview.TileX != 1 || preview.TileY != 5) {
+				t.Errorf("in-range preview tile = (%d, %d), want the attacker's own (1, 5)", preview.TileX, preview.TileY)
+			}
+			if !tt.wantInRange && um.GetUnit(attacker.ID).IsMoving() {
+				t.Error("PreviewAttack should never issue a move order")
+			}
+		})
+	}
+	
+	um := units.NewUnitManager(newTestMap(5, 5))
+	if _, err := um.PreviewAttack("unit_404", "unit_405"); err == nil {
+		t.Error("PreviewAttack with missing units should fail")
+	}
+}
+
+// Test that the nearest in-range tile is the closest free, walkable tile within reach of the target
+func TestNearestInRangeTile(t *testing.T) {
+	tests := []struct {
+		name         string
+		unitType     entities.UnitType
+		setup        func(t *testing.T, gameMap *world.Map, um *units.UnitManager)
+		wantX, wantY int
+		wantOK       bool
+	}{
+		{"warrior stops beside the enemy", entities.UnitWarrior, nil, 5, 5, true},
+		{"archer stops at full reach", entities.UnitArcher, nil, 2, 5, true},
+		{"occupied tile is skipped", entities.UnitWarrior, func(t *testing.T, gameMap *world.Map, um *units.UnitManager) {
+			if _, err := um.CreateUnit(entities.UnitWarrior, 5, 5, ""); err != nil {
+				t.Fatalf("CreateUnit failed: %v", err)
+			}
+		}, 5, 4, true},
+		{"moated enemy cannot be approached", entities.UnitWarrior, func(t *testing.T, gameMap *world.Map, um *units.UnitManager) {
+			for y := 4; y <= 6; y++ {
+				for x := 5; x <= 7; x++ {
+					if x != 6 || y != 5 {
+						gameMap.SetTile(x, y, world.TileWater)
+					}
+				}
+			}
+		}, 0, 0, false},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := newTestMap(10, 10)
+			um := units.NewUnitManager(gameMap)
+			attacker, err := um.CreateUnit(tt.unitType, 0, 5, "")
+			if err != nil {
+				t.Fatalf("CreateUnit failed: %v", err)
+			}
+			enemy := createEnemy(t, um, 6, 5)
+			if tt.setup != nil {
+				tt.setup(t, gameMap, um)
+			}
+			
+			x, y, ok := um.NearestInRangeTile(attacker, enemy)
+			if ok != tt.wantOK || (ok && (x != tt.wantX || y != tt.wantY)) {
+				t.Errorf("NearestInRangeTile() = (%d, %d, %v), want (%d, %d, %v)", x, y, ok, tt.wantX, tt.wantY, tt.wantOK)
+			}
+			preview, _ := um.PreviewAttack(attacker.ID, enemy.ID)
+			if preview.InRange || preview.CanApproach != tt.wantOK {
+				t.Errorf("PreviewAttack() = %+v, want out of range with CanApproach %v", preview, tt.wantOK)
+			}
+		})
+	}
+}
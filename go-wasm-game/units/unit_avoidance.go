@@ -0,0 +1,44 @@
+package units
+
+import (
+	"math"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// SetLocalAvoidance turns steering around nearby units on or off for every unit, including ones created later
+// It is off by default; paths are unchanged either way, units just sidestep each other while following them
+func (um *UnitManager) SetLocalAvoidance(enabled bool) {
+	um.localAvoidance = enabled
+	for _, unit := range um.OrderedUnits() {
+		um.applyLocalAvoidance(unit)
+	}
+}
+
+// applyLocalAvoidance hands a unit's movement system the neighbor query, or removes it when avoidance is off
+func (um *UnitManager) applyLocalAvoidance(unit *Unit) {
+	if unit.movementSystem == nil {
+		return
+	}
+	if !um.localAvoidance {
+		unit.movementSystem.SetAvoidance(nil, 0, 0)
+		return
+	}
+	unit.movementSystem.SetAvoidance(func(entity systems.Movable, radius float64) [][2]float64 {
+		return um.neighborCenters(unit, radius)
+	}, systems.DefaultAvoidanceRadius, systems.DefaultAvoidanceWeight)
+}
+
+// neighborCenters returns the world centers of other living units that may lie within radius of a unit,
+// found with the tile range query; RepulsionVector ignores the ones that turn out to be farther
+func (um *UnitManager) neighborCenters(unit *Unit, radius float64) [][2]float64 {
+	tileWidth, tileHeight := um.gameMap.TileDimensions()
+	tileRadius := math.Ceil(radius/math.Min(tileWidth, tileHeight)) + 1
+	
+	centers := make([][2]float64, 0)
+	for _, other := range um.GetUnitsInRadius(unit.TileX, unit.TileY, tileRadius) {
+		if other != unit {
+			centers = append(centers, [2]float64{other.X + other.Width/2, other.Y + other.Height/2})
+		}
+	}
+	return centers
+}
@@ -0,0 +1,145 @@
+package units
+
+import (
+	"fmt"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// buildDurations is how long a unit works next to a tile before the structure is placed
+var buildDurations = map[world.TileType]time.Duration{
+	world.TileWall:   5 * time.Second,
+	world.TileBridge: 8 * time.Second,
+}
+
+// BuildOrder is a construction job a unit walks to and then works on
+type BuildOrder struct {
+	TileX       int
+	TileY       int
+	Type        world.TileType
+	CompletesAt time.Time // Zero until the unit is adjacent and starts working
+}
+
+// OrderBuild sends a unit to build a wall or bridge on a tile
+// The unit walks to a free tile adjacent to the target and places the tile once its build timer finishes
+func (um *UnitManager) OrderBuild(unitID string, tileX, tileY int, tileType world.TileType) error {
+	unit := um.units[unitID]
+	if unit == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, unitID)
+	}
+	if !unit.IsAlive {
+		return fmt.Errorf("cannot build with %s: %w", unitID, ErrUnitDead)
+	}
+	if err := um.validateBuildTarget(tileX, tileY, tileType); err != nil {
+		return err
+	}
+	
+	standX, standY, found := um.findBuildPosition(unit, tileX, tileY)
+	if !found {
+		return fmt.Errorf("no free tile next to (%d, %d): %w", tileX, tileY, ErrOccupied)
+	}
+	
+	unit.BuildOrder = &BuildOrder{TileX: tileX, TileY: tileY, Type: tileType}
+	if standX != unit.TileX || standY != unit.TileY {
+		unit.MoveToTile(standX, standY)
+	}
+	return nil
+}
+
+// validateBuildTarget checks that a tile can hold the requested structure
+// Bridges must span water; walls need open, unoccupied ground
+func (um *UnitManager) validateBuildTarget(tileX, tileY int, tileType world.TileType) error {
+	if tileX < 0 || tileX >= um.gameMap.Width || tileY < 0 || tileY >= um.gameMap.Height {
+		return fmt.Errorf("%w: (%d, %d)", ErrOutOfBounds, tileX, tileY)
+	}
+	
+	current := um.gameMap.GetTile(tileX, tileY)
+	switch tileType {
+	case world.TileBridge:
+		if current != world.TileWater {
+			return fmt.Errorf("bridges must be built on water: %w", ErrNotBuildable)
+		}
+	case world.TileWall:
+		if !um.gameMap.TileDefAt(tileX, tileY).Walkable {
+			return fmt.Errorf("walls must be built on open ground: %w", ErrNotBuildable)
+		}
+		if um.spatialIndex.IsPositionOccupied(tileX, tileY) {
+			return fmt.Errorf("%w: (%d, %d)", ErrOccupied, tileX, tileY)
+		}
+	default:
+		return fmt.Errorf("tile type %d: %w", tileType, ErrNotBuildable)
+	}
+	return nil
+}
+
+// findBuildPosition picks the walkable, free tile adjacent to the target closest to the unit
+func (um *UnitManager) findBuildPosition(unit *Unit, tileX, tileY int) (int, int, bool) {
+	bestX, bestY, bestDistance := 0, 0, -1
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			x, y := tileX+dx, tileY+dy
+			if (dx == 0 && dy == 0) || x < 0 || x >= um.gameMap.Width || y < 0 || y >= um.gameMap.Height {
+				continue
+			}
+			if !um.gameMap.TileDefAt(x, y).Walkable {
+				continue
+			}
+			
+			// The builder's own tile counts as free
+			occupiedByOther := um.spatialIndex.IsPositionOccupied(x, y) && (x != unit.TileX || y != unit.TileY)
+			if occupiedByOther {
+				continue
+			}
+			
+			distance := (x-unit.TileX)*(x-unit.TileX) + (y-unit.TileY)*(y-unit.TileY)
+			if bestDistance < 0 || distance < bestDistance {
+				bestX, bestY, bestDistance = x, y, distance
+			}
+		}
+	}
+	return bestX, bestY, bestDistance >= 0
+}
+
+// processBuildOrder advances a unit's build order: start the timer on arrival, place the tile when done
+func (um *UnitManager) processBuildOrder(unit *Unit) {
+	order := unit.BuildOrder
+	if order == nil || unit.IsMoving() {
+		return
+	}
+	
+	// Stopped without reaching the site (e.g. no path), so give up
+	if !isAdjacentTile(unit.TileX, unit.TileY, order.TileX, order.TileY) {
+		unit.cancelBuildOrder()
+		return
+	}
+	
+	now := unit.now()
+	if order.CompletesAt.IsZero() {
+		order.CompletesAt = now.Add(buildDurations[order.Type])
+		unit.SetStatus(StatusBuilding)
+		return
+	}
+	if now.Before(order.CompletesAt) {
+		return
+	}
+	
+	// The site may have changed while building (e.g. a unit walked onto it)
+	if um.validateBuildTarget(order.TileX, order.TileY, order.Type) == nil {
+		um.gameMap.SetTile(order.TileX, order.TileY, order.Type)
+	}
+	unit.cancelBuildOrder()
+}
+
+// cancelBuildOrder drops any pending build order, returning a building unit to idle
+func (u *Unit) cancelBuildOrder() {
+	u.BuildOrder = nil
+	if u.Status == StatusBuilding {
+		u.SetStatus(StatusIdle)
+	}
+}
+
+// isAdjacentTile reports whether two tiles touch, including diagonally
+func isAdjacentTile(x1, y1, x2, y2 int) bool {
+	dx, dy := x1-x2, y1-y2
+	return (dx != 0 || dy != 0) && dx >= -1 && dx <= 1 && dy >= -1 && dy <= 1
+}
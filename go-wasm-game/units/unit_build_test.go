@@ -0,0 +1,151 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newBuildTest creates a manager on a fake clock with one warrior at the given tile
+func newBuildTest(t *testing.T, tileX, tileY int) (*units.UnitManager, *units.Unit, *world.Map, *fakeClock) {
+	gameMap := newTestMap(10, 10)
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	um := units.NewUnitManager(gameMap)
+	um.SetClock(clock.Now)
+	unit, err := um.CreateUnit(entities.UnitWarrior, tileX, tileY, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	return um, unit, gameMap, clock
+}
+
+// runUntilStopped updates the manager until the unit finishes walking
+func runUntilStopped(um *units.UnitManager, unit *units.Unit) {
+	for frame := 0; frame < 500; frame++ {
+		um.Update()
+		if !unit.IsMoving() {
+			return
+		}
+	}
+}
+
+// Test that a builder walks next to the target and never starts work from afar
+func TestBuildOrderRequiresAdjacency(t *testing.T) {
+	um, unit, gameMap, _ := newBuildTest(t, 1, 1)
+	
+	if err := um.OrderBuild(unit.ID, 6, 1, world.TileWall); err != nil {
+		t.Fatalf("OrderBuild failed: %v", err)
+	}
+	if !unit.IsMoving() {
+		t.Fatal("unit should walk toward the build site")
+	}
+	if !unit.BuildOrder.CompletesAt.IsZero() {
+		t.Error("build timer should not start before the unit is adjacent")
+	}
+	
+	runUntilStopped(um, unit)
+	um.Update()
+	if unit.TileX != 5 || unit.TileY != 1 {
+		t.Errorf("builder stopped at (%d, %d), want adjacent tile (5, 1)", unit.TileX, unit.TileY)
+	}
+	if unit.BuildOrder == nil || unit.BuildOrder.CompletesAt.IsZero() {
+		t.Fatal("build timer should start once the unit is adjacent")
+	}
+	if unit.Status != units.StatusBuilding {
+		t.Errorf("status = %q, want %q", unit.Status, units.StatusBuilding)
+	}
+	if gameMap.GetTile(6, 1) != world.TileGrass {
+		t.Error("tile should not change before the build timer finishes")
+	}
+}
+
+// Test that the tile is placed only after the build timer elapses
+func TestBuildOrderTimedPlacement(t *testing.T) {
+	um, unit, gameMap, clock := newBuildTest(t, 3, 3)
+	gameMap.SetTile(4, 3, world.TileWater)
+	
+	if err := um.OrderBuild(unit.ID, 4, 3, world.TileBridge); err != nil {
+		t.Fatalf("OrderBuild failed: %v", err)
+	}
+	if unit.IsMoving() {
+		t.Error("an adjacent builder should not need to move")
+	}
+	
+	um.Update() // Starts the timer
+	clock.Advance(7 * time.Second)
+	um.Update()
+	if gameMap.GetTile(4, 3) != world.TileWater {
+		t.Fatal("bridge placed before the build timer finished")
+	}
+	
+	clock.Advance(2 * time.Second)
+	um.Update()
+	if gameMap.GetTile(4, 3) != world.TileBridge {
+		t.Errorf("GetTile(4, 3) = %v, want bridge after the build timer", gameMap.GetTile(4, 3))
+	}
+	if unit.BuildOrder != nil || unit.Status != units.StatusIdle {
+		t.Errorf("order = %v, status = %q; want cleared order and idle", unit.BuildOrder, unit.Status)
+	}
+}
+
+// Test that a direct move order cancels construction in progress
+func TestBuildOrderCanceledByMove(t *testing.T) {
+	um, unit, gameMap, clock := newBuildTest(t, 3, 3)
+	
+	if err := um.OrderBuild(unit.ID, 4, 4, world.TileWall); err != nil {
+		t.Fatalf("OrderBuild failed: %v", err)
+	}
+	um.Update()
+	
+	if err := um.MoveUnit(unit.ID, 0, 0); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	if unit.BuildOrder != nil {
+		t.Fatal("move order should cancel the build order")
+	}
+	
+	clock.Advance(time.Minute)
+	runUntilStopped(um, unit)
+	if gameMap.GetTile(4, 4) != world.TileGrass {
+		t.Error("canceled build should never place its tile")
+	}
+}
+
+// Test that invalid build requests are rejected with sentinel errors
+func TestOrderBuildValidation(t *testing.T) {
+	um, unit, gameMap, _ := newBuildTest(t, 3, 3)
+	other, _ := um.CreateUnit(entities.UnitWarrior, 6, 6, "")
+	const tileBramble world.TileType = 99
+	world.TileDefinitions[tileBramble] = world.Tile{Name: "Bramble", Overlay: true, OverridesWalkability: true}
+	defer delete(world.TileDefinitions, tileBramble)
+	gameMap.SetOverlay(5, 2, tileBramble)
+	
+	tests := []struct {
+		name     string
+		tileX    int
+		tileY    int
+		tileType world.TileType
+		want     error
+	}{
+		{"Bridge on grass", 4, 4, world.TileBridge, units.ErrNotBuildable},
+		{"Unbuildable type", 4, 4, world.TileDirtPath, units.ErrNotBuildable},
+		{"Out of bounds", 12, 4, world.TileWall, units.ErrOutOfBounds},
+		{"Wall on a unit", other.TileX, other.TileY, world.TileWall, units.ErrOccupied},
+		{"Wall under a blocking overlay", 5, 2, world.TileWall, units.ErrNotBuildable},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := um.OrderBuild(unit.ID, tt.tileX, tt.tileY, tt.tileType)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("OrderBuild() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -3,38 +3,67 @@ package units
 import (
 	"fmt"
 	"math"
+	"math/rand"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
 )
 
 // UnitCombatSystem handles combat-related operations for units
-type UnitCombatSystem struct{}
+type UnitCombatSystem struct {
+	DamageVariance float64 // Fractional spread applied to base damage, e.g. 0.15 for ±15%; 0 is deterministic
+	RangeMetric    systems.RangeMetric // How attack reach and radius queries measure tiles; Chebyshev by default
+	rng            *rand.Rand
+	onDamage       func(unit *Unit, damage int) // Called after a unit loses health, see SetDamageCallback
+}
 
 // NewUnitCombatSystem creates a new combat system
 func NewUnitCombatSystem() *UnitCombatSystem {
-	return &UnitCombatSystem{}
+	return &UnitCombatSystem{rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// SetRand replaces the combat random source, e.g. with a fixed seed for reproducible fights
+func (cs *UnitCombatSystem) SetRand(rng *rand.Rand) {
+	cs.rng = rng
 }
 
 // DamageUnit applies damage to a unit
 func (cs *UnitCombatSystem) DamageUnit(unit *Unit, damage int) error {
+	_, err := cs.applyDamage(unit, damage)
+	return err
+}
+
+// applyDamage applies damage to a unit and returns the health it actually lost, which never exceeds
+// the health it had, so a killing blow only counts the damage that was needed
+func (cs *UnitCombatSystem) applyDamage(unit *Unit, damage int) (int, error) {
 	if unit == nil {
-		return fmt.Errorf("unit is nil")
+		return 0, fmt.Errorf("unit is nil")
 	}
 
 	if !unit.IsAlive {
-		return fmt.Errorf("unit is already dead: %s", unit.ID)
+		return 0, fmt.Errorf("%w: %s", ErrUnitDead, unit.ID)
 	}
 
-	// Apply damage (with defense reduction)
-	actualDamage := int(math.Max(1, float64(damage-unit.CurrentStats.Defense)))
+	// Apply damage (with variance and defense reduction)
+	actualDamage := int(math.Min(float64(cs.CalculateDamage(damage, unit.EffectiveStats().Defense)), float64(unit.CurrentStats.Health)))
 	unit.CurrentStats.Health -= actualDamage
+	unit.DamageTaken += actualDamage
 
 	// Check if unit died
 	if unit.CurrentStats.Health <= 0 {
 		unit.CurrentStats.Health = 0
 		unit.IsAlive = false
-		unit.Status = "dead"
+		unit.SetStatus(StatusDead)
 	}
+	cs.notifyDamage(unit, actualDamage)
 
-	return nil
+	return actualDamage, nil
+}
+
+// notifyDamage reports health a unit just lost to the damage callback, if any
+func (cs *UnitCombatSystem) notifyDamage(unit *Unit, damage int) {
+	if cs.onDamage != nil && damage > 0 {
+		cs.onDamage(unit, damage)
+	}
 }
 
 // HealUnit restores health to a unit
@@ -44,7 +73,7 @@ func (cs *UnitCombatSystem) HealUnit(unit *Unit, healAmount int) error {
 	}
 
 	if !unit.IsAlive {
-		return fmt.Errorf("cannot heal dead unit: %s", unit.ID)
+		return fmt.Errorf("cannot heal %s: %w", unit.ID, ErrUnitDead)
 	}
 
 	// Apply healing (capped at max health)
@@ -53,12 +82,87 @@ func (cs *UnitCombatSystem) HealUnit(unit *Unit, healAmount int) error {
 	return nil
 }
 
-// CalculateDamage calculates the actual damage after defense
+// CalculateDamage calculates the actual damage after variance and defense
+// Variance scales the base damage before defense so the minimum of 1 still holds
 func (cs *UnitCombatSystem) CalculateDamage(baseDamage, defense int) int {
+	if cs.DamageVariance > 0 && cs.rng != nil {
+		spread := (cs.rng.Float64()*2 - 1) * cs.DamageVariance
+		baseDamage = int(math.Round(float64(baseDamage) * (1 + spread)))
+	}
 	return int(math.Max(1, float64(baseDamage-defense)))
 }
 
 // IsUnitDead checks if a unit is dead
 func (cs *UnitCombatSystem) IsUnitDead(unit *Unit) bool {
 	return unit == nil || !unit.IsAlive || unit.CurrentStats.Health <= 0
-}
\ No newline at end of file
+}
+
+// Distance measures how far apart two units' tiles are under the combat RangeMetric
+func (cs *UnitCombatSystem) Distance(a, b *Unit) float64 {
+	return systems.TileDistance(a.TileX, a.TileY, b.TileX, b.TileY, cs.RangeMetric)
+}
+
+// CombatSystem returns the manager's combat system, e.g. to configure damage variance or the range metric
+func (um *UnitManager) CombatSystem() *UnitCombatSystem {
+	return um.combatSystem
+}
+
+// DamageUnit applies damage to a unit
+func (um *UnitManager) DamageUnit(unitID string, damage int) error {
+	unit := um.units[unitID]
+	if unit == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, unitID)
+	}
+
+	return um.combatSystem.DamageUnit(unit, damage)
+}
+
+// KillUnit drops a unit's health to zero outright, ignoring defense; the unit stays in the game as dead
+func (um *UnitManager) KillUnit(unitID string) error {
+	unit := um.units[unitID]
+	if unit == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, unitID)
+	}
+	if !unit.IsAlive {
+		return fmt.Errorf("%w: %s", ErrUnitDead, unitID)
+	}
+
+	lost := unit.CurrentStats.Health
+	unit.CurrentStats.Health = 0
+	unit.IsAlive = false
+	unit.SetStatus(StatusDead)
+	um.combatSystem.notifyDamage(unit, lost)
+	return nil
+}
+
+// SetDamageCallback registers fn to run whenever a unit loses health to an attack, area effect or kill,
+// with the health lost; a unit the damage killed is no longer IsAlive. nil removes the callback
+func (um *UnitManager) SetDamageCallback(fn func(unit *Unit, damage int)) {
+	um.combatSystem.onDamage = fn
+}
+
+// GetUnitsInRadius returns living units whose tile lies within radius of (tileX, tileY), measured with
+// the combat RangeMetric, in creation order. Carried units are inside their transport and never counted
+func (um *UnitManager) GetUnitsInRadius(tileX, tileY int, radius float64) []*Unit {
+	found := make([]*Unit, 0)
+	for _, id := range um.unitOrder {
+		unit := um.units[id]
+		if unit == nil || !unit.IsAlive || unit.IsCarried() {
+			continue
+		}
+		if systems.TileDistance(tileX, tileY, unit.TileX, unit.TileY, um.combatSystem.RangeMetric) <= radius {
+			found = append(found, unit)
+		}
+	}
+	return found
+}
+
+// HealUnit restores health to a unit
+func (um *UnitManager) HealUnit(unitID string, healAmount int) error {
+	unit := um.units[unitID]
+	if unit == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, unitID)
+	}
+
+	return um.combatSystem.HealUnit(unit, healAmount)
+}
@@ -0,0 +1,90 @@
+package units
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CombatAction is an attack queued during a frame and resolved at the end of Update
+type CombatAction struct {
+	AttackerID string
+	TargetID   string
+}
+
+// QueueAttack queues an attack from one unit on another for resolution in the next Update
+func (um *UnitManager) QueueAttack(attackerID, targetID string) error {
+	attacker := um.units[attackerID]
+	if attacker == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, attackerID)
+	}
+	if um.units[targetID] == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, targetID)
+	}
+	if !attacker.IsAlive {
+		return fmt.Errorf("cannot attack with %s: %w", attackerID, ErrUnitDead)
+	}
+
+	um.combatQueue = append(um.combatQueue, CombatAction{AttackerID: attackerID, TargetID: targetID})
+	return nil
+}
+
+// resolveCombatQueue resolves all queued attacks in initiative order and clears the queue
+// Faster units (higher Speed) strike first, ties broken by creation order (older units first), so outcomes are deterministic
+func (um *UnitManager) resolveCombatQueue() {
+	queue := um.combatQueue
+	um.combatQueue = nil
+
+	// Units that stopped attacking this frame go back to idle
+	attacking := make(map[string]bool, len(queue))
+	for _, action := range queue {
+		attacking[action.AttackerID] = true
+	}
+	for _, unit := range um.OrderedUnits() {
+		if unit.Status == StatusAttacking && !attacking[unit.ID] {
+			unit.SetStatus(StatusIdle)
+		}
+	}
+
+	if len(queue) == 0 {
+		return
+	}
+
+	creationIndex := make(map[string]int, len(um.unitOrder))
+	for i, id := range um.unitOrder {
+		creationIndex[id] = i
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		a, b := um.units[queue[i].AttackerID], um.units[queue[j].AttackerID]
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		if speedA, speedB := a.EffectiveStats().Speed, b.EffectiveStats().Speed; speedA != speedB {
+			return speedA > speedB
+		}
+		return creationIndex[a.ID] < creationIndex[b.ID]
+	})
+
+	for _, action := range queue {
+		attacker := um.units[action.AttackerID]
+		target := um.units[action.TargetID]
+
+		// Units killed (or removed) earlier in this pass don't get to act
+		if attacker == nil || !attacker.IsAlive || target == nil || !target.IsAlive {
+			continue
+		}
+
+		// Movement status takes precedence while the attacker is still walking
+		if !attacker.IsMoving() {
+			attacker.SetStatus(StatusAttacking)
+		}
+		dealt, err := um.combatSystem.applyDamage(target, attacker.EffectiveStats().Damage)
+		if err != nil {
+			continue
+		}
+		attacker.DamageDealt += dealt
+		if !target.IsAlive {
+			attacker.Kills++
+			um.AwardKillExperience(attacker.ID, target.ID)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"math/rand"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that mutually-attacking units resolve in initiative order and the loser never acts
+func TestCombatQueueInitiativeOrder(t *testing.T) {
+	// Repeat to make sure the outcome doesn't depend on map iteration order
+	for run := 0; run < 20; run++ {
+		um := units.NewUnitManager(newTestMap(5, 5))
+		warrior, _ := um.CreateUnit(entities.UnitWarrior, 1, 1, "") // Speed 2
+		archer, _ := um.CreateUnit(entities.UnitArcher, 2, 1, "")   // Speed 4
+		
+		// Either unit would kill the other with a single hit
+		warrior.CurrentStats.Health = 10
+		archer.CurrentStats.Health = 10
+		
+		// Queue the slower unit first to prove queue order doesn't matter
+		if err := um.QueueAttack(warrior.ID, archer.ID); err != nil {
+			t.Fatalf("QueueAttack failed: %v", err)
+		}
+		if err := um.QueueAttack(archer.ID, warrior.ID); err != nil {
+			t.Fatalf("QueueAttack failed: %v", err)
+		}
+		um.Update()
+		
+		if warrior.IsAlive {
+			t.Fatalf("run %d: faster archer should have killed the warrior first", run)
+		}
+		if !archer.IsAlive || archer.CurrentStats.Health != 10 {
+			t.Fatalf("run %d: warrior died before acting, archer health = %d, want 10", run, archer.CurrentStats.Health)
+		}
+	}
+}
+
+// Test that equal-speed units are resolved by creation order, not by comparing ID strings
+func TestCombatQueueCreationOrderTiebreak(t *testing.T) {
+	for run := 0; run < 20; run++ {
+		um := units.NewUnitManager(newTestMap(5, 5))
+		created := make([]*units.Unit, 0, 10)
+		for i := 0; i < 10; i++ {
+			unit, err := um.CreateUnit(entities.UnitWarrior, i%5, i/5, "")
+			if err != nil {
+				t.Fatalf("CreateUnit failed: %v", err)
+			}
+			created = append(created, unit)
+		}
+		// The older unit's ID sorts after the newer one's (unit_2 > unit_10 as strings)
+		older, newer := created[1], created[9]
+		if older.ID <= newer.ID {
+			t.Fatalf("IDs %s and %s don't exercise the string order", older.ID, newer.ID)
+		}
+		older.CurrentStats.Health = 5
+		newer.CurrentStats.Health = 5
+		
+		um.QueueAttack(newer.ID, older.ID)
+		um.QueueAttack(older.ID, newer.ID)
+		um.Update()
+		
+		if !older.IsAlive || newer.IsAlive {
+			t.Fatalf("run %d: older %s should strike first on a speed tie", run, older.ID)
+		}
+	}
+}
+
+// Test that the combat queue is cleared after resolution
+func TestCombatQueueClearedAfterUpdate(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(5, 5))
+	attacker, _ := um.CreateUnit(entities.UnitArcher, 1, 1, "")
+	target, _ := um.CreateUnit(entities.UnitWarrior, 2, 1, "")
+	
+	um.QueueAttack(attacker.ID, target.ID)
+	um.Update()
+	healthAfterHit := target.CurrentStats.Health
+	if healthAfterHit != target.MaxStats.Health-25 {
+		t.Errorf("target health = %d, want %d", healthAfterHit, target.MaxStats.Health-25)
+	}
+	
+	um.Update()
+	if target.CurrentStats.Health != healthAfterHit {
+		t.Error("attack should not be resolved twice")
+	}
+	
+	if err := um.QueueAttack("unit_404", target.ID); err == nil {
+		t.Error("QueueAttack should reject unknown attackers")
+	}
+}
+
+// Test that zero variance reproduces the plain base-minus-defense damage with a floor of 1
+func TestCalculateDamageWithoutVariance(t *testing.T) {
+	cs := units.NewUnitCombatSystem()
+	tests := []struct {
+		base, defense, expected int
+	}{
+		{25, 10, 15},
+		{10, 10, 1},
+		{5, 20, 1},
+		{40, 0, 40},
+	}
+	
+	for _, tt := range tests {
+		for i := 0; i < 10; i++ {
+			if got := cs.CalculateDamage(tt.base, tt.defense); got != tt.expected {
+				t.Fatalf("CalculateDamage(%d, %d) = %d, want %d", tt.base, tt.defense, got, tt.expected)
+			}
+		}
+	}
+}
+
+// Test that seeded variance stays within ±DamageVariance of the base damage and actually varies
+func TestCalculateDamageVarianceBounds(t *testing.T) {
+	cs := units.NewUnitCombatSystem()
+	cs.DamageVariance = 0.15
+	cs.SetRand(rand.New(rand.NewSource(42)))
+	
+	seen := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		damage := cs.CalculateDamage(100, 10)
+		if damage < 75 || damage > 105 {
+			t.Fatalf("CalculateDamage(100, 10) = %d, want within [75, 105] for ±15%%", damage)
+		}
+		seen[damage] = true
+	}
+	if len(seen) < 10 {
+		t.Errorf("saw only %d distinct damage values, want variance to spread hits", len(seen))
+	}
+	
+	// Heavily armored targets still take at least 1 damage
+	for i := 0; i < 100; i++ {
+		if damage := cs.CalculateDamage(10, 50); damage != 1 {
+			t.Fatalf("CalculateDamage(10, 50) = %d, want the minimum of 1", damage)
+		}
+	}
+	
+	// The same seed replays the same sequence of hits
+	replay := units.NewUnitCombatSystem()
+	replay.DamageVariance = 0.15
+	replay.SetRand(rand.New(rand.NewSource(7)))
+	cs.SetRand(rand.New(rand.NewSource(7)))
+	for i := 0; i < 20; i++ {
+		if a, b := cs.CalculateDamage(60, 5), replay.CalculateDamage(60, 5); a != b {
+			t.Fatalf("hit %d: %d != %d with identical seeds", i, a, b)
+		}
+	}
+}
+
+// Test that the combat range metric decides whether a diagonal neighbor is in melee reach and radius queries
+func TestRangeMetricDiagonalReach(t *testing.T) {
+	tests := []struct {
+		name         string
+		metric       systems.RangeMetric
+		diagonalHits bool
+		wantInRadius int
+	}{
+		{"Chebyshev default", systems.RangeChebyshev, true, 3},
+		{"Manhattan", systems.RangeManhattan, false, 2},
+		{"Euclidean", systems.RangeEuclidean, false, 2},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			um := units.NewUnitManager(newTestMap(10, 10))
+			um.CombatSystem().RangeMetric = tt.metric
+			warrior, _ := um.CreateUnit(entities.UnitWarrior, 4, 4, "")
+			diagonal := createEnemy(t, um, 5, 5)
+			
+			if got := um.InAttackRange(warrior, diagonal); got != tt.diagonalHits {
+				t.Errorf("InAttackRange(diagonal) = %v, want %v", got, tt.diagonalHits)
+			}
+			
+			// The warrior, an orthogonal neighbor and the diagonal one around (4, 4)
+			um.CreateUnit(entities.UnitWarrior, 4, 3, "")
+			if got := um.GetUnitsInRadius(4, 4, 1); len(got) != tt.wantInRadius {
+				t.Errorf("GetUnitsInRadius(4, 4, 1) found %d units, want %d", len(got), tt.wantInRadius)
+			}
+		})
+	}
+	
+	if units.NewUnitCombatSystem().RangeMetric != systems.RangeChebyshev {
+		t.Errorf("default RangeMetric should be Chebyshev")
+	}
+}
+
+// Test that damage dealt and taken accumulate over several hits and the killing blow counts a kill
+func TestCombatStatsAccumulate(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(5, 5))
+	attacker, _ := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	helper, _ := um.CreateUnit(entities.UnitWarrior, 3, 1, "")
+	target, _ := um.CreateUnit(entities.UnitWarrior, 2, 1, "")
+	target.CurrentStats.Health = 35 // Each warrior hit deals 25 - 15 defense = 10
+	
+	tests := []struct {
+		name                     string
+		attackers                []*units.Unit
+		wantDealt, wantHelper    int
+		wantTaken, wantKills     int
+	}{
+		{"first hit", []*units.Unit{attacker}, 10, 0, 10, 0},
+		{"second hit", []*units.Unit{attacker}, 20, 0, 20, 0},
+		{"helper joins in", []*units.Unit{helper}, 20, 10, 30, 0},
+		{"killing blow only counts the health left", []*units.Unit{attacker}, 25, 10, 35, 1},
+		{"attacks on the dead change nothing", []*units.Unit{attacker, helper}, 25, 10, 35, 1},
+	}
+	
+	for _, tt := range tests {
+		for _, unit := range tt.attackers {
+			if err := um.QueueAttack(unit.ID, target.ID); err != nil {
+				t.Fatalf("%s: QueueAttack failed: %v", tt.name, err)
+			}
+		}
+		um.Update()
+		
+		if attacker.DamageDealt != tt.wantDealt || helper.DamageDealt != tt.wantHelper {
+			t.Errorf("%s: DamageDealt = %d and %d, want %d and %d", tt.name, attacker.DamageDealt, helper.DamageDealt, tt.wantDealt, tt.wantHelper)
+		}
+		if target.DamageTaken != tt.wantTaken {
+			t.Errorf("%s: DamageTaken = %d, want %d", tt.name, target.DamageTaken, tt.wantTaken)
+		}
+		if attacker.Kills != tt.wantKills || helper.Kills != 0 {
+			t.Errorf("%s: Kills = %d and %d, want %d and 0", tt.name, attacker.Kills, helper.Kills, tt.wantKills)
+		}
+	}
+	if attacker.DamageTaken != 0 {
+		t.Errorf("attacker DamageTaken = %d, want 0 since nothing hit it", attacker.DamageTaken)
+	}
+}
+
+// Test that the damage callback sees the health actually lost by every kind of damage, and deaths
+func TestDamageCallback(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(5, 5))
+	attacker, _ := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	target, _ := um.CreateUnit(entities.UnitWarrior, 2, 1, "")
+	victim, _ := um.CreateUnit(entities.UnitWarrior, 3, 3, "")
+	
+	type hit struct {
+		id     string
+		damage int
+		alive  bool
+	}
+	var hits []hit
+	um.SetDamageCallback(func(unit *units.Unit, damage int) {
+		hits = append(hits, hit{unit.ID, damage, unit.IsAlive})
+	})
+	
+	um.DamageUnit(target.ID, 40) // 40 - 15 defense
+	um.QueueAttack(attacker.ID, target.ID)
+	um.Update() // 25 - 15 defense
+	um.KillUnit(victim.ID)
+	um.DamageUnit(victim.ID, 50) // Already dead: no damage, no callback
+	
+	want := []hit{{target.ID, 25, true}, {target.ID, 10, true}, {victim.ID, 100, false}}
+	if len(hits) != len(want) {
+		t.Fatalf("callback saw %v, want %v", hits, want)
+	}
+	for i := range want {
+		if hits[i] != want[i] {
+			t.Errorf("hit %d = %+v, want %+v", i, hits[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package units
+
+import (
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+)
+
+// GetUnitTypeCounts returns the count of each unit type
+func (um *UnitManager) GetUnitTypeCounts() map[entities.UnitType]int {
+	counts := make(map[entities.UnitType]int)
+	
+	for _, unit := range um.units {
+		if unit.IsAlive {
+			counts[unit.TypeID]++
+		}
+	}
+	
+	return counts
+}
+
+// GetTotalUnitCount returns the total number of alive units
+func (um *UnitManager) GetTotalUnitCount() int {
+	count := 0
+	for _, unit := range um.units {
+		if unit.IsAlive {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,129 @@
+package units
+
+import (
+	"fmt"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// statNames maps stats to the names used by the JS interface
+var statNames = map[Stat]string{
+	StatDamage:  "damage",
+	StatSpeed:   "speed",
+	StatDefense: "defense",
+	StatRange:   "range",
+}
+
+// String returns the stat's name
+func (s Stat) String() string {
+	if name, ok := statNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// UnitEffect is one active stat modifier as shown in a unit's detail
+type UnitEffect struct {
+	Source    string
+	Stat      Stat
+	Add       float64
+	Multiply  float64
+	Remaining time.Duration // Time left before it expires, 0 if it never does
+}
+
+// UnitDetail is a snapshot of everything a detail panel shows about one unit
+type UnitDetail struct {
+	ID             string
+	Name           string
+	TypeID         entities.UnitType
+	Team           int
+	Level          int
+	Experience     int
+	Kills          int
+	DamageDealt    int
+	DamageTaken    int
+	Status         string
+	Stance         Stance
+	IsAlive        bool
+	TileX, TileY   int
+	X, Y           float64 // World position of the unit's top-left corner
+	Stats          entities.UnitStats // Current stats; Health is the unit's current health
+	MaxStats       entities.UnitStats
+	EffectiveStats entities.UnitStats // Stats with active effects applied
+	Moving         bool
+	PathLength     int // Path tiles still ahead of the unit, 0 when not moving
+	Effects        []UnitEffect // Active stat modifiers, in the order they were added
+	CarriedBy      string
+}
+
+// UnitDetail returns a detail snapshot of a unit, or ErrNotFound if there is no unit with that ID
+func (um *UnitManager) UnitDetail(unitID string) (UnitDetail, error) {
+	unit := um.units[unitID]
+	if unit == nil {
+		return UnitDetail{}, fmt.Errorf("%w: %s", ErrNotFound, unitID)
+	}
+
+	x, y := unit.GetPosition()
+	detail := UnitDetail{
+		ID:             unit.ID,
+		Name:           unit.Name,
+		TypeID:         unit.TypeID,
+		Team:           unit.Team,
+		Level:          unit.Level,
+		Experience:     unit.Experience,
+		Kills:          unit.Kills,
+		DamageDealt:    unit.DamageDealt,
+		DamageTaken:    unit.DamageTaken,
+		Status:         unit.Status,
+		Stance:         unit.Stance,
+		IsAlive:        unit.IsAlive,
+		TileX:          unit.TileX,
+		TileY:          unit.TileY,
+		X:              x,
+		Y:              y,
+		Stats:          unit.CurrentStats,
+		MaxStats:       unit.MaxStats,
+		EffectiveStats: unit.EffectiveStats(),
+		Moving:         unit.IsMoving(),
+		PathLength:     unit.remainingPathLength(),
+		Effects:        unit.activeEffects(),
+		CarriedBy:      unit.carriedBy,
+	}
+	return detail, nil
+}
+
+// remainingPathLength counts the path tiles past the current step, 0 when not moving
+// Tiles a smoothed path skips between waypoints are counted too, including those before the current one
+func (u *Unit) remainingPathLength() int {
+	path, step := u.GetPath(), u.GetPathStep()
+	if !u.IsMoving() || step >= len(path) {
+		return 0
+	}
+	gameMap := u.movementSystem.GetGameMap()
+	remaining := 0
+	if ahead := systems.SegmentLength(u.TileX, u.TileY, path[step].X, path[step].Y, gameMap) - 1; ahead > 0 {
+		remaining = ahead
+	}
+	for i := step + 1; i < len(path); i++ {
+		remaining += systems.SegmentLength(path[i-1].X, path[i-1].Y, path[i].X, path[i].Y, gameMap)
+	}
+	return remaining
+}
+
+// activeEffects lists the unit's unexpired modifiers with the time each has left
+func (u *Unit) activeEffects() []UnitEffect {
+	now := u.now()
+	effects := make([]UnitEffect, 0, len(u.Modifiers))
+	for _, modifier := range u.Modifiers {
+		if modifier.expired(now) {
+			continue
+		}
+		effect := UnitEffect{Source: modifier.Source, Stat: modifier.Stat, Add: modifier.Add, Multiply: modifier.Multiply}
+		if !modifier.Until.IsZero() {
+			effect.Remaining = modifier.Until.Sub(now)
+		}
+		effects = append(effects, effect)
+	}
+	return effects
+}
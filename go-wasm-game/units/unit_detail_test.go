@@ -0,0 +1,82 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that a unit's detail reports its active effects, effective stats and remaining path
+func TestUnitDetailWithEffectsAndPath(t *testing.T) {
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	um := units.NewUnitManager(newTestMap(10, 10))
+	um.SetClock(clock.Now)
+	warrior := createUnits(t, um, 1)[0]
+	
+	warrior.AddModifier(units.StatModifier{Source: "sword", Stat: units.StatDamage, Add: 5})
+	warrior.AddTimedModifier(units.StatModifier{Source: "haste", Stat: units.StatSpeed, Multiply: 1.5}, 4*time.Second)
+	warrior.AddTimedModifier(units.StatModifier{Source: "faded", Stat: units.StatDefense, Add: 3}, time.Second)
+	clock.Advance(2 * time.Second)
+	if err := um.MoveUnit(warrior.ID, 6, 0); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	
+	detail, err := um.UnitDetail(warrior.ID)
+	if err != nil {
+		t.Fatalf("UnitDetail failed: %v", err)
+	}
+	if detail.ID != warrior.ID || detail.Level != 1 || detail.TileX != 0 || detail.TileY != 0 || !detail.IsAlive {
+		t.Errorf("detail = %+v, want the level 1 warrior alive at (0, 0)", detail)
+	}
+	if !detail.Moving || detail.PathLength != 6 {
+		t.Errorf("Moving, PathLength = %v, %d, want true, 6", detail.Moving, detail.PathLength)
+	}
+	if detail.EffectiveStats.Damage != warrior.MaxStats.Damage+5 || detail.Stats != warrior.CurrentStats {
+		t.Errorf("EffectiveStats = %+v, Stats = %+v, want +5 damage over current stats", detail.EffectiveStats, detail.Stats)
+	}
+	
+	want := []units.UnitEffect{
+		{Source: "sword", Stat: units.StatDamage, Add: 5},
+		{Source: "haste", Stat: units.StatSpeed, Multiply: 1.5, Remaining: 2 * time.Second},
+	}
+	if len(detail.Effects) != len(want) {
+		t.Fatalf("Effects = %+v, want %+v without the expired one", detail.Effects, want)
+	}
+	for i := range want {
+		if detail.Effects[i] != want[i] {
+			t.Errorf("Effects[%d] = %+v, want %+v", i, detail.Effects[i], want[i])
+		}
+	}
+	
+	runUntilStopped(um, warrior)
+	if detail, _ := um.UnitDetail(warrior.ID); detail.Moving || detail.PathLength != 0 || detail.TileX != 6 {
+		t.Errorf("after arriving Moving, PathLength, TileX = %v, %d, %d, want false, 0, 6", detail.Moving, detail.PathLength, detail.TileX)
+	}
+}
+
+// Test that the remaining path length counts the tiles a smoothed path skips between its waypoints
+func TestUnitDetailPathLengthOnSmoothedPath(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	unit := createUnits(t, um, 1)[0]
+	
+	// The same route as the 10-tile grid path (0, 0) -> (6, 0) -> (6, 3), walking toward (6, 0)
+	unit.SetPath(systems.Path{{X: 0, Y: 0}, {X: 6, Y: 0}, {X: 6, Y: 3}})
+	unit.SetPathStep(1)
+	unit.SetMoving(true)
+	if detail, _ := um.UnitDetail(unit.ID); detail.PathLength != 8 {
+		t.Errorf("PathLength = %d, want 8 like the tile-by-tile path", detail.PathLength)
+	}
+}
+
+// Test that asking for a missing unit's detail fails with ErrNotFound
+func TestUnitDetailNotFound(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(5, 5))
+	if _, err := um.UnitDetail("unit_404"); !errors.Is(err, units.ErrNotFound) {
+		t.Errorf("UnitDetail(unit_404) error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,21 @@
+package units
+
+import "errors"
+
+// Sentinel errors returned by the unit management system
+// Callers can match them with errors.Is; the wrapped message keeps the details
+var (
+	ErrOutOfBounds     = errors.New("tile coordinates out of bounds")
+	ErrNotWalkable     = errors.New("cannot place unit on non-walkable tile")
+	ErrOccupied        = errors.New("tile already occupied")
+	ErrNotFound        = errors.New("unit not found")
+	ErrUnitDead        = errors.New("unit is dead")
+	ErrUnknownUnitType = errors.New("unknown unit type")
+	ErrNotBuildable    = errors.New("cannot build on tile")
+	ErrCarried         = errors.New("unit is being carried")
+	ErrTransportFull   = errors.New("transport is full")
+	ErrNotAdjacent     = errors.New("tile is not adjacent")
+	ErrQueueFull       = errors.New("move queue is full")
+	ErrAbilityUnavailable = errors.New("ability unavailable")
+	ErrInvalidSpec     = errors.New("invalid unit spec")
+)
@@ -0,0 +1,43 @@
+package units
+
+import "fmt"
+
+const (
+	// KillExperience is the experience a kill is worth per level of the defeated unit
+	KillExperience = 20
+	// ExperienceShareRadius is how many tiles from the killer (diagonals count as 1) allies share kill experience
+	ExperienceShareRadius = 3
+	// killerShareWeight is the killer's share of kill experience relative to each nearby ally's single share
+	killerShareWeight = 2
+)
+
+// AwardKillExperience splits a kill's experience between the killer and alive allies near it
+// The killer gets a double share plus any remainder, so squads that fight together level together
+func (um *UnitManager) AwardKillExperience(killerID, victimID string) error {
+	killer, victim := um.units[killerID], um.units[victimID]
+	if killer == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, killerID)
+	}
+	if victim == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, victimID)
+	}
+
+	allies := make([]*Unit, 0)
+	for _, id := range um.unitOrder {
+		ally := um.units[id]
+		if ally == nil || ally == killer || ally == victim || !ally.IsAlive || ally.Team != killer.Team {
+			continue
+		}
+		if tileDistance(killer, ally) <= ExperienceShareRadius {
+			allies = append(allies, ally)
+		}
+	}
+
+	total := KillExperience * victim.Level
+	share := total / (killerShareWeight + len(allies))
+	for _, ally := range allies {
+		ally.Experience += share
+	}
+	killer.Experience += total - share*len(allies)
+	return nil
+}
@@ -0,0 +1,83 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that kill experience is split between the killer and allies within the share radius
+func TestAwardKillExperienceSplitsAmongNearbyAllies(t *testing.T) {
+	tests := []struct {
+		name       string
+		allyTiles  [][2]int
+		wantKiller int
+		wantAllies []int
+	}{
+		{"killer alone", nil, 20, nil},
+		{"two nearby allies", [][2]int{{2, 1}, {1, 2}}, 10, []int{5, 5}},
+		{"one ally plus remainder", [][2]int{{3, 3}}, 14, []int{6}},
+		{"distant ally gets none", [][2]int{{2, 1}, {8, 8}}, 14, []int{6, 0}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			um := units.NewUnitManager(newTestMap(10, 10))
+			killer, _ := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+			allies := make([]*units.Unit, 0, len(tt.allyTiles))
+			for _, tile := range tt.allyTiles {
+				ally, err := um.CreateUnit(entities.UnitWarrior, tile[0], tile[1], "")
+				if err != nil {
+					t.Fatalf("CreateUnit(%d, %d) failed: %v", tile[0], tile[1], err)
+				}
+				allies = append(allies, ally)
+			}
+			// Enemies next to the kill never share in it
+			bystander := createEnemy(t, um, 0, 1)
+			victim := createEnemy(t, um, 0, 0)
+			
+			if err := um.AwardKillExperience(killer.ID, victim.ID); err != nil {
+				t.Fatalf("AwardKillExperience() failed: %v", err)
+			}
+			if killer.Experience != tt.wantKiller {
+				t.Errorf("killer experience = %d, want %d", killer.Experience, tt.wantKiller)
+			}
+			for i, ally := range allies {
+				if ally.Experience != tt.wantAllies[i] {
+					t.Errorf("ally at %v experience = %d, want %d", tt.allyTiles[i], ally.Experience, tt.wantAllies[i])
+				}
+			}
+			if bystander.Experience != 0 {
+				t.Errorf("enemy bystander experience = %d, want 0", bystander.Experience)
+			}
+		})
+	}
+}
+
+// Test that dead allies are skipped and a fatal attack in combat awards the experience
+func TestKillInCombatAwardsExperience(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	killer, _ := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	fallen, _ := um.CreateUnit(entities.UnitWarrior, 2, 1, "")
+	fallen.IsAlive = false
+	victim := createEnemy(t, um, 1, 2)
+	victim.CurrentStats.Health = 1
+	
+	if err := um.QueueAttack(killer.ID, victim.ID); err != nil {
+		t.Fatalf("QueueAttack() failed: %v", err)
+	}
+	um.Update()
+	
+	if victim.IsAlive {
+		t.Fatalf("victim survived a fatal attack")
+	}
+	if killer.Experience != units.KillExperience {
+		t.Errorf("killer experience = %d, want the full %d", killer.Experience, units.KillExperience)
+	}
+	if fallen.Experience != 0 {
+		t.Errorf("dead ally experience = %d, want 0", fallen.Experience)
+	}
+}
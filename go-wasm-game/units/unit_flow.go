@@ -0,0 +1,55 @@
+package units
+
+import (
+	"fmt"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// FollowFlowField switches the unit into flow-field movement, stepping one tile at a time along a
+// shared field until it reaches the goal. A direct MoveToTile order leaves flow-field mode
+func (u *Unit) FollowFlowField(field [][]int) {
+	u.flowField = field
+	u.SetPath(nil)
+	u.SetPathStep(0)
+	u.SetMoving(false)
+}
+
+// IsFollowingFlowField returns true while the unit is in flow-field movement
+func (u *Unit) IsFollowingFlowField() bool {
+	return u.flowField != nil
+}
+
+// stepFlowField queues the next flow-field step once the previous one finishes,
+// leaving flow-field mode at the goal (or when the goal is unreachable)
+func (u *Unit) stepFlowField() {
+	if u.flowField == nil || u.movementSystem == nil {
+		return
+	}
+	if !u.movementSystem.StepAlongFlowField(u, u.flowField) {
+		u.flowField = nil
+	}
+}
+
+// MoveUnitsByFlowField sends several units to one tile using a single shared flow field
+// instead of running a path search per unit
+func (um *UnitManager) MoveUnitsByFlowField(unitIDs []string, goalX, goalY int) error {
+	if goalX < 0 || goalX >= um.gameMap.Width || goalY < 0 || goalY >= um.gameMap.Height {
+		return fmt.Errorf("%w: (%d, %d)", ErrOutOfBounds, goalX, goalY)
+	}
+	
+	field := systems.ComputeFlowField(goalX, goalY, um.gameMap)
+	for _, unitID := range unitIDs {
+		unit := um.units[unitID]
+		if unit == nil {
+			return fmt.Errorf("%w: %s", ErrNotFound, unitID)
+		}
+		if !unit.IsAlive {
+			return fmt.Errorf("cannot move %s: %w", unitID, ErrUnitDead)
+		}
+		unit.cancelBuildOrder()
+		unit.chaseTargetID = ""
+		unit.setHome(goalX, goalY)
+		unit.FollowFlowField(field)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"math"
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// walkFor sends a fresh unit across the map and updates it with the given frame times in turn
+// until total has passed, returning where it ends up
+func walkFor(t *testing.T, total time.Duration, frames ...time.Duration) (float64, float64) {
+	t.Helper()
+	um := units.NewUnitManager(newTestMap(12, 12))
+	unit, err := um.CreateUnit(entities.UnitWarrior, 0, 0, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	if err := um.MoveUnit(unit.ID, 10, 7); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	for elapsed, i := time.Duration(0), 0; elapsed < total; i++ {
+		dt := frames[i%len(frames)]
+		um.UpdateDT(dt)
+		elapsed += dt
+	}
+	if !unit.IsMoving() {
+		t.Fatalf("unit arrived within %v, want it still walking so the distance is comparable", total)
+	}
+	return unit.GetPosition()
+}
+
+// Test that a unit covers the same ground over a second of simulated time however the second is split into updates
+func TestUnitMovementIndependentOfFrameTime(t *testing.T) {
+	wantX, wantY := walkFor(t, time.Second, 10*time.Millisecond)
+	startX, startY := 0.0, 0.0
+	if moved := math.Hypot(wantX-startX, wantY-startY); moved < 32 {
+		t.Fatalf("unit moved %.1f px in a second, want it to cross at least a tile", moved)
+	}
+	
+	tests := []struct {
+		name   string
+		frames []time.Duration
+	}{
+		{"20ms frames", []time.Duration{20 * time.Millisecond}},
+		{"50ms frames", []time.Duration{50 * time.Millisecond}},
+		{"100ms frames", []time.Duration{100 * time.Millisecond}},
+		{"250ms frames passing several waypoints each", []time.Duration{250 * time.Millisecond}},
+		{"uneven frames", []time.Duration{30 * time.Millisecond, 70 * time.Millisecond}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := walkFor(t, time.Second, tt.frames...)
+			if math.Abs(x-wantX) > 1e-6 || math.Abs(y-wantY) > 1e-6 {
+				t.Errorf("position after 1s = (%.4f, %.4f), want (%.4f, %.4f) as with 10ms frames", x, y, wantX, wantY)
+			}
+		})
+	}
+}
+
+// Test that Update keeps the old one-frame step, matching UpdateDT with FrameDuration
+func TestUnitUpdateDefaultsToOneFrame(t *testing.T) {
+	positions := make([][2]float64, 0, 2)
+	for _, step := range []func(*units.UnitManager){
+		func(um *units.UnitManager) { um.Update() },
+		func(um *units.UnitManager) { um.UpdateDT(systems.FrameDuration) },
+	} {
+		um := units.NewUnitManager(newTestMap(12, 12))
+		unit, _ := um.CreateUnit(entities.UnitWarrior, 0, 0, "")
+		um.MoveUnit(unit.ID, 10, 0)
+		for frame := 0; frame < 30; frame++ {
+			step(um)
+		}
+		x, y := unit.GetPosition()
+		positions = append(positions, [2]float64{x, y})
+	}
+	if positions[0] != positions[1] {
+		t.Errorf("Update() moved the unit to %v, UpdateDT(FrameDuration) to %v, want the same", positions[0], positions[1])
+	}
+}
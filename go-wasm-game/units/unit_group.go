@@ -0,0 +1,73 @@
+package units
+
+import (
+	"fmt"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// MoveGroupToTile sends several units to free tiles around (tileX, tileY), closest tiles to the earliest listed units.
+// Their paths share one trunk between the group's centroids (see systems.SharedGroupPaths): each unit joins it at the
+// trunk tile nearest its start and leaves at the one nearest its goal, so a group costs one long search plus a short
+// head and tail per member instead of a full search each. The first unit's path options are used for every member
+func (um *UnitManager) MoveGroupToTile(unitIDs []string, tileX, tileY int) error {
+	if tileX < 0 || tileX >= um.gameMap.Width || tileY < 0 || tileY >= um.gameMap.Height {
+		return fmt.Errorf("%w: (%d, %d)", ErrOutOfBounds, tileX, tileY)
+	}
+
+	members := make([]*Unit, 0, len(unitIDs))
+	inGroup := make(map[string]bool)
+	for _, unitID := range unitIDs {
+		unit := um.units[unitID]
+		if unit == nil {
+			return fmt.Errorf("%w: %s", ErrNotFound, unitID)
+		}
+		if !unit.IsAlive {
+			return fmt.Errorf("cannot move %s: %w", unitID, ErrUnitDead)
+		}
+		if unit.IsCarried() {
+			return fmt.Errorf("cannot move %s: %w", unitID, ErrCarried)
+		}
+		if !inGroup[unitID] {
+			inGroup[unitID] = true
+			members = append(members, unit)
+		}
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	// Tiles held by units outside the group can't be goals; the group's own tiles free up as it leaves
+	goals := systems.GroupGoalTiles(tileX, tileY, len(members), um.gameMap, func(x, y int) bool {
+		for _, unit := range um.spatialIndex.GetUnitsAtTile(x, y) {
+			if !inGroup[unit.ID] {
+				return true
+			}
+		}
+		return false
+	})
+	if len(goals) < len(members) {
+		return fmt.Errorf("%w: not enough free tiles around (%d, %d)", ErrOccupied, tileX, tileY)
+	}
+
+	starts := make([][2]int, len(members))
+	for i, unit := range members {
+		starts[i] = [2]int{unit.TileX, unit.TileY}
+	}
+	paths := systems.SharedGroupPaths(starts, goals, um.gameMap, members[0].movementSystem.PathOptions())
+
+	for i, unit := range members {
+		// Like MoveUnit, a group order interrupts construction, chases, queued moves and flow fields
+		unit.cancelBuildOrder()
+		unit.chaseTargetID = ""
+		unit.flowField = nil
+		unit.ClearMoveQueue()
+		unit.setHome(goals[i][0], goals[i][1])
+		if len(paths[i].Path) > 1 {
+			unit.movementSystem.FollowPath(unit, paths[i].Path)
+			unit.SetStatus(StatusMoving)
+			unit.LastMoved = time.Now()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package units
+
+// groupArrival is an OnGroupArrived callback waiting for the rest of its units to stop
+type groupArrival struct {
+	waiting map[string]bool // Units still walking to their destination
+	fn      func()
+}
+
+// OnGroupArrived registers fn to run once, after every listed unit has finished its current path,
+// died or been removed. Register it after issuing the group's move orders: units that aren't moving
+// then count as already arrived, and with none moving fn runs on the next Update
+func (um *UnitManager) OnGroupArrived(unitIDs []string, fn func()) {
+	waiting := make(map[string]bool, len(unitIDs))
+	for _, id := range unitIDs {
+		if unit := um.units[id]; unit != nil && unit.IsAlive && unit.IsMoving() {
+			waiting[id] = true
+		}
+	}
+	um.groupArrivals = append(um.groupArrivals, &groupArrival{waiting: waiting, fn: fn})
+}
+
+// notifyGroupArrivals drops units that stopped, died or were removed from every pending group and
+// fires the groups left with nobody walking, in registration order
+func (um *UnitManager) notifyGroupArrivals() {
+	if len(um.groupArrivals) == 0 {
+		return
+	}
+	
+	pending := um.groupArrivals[:0]
+	var done []*groupArrival
+	for _, group := range um.groupArrivals {
+		for id := range group.waiting {
+			if unit := um.units[id]; unit == nil || !unit.IsAlive || !unit.IsMoving() {
+				delete(group.waiting, id)
+			}
+		}
+		if len(group.waiting) == 0 {
+			done = append(done, group)
+		} else {
+			pending = append(pending, group)
+		}
+	}
+	um.groupArrivals = pending
+	
+	// Fire after updating the list so callbacks can safely register new groups
+	for _, group := range done {
+		group.fn()
+	}
+}
+
+// awaitingGroup reports whether a unit is still walking as part of a pending group arrival
+func (um *UnitManager) awaitingGroup(unitID string) bool {
+	for _, group := range um.groupArrivals {
+		if group.waiting[unitID] {
+			return true
+		}
+	}
+	return false
+}
+
+// showsDestinationMarker reports whether a unit's trail and destination marker are drawn: the selected
+// unit's, plus those of every unit a group arrival is waiting on, so a whole squad's targets show at once
+func (um *UnitManager) showsDestinationMarker(unit *Unit) bool {
+	return unit.ID == um.selectedUnitID || um.awaitingGroup(unit.ID)
+}
@@ -0,0 +1,88 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that a group callback fires once, only after the unit with the longest walk arrives
+func TestOnGroupArrivedWaitsForLastUnit(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(12, 12))
+	near, err := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	far, err := um.CreateUnit(entities.UnitWarrior, 1, 3, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	
+	um.MoveUnit(near.ID, 3, 1)
+	um.MoveUnit(far.ID, 10, 3)
+	calls := 0
+	um.OnGroupArrived([]string{near.ID, far.ID}, func() { calls++ })
+	
+	for frame := 0; frame < 1000 && far.IsMoving(); frame++ {
+		um.Update()
+		if far.IsMoving() && calls != 0 {
+			t.Fatalf("callback fired while %s was still walking at (%d, %d)", far.ID, far.TileX, far.TileY)
+		}
+	}
+	if near.IsMoving() || far.IsMoving() {
+		t.Fatalf("units still moving after 1000 frames")
+	}
+	
+	// Arrival is noticed on the frame the last unit stops
+	if calls != 1 {
+		t.Fatalf("calls = %d once both units stopped, want 1", calls)
+	}
+	um.MoveUnit(far.ID, 1, 3)
+	runUntilStopped(um, far)
+	if calls != 1 {
+		t.Errorf("calls = %d after a later move, want the callback to fire only once", calls)
+	}
+}
+
+// Test that removed, dead and unknown units count as arrived
+func TestOnGroupArrivedCountsMissingUnits(t *testing.T) {
+	tests := []struct {
+		name string
+		drop func(um *units.UnitManager, unit *units.Unit)
+	}{
+		{"removed", func(um *units.UnitManager, unit *units.Unit) { um.RemoveUnit(unit.ID) }},
+		{"killed", func(um *units.UnitManager, unit *units.Unit) { um.KillUnit(unit.ID) }},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			um := units.NewUnitManager(newTestMap(12, 12))
+			walker, err := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+			if err != nil {
+				t.Fatalf("CreateUnit failed: %v", err)
+			}
+			dropped, err := um.CreateUnit(entities.UnitWarrior, 1, 3, "")
+			if err != nil {
+				t.Fatalf("CreateUnit failed: %v", err)
+			}
+			
+			um.MoveUnit(walker.ID, 4, 1)
+			um.MoveUnit(dropped.ID, 10, 3)
+			calls := 0
+			um.OnGroupArrived([]string{walker.ID, dropped.ID, "unit_missing"}, func() { calls++ })
+			
+			runUntilStopped(um, walker)
+			if calls != 0 {
+				t.Fatalf("callback fired while %s was still walking", dropped.ID)
+			}
+			tt.drop(um, dropped)
+			um.Update()
+			if calls != 1 {
+				t.Errorf("calls = %d after the straggler was %s, want 1", calls, tt.name)
+			}
+		})
+	}
+}
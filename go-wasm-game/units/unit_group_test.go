@@ -0,0 +1,47 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"errors"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that a group order sends every member along a shared route to its own tile around the target
+func TestMoveGroupToTile(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(12, 12))
+	group := createUnits(t, um, 3)
+	ids := []string{group[0].ID, group[1].ID, group[2].ID}
+
+	if err := um.MoveGroupToTile(ids, 8, 8); err != nil {
+		t.Fatalf("MoveGroupToTile failed: %v", err)
+	}
+	for _, unit := range group {
+		if !unit.IsMoving() || unit.Status != units.StatusMoving {
+			t.Fatalf("%s moving = %v, status %q, want it moving", unit.ID, unit.IsMoving(), unit.Status)
+		}
+	}
+
+	for frame := 0; frame < 1000 && (group[0].IsMoving() || group[1].IsMoving() || group[2].IsMoving()); frame++ {
+		um.Update()
+	}
+	arrived := make(map[[2]int]bool)
+	for _, unit := range group {
+		if unit.IsMoving() {
+			t.Fatalf("%s never arrived", unit.ID)
+		}
+		if dx, dy := unit.TileX-8, unit.TileY-8; dx*dx > 1 || dy*dy > 1 {
+			t.Errorf("%s stopped at (%d, %d), want a tile next to (8, 8)", unit.ID, unit.TileX, unit.TileY)
+		}
+		arrived[[2]int{unit.TileX, unit.TileY}] = true
+	}
+	if len(arrived) != len(group) {
+		t.Errorf("group ended on %d distinct tiles, want %d", len(arrived), len(group))
+	}
+
+	if err := um.MoveGroupToTile([]string{ids[0], "missing"}, 2, 2); !errors.Is(err, units.ErrNotFound) {
+		t.Errorf("MoveGroupToTile() with a missing unit error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,33 @@
+package units
+
+// recordOccupancy counts a unit entering a tile for the occupancy heatmap
+func (um *UnitManager) recordOccupancy(tileX, tileY int) {
+	if tileX < 0 || tileX >= um.gameMap.Width || tileY < 0 || tileY >= um.gameMap.Height {
+		return
+	}
+	if um.occupancyCount == nil {
+		um.ResetHeatmap()
+	}
+	um.occupancyCount[tileY][tileX]++
+}
+
+// ExportHeatmap returns a copy of how many times units have entered each tile, indexed [y][x]
+// High counts reveal chokepoints and popular routes
+func (um *UnitManager) ExportHeatmap() [][]int {
+	heatmap := make([][]int, um.gameMap.Height)
+	for y := range heatmap {
+		heatmap[y] = make([]int, um.gameMap.Width)
+		if um.occupancyCount != nil {
+			copy(heatmap[y], um.occupancyCount[y])
+		}
+	}
+	return heatmap
+}
+
+// ResetHeatmap zeroes all occupancy counts
+func (um *UnitManager) ResetHeatmap() {
+	um.occupancyCount = make([][]int, um.gameMap.Height)
+	for y := range um.occupancyCount {
+		um.occupancyCount[y] = make([]int, um.gameMap.Width)
+	}
+}
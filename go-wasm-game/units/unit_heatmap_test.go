@@ -0,0 +1,63 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that a unit walking a row increments each tile it enters, and reset zeroes the grid
+func TestOccupancyHeatmap(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(8, 4))
+	unit, err := um.CreateUnit(entities.UnitWarrior, 1, 2, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	
+	if err := um.MoveUnit(unit.ID, 4, 2); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	for frame := 0; frame < 500 && unit.IsMoving(); frame++ {
+		um.Update()
+	}
+	
+	heatmap := um.ExportHeatmap()
+	if len(heatmap) != 4 || len(heatmap[0]) != 8 {
+		t.Fatalf("heatmap size = %dx%d, want 8x4", len(heatmap[0]), len(heatmap))
+	}
+	for x := 1; x <= 4; x++ {
+		if heatmap[2][x] != 1 {
+			t.Errorf("heatmap[2][%d] = %d, want 1", x, heatmap[2][x])
+		}
+	}
+	if heatmap[2][5] != 0 || heatmap[1][2] != 0 {
+		t.Error("tiles the unit never entered should stay at zero")
+	}
+	
+	// Walking back over the same tiles accumulates
+	um.MoveUnit(unit.ID, 2, 2)
+	for frame := 0; frame < 500 && unit.IsMoving(); frame++ {
+		um.Update()
+	}
+	if count := um.ExportHeatmap()[2][3]; count != 2 {
+		t.Errorf("heatmap[2][3] after walking back = %d, want 2", count)
+	}
+	
+	// Exported grids are copies
+	heatmap[2][1] = 99
+	if um.ExportHeatmap()[2][1] == 99 {
+		t.Error("modifying the exported heatmap should not affect the manager")
+	}
+	
+	um.ResetHeatmap()
+	for y, row := range um.ExportHeatmap() {
+		for x, count := range row {
+			if count != 0 {
+				t.Errorf("heatmap[%d][%d] = %d after reset, want 0", y, x, count)
+			}
+		}
+	}
+}
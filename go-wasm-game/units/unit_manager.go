@@ -2,6 +2,7 @@ package units
 
 import (
 	"fmt"
+	"math/rand"
 	"syscall/js"
 	"time"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
@@ -12,106 +13,55 @@ import (
 // UnitManager manages all units in the game
 type UnitManager struct {
 	units        map[string]*Unit
+	unitOrder    []string // Unit IDs in creation order (oldest first)
 	nextUnitID   int
 	gameMap      *world.Map
 	spatialIndex *UnitSpatialIndex
 	combatSystem *UnitCombatSystem
 	renderer     *UnitRenderer
+	selectedUnitID string // Currently selected unit ("" if none)
+	clock        Clock
+	combatQueue  []CombatAction // Attacks queued this frame, resolved in initiative order
+	occupancyCount [][]int      // [y][x] -> times a unit has entered the tile, nil until first use
+	rng          *rand.Rand     // Random source for spawning; seeded on first use unless set via SetRand
+	threatMaps   map[int]*cachedThreatMap // Per-team threat maps reused until ThreatMapInterval passes
+	threatWeight float64        // Path cost per unit of enemy threat, 0 = units ignore threat
+	spawnTicks   int            // Grow-in duration given to new units, see SetSpawnTicks
+	localAvoidance bool         // Whether units steer around each other, see SetLocalAvoidance
+	spacing      IdleSpacing    // Spread pass settings for crowded idle units, off by default
+	groupArrivals []*groupArrival // Pending OnGroupArrived callbacks, in registration order
+	RallyPoint   *struct{ X, Y int } // Tile units spawned from the build queue walk to once grown in, nil to leave them in place
+	reconcileInterval time.Duration  // Time between spatial index Reconcile passes, 0 = off, see SetSpatialReconcile
+	lastReconcile time.Time          // When the spatial index was last reconciled
+	elapsed      time.Duration       // Simulated time advanced by UpdateDT, read by the default clock
+	planners     *systems.DStarPlanners // Incremental planners shared per destination, see RepathAfterTileChange
 }
 
+// simulationEpoch is what the default clock reads before any simulated time has passed
+var simulationEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
 // NewUnitManager creates a new unit manager
+// Its clock counts simulated time, so cooldowns and timers stop while the game is paused and
+// come out the same at any frame rate; SetClock replaces it. Map edits keep its shared planners current
 func NewUnitManager(gameMap *world.Map) *UnitManager {
-	return &UnitManager{
+	um := &UnitManager{
 		units:        make(map[string]*Unit),
+		unitOrder:    make([]string, 0),
 		nextUnitID:   1,
 		gameMap:      gameMap,
 		spatialIndex: NewUnitSpatialIndex(),
 		combatSystem: NewUnitCombatSystem(),
 		renderer:     NewUnitRenderer(gameMap),
+		spawnTicks:   DefaultSpawnTicks,
 	}
+	um.clock = um.simulationTime
+	gameMap.OnTileChange(um.tileChanged)
+	return um
 }
 
-// CreateUnit creates a new unit at the specified tile coordinates
-func (um *UnitManager) CreateUnit(unitType entities.UnitType, tileX, tileY int, name string) (*Unit, error) {
-	// Validate position
-	if err := um.validatePosition(tileX, tileY); err != nil {
-		return nil, err
-	}
-
-	// Get unit type definition
-	typeDef, exists := entities.UnitTypeDefinitions[unitType]
-	if !exists {
-		return nil, fmt.Errorf("unknown unit type: %v", unitType)
-	}
-
-	// Generate unit ID and name
-	unitID := fmt.Sprintf("unit_%d", um.nextUnitID)
-	um.nextUnitID++
-
-	if name == "" {
-		name = fmt.Sprintf("%s #%d", typeDef.Name, um.nextUnitID-1)
-	}
-
-	// Calculate world position from tile coordinates
-	worldX, worldY := um.gameMap.GridToWorld(tileX, tileY)
-	unitWidth, unitHeight := 16.0, 16.0 // Standard unit size
-
-	// Create and register unit
-	unit := &Unit{
-		ID:           unitID,
-		TypeID:       unitType,
-		Name:         name,
-		TileX:        tileX,
-		TileY:        tileY,
-		CurrentStats: typeDef.Stats,
-		MaxStats:     typeDef.Stats,
-		Level:        1,
-		Experience:   0,
-		IsAlive:      true,
-		Status:       "idle",
-		CreatedAt:    time.Now(),
-		LastMoved:    time.Now(),
-		MovableEntity: systems.MovableEntity{
-			X:         worldX - unitWidth/2,
-			Y:         worldY - unitHeight/2,
-			Width:     unitWidth,
-			Height:    unitHeight,
-			TargetX:   worldX - unitWidth/2,
-			TargetY:   worldY - unitHeight/2,
-			IsMovingFlag: false,
-			MoveSpeed: 2.0, // Slightly slower than player
-			Path:      nil,
-			PathStep:  0,
-		},
-		movementSystem: systems.NewMovementSystem(um.gameMap),
-	}
-
-	um.units[unitID] = unit
-	um.spatialIndex.AddUnit(unit)
-
-	return unit, nil
-}
-
-// validatePosition checks if a position is valid for unit placement
-func (um *UnitManager) validatePosition(tileX, tileY int) error {
-	// Check bounds
-	if tileX < 0 || tileX >= um.gameMap.Width || tileY < 0 || tileY >= um.gameMap.Height {
-		return fmt.Errorf("tile coordinates out of bounds: (%d, %d)", tileX, tileY)
-	}
-
-	// Check walkability
-	tileType := um.gameMap.GetTile(tileX, tileY)
-	tileDef, exists := world.TileDefinitions[tileType]
-	if !exists || !tileDef.Walkable {
-		return fmt.Errorf("cannot place unit on non-walkable tile at (%d, %d)", tileX, tileY)
-	}
-
-	// Check occupation
-	if um.spatialIndex.IsPositionOccupied(tileX, tileY) {
-		return fmt.Errorf("tile already occupied at (%d, %d)", tileX, tileY)
-	}
-
-	return nil
+// simulationTime is the default clock: simulationEpoch plus the simulated time UpdateDT has covered
+func (um *UnitManager) simulationTime() time.Time {
+	return simulationEpoch.Add(um.elapsed)
 }
 
 // GetUnit retrieves a unit by ID
@@ -138,15 +88,19 @@ func (um *UnitManager) IsPositionOccupied(tileX, tileY int) bool {
 	return um.spatialIndex.IsPositionOccupied(tileX, tileY)
 }
 
-// MoveUnit moves a unit to a new tile position using the unified movement system
+// MoveUnit moves a unit to a new tile position using the unified movement system, routing around
+// tiles other units currently hold when it can
 func (um *UnitManager) MoveUnit(unitID string, tileX, tileY int) error {
 	unit := um.units[unitID]
 	if unit == nil {
-		return fmt.Errorf("unit not found: %s", unitID)
+		return fmt.Errorf("%w: %s", ErrNotFound, unitID)
 	}
 
 	if !unit.IsAlive {
-		return fmt.Errorf("cannot move dead unit: %s", unitID)
+		return fmt.Errorf("cannot move %s: %w", unitID, ErrUnitDead)
+	}
+	if unit.IsCarried() {
+		return fmt.Errorf("cannot move %s: %w", unitID, ErrCarried)
 	}
 
 	// Skip validation if moving to same position
@@ -154,88 +108,75 @@ func (um *UnitManager) MoveUnit(unitID string, tileX, tileY int) error {
 		return nil
 	}
 
-	// Use the unified movement system for pathfinding-based movement
-	unit.MoveToTile(tileX, tileY)
+	// A direct move order interrupts any construction in progress, automatic chase or queued moves
+	unit.cancelBuildOrder()
+	unit.chaseTargetID = ""
+	unit.ClearMoveQueue()
+	unit.setHome(tileX, tileY)
+	unit.moveToTileAvoiding(tileX, tileY, um.occupiedByOthers(unit, tileX, tileY))
 	unit.LastMoved = time.Now()
 
 	return nil
 }
 
-// Update all units using the unified movement system
+// Update all units for one systems.FrameDuration; see UpdateDT
 func (um *UnitManager) Update() {
-	for _, unit := range um.units {
-		if unit.IsAlive {
+	um.UpdateDT(systems.FrameDuration)
+}
+
+// UpdateDT moves all units for dt of simulated time using the unified movement system, oldest first
+func (um *UnitManager) UpdateDT(dt time.Duration) {
+	um.elapsed += dt
+	for _, unit := range um.OrderedUnits() {
+		if unit.IsAlive && !unit.IsCarried() {
 			oldX, oldY := unit.TileX, unit.TileY
-			unit.Update()
+			unit.Update(dt)
 			// Update spatial index if position changed
 			if unit.TileX != oldX || unit.TileY != oldY {
 				um.spatialIndex.UpdateUnitPosition(unit, oldX, oldY, unit.TileX, unit.TileY)
+				um.recordOccupancy(unit.TileX, unit.TileY)
+				um.gameMap.RecordTraffic(unit.TileX, unit.TileY)
 			}
+			um.processBuildOrder(unit)
+			um.sendToRally(unit)
 		}
 	}
+	um.notifyGroupArrivals()
+	um.updateTransports()
+	
+	// Old traffic fades so only currently popular routes stay cheap
+	um.gameMap.DecayTraffic()
+	
+	// Queue automatic attacks per stance, then resolve this frame's attacks deterministically
+	um.updateStances()
+	um.resolveCombatQueue()
+	um.spreadIdleUnits()
+	um.reconcileSpatialIndex()
 }
 
 // RemoveUnit removes a unit from the game
 func (um *UnitManager) RemoveUnit(unitID string) error {
 	unit := um.units[unitID]
 	if unit == nil {
-		return fmt.Errorf("unit not found: %s", unitID)
+		return fmt.Errorf("%w: %s", ErrNotFound, unitID)
 	}
 
-	// Remove from spatial index
+	// Unload any passengers, then remove from spatial index
+	um.detachCargo(unit)
 	um.spatialIndex.RemoveUnit(unit)
 
-	// Remove from units map
+	// Remove from units map and creation order; a pending arrival callback will never fire
+	unit.onArrival = nil
 	delete(um.units, unitID)
-
-	return nil
-}
-
-// DamageUnit applies damage to a unit
-func (um *UnitManager) DamageUnit(unitID string, damage int) error {
-	unit := um.units[unitID]
-	if unit == nil {
-		return fmt.Errorf("unit not found: %s", unitID)
-	}
-
-	return um.combatSystem.DamageUnit(unit, damage)
-}
-
-// HealUnit restores health to a unit
-func (um *UnitManager) HealUnit(unitID string, healAmount int) error {
-	unit := um.units[unitID]
-	if unit == nil {
-		return fmt.Errorf("unit not found: %s", unitID)
-	}
-
-	return um.combatSystem.HealUnit(unit, healAmount)
-}
-
-// GetUnitTypeCounts returns the count of each unit type
-func (um *UnitManager) GetUnitTypeCounts() map[entities.UnitType]int {
-	counts := make(map[entities.UnitType]int)
-	
-	for _, unit := range um.units {
-		if unit.IsAlive {
-			counts[unit.TypeID]++
-		}
+	um.removeFromOrder(unitID)
+	if um.selectedUnitID == unitID {
+		um.selectedUnitID = ""
 	}
-	
-	return counts
-}
 
-// GetTotalUnitCount returns the total number of alive units
-func (um *UnitManager) GetTotalUnitCount() int {
-	count := 0
-	for _, unit := range um.units {
-		if unit.IsAlive {
-			count++
-		}
-	}
-	return count
+	return nil
 }
 
-// Render draws all units on the screen
-func (um *UnitManager) Render(ctx js.Value, cameraX, cameraY float64) {
-	um.renderer.RenderUnits(ctx, um.units, cameraX, cameraY)
+// Render draws all units, plus any other renderables (e.g. the player), depth-sorted on the screen
+func (um *UnitManager) Render(ctx js.Value, cameraX, cameraY float64, others ...entities.Renderable) {
+	um.renderer.RenderUnits(ctx, um.OrderedUnits(), um.Renderables(others...), um.showsDestinationMarker, cameraX, cameraY)
 }
\ No newline at end of file
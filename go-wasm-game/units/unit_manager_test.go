@@ -0,0 +1,219 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Package units_test provides unit tests for the unit management system.
+// These tests exercise the manager against small hand-built maps and run
+// under the js/wasm target (via go_js_wasm_exec) because the units package
+// depends on syscall/js for rendering.
+package units_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newTestMap builds an all-grass map without running terrain generation
+func newTestMap(width, height int) *world.Map {
+	m := &world.Map{
+		Width:    width,
+		Height:   height,
+		TileSize: 32.0,
+		Tiles:    make([][]world.TileType, height),
+	}
+	for y := range m.Tiles {
+		m.Tiles[y] = make([]world.TileType, width)
+	}
+	return m
+}
+
+// createUnits creates count warriors along the first row of the map
+func createUnits(t *testing.T, um *units.UnitManager, count int) []*units.Unit {
+	t.Helper()
+	created := make([]*units.Unit, 0, count)
+	for i := 0; i < count; i++ {
+		unit, err := um.CreateUnit(entities.UnitWarrior, i, 0, "")
+		if err != nil {
+			t.Fatalf("CreateUnit(%d, 0) failed: %v", i, err)
+		}
+		created = append(created, unit)
+	}
+	return created
+}
+
+// Test that RemoveNewestUnit removes units in exact LIFO order even when timestamps collide
+func TestRemoveNewestUnitIsLIFO(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	created := createUnits(t, um, 5)
+	
+	// Give every unit the same timestamp so a CreatedAt scan would be ambiguous
+	sameTime := time.Now()
+	for _, unit := range created {
+		unit.CreatedAt = sameTime
+	}
+	
+	for i := len(created) - 1; i >= 1; i-- {
+		if err := um.RemoveNewestUnit(); err != nil {
+			t.Fatalf("RemoveNewestUnit() failed: %v", err)
+		}
+		if um.GetUnit(created[i].ID) != nil {
+			t.Errorf("expected %s to be removed, but it still exists", created[i].ID)
+		}
+		if um.GetUnit(created[i-1].ID) == nil {
+			t.Errorf("expected %s to remain after removing %s", created[i-1].ID, created[i].ID)
+		}
+	}
+	
+	// The last unit is protected by the minimum-unit rule
+	if err := um.RemoveNewestUnit(); err == nil {
+		t.Error("RemoveNewestUnit() should refuse to remove the last unit")
+	}
+}
+
+// Test that the creation order stays consistent after arbitrary removals
+func TestUnitOrderAfterArbitraryRemovals(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	created := createUnits(t, um, 6)
+	
+	// Remove from the middle, the front and the back
+	for _, index := range []int{2, 0, 5} {
+		if err := um.RemoveUnit(created[index].ID); err != nil {
+			t.Fatalf("RemoveUnit(%s) failed: %v", created[index].ID, err)
+		}
+	}
+	
+	expected := []string{created[1].ID, created[3].ID, created[4].ID}
+	order := um.GetUnitIDsInOrder()
+	if len(order) != len(expected) {
+		t.Fatalf("GetUnitIDsInOrder() = %v, want %v", order, expected)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("GetUnitIDsInOrder()[%d] = %s, want %s", i, order[i], expected[i])
+		}
+	}
+	
+	// New units are appended after the survivors
+	unit, err := um.CreateUnit(entities.UnitArcher, 0, 1, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	order = um.GetUnitIDsInOrder()
+	if order[len(order)-1] != unit.ID {
+		t.Errorf("newest unit should be last in order, got %v", order)
+	}
+	
+	// Removing the newest now targets the freshly created unit
+	if err := um.RemoveNewestUnit(); err != nil {
+		t.Fatalf("RemoveNewestUnit() failed: %v", err)
+	}
+	if um.GetUnit(unit.ID) != nil {
+		t.Errorf("RemoveNewestUnit() should have removed %s", unit.ID)
+	}
+	if len(um.GetUnitIDsInOrder()) != um.GetTotalUnitCount() {
+		t.Errorf("order length %d does not match unit count %d", len(um.GetUnitIDsInOrder()), um.GetTotalUnitCount())
+	}
+}
+
+// Test that OrderedUnits follows creation order, is stable across calls and keeps dead units
+func TestOrderedUnits(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	created := createUnits(t, um, 8)
+	um.RemoveUnit(created[3].ID)
+	created[5].IsAlive = false
+	expected := append(append([]*units.Unit{}, created[:3]...), created[4:]...)
+	
+	for call := 0; call < 20; call++ {
+		ordered := um.OrderedUnits()
+		if len(ordered) != len(expected) {
+			t.Fatalf("call %d: OrderedUnits() has %d units, want %d", call, len(ordered), len(expected))
+		}
+		for i := range expected {
+			if ordered[i] != expected[i] {
+				t.Fatalf("call %d: OrderedUnits()[%d] = %s, want %s", call, i, ordered[i].ID, expected[i].ID)
+			}
+		}
+	}
+	
+	// Callers may reorder the result without touching the manager's order
+	ordered := um.OrderedUnits()
+	ordered[0], ordered[1] = ordered[1], ordered[0]
+	if um.OrderedUnits()[0] != created[0] {
+		t.Errorf("reordering the returned slice changed the manager's order")
+	}
+}
+
+// Test that placement and lookup failures return matchable sentinel errors
+func TestUnitManagerSentinelErrors(t *testing.T) {
+	gameMap := newTestMap(10, 10)
+	gameMap.SetTile(4, 4, world.TileWater)
+	um := units.NewUnitManager(gameMap)
+	createUnits(t, um, 1)
+	
+	_, errBounds := um.CreateUnit(entities.UnitWarrior, -1, 3, "")
+	_, errWater := um.CreateUnit(entities.UnitWarrior, 4, 4, "")
+	_, errOccupied := um.CreateUnit(entities.UnitWarrior, 0, 0, "")
+	
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"Out of bounds", errBounds, units.ErrOutOfBounds},
+		{"Not walkable", errWater, units.ErrNotWalkable},
+		{"Occupied", errOccupied, units.ErrOccupied},
+		{"Move missing unit", um.MoveUnit("unit_404", 1, 1), units.ErrNotFound},
+		{"Remove missing unit", um.RemoveUnit("unit_404"), units.ErrNotFound},
+		{"Damage missing unit", um.DamageUnit("unit_404", 10), units.ErrNotFound},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.expected) {
+				t.Errorf("error = %v, want errors.Is(%v)", tt.err, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that a unit's movable footprint follows its appearance size, or an explicit FootprintSize
+func TestUnitFootprintMatchesAppearance(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	for i, unitType := range []entities.UnitType{entities.UnitWarrior, entities.UnitArcher, entities.UnitMage, entities.UnitScout} {
+		unit, err := um.CreateUnit(unitType, i*2, 0, "")
+		if err != nil {
+			t.Fatalf("CreateUnit(%v) failed: %v", unitType, err)
+		}
+		size := entities.UnitTypeDefinitions[unitType].Appearance.Size
+		if width, height := unit.GetSize(); width != size || height != size {
+			t.Errorf("%v footprint = %vx%v, want %vx%v", unitType, width, height, size, size)
+		}
+	}
+	
+	// A giant whose footprint spills past its tile can't stand next to water
+	const unitGiant entities.UnitType = 99
+	giant := entities.UnitTypeDefinitions[entities.UnitWarrior]
+	giant.Appearance.FootprintSize = 48.0
+	entities.UnitTypeDefinitions[unitGiant] = giant
+	defer delete(entities.UnitTypeDefinitions, unitGiant)
+	
+	gameMap := newTestMap(10, 10)
+	gameMap.SetTile(6, 6, world.TileWater)
+	um = units.NewUnitManager(gameMap)
+	unit, err := um.CreateUnit(unitGiant, 2, 2, "")
+	if err != nil {
+		t.Fatalf("CreateUnit(giant) failed: %v", err)
+	}
+	if width, height := unit.GetSize(); width != 48.0 || height != 48.0 {
+		t.Errorf("giant footprint = %vx%v, want 48x48", width, height)
+	}
+	if _, err := um.CreateUnit(unitGiant, 5, 5, ""); !errors.Is(err, units.ErrNotWalkable) {
+		t.Errorf("CreateUnit(giant beside water) error = %v, want ErrNotWalkable", err)
+	}
+	if _, err := um.CreateUnit(entities.UnitWarrior, 5, 5, ""); err != nil {
+		t.Errorf("a warrior's footprint fits beside water, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package units
+
+import (
+	"syscall/js"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// destinationMarkerSize is the half-width of the 'X' drawn on a destination tile
+const destinationMarkerSize = 6.0
+
+// renderDestinationMarkers draws the remaining trail and an 'X' on the final tile of each moving unit showMarker picks
+// Other units are skipped to keep the map readable when many units are moving
+func (renderer *UnitRenderer) renderDestinationMarkers(ctx js.Value, units []*Unit, showMarker func(*Unit) bool, cameraX, cameraY float64) {
+	for _, unit := range units {
+		if !unit.IsAlive || !unit.IsMoving() || !showMarker(unit) {
+			continue
+		}
+		
+		path := unit.GetPath()
+		destX, destY, ok := systems.DestinationScreenPoint(path, renderer.gameMap, cameraX, cameraY)
+		if !ok {
+			continue
+		}
+		
+		// Trail from the unit through the steps it hasn't reached yet
+		ctx.Set("strokeStyle", "rgba(255, 255, 255, 0.6)")
+		ctx.Set("lineWidth", 2)
+		ctx.Call("beginPath")
+		unitX, unitY := systems.TileToScreen(renderer.gameMap, unit.TileX, unit.TileY, cameraX, cameraY)
+		ctx.Call("moveTo", unitX, unitY)
+		for i := unit.GetPathStep(); i < len(path); i++ {
+			stepX, stepY := systems.TileToScreen(renderer.gameMap, path[i].X, path[i].Y, cameraX, cameraY)
+			ctx.Call("lineTo", stepX, stepY)
+		}
+		ctx.Call("stroke")
+		
+		// Destination 'X'
+		ctx.Set("strokeStyle", "#FF0000")
+		ctx.Set("lineWidth", 3)
+		ctx.Call("beginPath")
+		ctx.Call("moveTo", destX-destinationMarkerSize, destY-destinationMarkerSize)
+		ctx.Call("lineTo", destX+destinationMarkerSize, destY+destinationMarkerSize)
+		ctx.Call("moveTo", destX+destinationMarkerSize, destY-destinationMarkerSize)
+		ctx.Call("lineTo", destX-destinationMarkerSize, destY+destinationMarkerSize)
+		ctx.Call("stroke")
+	}
+}
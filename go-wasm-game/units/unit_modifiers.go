@@ -0,0 +1,108 @@
+package units
+
+import (
+	"math"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+)
+
+// Stat names a unit stat that modifiers can change
+type Stat int
+
+const (
+	StatDamage Stat = iota
+	StatSpeed
+	StatDefense
+	StatRange
+)
+
+// StatModifier changes one stat on top of the unit's base (MaxStats) value, e.g. from an item or buff
+// Within a stat all Add values are summed first, then the total is scaled by every Multiply in turn
+type StatModifier struct {
+	Source   string    // What granted it (e.g. "rage potion"), used to remove it again
+	Stat     Stat
+	Add      float64   // Flat bonus, e.g. +5 damage
+	Multiply float64   // Scale factor, e.g. 1.2 for +20%; 0 means no scaling
+	Until    time.Time // When the modifier expires; zero never expires
+}
+
+// AddModifier attaches a stat modifier to the unit
+func (u *Unit) AddModifier(modifier StatModifier) {
+	u.Modifiers = append(u.Modifiers, modifier)
+}
+
+// AddTimedModifier attaches a stat modifier that expires after duration
+func (u *Unit) AddTimedModifier(modifier StatModifier, duration time.Duration) {
+	modifier.Until = u.now().Add(duration)
+	u.AddModifier(modifier)
+}
+
+// RemoveModifiers drops every modifier granted by source
+func (u *Unit) RemoveModifiers(source string) {
+	kept := u.Modifiers[:0]
+	for _, modifier := range u.Modifiers {
+		if modifier.Source != source {
+			kept = append(kept, modifier)
+		}
+	}
+	u.Modifiers = kept
+}
+
+// EffectiveStats returns the stats combat and movement use: MaxStats with every active modifier applied
+// Health is always the unit's current health, which modifiers never change
+func (u *Unit) EffectiveStats() entities.UnitStats {
+	stats := u.MaxStats
+	stats.Health = u.CurrentStats.Health
+	if len(u.Modifiers) == 0 {
+		return stats
+	}
+	
+	now := u.now()
+	stats.Damage = u.modifiedStat(StatDamage, stats.Damage, now)
+	stats.Speed = u.modifiedStat(StatSpeed, stats.Speed, now)
+	stats.Defense = u.modifiedStat(StatDefense, stats.Defense, now)
+	stats.Range = u.modifiedStat(StatRange, stats.Range, now)
+	return stats
+}
+
+// GetMoveSpeed scales the unit's walking speed by how much its Speed stat is buffed or debuffed
+func (u *Unit) GetMoveSpeed() float64 {
+	if u.MaxStats.Speed <= 0 {
+		return u.MovableEntity.GetMoveSpeed()
+	}
+	return u.MovableEntity.GetMoveSpeed() * float64(u.EffectiveStats().Speed) / float64(u.MaxStats.Speed)
+}
+
+// modifiedStat applies the active modifiers for one stat to its base value, never going below 0
+func (u *Unit) modifiedStat(stat Stat, base int, now time.Time) int {
+	value, scale := float64(base), 1.0
+	for _, modifier := range u.Modifiers {
+		if modifier.Stat != stat || modifier.expired(now) {
+			continue
+		}
+		value += modifier.Add
+		if modifier.Multiply != 0 {
+			scale *= modifier.Multiply
+		}
+	}
+	return int(math.Max(0, math.Round(value*scale)))
+}
+
+// pruneModifiers forgets modifiers that have expired
+func (u *Unit) pruneModifiers() {
+	if len(u.Modifiers) == 0 {
+		return
+	}
+	now := u.now()
+	kept := u.Modifiers[:0]
+	for _, modifier := range u.Modifiers {
+		if !modifier.expired(now) {
+			kept = append(kept, modifier)
+		}
+	}
+	u.Modifiers = kept
+}
+
+func (m StatModifier) expired(now time.Time) bool {
+	return !m.Until.IsZero() && !now.Before(m.Until)
+}
@@ -0,0 +1,94 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that flat bonuses are summed before multipliers scale the total
+func TestEffectiveStatsStacking(t *testing.T) {
+	tests := []struct {
+		name      string
+		modifiers []units.StatModifier
+		want      int
+	}{
+		{"no modifiers", nil, 25},
+		{"one flat bonus", []units.StatModifier{{Stat: units.StatDamage, Add: 5}}, 30},
+		{"flat bonuses add", []units.StatModifier{{Stat: units.StatDamage, Add: 5}, {Stat: units.StatDamage, Add: 10}}, 40},
+		{"+20% buff", []units.StatModifier{{Stat: units.StatDamage, Multiply: 1.2}}, 30},
+		{"multipliers compound", []units.StatModifier{{Stat: units.StatDamage, Multiply: 1.2}, {Stat: units.StatDamage, Multiply: 2}}, 60},
+		{"flat then scaled", []units.StatModifier{{Stat: units.StatDamage, Multiply: 2}, {Stat: units.StatDamage, Add: 5}}, 60},
+		{"other stats ignored", []units.StatModifier{{Stat: units.StatDefense, Add: 100}}, 25},
+		{"never negative", []units.StatModifier{{Stat: units.StatDamage, Add: -100}}, 0},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			um := units.NewUnitManager(newTestMap(5, 5))
+			warrior := createUnits(t, um, 1)[0]
+			for _, modifier := range tt.modifiers {
+				warrior.AddModifier(modifier)
+			}
+			if got := warrior.EffectiveStats().Damage; got != tt.want {
+				t.Errorf("EffectiveStats().Damage = %d, want %d", got, tt.want)
+			}
+			if warrior.MaxStats.Damage != 25 {
+				t.Errorf("MaxStats.Damage = %d, modifiers must leave base stats alone", warrior.MaxStats.Damage)
+			}
+		})
+	}
+}
+
+// Test that expired modifiers no longer count and are dropped on the next update
+func TestExpiredModifiersExcluded(t *testing.T) {
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	um := units.NewUnitManager(newTestMap(5, 5))
+	um.SetClock(clock.Now)
+	warrior := createUnits(t, um, 1)[0]
+	
+	warrior.AddTimedModifier(units.StatModifier{Source: "rage", Stat: units.StatDamage, Multiply: 1.2}, 5*time.Second)
+	warrior.AddModifier(units.StatModifier{Source: "sword", Stat: units.StatDamage, Add: 5})
+	if got := warrior.EffectiveStats().Damage; got != 36 {
+		t.Fatalf("buffed damage = %d, want (25 + 5) * 1.2 = 36", got)
+	}
+	
+	clock.Advance(5 * time.Second)
+	if got := warrior.EffectiveStats().Damage; got != 30 {
+		t.Errorf("damage after the buff expired = %d, want 30", got)
+	}
+	um.Update()
+	if len(warrior.Modifiers) != 1 || warrior.Modifiers[0].Source != "sword" {
+		t.Errorf("Modifiers after update = %v, want only the permanent sword bonus", warrior.Modifiers)
+	}
+	
+	warrior.RemoveModifiers("sword")
+	if got := warrior.EffectiveStats().Damage; got != 25 || len(warrior.Modifiers) != 0 {
+		t.Errorf("after RemoveModifiers damage = %d with %d modifiers, want base 25 and none", got, len(warrior.Modifiers))
+	}
+}
+
+// Test that combat hits with effective damage and movement follows the effective speed
+func TestCombatAndMovementUseEffectiveStats(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	warrior := createUnits(t, um, 1)[0]
+	enemy := createEnemy(t, um, 1, 0)
+	um.SetStance(enemy.ID, units.StancePassive)
+	
+	warrior.AddModifier(units.StatModifier{Stat: units.StatDamage, Multiply: 2})
+	before := enemy.CurrentStats.Health
+	um.QueueAttack(warrior.ID, enemy.ID)
+	um.Update()
+	if dealt, want := before-enemy.CurrentStats.Health, 50-enemy.MaxStats.Defense; dealt < want {
+		t.Errorf("buffed hit dealt %d damage, want at least %d", dealt, want)
+	}
+	
+	baseSpeed := warrior.GetMoveSpeed()
+	warrior.AddModifier(units.StatModifier{Stat: units.StatSpeed, Multiply: 1.5})
+	if got := warrior.GetMoveSpeed(); got != baseSpeed*1.5 {
+		t.Errorf("hasted GetMoveSpeed() = %v, want %v", got, baseSpeed*1.5)
+	}
+}
@@ -0,0 +1,39 @@
+package units
+
+// GetUnitIDsInOrder returns all unit IDs in creation order (oldest first)
+func (um *UnitManager) GetUnitIDsInOrder() []string {
+	result := make([]string, len(um.unitOrder))
+	copy(result, um.unitOrder)
+	return result
+}
+
+// OrderedUnits returns every tracked unit in creation order (oldest first), dead ones included
+// Anything whose outcome depends on visiting order (movement, combat, AI, rendering) should iterate this
+// instead of ranging over the unit map, so runs are reproducible
+func (um *UnitManager) OrderedUnits() []*Unit {
+	result := make([]*Unit, 0, len(um.unitOrder))
+	for _, id := range um.unitOrder {
+		if unit := um.units[id]; unit != nil {
+			result = append(result, unit)
+		}
+	}
+	return result
+}
+
+// newestUnitID returns the ID of the most recently created unit still tracked
+func (um *UnitManager) newestUnitID() (string, bool) {
+	if len(um.unitOrder) == 0 {
+		return "", false
+	}
+	return um.unitOrder[len(um.unitOrder)-1], true
+}
+
+// removeFromOrder drops a unit ID from the creation order, preserving the order of the rest
+func (um *UnitManager) removeFromOrder(unitID string) {
+	for i, id := range um.unitOrder {
+		if id == unitID {
+			um.unitOrder = append(um.unitOrder[:i], um.unitOrder[i+1:]...)
+			return
+		}
+	}
+}
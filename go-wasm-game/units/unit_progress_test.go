@@ -0,0 +1,48 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that path progress starts at 0, only grows while walking and reads 1 once the unit arrives
+func TestUnitPathProgress(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	unit, err := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	if got := unit.PathProgress(); got != 1 {
+		t.Errorf("idle PathProgress() = %v, want 1", got)
+	}
+
+	if err := um.MoveUnit(unit.ID, 6, 1); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	if got := unit.PathProgress(); got != 0 {
+		t.Errorf("PathProgress() right after MoveUnit = %v, want 0", got)
+	}
+
+	last, sawMiddle := 0.0, false
+	for frame := 0; frame < 500 && unit.IsMoving(); frame++ {
+		um.Update()
+		progress := unit.PathProgress()
+		if progress < last {
+			t.Fatalf("PathProgress() went back from %v to %v", last, progress)
+		}
+		if progress > 0.4 && progress < 0.6 {
+			sawMiddle = true
+		}
+		last = progress
+	}
+	if !sawMiddle {
+		t.Errorf("PathProgress() never reported the middle of the path")
+	}
+	if got := unit.PathProgress(); unit.IsMoving() || got != 1 {
+		t.Errorf("after arriving moving = %v, PathProgress() = %v, want stopped at 1", unit.IsMoving(), got)
+	}
+}
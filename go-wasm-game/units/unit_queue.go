@@ -0,0 +1,40 @@
+package units
+
+import "fmt"
+
+// QueueMove adds a destination for a unit to path to after its current move
+// An idle unit with nothing queued starts moving right away
+func (um *UnitManager) QueueMove(unitID string, tileX, tileY int) error {
+	unit := um.units[unitID]
+	if unit == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, unitID)
+	}
+	if !unit.IsAlive {
+		return fmt.Errorf("cannot move %s: %w", unitID, ErrUnitDead)
+	}
+	if unit.IsCarried() {
+		return fmt.Errorf("cannot move %s: %w", unitID, ErrCarried)
+	}
+	if tileX < 0 || tileX >= um.gameMap.Width || tileY < 0 || tileY >= um.gameMap.Height {
+		return fmt.Errorf("%w: (%d, %d)", ErrOutOfBounds, tileX, tileY)
+	}
+
+	if !unit.IsMoving() && len(unit.MoveQueue) == 0 {
+		return um.MoveUnit(unitID, tileX, tileY)
+	}
+	if !unit.EnqueueMove(tileX, tileY) {
+		return fmt.Errorf("%w: %s already has %d moves queued", ErrQueueFull, unitID, unit.QueueLimit())
+	}
+	return nil
+}
+
+// advanceMoveQueue starts the next queued move once the unit has stopped
+func (u *Unit) advanceMoveQueue() {
+	if u.IsMoving() {
+		return
+	}
+	if next, ok := u.NextQueuedMove(); ok {
+		u.setHome(next.X, next.Y)
+		u.MoveToTile(next.X, next.Y)
+	}
+}
@@ -0,0 +1,47 @@
+package units
+
+import "fmt"
+
+// SetRallyPoint makes units spawned from the build queue walk to a tile once they have finished spawning
+// (see RallyOnceSpawned); units loaded from saves or created by scripts stay where they are created
+func (um *UnitManager) SetRallyPoint(tileX, tileY int) error {
+	if tileX < 0 || tileX >= um.gameMap.Width || tileY < 0 || tileY >= um.gameMap.Height {
+		return fmt.Errorf("%w: (%d, %d)", ErrOutOfBounds, tileX, tileY)
+	}
+	um.RallyPoint = &struct{ X, Y int }{X: tileX, Y: tileY}
+	return nil
+}
+
+// ClearRallyPoint lets newly spawned units stay where they spawned
+func (um *UnitManager) ClearRallyPoint() {
+	um.RallyPoint = nil
+}
+
+// RallyOnceSpawned marks a unit just spawned from the build queue (the spawn button or a placement click)
+// to walk to the rally point once it finishes its grow-in, if a rally point is set by then
+func (um *UnitManager) RallyOnceSpawned(unitID string) error {
+	unit := um.units[unitID]
+	if unit == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, unitID)
+	}
+	unit.awaitingRally = true
+	return nil
+}
+
+// AwaitingRally reports whether the unit is still to be sent to the rally point once grown in
+func (u *Unit) AwaitingRally() bool {
+	return u.awaitingRally
+}
+
+// sendToRally orders a unit that just finished its grow-in to the rally point, once per unit
+// Units already given orders meanwhile (a move, a queued move or a build) keep them
+func (um *UnitManager) sendToRally(unit *Unit) {
+	if !unit.awaitingRally || unit.SpawnTicks > 0 {
+		return
+	}
+	unit.awaitingRally = false
+	if um.RallyPoint == nil || unit.IsMoving() || len(unit.MoveQueue) > 0 || unit.BuildOrder != nil {
+		return
+	}
+	um.MoveUnit(unit.ID, um.RallyPoint.X, um.RallyPoint.Y)
+}
@@ -0,0 +1,93 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"errors"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that a unit spawned from the build queue walks to the rally point once it finishes spawning,
+// and stays put without one or when created some other way
+func TestRallyPoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		rally        bool
+		fromQueue    bool
+		wantX, wantY int
+	}{
+		{"rally point set", true, true, 7, 4},
+		{"no rally point", false, true, 1, 1},
+		{"created outside the build queue", true, false, 1, 1},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			um := units.NewUnitManager(newTestMap(10, 10))
+			if tt.rally {
+				if err := um.SetRallyPoint(7, 4); err != nil {
+					t.Fatalf("SetRallyPoint failed: %v", err)
+				}
+			}
+			unit, err := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+			if err != nil {
+				t.Fatalf("CreateUnit failed: %v", err)
+			}
+			if tt.fromQueue {
+				if err := um.RallyOnceSpawned(unit.ID); err != nil {
+					t.Fatalf("RallyOnceSpawned failed: %v", err)
+				}
+			}
+			
+			// Nothing happens while the unit is still growing in
+			for tick := 1; tick < units.DefaultSpawnTicks; tick++ {
+				um.Update()
+			}
+			if unit.IsMoving() {
+				t.Fatalf("unit moving with %d spawn ticks left, want it to wait until built", unit.SpawnTicks)
+			}
+			
+			um.Update()
+			if want := tt.rally && tt.fromQueue; unit.IsMoving() != want {
+				t.Fatalf("IsMoving() once built = %v, want %v", unit.IsMoving(), want)
+			}
+			runUntilStopped(um, unit)
+			if unit.TileX != tt.wantX || unit.TileY != tt.wantY {
+				t.Errorf("unit ended at (%d, %d), want (%d, %d)", unit.TileX, unit.TileY, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+// Test that orders given during the grow-in take priority and clearing the rally point stops new rallies
+func TestRallyPointDefersToOrders(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	um.SetRallyPoint(7, 4)
+	ordered, _ := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	um.RallyOnceSpawned(ordered.ID)
+	um.MoveUnit(ordered.ID, 1, 8)
+	runUntilStopped(um, ordered)
+	if ordered.TileX != 1 || ordered.TileY != 8 {
+		t.Errorf("ordered unit ended at (%d, %d), want its own destination (1, 8)", ordered.TileX, ordered.TileY)
+	}
+	
+	um.ClearRallyPoint()
+	idle, _ := um.CreateUnit(entities.UnitWarrior, 3, 1, "")
+	um.RallyOnceSpawned(idle.ID)
+	for tick := 0; tick <= units.DefaultSpawnTicks; tick++ {
+		um.Update()
+	}
+	if idle.IsMoving() {
+		t.Errorf("unit created after ClearRallyPoint is moving")
+	}
+	
+	if err := um.SetRallyPoint(10, 0); !errors.Is(err, units.ErrOutOfBounds) {
+		t.Errorf("SetRallyPoint off the map error = %v, want ErrOutOfBounds", err)
+	}
+	if err := um.RallyOnceSpawned("missing"); !errors.Is(err, units.ErrNotFound) {
+		t.Errorf("RallyOnceSpawned(missing) error = %v, want ErrNotFound", err)
+	}
+}
@@ -3,36 +3,95 @@ package units
 import (
 	"fmt"
 	"math"
+	"sort"
 	"syscall/js"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
 )
 
+// DefaultHealthBarOffset is the gap in pixels (at zoom 1) between a unit's circle and its health bar
+const DefaultHealthBarOffset = 8.0
+
 // UnitRenderer handles rendering of units on the screen
 type UnitRenderer struct {
 	gameMap *world.Map
+	Zoom            float64 // Screen pixels per world pixel; unit sizes and health bars scale with it
+	HealthBarOffset float64 // Gap above the unit circle at zoom 1, scaled by Zoom
 }
 
 // NewUnitRenderer creates a new unit renderer
 func NewUnitRenderer(gameMap *world.Map) *UnitRenderer {
 	return &UnitRenderer{
 		gameMap: gameMap,
+		Zoom:            1.0,
+		HealthBarOffset: DefaultHealthBarOffset,
 	}
 }
 
-// RenderUnits draws all units on the screen
-func (renderer *UnitRenderer) RenderUnits(ctx js.Value, units map[string]*Unit, cameraX, cameraY float64) {
-	for _, unit := range units {
-		if !unit.IsAlive {
+// Renderer returns the manager's unit renderer, e.g. to set zoom or health bar offset
+func (um *UnitManager) Renderer() *UnitRenderer {
+	return um.renderer
+}
+
+// HealthBarLayout returns the on-screen health bar width, height and its top edge relative to the unit center
+// Everything scales with zoom so the bar stays proportional to the unit; radius is the unzoomed radius
+func HealthBarLayout(radius, zoom, offset float64) (width, height, top float64) {
+	if zoom <= 0 {
+		zoom = 1.0
+	}
+	width = radius * 2 * zoom
+	height = 4.0 * zoom
+	top = -(radius + offset) * zoom
+	return width, height, top
+}
+
+// RenderUnits draws destination markers for the units showMarker picks, then the depth-sorted drawables on top
+func (renderer *UnitRenderer) RenderUnits(ctx js.Value, units []*Unit, drawables []entities.Renderable, showMarker func(*Unit) bool, cameraX, cameraY float64) {
+	// Trails and destination markers go underneath the units themselves
+	renderer.renderDestinationMarkers(ctx, units, showMarker, cameraX, cameraY)
+	
+	for _, drawable := range drawables {
+		drawable.Draw(ctx, cameraX, cameraY)
+	}
+}
+
+// unitDrawable adapts a unit to entities.Renderable so it can be depth-sorted with the player
+type unitDrawable struct {
+	unit     *Unit
+	renderer *UnitRenderer
+	selected bool
+}
+
+// DepthY returns the bottom of the unit as drawn, centered on its tile
+func (d unitDrawable) DepthY() float64 {
+	_, worldY := d.renderer.gameMap.GridToWorld(d.unit.TileX, d.unit.TileY)
+	return worldY + d.unit.Height/2
+}
+
+// Draw renders the unit with the manager's renderer
+func (d unitDrawable) Draw(ctx js.Value, cameraX, cameraY float64) {
+	d.renderer.renderUnit(ctx, d.unit, d.selected, cameraX, cameraY)
+}
+
+// Renderables returns every visible unit plus others in back-to-front draw order
+// Units come first in creation order, so equal depths resolve the same way every frame
+func (um *UnitManager) Renderables(others ...entities.Renderable) []entities.Renderable {
+	selected := um.GetSelectedUnit()
+	items := make([]entities.Renderable, 0, len(um.unitOrder)+len(others))
+	for _, id := range um.unitOrder {
+		unit := um.units[id]
+		if unit == nil || !unit.IsAlive || unit.IsCarried() {
 			continue
 		}
-
-		renderer.renderUnit(ctx, unit, cameraX, cameraY)
+		items = append(items, unitDrawable{unit: unit, renderer: um.renderer, selected: unit == selected})
 	}
+	items = append(items, others...)
+	entities.SortByDepth(items)
+	return items
 }
 
 // renderUnit draws a single unit
-func (renderer *UnitRenderer) renderUnit(ctx js.Value, unit *Unit, cameraX, cameraY float64) {
+func (renderer *UnitRenderer) renderUnit(ctx js.Value, unit *Unit, selected bool, cameraX, cameraY float64) {
 	// Convert tile coordinates to world coordinates
 	worldX, worldY := renderer.gameMap.GridToWorld(unit.TileX, unit.TileY)
 	
@@ -57,17 +116,28 @@ func (renderer *UnitRenderer) renderUnit(ctx js.Value, unit *Unit, cameraX, came
 		return
 	}
 
-	// Draw unit as a colored circle with icon
-	radius := typeDef.Appearance.Size / 2
+	// Draw unit as a colored circle with icon, sized for the current zoom and grown in while spawning
+	zoom := renderer.Zoom
+	if zoom <= 0 {
+		zoom = 1.0
+	}
+	scale := unit.SpawnScale()
+	radius := typeDef.Appearance.Size / 2 * zoom * scale
 
-	// Draw unit circle
-	ctx.Set("fillStyle", typeDef.Appearance.Color)
+	// Draw unit circle, tinted and bordered with its team color
+	teamColor := unit.teamColor()
+	ctx.Set("fillStyle", TeamTintedColor(typeDef.Appearance.Color, teamColor))
 	ctx.Call("beginPath")
 	ctx.Call("arc", screenX, screenY, radius, 0, 2*math.Pi)
 	ctx.Call("fill")
+	if teamColor != "" {
+		ctx.Set("strokeStyle", teamColor)
+		ctx.Set("lineWidth", 2)
+		ctx.Call("stroke")
+	}
 
 	// Draw unit icon (if supported by browser)
-	ctx.Set("font", fmt.Sprintf("%dpx Arial", int(typeDef.Appearance.Size)))
+	ctx.Set("font", fmt.Sprintf("%dpx Arial", int(typeDef.Appearance.Size*zoom*scale)))
 	ctx.Set("textAlign", "center")
 	ctx.Set("textBaseline", "middle")
 	ctx.Set("fillStyle", "white")
@@ -75,15 +145,39 @@ func (renderer *UnitRenderer) renderUnit(ctx js.Value, unit *Unit, cameraX, came
 
 	// Draw health bar if damaged
 	if unit.CurrentStats.Health < unit.MaxStats.Health {
-		renderer.renderHealthBar(ctx, unit, screenX, screenY, radius)
+		renderer.renderHealthBar(ctx, unit, screenX, screenY, typeDef.Appearance.Size/2)
+	}
+
+	// Draw ability cooldown arcs for the selected unit
+	if selected {
+		renderer.renderCooldowns(ctx, unit, screenX, screenY, radius)
+	}
+}
+
+// renderCooldowns draws one arc per recharging ability around the unit, shrinking as it recharges
+func (renderer *UnitRenderer) renderCooldowns(ctx js.Value, unit *Unit, screenX, screenY, radius float64) {
+	cooldowns := unit.ActiveCooldowns()
+	names := make([]string, 0, len(cooldowns))
+	for name := range cooldowns {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Stable ring order between frames
+
+	ctx.Set("strokeStyle", "rgba(255, 255, 255, 0.8)")
+	ctx.Set("lineWidth", 2)
+	for i, name := range names {
+		ringRadius := radius + 4 + float64(i)*4
+		startAngle := -math.Pi / 2
+		ctx.Call("beginPath")
+		ctx.Call("arc", screenX, screenY, ringRadius, startAngle, startAngle+2*math.Pi*cooldowns[name])
+		ctx.Call("stroke")
 	}
 }
 
-// renderHealthBar draws a health bar above the unit
+// renderHealthBar draws a health bar above the unit; radius is the unit's unzoomed radius
 func (renderer *UnitRenderer) renderHealthBar(ctx js.Value, unit *Unit, screenX, screenY, radius float64) {
-	barWidth := radius * 2
-	barHeight := 4.0
-	barY := screenY - radius - 8
+	barWidth, barHeight, barTop := HealthBarLayout(radius, renderer.Zoom, renderer.HealthBarOffset)
+	barY := screenY + barTop
 
 	// Background (red)
 	ctx.Set("fillStyle", "#ff0000")
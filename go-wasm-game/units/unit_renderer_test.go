@@ -0,0 +1,89 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that health bar size and offset scale with zoom so the bar stays proportional to the unit
+func TestHealthBarLayout(t *testing.T) {
+	tests := []struct {
+		name                string
+		radius, zoom, offset float64
+		width, height, top  float64
+	}{
+		{"Zoom 1 default offset", 12, 1.0, units.DefaultHealthBarOffset, 24, 4, -20},
+		{"Zoom 2 default offset", 12, 2.0, units.DefaultHealthBarOffset, 48, 8, -40},
+		{"Zoom 1 custom offset", 10, 1.0, 3, 20, 4, -13},
+		{"Zoom 2 custom offset", 10, 2.0, 3, 40, 8, -26},
+		{"Invalid zoom treated as 1", 12, 0, units.DefaultHealthBarOffset, 24, 4, -20},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height, top := units.HealthBarLayout(tt.radius, tt.zoom, tt.offset)
+			if width != tt.width || height != tt.height || top != tt.top {
+				t.Errorf("HealthBarLayout(%v, %v, %v) = (%v, %v, %v), want (%v, %v, %v)",
+					tt.radius, tt.zoom, tt.offset, width, height, top, tt.width, tt.height, tt.top)
+			}
+		})
+	}
+}
+
+// Test that a new manager's renderer starts at zoom 1 with the default offset
+func TestRendererDefaults(t *testing.T) {
+	renderer := units.NewUnitManager(newTestMap(4, 4)).Renderer()
+	if renderer.Zoom != 1.0 || renderer.HealthBarOffset != units.DefaultHealthBarOffset {
+		t.Errorf("renderer zoom %v offset %v, want 1.0 and %v", renderer.Zoom, renderer.HealthBarOffset, units.DefaultHealthBarOffset)
+	}
+}
+
+// Test that the merged render list draws the player after units above it and before units below it
+func TestRenderablesDepthSortsPlayerWithUnits(t *testing.T) {
+	gameMap := newTestMap(10, 10)
+	um := units.NewUnitManager(gameMap)
+	// Created bottom first so creation order alone would get the overlap wrong
+	if _, err := um.CreateUnit(entities.UnitWarrior, 3, 6, ""); err != nil {
+		t.Fatalf("CreateUnit(below) failed: %v", err)
+	}
+	if _, err := um.CreateUnit(entities.UnitWarrior, 3, 2, ""); err != nil {
+		t.Fatalf("CreateUnit(above) failed: %v", err)
+	}
+	player := entities.NewPlayer(3*32+6, 4*32+6, gameMap)
+	
+	items := um.Renderables(player)
+	if len(items) != 3 {
+		t.Fatalf("Renderables() returned %d items, want 2 units and the player", len(items))
+	}
+	for i := 1; i < len(items); i++ {
+		if items[i].DepthY() < items[i-1].DepthY() {
+			t.Errorf("item %d depth %v drawn after deeper item %v", i, items[i].DepthY(), items[i-1].DepthY())
+		}
+	}
+	if items[1] != entities.Renderable(player) {
+		t.Errorf("player drawn at position %d, want 1 (between the units)", indexOf(items, player))
+	}
+	if items[0].DepthY() >= player.DepthY() || items[2].DepthY() <= player.DepthY() {
+		t.Errorf("unit depths %v and %v should straddle the player's %v", items[0].DepthY(), items[2].DepthY(), player.DepthY())
+	}
+	
+	// Moving the player below both units puts it last
+	player.SetPosition(3*32+6, 8*32+6)
+	if items := um.Renderables(player); items[2] != entities.Renderable(player) {
+		t.Errorf("player below both units drawn at position %d, want 2", indexOf(items, player))
+	}
+}
+
+// indexOf returns where a renderable sits in a draw list, -1 if absent
+func indexOf(items []entities.Renderable, target entities.Renderable) int {
+	for i, item := range items {
+		if item == target {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,38 @@
+package units
+
+import (
+	"time"
+)
+
+// DefaultRepathInterval bounds how often a unit following a moving target recomputes its path
+const DefaultRepathInterval = 500 * time.Millisecond
+
+// SetRepathInterval changes the minimum time between repaths for this unit (0 restores the default)
+func (u *Unit) SetRepathInterval(interval time.Duration) {
+	u.minRepathInterval = interval
+}
+
+// CanRepath reports whether enough time has passed since the last repath
+// Behaviors that chase moving targets should check this before calling FindPath
+func (u *Unit) CanRepath(now time.Time) bool {
+	if u.lastRepathAt.IsZero() {
+		return true
+	}
+	interval := u.minRepathInterval
+	if interval == 0 {
+		interval = DefaultRepathInterval
+	}
+	return !now.Before(u.lastRepathAt.Add(interval))
+}
+
+// RepathToTile re-targets the unit if the repath interval allows it, using the unit's clock (simulated time for managed units)
+// Returns false (leaving the current path alone) when throttled
+func (u *Unit) RepathToTile(tileX, tileY int) bool {
+	now := u.now()
+	if !u.CanRepath(now) {
+		return false
+	}
+	u.lastRepathAt = now
+	u.MoveToTile(tileX, tileY)
+	return true
+}
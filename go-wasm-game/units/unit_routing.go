@@ -0,0 +1,115 @@
+package units
+
+import "github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+
+// occupiedByOthers reports tiles where another living unit stands right now, leaving the unit's own
+// tile and its destination open so it can always leave and arrive
+func (um *UnitManager) occupiedByOthers(unit *Unit, destX, destY int) systems.BlockedFunc {
+	return func(tileX, tileY int) bool {
+		if (tileX == unit.TileX && tileY == unit.TileY) || (tileX == destX && tileY == destY) {
+			return false
+		}
+		for _, other := range um.spatialIndex.GetUnitsAtTile(tileX, tileY) {
+			if other != unit && other.IsAlive {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// moveToTileAvoiding paths the unit to a tile around the tiles avoid reports, like MoveToTile otherwise
+func (u *Unit) moveToTileAvoiding(tileX, tileY int, avoid systems.BlockedFunc) {
+	if u.movementSystem == nil {
+		return
+	}
+	u.flowField = nil
+	u.movementSystem.MoveToTileAvoiding(u, tileX, tileY, avoid)
+	if u.IsMoving() {
+		u.SetStatus(StatusMoving)
+	}
+}
+
+// tileChanged passes a map edit on to the shared planners, whichever way it was made (see world.Map.OnTileChange)
+func (um *UnitManager) tileChanged(tileX, tileY int) {
+	if um.planners != nil {
+		um.planners.TileChanged(tileX, tileY)
+	}
+}
+
+// RepathAfterTileChange re-plans moving units after (tileX, tileY) was edited: those whose remaining path
+// crosses it when it became unwalkable, and every moving unit when it opened up, since a shorter route may
+// now exist. Units heading to the same destination share one D* Lite planner, which repairs only the part
+// of its search the edit touched; planners nobody is heading to any more are dropped. Edits through the
+// map's setters reach the planners on their own, so calling this for them only re-plans; it also tells the
+// planners about the tile, for edits made straight to the map's Tiles. Units following a flow field keep
+// their field. Returns the IDs re-planned, oldest first
+func (um *UnitManager) RepathAfterTileChange(tileX, tileY int) []string {
+	if um.planners == nil {
+		um.planners = systems.NewDStarPlanners(um.gameMap)
+	}
+	um.planners.TileChanged(tileX, tileY)
+	
+	opened := um.gameMap.TileDefAt(tileX, tileY).Walkable
+	repathed := make([]string, 0)
+	destinations := make(map[[2]int]bool)
+	for _, unit := range um.OrderedUnits() {
+		path := unit.GetPath()
+		if !unit.IsAlive || unit.IsCarried() || !unit.IsMoving() || unit.IsFollowingFlowField() || len(path) == 0 {
+			continue
+		}
+		dest := path[len(path)-1]
+		destinations[[2]int{dest.X, dest.Y}] = true
+		remaining := path
+		if step := unit.GetPathStep(); step < len(path) {
+			remaining = path[step:]
+		}
+		if !opened && !pathCrosses(remaining, tileX, tileY) {
+			continue
+		}
+		um.replanWithDStar(unit, dest.X, dest.Y)
+		repathed = append(repathed, unit.ID)
+	}
+	um.planners.Retain(destinations)
+	return repathed
+}
+
+// replanWithDStar sends the unit along its destination's shared planner, falling back to a fresh search
+// around other units when the planner finds no terrain route, hands back one it can no longer walk (edits
+// made behind its back, straight to the map's Tiles) or while traffic discounts are on, which the planners
+// leave out of their costs. With no route at all the unit stops rather than keep walking its old path into the edit
+func (um *UnitManager) replanWithDStar(unit *Unit, destX, destY int) {
+	var path systems.Path
+	if um.gameMap.MinTrafficCostMultiplier() == 1 {
+		planner := um.planners.Planner(destX, destY)
+		path = planner.PathFrom(unit.TileX, unit.TileY)
+		if !planner.PathValid(path) {
+			um.planners.Release(destX, destY)
+			path = nil
+		}
+	}
+	if len(path) < 2 {
+		unit.SetPath(nil)
+		unit.SetPathStep(0)
+		unit.SetMoving(false)
+		unit.moveToTileAvoiding(destX, destY, um.occupiedByOthers(unit, destX, destY))
+		if !unit.IsMoving() {
+			unit.SetStatus(StatusIdle)
+			unit.onArrival = nil
+		}
+		return
+	}
+	unit.flowField = nil
+	unit.movementSystem.FollowPath(unit, path)
+	unit.SetStatus(StatusMoving)
+}
+
+// pathCrosses reports whether a path visits a tile
+func pathCrosses(path systems.Path, tileX, tileY int) bool {
+	for _, step := range path {
+		if step.X == tileX && step.Y == tileY {
+			return true
+		}
+	}
+	return false
+}
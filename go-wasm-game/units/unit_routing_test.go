@@ -0,0 +1,301 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newCorridorManager builds a 12x8 map split by water at x = 5, crossable through a one-tile corridor
+// at row 2 and, when detour is set, a longer way round at row 7
+func newCorridorManager(detour bool) *units.UnitManager {
+	gameMap := newTestMap(12, 8)
+	for y := 0; y < gameMap.Height; y++ {
+		if y != 2 && (y != 7 || !detour) {
+			gameMap.Tiles[y][5] = world.TileWater
+		}
+	}
+	return units.NewUnitManager(gameMap)
+}
+
+// Test that a move order routes around a unit standing in the corridor when another way exists,
+// and still takes the corridor when it is the only route, also for a unit already on the move
+func TestMoveUnitAvoidsOccupiedTiles(t *testing.T) {
+	tests := []struct {
+		name         string
+		detour       bool
+		moving       bool
+		wantCorridor bool
+	}{
+		{"detour available", true, false, false},
+		{"corridor is the only route", false, false, true},
+		{"corridor is the only route while moving", false, true, true},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			um := newCorridorManager(tt.detour)
+			walker, err := um.CreateUnit(entities.UnitWarrior, 2, 2, "")
+			if err != nil {
+				t.Fatalf("CreateUnit failed: %v", err)
+			}
+			if _, err := um.CreateUnit(entities.UnitWarrior, 5, 2, ""); err != nil {
+				t.Fatalf("CreateUnit blocker failed: %v", err)
+			}
+			if tt.moving {
+				um.MoveUnit(walker.ID, 2, 6)
+				um.Update()
+				if !walker.IsMoving() {
+					t.Fatal("setup: walker should be on its way to (2, 6)")
+				}
+			}
+			
+			if err := um.MoveUnit(walker.ID, 8, 2); err != nil {
+				t.Fatalf("MoveUnit failed: %v", err)
+			}
+			if !walker.IsMoving() {
+				t.Fatalf("walker is not moving")
+			}
+			throughCorridor := false
+			for _, step := range walker.GetPath() {
+				throughCorridor = throughCorridor || (step.X == 5 && step.Y == 2)
+			}
+			if throughCorridor != tt.wantCorridor {
+				t.Errorf("path %v through the occupied corridor = %v, want %v", walker.GetPath(), throughCorridor, tt.wantCorridor)
+			}
+		})
+	}
+}
+
+// Test that dead units never count as obstacles and an occupied destination doesn't force the blocked route
+func TestMoveUnitIgnoresDestinationAndDeadUnits(t *testing.T) {
+	um := newCorridorManager(true)
+	walker, _ := um.CreateUnit(entities.UnitWarrior, 2, 2, "")
+	fallen, _ := um.CreateUnit(entities.UnitWarrior, 5, 2, "")
+	um.KillUnit(fallen.ID)
+	
+	um.MoveUnit(walker.ID, 8, 2)
+	if path := walker.GetPath(); len(path) != 7 {
+		t.Errorf("path %v, want the direct 6-step route past the dead unit", path)
+	}
+	
+	// With another unit on the destination, the walker still detours around a living blocker
+	um.RemoveUnit(fallen.ID)
+	um.CreateUnit(entities.UnitWarrior, 5, 2, "")
+	um.CreateUnit(entities.UnitWarrior, 8, 2, "")
+	um.MoveUnit(walker.ID, 2, 3)
+	runUntilStopped(um, walker)
+	um.MoveUnit(walker.ID, 8, 2)
+	path := walker.GetPath()
+	if len(path) == 0 || path[len(path)-1].X != 8 || path[len(path)-1].Y != 2 {
+		t.Fatalf("path %v, want it to end on the occupied destination (8, 2)", path)
+	}
+	for _, step := range path {
+		if step.X == 5 && step.Y == 2 {
+			t.Errorf("path %v goes through the occupied corridor despite the detour", path)
+		}
+	}
+}
+
+// Test that opening a tile re-plans moving units onto the shorter route, and closing one only re-plans
+// units whose remaining path crosses it
+func TestRepathAfterTileChange(t *testing.T) {
+	gameMap := newTestMap(12, 8)
+	for y := 0; y < gameMap.Height; y++ {
+		if y != 2 {
+			gameMap.Tiles[y][5] = world.TileWater
+		}
+	}
+	um := units.NewUnitManager(gameMap)
+	unit, err := um.CreateUnit(entities.UnitWarrior, 0, 7, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	bystander, err := um.CreateUnit(entities.UnitWarrior, 0, 0, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	if err := um.MoveUnit(unit.ID, 11, 7); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	if err := um.MoveUnit(bystander.ID, 3, 0); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	crosses := func(u *units.Unit, x, y int) bool {
+		for _, step := range u.GetPath() {
+			if step.X == x && step.Y == y {
+				return true
+			}
+		}
+		return false
+	}
+	if !crosses(unit, 5, 2) {
+		t.Fatalf("setup: path %v does not use the corridor at (5, 2)", unit.GetPath())
+	}
+	
+	gameMap.Tiles[7][5] = world.TileGrass
+	if got := um.RepathAfterTileChange(5, 7); len(got) != 2 {
+		t.Errorf("RepathAfterTileChange() after opening = %v, want both moving units", got)
+	}
+	if !crosses(unit, 5, 7) {
+		t.Errorf("path after opening (5, 7) = %v, want the direct route through it", unit.GetPath())
+	}
+	
+	gameMap.Tiles[7][5] = world.TileWater
+	if got := um.RepathAfterTileChange(5, 7); len(got) != 1 || got[0] != unit.ID {
+		t.Errorf("RepathAfterTileChange() after closing = %v, want only %s", got, unit.ID)
+	}
+	if !unit.IsMoving() || crosses(unit, 5, 7) {
+		t.Errorf("path after closing (5, 7) = %v, want the corridor route again", unit.GetPath())
+	}
+}
+
+// Test that a unit whose only route gets closed stops instead of walking its old path into the water
+func TestRepathAfterTileChangeWithoutRoute(t *testing.T) {
+	gameMap := newTestMap(12, 8)
+	for y := 0; y < gameMap.Height; y++ {
+		if y != 2 {
+			gameMap.Tiles[y][5] = world.TileWater
+		}
+	}
+	um := units.NewUnitManager(gameMap)
+	unit, err := um.CreateUnit(entities.UnitWarrior, 2, 2, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	if err := um.MoveUnit(unit.ID, 8, 2); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	um.Update()
+	
+	gameMap.Tiles[2][5] = world.TileWater
+	if got := um.RepathAfterTileChange(5, 2); len(got) != 1 || got[0] != unit.ID {
+		t.Errorf("RepathAfterTileChange() = %v, want [%s]", got, unit.ID)
+	}
+	if unit.IsMoving() || len(unit.GetPath()) != 0 || unit.Status != units.StatusIdle {
+		t.Errorf("moving = %v, path = %v, status = %q, want the unit stopped and idle", unit.IsMoving(), unit.GetPath(), unit.Status)
+	}
+	for frame := 0; frame < 200; frame++ {
+		um.Update()
+	}
+	if unit.TileX >= 5 {
+		t.Errorf("unit ended at (%d, %d), want it to stay west of the closed corridor", unit.TileX, unit.TileY)
+	}
+}
+
+// Test that units bound for the same tile both re-plan around a closed tile onto routes A* agrees with
+func TestRepathAfterTileChangeSharedDestination(t *testing.T) {
+	gameMap := newTestMap(12, 8)
+	for y := 0; y < gameMap.Height; y++ {
+		if y != 2 && y != 6 {
+			gameMap.Tiles[y][5] = world.TileWater
+		}
+	}
+	um := units.NewUnitManager(gameMap)
+	first, _ := um.CreateUnit(entities.UnitWarrior, 0, 7, "")
+	second, _ := um.CreateUnit(entities.UnitWarrior, 1, 5, "")
+	for _, unit := range []*units.Unit{first, second} {
+		if err := um.MoveUnit(unit.ID, 11, 7); err != nil {
+			t.Fatalf("MoveUnit failed: %v", err)
+		}
+	}
+	
+	gameMap.Tiles[6][5] = world.TileWater
+	if got := um.RepathAfterTileChange(5, 6); len(got) != 2 {
+		t.Fatalf("RepathAfterTileChange() = %v, want both units re-planned", got)
+	}
+	for _, unit := range []*units.Unit{first, second} {
+		path := unit.GetPath()
+		want := systems.FindPath(unit.TileX, unit.TileY, 11, 7, gameMap)
+		if !unit.IsMoving() || len(path) != len(want) || path[len(path)-1] != want[len(want)-1] {
+			t.Errorf("%s path = %v, want one as short as A*'s %v", unit.ID, path, want)
+		}
+		for _, step := range path {
+			if !gameMap.TileDefAt(step.X, step.Y).Walkable {
+				t.Errorf("%s path crosses unwalkable (%d, %d)", unit.ID, step.X, step.Y)
+			}
+		}
+	}
+}
+
+// Test that a planner hears about edits made through the map's setters without RepathAfterTileChange,
+// so a later re-plan doesn't send the unit through an edge wall built in the meantime
+func TestRepathAfterUntrackedEdgeWall(t *testing.T) {
+	gameMap := newTestMap(12, 8)
+	for y := 0; y < gameMap.Height; y++ {
+		if y != 2 && y != 7 {
+			gameMap.Tiles[y][5] = world.TileWater
+		}
+	}
+	um := units.NewUnitManager(gameMap)
+	unit, err := um.CreateUnit(entities.UnitWarrior, 2, 2, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	if err := um.MoveUnit(unit.ID, 8, 2); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	
+	// The first re-plan creates the shared planner for (8, 2) through the corridor
+	um.RepathAfterTileChange(0, 0)
+	gameMap.AddEdgeWall(4, 2, 5, 2)
+	um.RepathAfterTileChange(0, 0)
+	path := unit.GetPath()
+	for i := 1; i < len(path); i++ {
+		if !gameMap.CanCrossEdge(path[i-1].X, path[i-1].Y, path[i].X, path[i].Y) {
+			t.Fatalf("path %v crosses the edge wall between (%d, %d) and (%d, %d)", path, path[i-1].X, path[i-1].Y, path[i].X, path[i].Y)
+		}
+	}
+	if !pathCrossesTile(path, 5, 7) {
+		t.Errorf("path %v, want the detour through (5, 7)", path)
+	}
+}
+
+// Test that re-planning keeps following traffic-discounted routes like A*, which the shared planners can't price
+func TestRepathAfterTileChangeWithTraffic(t *testing.T) {
+	gameMap := newTestMap(12, 10)
+	for y := 0; y < gameMap.Height; y++ {
+		if y != 3 && y != 6 {
+			gameMap.Tiles[y][5] = world.TileWater
+		}
+	}
+	gameMap.SetTrafficDiscount(world.MaxTrafficDiscount)
+	for y := 4; y < gameMap.Height; y++ {
+		for x := 0; x < gameMap.Width; x++ {
+			for visit := 0; visit < int(world.TrafficSaturation); visit++ {
+				gameMap.RecordTraffic(x, y)
+			}
+		}
+	}
+	um := units.NewUnitManager(gameMap)
+	unit, err := um.CreateUnit(entities.UnitWarrior, 0, 3, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	if err := um.MoveUnit(unit.ID, 11, 3); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	if !pathCrossesTile(unit.GetPath(), 5, 6) {
+		t.Fatalf("setup: path %v does not take the busy route through (5, 6)", unit.GetPath())
+	}
+	
+	um.RepathAfterTileChange(0, 0)
+	if !pathCrossesTile(unit.GetPath(), 5, 6) {
+		t.Errorf("path after re-planning = %v, want the busy route through (5, 6) like A*", unit.GetPath())
+	}
+}
+
+// pathCrossesTile reports whether a path visits a tile
+func pathCrossesTile(path systems.Path, tileX, tileY int) bool {
+	for _, step := range path {
+		if step.X == tileX && step.Y == tileY {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package units
+
+import (
+	"fmt"
+)
+
+// SelectUnit marks a unit as the currently selected unit
+func (um *UnitManager) SelectUnit(unitID string) error {
+	if um.units[unitID] == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, unitID)
+	}
+	um.selectedUnitID = unitID
+	return nil
+}
+
+// ClearSelection deselects the currently selected unit
+func (um *UnitManager) ClearSelection() {
+	um.selectedUnitID = ""
+}
+
+// GetSelectedUnit returns the currently selected unit, or nil if none is selected
+func (um *UnitManager) GetSelectedUnit() *Unit {
+	return um.units[um.selectedUnitID]
+}
+
+// SetClock replaces the clock used for timed unit behavior (cooldowns, timers)
+// Units created afterwards use the new clock as well as all existing ones
+func (um *UnitManager) SetClock(clock Clock) {
+	um.clock = clock
+	for _, unit := range um.units {
+		unit.clock = clock
+	}
+}
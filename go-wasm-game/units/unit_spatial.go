@@ -2,8 +2,22 @@ package units
 
 import (
 	"fmt"
+	"log"
+	"sort"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
 )
 
+// reconcileSearchRadius is how many rings out Reconcile looks for a free tile
+const reconcileSearchRadius = 10
+
+// TileCorrection records one unit Reconcile moved off a shared tile
+type TileCorrection struct {
+	UnitID       string
+	FromX, FromY int
+	ToX, ToY     int
+}
+
 // UnitSpatialIndex manages spatial indexing for units
 type UnitSpatialIndex struct {
 	unitsByTile map[string]map[string]*Unit // "x,y" -> unit map
@@ -78,4 +92,91 @@ func (si *UnitSpatialIndex) UpdateUnitPosition(unit *Unit, oldX, oldY, newX, new
 	unit.TileX = newX
 	unit.TileY = newY
 	si.AddUnit(unit)
-}
\ No newline at end of file
+}
+
+// Reconcile finds living, stationary units registered on the same tile and moves all but the oldest
+// to the nearest free walkable tile, logging each correction. Units walking through a tile are left alone
+func (si *UnitSpatialIndex) Reconcile(gameMap *world.Map) []TileCorrection {
+	tileKeys := make([]string, 0)
+	for tileKey, unitsAtTile := range si.unitsByTile {
+		if len(unitsAtTile) > 1 {
+			tileKeys = append(tileKeys, tileKey)
+		}
+	}
+	sort.Strings(tileKeys)
+	
+	corrections := make([]TileCorrection, 0)
+	for _, tileKey := range tileKeys {
+		stacked := make([]*Unit, 0, len(si.unitsByTile[tileKey]))
+		for _, unit := range si.unitsByTile[tileKey] {
+			if unit.IsAlive && !unit.IsCarried() && !unit.IsMoving() {
+				stacked = append(stacked, unit)
+			}
+		}
+		sort.Slice(stacked, func(i, j int) bool {
+			if !stacked[i].CreatedAt.Equal(stacked[j].CreatedAt) {
+				return stacked[i].CreatedAt.Before(stacked[j].CreatedAt)
+			}
+			return stacked[i].ID < stacked[j].ID
+		})
+		
+		// The oldest unit keeps the tile
+		for i := 1; i < len(stacked); i++ {
+			unit := stacked[i]
+			toX, toY, ok := si.nearestFreeTile(unit.TileX, unit.TileY, gameMap)
+			if !ok {
+				log.Printf("units: %s shares tile (%d, %d) but no free tile is nearby", unit.ID, unit.TileX, unit.TileY)
+				continue
+			}
+			correction := TileCorrection{UnitID: unit.ID, FromX: unit.TileX, FromY: unit.TileY, ToX: toX, ToY: toY}
+			si.relocate(unit, toX, toY, gameMap)
+			log.Printf("units: moved %s from shared tile (%d, %d) to (%d, %d)", unit.ID, correction.FromX, correction.FromY, toX, toY)
+			corrections = append(corrections, correction)
+		}
+	}
+	return corrections
+}
+
+// nearestFreeTile searches rings around (tileX, tileY) for a walkable tile no unit is registered on
+func (si *UnitSpatialIndex) nearestFreeTile(tileX, tileY int, gameMap *world.Map) (int, int, bool) {
+	for radius := 1; radius <= reconcileSearchRadius; radius++ {
+		for y := tileY - radius; y <= tileY+radius; y++ {
+			for x := tileX - radius; x <= tileX+radius; x++ {
+				if chebyshevDistance(x, y, tileX, tileY) != radius || x < 0 || x >= gameMap.Width || y < 0 || y >= gameMap.Height {
+					continue
+				}
+				if gameMap.TileDefAt(x, y).Walkable && !si.IsPositionOccupied(x, y) {
+					return x, y, true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// relocate places a unit centered on a tile and moves its index entry there
+func (si *UnitSpatialIndex) relocate(unit *Unit, tileX, tileY int, gameMap *world.Map) {
+	worldX, worldY := gameMap.GridToWorld(tileX, tileY)
+	unit.MovableEntity.SetPosition(worldX-unit.Width/2, worldY-unit.Height/2)
+	unit.SetTarget(unit.X, unit.Y)
+	si.UpdateUnitPosition(unit, unit.TileX, unit.TileY, tileX, tileY)
+}
+
+// SetSpatialReconcile makes Update run the spatial index Reconcile pass at most once per interval of
+// the manager's clock; 0 turns it off, which is the default since formation moves may stack units on purpose
+func (um *UnitManager) SetSpatialReconcile(interval time.Duration) {
+	um.reconcileInterval = interval
+}
+
+// reconcileSpatialIndex runs Reconcile when it is on and its interval has passed
+func (um *UnitManager) reconcileSpatialIndex() {
+	if um.reconcileInterval <= 0 {
+		return
+	}
+	now := um.clock()
+	if now.Sub(um.lastReconcile) < um.reconcileInterval {
+		return
+	}
+	um.lastReconcile = now
+	um.spatialIndex.Reconcile(um.gameMap)
+}
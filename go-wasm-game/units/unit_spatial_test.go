@@ -0,0 +1,114 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that Reconcile spreads units double-registered on one tile onto distinct free tiles
+func TestSpatialIndexReconcile(t *testing.T) {
+	gameMap := newTestMap(10, 10)
+	um := units.NewUnitManager(gameMap)
+	created := make([]*units.Unit, 0, 3)
+	for i := 0; i < 3; i++ {
+		unit, err := um.CreateUnit(entities.UnitWarrior, 5, 5+i, "")
+		if err != nil {
+			t.Fatalf("CreateUnit(5, %d) failed: %v", 5+i, err)
+		}
+		created = append(created, unit)
+	}
+
+	// Register everyone on the first unit's tile
+	index := units.NewUnitSpatialIndex()
+	for _, unit := range created {
+		unit.TileX, unit.TileY = 5, 5
+		index.AddUnit(unit)
+	}
+
+	corrections := index.Reconcile(gameMap)
+	if len(corrections) != 2 {
+		t.Fatalf("Reconcile() made %d corrections, want 2", len(corrections))
+	}
+	if created[0].TileX != 5 || created[0].TileY != 5 {
+		t.Errorf("oldest unit moved to (%d, %d), want it to keep (5, 5)", created[0].TileX, created[0].TileY)
+	}
+
+	seen := make(map[[2]int]bool)
+	for _, unit := range created {
+		tile := [2]int{unit.TileX, unit.TileY}
+		if seen[tile] {
+			t.Errorf("two units still share tile %v", tile)
+		}
+		seen[tile] = true
+		if got := index.GetUnitsAtTile(unit.TileX, unit.TileY); len(got) != 1 || got[0] != unit {
+			t.Errorf("index at (%d, %d) = %v, want only %s", unit.TileX, unit.TileY, got, unit.ID)
+		}
+		if worldX, worldY := gameMap.GridToWorld(unit.TileX, unit.TileY); unit.X != worldX-unit.Width/2 || unit.Y != worldY-unit.Height/2 {
+			t.Errorf("%s at (%.1f, %.1f), want it centered on tile (%d, %d)", unit.ID, unit.X, unit.Y, unit.TileX, unit.TileY)
+		}
+	}
+
+	if again := index.Reconcile(gameMap); len(again) != 0 {
+		t.Errorf("second Reconcile() = %v, want no corrections", again)
+	}
+}
+
+// Test that a unit manager with reconciliation on fixes a shared tile during Update, at most once per interval
+func TestUpdateReconcilesSharedTiles(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	um.SetClock(clock.Now)
+	created := createUnits(t, um, 3)
+	um.SetSpatialReconcile(time.Second)
+
+	// Teleport a unit onto another's tile without going through MoveUnit
+	stack := func(unit, onto *units.Unit) {
+		unit.MovableEntity.SetPosition(onto.X, onto.Y)
+		unit.SetTarget(onto.X, onto.Y)
+	}
+
+	stack(created[1], created[0])
+	um.Update()
+	if created[1].TileX == created[0].TileX && created[1].TileY == created[0].TileY {
+		t.Fatalf("%s still shares tile (%d, %d) after Update", created[1].ID, created[0].TileX, created[0].TileY)
+	}
+
+	stack(created[2], created[0])
+	um.Update()
+	if len(um.GetUnitsAtTile(created[0].TileX, created[0].TileY)) != 2 {
+		t.Fatalf("shared tile reconciled again before the interval passed")
+	}
+	clock.Advance(time.Second)
+	um.Update()
+	if got := um.GetUnitsAtTile(created[0].TileX, created[0].TileY); len(got) != 1 {
+		t.Errorf("%d units on (%d, %d) after the interval, want 1", len(got), created[0].TileX, created[0].TileY)
+	}
+}
+
+// Test that without SetClock the reconcile interval runs on simulated time, so paused frames don't count
+func TestUpdateReconcilesOnSimulatedTime(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	created := createUnits(t, um, 3)
+	um.SetSpatialReconcile(time.Second)
+	stack := func(unit, onto *units.Unit) {
+		unit.MovableEntity.SetPosition(onto.X, onto.Y)
+		unit.SetTarget(onto.X, onto.Y)
+	}
+
+	stack(created[1], created[0])
+	um.UpdateDT(16 * time.Millisecond)
+	stack(created[2], created[0])
+	um.UpdateDT(500 * time.Millisecond)
+	if len(um.GetUnitsAtTile(created[0].TileX, created[0].TileY)) != 2 {
+		t.Fatalf("shared tile reconciled again after half the interval of simulated time")
+	}
+	um.UpdateDT(500 * time.Millisecond)
+	if got := um.GetUnitsAtTile(created[0].TileX, created[0].TileY); len(got) != 1 {
+		t.Errorf("%d units on (%d, %d) after the interval of simulated time, want 1", len(got), created[0].TileX, created[0].TileY)
+	}
+}
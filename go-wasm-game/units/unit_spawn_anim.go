@@ -0,0 +1,26 @@
+package units
+
+// DefaultSpawnTicks is how many updates a new unit takes to grow in from nothing to full size
+const DefaultSpawnTicks = 12
+
+// SetSpawnTicks sets the grow-in duration, in updates, for units created afterwards; 0 makes them appear at full size
+func (um *UnitManager) SetSpawnTicks(ticks int) {
+	if ticks < 0 {
+		ticks = 0
+	}
+	um.spawnTicks = ticks
+}
+
+// startSpawnAnimation begins a unit's grow-in; the unit moves and collides normally meanwhile
+func (u *Unit) startSpawnAnimation(ticks int) {
+	u.SpawnTicks = ticks
+	u.spawnTotal = ticks
+}
+
+// SpawnScale returns how far the unit has grown in, from 0 when just created to 1 at full size
+func (u *Unit) SpawnScale() float64 {
+	if u.SpawnTicks <= 0 || u.spawnTotal <= 0 {
+		return 1.0
+	}
+	return 1.0 - float64(u.SpawnTicks)/float64(u.spawnTotal)
+}
@@ -0,0 +1,74 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that a new unit starts its spawn counter and its render scale ramps from near zero to full size
+func TestSpawnAnimationRampsScale(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	unit := createUnits(t, um, 1)[0]
+	if unit.SpawnTicks != units.DefaultSpawnTicks {
+		t.Fatalf("SpawnTicks = %d, want %d", unit.SpawnTicks, units.DefaultSpawnTicks)
+	}
+	if scale := unit.SpawnScale(); scale > 0.1 {
+		t.Errorf("SpawnScale() on creation = %v, want near zero", scale)
+	}
+	
+	// Spawning is cosmetic: the unit holds its tile from the start
+	if !um.IsPositionOccupied(unit.TileX, unit.TileY) {
+		t.Errorf("spawning unit does not occupy its tile")
+	}
+	
+	previous := unit.SpawnScale()
+	for tick := 1; tick <= units.DefaultSpawnTicks; tick++ {
+		um.Update()
+		scale := unit.SpawnScale()
+		if scale <= previous {
+			t.Fatalf("update %d: SpawnScale() = %v, want it to grow past %v", tick, scale, previous)
+		}
+		previous = scale
+	}
+	if unit.SpawnTicks != 0 || unit.SpawnScale() != 1.0 {
+		t.Errorf("after %d updates SpawnTicks = %d, SpawnScale() = %v; want 0 and full size", units.DefaultSpawnTicks, unit.SpawnTicks, unit.SpawnScale())
+	}
+	um.Update()
+	if unit.SpawnTicks != 0 || unit.SpawnScale() != 1.0 {
+		t.Errorf("spawn counter went below zero: SpawnTicks = %d", unit.SpawnTicks)
+	}
+}
+
+// Test that the spawn duration is configurable, and that zero makes units appear at full size
+func TestSetSpawnTicks(t *testing.T) {
+	tests := []struct {
+		name  string
+		ticks int
+		want  int
+	}{
+		{"longer animation", 30, 30},
+		{"disabled", 0, 0},
+		{"negative clamps to disabled", -4, 0},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			um := units.NewUnitManager(newTestMap(10, 10))
+			um.SetSpawnTicks(tt.ticks)
+			unit, err := um.CreateUnit(entities.UnitWarrior, 2, 2, "")
+			if err != nil {
+				t.Fatalf("CreateUnit failed: %v", err)
+			}
+			if unit.SpawnTicks != tt.want {
+				t.Errorf("SpawnTicks = %d, want %d", unit.SpawnTicks, tt.want)
+			}
+			if tt.want == 0 && unit.SpawnScale() != 1.0 {
+				t.Errorf("SpawnScale() = %v, want full size with the animation disabled", unit.SpawnScale())
+			}
+		})
+	}
+}
@@ -5,32 +5,181 @@ import (
 	"math/rand"
 	"time"
 	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
 )
 
-// SpawnRandomUnit spawns a random unit at a random valid location
+// spawnCandidates is how many valid tiles a random spawn compares before picking the most suitable
+const spawnCandidates = 8
+
+// CreateUnit creates a new unit at the specified tile coordinates
+func (um *UnitManager) CreateUnit(unitType entities.UnitType, tileX, tileY int, name string) (*Unit, error) {
+	// Validate position
+	if err := um.validatePosition(tileX, tileY); err != nil {
+		return nil, err
+	}
+
+	// Get unit type definition
+	typeDef, exists := entities.UnitTypeDefinitions[unitType]
+	if !exists {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownUnitType, unitType)
+	}
+	footprint := typeDef.Appearance.Footprint()
+	if !systems.CanPlayerMoveToTile(tileX, tileY, footprint, footprint, um.gameMap) {
+		return nil, fmt.Errorf("%w: footprint at (%d, %d)", ErrNotWalkable, tileX, tileY)
+	}
+
+	// Generate unit ID and name
+	unitID := fmt.Sprintf("unit_%d", um.nextUnitID)
+	um.nextUnitID++
+
+	if name == "" {
+		name = fmt.Sprintf("%s #%d", typeDef.Name, um.nextUnitID-1)
+	}
+
+	// Calculate world position from tile coordinates
+	worldX, worldY := um.gameMap.GridToWorld(tileX, tileY)
+	unitWidth, unitHeight := footprint, footprint
+
+	// Create and register unit
+	unit := &Unit{
+		ID:           unitID,
+		TypeID:       unitType,
+		Name:         name,
+		TileX:        tileX,
+		TileY:        tileY,
+		CurrentStats: typeDef.Stats,
+		MaxStats:     typeDef.Stats,
+		Level:        1,
+		Experience:   0,
+		IsAlive:      true,
+		Status:       StatusIdle,
+		CreatedAt:    time.Now(),
+		LastMoved:    time.Now(),
+		MovableEntity: systems.MovableEntity{
+			X:         worldX - unitWidth/2,
+			Y:         worldY - unitHeight/2,
+			Width:     unitWidth,
+			Height:    unitHeight,
+			TargetX:   worldX - unitWidth/2,
+			TargetY:   worldY - unitHeight/2,
+			IsMovingFlag: false,
+			MoveSpeed: 2.0, // Slightly slower than player
+			Path:      nil,
+			PathStep:  0,
+		},
+		movementSystem: systems.NewMovementSystem(um.gameMap),
+		clock:          um.clock,
+	}
+	unit.setHome(tileX, tileY)
+	unit.startSpawnAnimation(um.spawnTicks)
+
+	um.units[unitID] = unit
+	um.unitOrder = append(um.unitOrder, unitID)
+	um.spatialIndex.AddUnit(unit)
+	um.recordOccupancy(tileX, tileY)
+	um.applyThreatAvoidance(unit)
+	um.applyLocalAvoidance(unit)
+
+	return unit, nil
+}
+
+// validatePosition checks if a position is valid for unit placement
+func (um *UnitManager) validatePosition(tileX, tileY int) error {
+	// Check bounds
+	if tileX < 0 || tileX >= um.gameMap.Width || tileY < 0 || tileY >= um.gameMap.Height {
+		return fmt.Errorf("%w: (%d, %d)", ErrOutOfBounds, tileX, tileY)
+	}
+
+	// Check walkability
+	if !um.gameMap.TileDefAt(tileX, tileY).Walkable {
+		return fmt.Errorf("%w at (%d, %d)", ErrNotWalkable, tileX, tileY)
+	}
+
+	// Check occupation
+	if um.spatialIndex.IsPositionOccupied(tileX, tileY) {
+		return fmt.Errorf("%w at (%d, %d)", ErrOccupied, tileX, tileY)
+	}
+
+	return nil
+}
+
+// SetRand replaces the manager's random source, e.g. with a fixed seed for reproducible spawns
+func (um *UnitManager) SetRand(rng *rand.Rand) {
+	um.rng = rng
+}
+
+// SetSeed reseeds the spawning and combat random sources from streams of one master seed,
+// making random spawns and damage variance reproducible
+func (um *UnitManager) SetSeed(master int64) {
+	um.SetRand(rand.New(rand.NewSource(systems.DeriveSeed(master, systems.SeedStreamSpawning))))
+	um.combatSystem.SetRand(rand.New(rand.NewSource(systems.DeriveSeed(master, systems.SeedStreamCombat))))
+}
+
+// random returns the manager's random source, seeding one from the clock on first use
+func (um *UnitManager) random() *rand.Rand {
+	if um.rng == nil {
+		um.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return um.rng
+}
+
+// TileSuitability scores how good a tile is to spawn on: one point per walkable neighbor
+// (of 8) plus one for open grass, so tiles hemmed in by water score lowest
+func (um *UnitManager) TileSuitability(x, y int) int {
+	score := 0
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+			if um.gameMap.TileDefAt(x+dx, y+dy).Walkable {
+				score++
+			}
+		}
+	}
+	if um.gameMap.GetTile(x, y) == world.TileGrass {
+		score++
+	}
+	return score
+}
+
+// SpawnRandomUnit spawns a random unit at a random valid location, preferring open terrain
 func (um *UnitManager) SpawnRandomUnit() error {
 	if um.GetTotalUnitCount() >= 10 {
 		return fmt.Errorf("maximum unit count reached")
 	}
 	
 	// Random unit type
+	rng := um.random()
 	unitTypes := []entities.UnitType{entities.UnitWarrior, entities.UnitArcher, entities.UnitMage}
-	unitType := unitTypes[rand.Intn(len(unitTypes))]
+	unitType := unitTypes[rng.Intn(len(unitTypes))]
 	
-	// Try to find a valid spawn location (max 50 attempts)
-	for attempts := 0; attempts < 50; attempts++ {
-		x := rand.Intn(um.gameMap.Width)
-		y := rand.Intn(um.gameMap.Height)
+	// Sample valid spawn locations (max 50 attempts) and keep the most suitable one
+	bestX, bestY, bestScore := 0, 0, -1
+	found := 0
+	for attempts := 0; attempts < 50 && found < spawnCandidates; attempts++ {
+		x := rng.Intn(um.gameMap.Width)
+		y := rng.Intn(um.gameMap.Height)
 		
 		if err := um.validatePosition(x, y); err == nil {
-			// Generate unique name with timestamp
-			name := fmt.Sprintf("Unit_%d", time.Now().UnixNano()%10000)
-			_, err := um.CreateUnit(unitType, x, y, name)
-			return err
+			found++
+			if score := um.TileSuitability(x, y); score > bestScore {
+				bestX, bestY, bestScore = x, y, score
+			}
 		}
 	}
+	if found == 0 {
+		return fmt.Errorf("no valid spawn location found")
+	}
 	
-	return fmt.Errorf("no valid spawn location found")
+	// Name after the next unit ID rather than the clock so seeded spawns repeat exactly
+	name := fmt.Sprintf("Unit_%d", um.nextUnitID)
+	unit, err := um.CreateUnit(unitType, bestX, bestY, name)
+	if err != nil {
+		return err
+	}
+	return um.RallyOnceSpawned(unit.ID)
 }
 
 // RemoveNewestUnit removes the most recently created unit
@@ -39,19 +188,9 @@ func (um *UnitManager) RemoveNewestUnit() error {
 		return fmt.Errorf("cannot remove unit: minimum of 1 unit required")
 	}
 	
-	var newestUnit *Unit
-	var newestTime time.Time
-	var newestID string
-	
-	for id, unit := range um.units {
-		if unit.CreatedAt.After(newestTime) {
-			newestTime = unit.CreatedAt
-			newestUnit = unit
-			newestID = id
-		}
-	}
-	
-	if newestUnit != nil {
+	// The creation order slice makes this O(1) and unambiguous even when
+	// timestamps collide
+	if newestID, ok := um.newestUnitID(); ok {
 		return um.RemoveUnit(newestID)
 	}
 	
@@ -0,0 +1,152 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"math/rand"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newIslandMap builds a water map with a 4x4 grass field in one corner and single-tile islands elsewhere
+func newIslandMap() (*world.Map, map[[2]int]bool) {
+	gameMap := newTestMap(10, 10)
+	islands := map[[2]int]bool{}
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			if x < 4 && y < 4 {
+				continue
+			}
+			if x%2 == 1 && y%2 == 1 && (x > 4 || y > 4) {
+				islands[[2]int{x, y}] = true
+				continue
+			}
+			gameMap.SetTile(x, y, world.TileWater)
+		}
+	}
+	return gameMap, islands
+}
+
+// Test that tiles hemmed in by water score lower than open grass
+func TestTileSuitability(t *testing.T) {
+	gameMap, _ := newIslandMap()
+	gameMap.SetTile(2, 2, world.TileDirtPath)
+	um := units.NewUnitManager(gameMap)
+	
+	island := um.TileSuitability(7, 7)
+	open := um.TileSuitability(1, 1)
+	edge := um.TileSuitability(3, 1)
+	dirt := um.TileSuitability(2, 2)
+	if island >= edge || edge >= open {
+		t.Errorf("scores island=%d edge=%d open=%d, want island < edge < open", island, edge, open)
+	}
+	if dirt >= open {
+		t.Errorf("dirt score %d should be below open grass %d", dirt, open)
+	}
+	
+	// Neighbors under an overlay that overrides walkability no longer count
+	const tileBramble world.TileType = 99
+	world.TileDefinitions[tileBramble] = world.Tile{Name: "Bramble", Overlay: true, OverridesWalkability: true}
+	defer delete(world.TileDefinitions, tileBramble)
+	gameMap.SetOverlay(0, 0, tileBramble)
+	if covered := um.TileSuitability(1, 1); covered != open-1 {
+		t.Errorf("score beside a blocking overlay = %d, want %d", covered, open-1)
+	}
+}
+
+// Test that seeded random spawns stay off the single-tile islands
+func TestSpawnRandomUnitAvoidsWorstTiles(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		gameMap, islands := newIslandMap()
+		um := units.NewUnitManager(gameMap)
+		um.SetRand(rand.New(rand.NewSource(seed)))
+		
+		for i := 0; i < 10; i++ {
+			if err := um.SpawnRandomUnit(); err != nil {
+				t.Fatalf("seed %d: SpawnRandomUnit() #%d failed: %v", seed, i, err)
+			}
+		}
+		for _, unit := range um.GetAllUnits() {
+			if islands[[2]int{unit.TileX, unit.TileY}] {
+				t.Errorf("seed %d: %s spawned on an island at (%d, %d)", seed, unit.ID, unit.TileX, unit.TileY)
+			}
+		}
+	}
+}
+
+// Test that the same seed spawns units at the same tiles
+func TestSpawnRandomUnitDeterministic(t *testing.T) {
+	spawnAll := func() [][2]int {
+		um := units.NewUnitManager(newTestMap(10, 10))
+		um.SetRand(rand.New(rand.NewSource(99)))
+		positions := [][2]int{}
+		for i := 0; i < 5; i++ {
+			um.SpawnRandomUnit()
+		}
+		for _, id := range um.GetUnitIDsInOrder() {
+			unit := um.GetUnit(id)
+			positions = append(positions, [2]int{unit.TileX, unit.TileY})
+		}
+		return positions
+	}
+	
+	first, second := spawnAll(), spawnAll()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("spawn %d at %v then %v, want identical positions for the same seed", i, first[i], second[i])
+		}
+	}
+}
+
+// Test that two fresh managers given the same master seed spawn identical units and roll identical damage
+func TestSetSeedReproducible(t *testing.T) {
+	type spawn struct {
+		name         string
+		typeID       int
+		tileX, tileY int
+	}
+	run := func(seed int64) ([]spawn, []int) {
+		um := units.NewUnitManager(newTestMap(10, 10))
+		um.SetSeed(seed)
+		um.CombatSystem().DamageVariance = 0.5
+		for i := 0; i < 6; i++ {
+			if err := um.SpawnRandomUnit(); err != nil {
+				t.Fatalf("SpawnRandomUnit() failed: %v", err)
+			}
+		}
+		spawns := []spawn{}
+		for _, id := range um.GetUnitIDsInOrder() {
+			unit := um.GetUnit(id)
+			spawns = append(spawns, spawn{unit.Name, int(unit.TypeID), unit.TileX, unit.TileY})
+		}
+		damage := []int{}
+		for i := 0; i < 6; i++ {
+			damage = append(damage, um.CombatSystem().CalculateDamage(40, 0))
+		}
+		return spawns, damage
+	}
+	
+	firstSpawns, firstDamage := run(7)
+	secondSpawns, secondDamage := run(7)
+	for i := range firstSpawns {
+		if firstSpawns[i] != secondSpawns[i] {
+			t.Errorf("spawn %d = %+v then %+v, want identical spawns for the same seed", i, firstSpawns[i], secondSpawns[i])
+		}
+	}
+	for i := range firstDamage {
+		if firstDamage[i] != secondDamage[i] {
+			t.Errorf("damage roll %d = %d then %d, want identical rolls for the same seed", i, firstDamage[i], secondDamage[i])
+		}
+	}
+	
+	otherSpawns, _ := run(8)
+	same := true
+	for i := range firstSpawns {
+		same = same && firstSpawns[i] == otherSpawns[i]
+	}
+	if same {
+		t.Errorf("seeds 7 and 8 spawned identical units, want the master seed to matter")
+	}
+}
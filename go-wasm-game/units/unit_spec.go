@@ -0,0 +1,64 @@
+package units
+
+import (
+	"fmt"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+)
+
+// UnitSpec fully describes a unit to create, e.g. when loading a saved game, a scenario or a replay
+// Zero values keep CreateUnit's defaults: level 1, no experience, team 0 and the type's full health
+type UnitSpec struct {
+	Type         entities.UnitType
+	TileX, TileY int
+	Name         string
+	Level        int
+	Experience   int
+	Health       int // Current health; 0 means full health
+	MaxHealth    int // 0 means the type's max health
+	Team         int
+	Kills, DamageDealt, DamageTaken int // Lifetime combat stats carried over from a save
+}
+
+// validate rejects specs whose stats can't describe a living unit
+func (spec UnitSpec) validate() error {
+	if spec.Level < 0 || spec.Experience < 0 || spec.Health < 0 || spec.MaxHealth < 0 {
+		return fmt.Errorf("%w: negative level, experience or health", ErrInvalidSpec)
+	}
+	if spec.Kills < 0 || spec.DamageDealt < 0 || spec.DamageTaken < 0 {
+		return fmt.Errorf("%w: negative combat stats", ErrInvalidSpec)
+	}
+	if spec.MaxHealth > 0 && spec.Health > spec.MaxHealth {
+		return fmt.Errorf("%w: health %d above max health %d", ErrInvalidSpec, spec.Health, spec.MaxHealth)
+	}
+	return nil
+}
+
+// CreateUnitFull creates a unit like CreateUnit, with the same placement validation, then applies the spec's stats
+func (um *UnitManager) CreateUnitFull(spec UnitSpec) (*Unit, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	if typeDef, exists := entities.UnitTypeDefinitions[spec.Type]; exists && spec.MaxHealth == 0 && spec.Health > typeDef.Stats.Health {
+		return nil, fmt.Errorf("%w: health %d above max health %d", ErrInvalidSpec, spec.Health, typeDef.Stats.Health)
+	}
+
+	unit, err := um.CreateUnit(spec.Type, spec.TileX, spec.TileY, spec.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.Level > 0 {
+		unit.Level = spec.Level
+	}
+	unit.Experience = spec.Experience
+	unit.Team = spec.Team
+	unit.Kills, unit.DamageDealt, unit.DamageTaken = spec.Kills, spec.DamageDealt, spec.DamageTaken
+	if spec.MaxHealth > 0 {
+		unit.MaxStats.Health = spec.MaxHealth
+		unit.CurrentStats.Health = spec.MaxHealth
+	}
+	if spec.Health > 0 {
+		unit.CurrentStats.Health = spec.Health
+	}
+	return unit, nil
+}
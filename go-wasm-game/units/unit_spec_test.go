@@ -0,0 +1,81 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"errors"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that a fully specified unit keeps the level, health, experience, team and name it was given
+func TestCreateUnitFullKeepsSpec(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	spec := units.UnitSpec{
+		Type: entities.UnitWarrior, TileX: 3, TileY: 4, Name: "Veteran",
+		Level: 4, Experience: 75, Health: 30, MaxHealth: 140, Team: 2,
+		Kills: 3, DamageDealt: 210, DamageTaken: 110,
+	}
+
+	unit, err := um.CreateUnitFull(spec)
+	if err != nil {
+		t.Fatalf("CreateUnitFull failed: %v", err)
+	}
+	if unit.Name != "Veteran" || unit.TileX != 3 || unit.TileY != 4 {
+		t.Errorf("unit %q at (%d, %d), want Veteran at (3, 4)", unit.Name, unit.TileX, unit.TileY)
+	}
+	if unit.Level != 4 || unit.Experience != 75 || unit.Team != 2 {
+		t.Errorf("level %d, experience %d, team %d, want 4, 75, 2", unit.Level, unit.Experience, unit.Team)
+	}
+	if unit.CurrentStats.Health != 30 || unit.MaxStats.Health != 140 {
+		t.Errorf("health %d/%d, want 30/140", unit.CurrentStats.Health, unit.MaxStats.Health)
+	}
+	if unit.Kills != 3 || unit.DamageDealt != 210 || unit.DamageTaken != 110 {
+		t.Errorf("kills %d, dealt %d, taken %d, want 3, 210, 110", unit.Kills, unit.DamageDealt, unit.DamageTaken)
+	}
+
+	// Unset fields keep CreateUnit's defaults
+	fresh, err := um.CreateUnitFull(units.UnitSpec{Type: entities.UnitWarrior, TileX: 5, TileY: 5})
+	if err != nil {
+		t.Fatalf("CreateUnitFull failed: %v", err)
+	}
+	typeDef := entities.UnitTypeDefinitions[entities.UnitWarrior]
+	if fresh.Level != 1 || fresh.CurrentStats.Health != typeDef.Stats.Health || fresh.MaxStats.Health != typeDef.Stats.Health {
+		t.Errorf("default spec gave level %d, health %d/%d, want a fresh level-1 unit", fresh.Level, fresh.CurrentStats.Health, fresh.MaxStats.Health)
+	}
+}
+
+// Test that CreateUnitFull applies the same placement validation as CreateUnit and rejects bad stats
+func TestCreateUnitFullValidation(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	if _, err := um.CreateUnitFull(units.UnitSpec{Type: entities.UnitWarrior, TileX: 1, TileY: 1}); err != nil {
+		t.Fatalf("CreateUnitFull failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		spec units.UnitSpec
+		want error
+	}{
+		{"out of bounds", units.UnitSpec{Type: entities.UnitWarrior, TileX: 12, TileY: 1}, units.ErrOutOfBounds},
+		{"occupied", units.UnitSpec{Type: entities.UnitWarrior, TileX: 1, TileY: 1}, units.ErrOccupied},
+		{"unknown type", units.UnitSpec{Type: entities.UnitType(99), TileX: 2, TileY: 2}, units.ErrUnknownUnitType},
+		{"health above max", units.UnitSpec{Type: entities.UnitWarrior, TileX: 2, TileY: 2, Health: 50, MaxHealth: 40}, units.ErrInvalidSpec},
+		{"health above type max", units.UnitSpec{Type: entities.UnitWarrior, TileX: 2, TileY: 2, Health: 1000}, units.ErrInvalidSpec},
+		{"negative level", units.UnitSpec{Type: entities.UnitWarrior, TileX: 2, TileY: 2, Level: -1}, units.ErrInvalidSpec},
+		{"negative kills", units.UnitSpec{Type: entities.UnitWarrior, TileX: 2, TileY: 2, Kills: -1}, units.ErrInvalidSpec},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := um.CreateUnitFull(tt.spec); !errors.Is(err, tt.want) {
+				t.Errorf("CreateUnitFull() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if count := len(um.GetAllUnits()); count != 1 {
+		t.Errorf("%d units after rejected specs, want only the first", count)
+	}
+}
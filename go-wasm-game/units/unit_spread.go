@@ -0,0 +1,90 @@
+package units
+
+// IdleSpacing configures the spread pass that nudges crowded idle units apart
+type IdleSpacing struct {
+	MinSpacing        int // Tiles (Chebyshev) idle units keep between each other; 1 only separates units sharing a tile, 0 disables
+	MaxNudgesPerFrame int // Most spread moves issued per Update, so a big cluster untangles over several frames
+}
+
+// DefaultMaxSpreadNudges is the per-frame nudge bound used when IdleSpacing.MaxNudgesPerFrame is 0
+const DefaultMaxSpreadNudges = 2
+
+// SetIdleSpacing turns the spread pass on (MinSpacing > 0) or off; it is off by default
+func (um *UnitManager) SetIdleSpacing(spacing IdleSpacing) {
+	um.spacing = spacing
+}
+
+// spreadIdleUnits moves crowded idle units to the nearest free tile that meets the minimum spacing.
+// Older units hold their ground and newer ones step aside, and units already walking are judged by
+// where they will stop, so two units never get nudged onto the same tile
+func (um *UnitManager) spreadIdleUnits() {
+	if um.spacing.MinSpacing <= 0 {
+		return
+	}
+	maxNudges := um.spacing.MaxNudgesPerFrame
+	if maxNudges <= 0 {
+		maxNudges = DefaultMaxSpreadNudges
+	}
+	
+	nudges := 0
+	ordered := um.OrderedUnits()
+	for i := len(ordered) - 1; i >= 0; i-- {
+		unit := ordered[i]
+		if nudges >= maxNudges {
+			return
+		}
+		if !unit.isIdle() || !um.crowded(unit, unit.TileX, unit.TileY) {
+			continue
+		}
+		if tileX, tileY, ok := um.spreadTile(unit); ok && um.MoveUnit(unit.ID, tileX, tileY) == nil {
+			nudges++
+		}
+	}
+}
+
+// isIdle reports whether a unit is standing around with nothing to do
+func (u *Unit) isIdle() bool {
+	return u.IsAlive && !u.IsCarried() && !u.IsMoving() && u.Status == StatusIdle &&
+		u.BuildOrder == nil && u.chaseTargetID == "" && len(u.MoveQueue) == 0
+}
+
+// restingTile returns where a unit will stand once it stops: its path's end while moving, else its tile
+func (u *Unit) restingTile() (int, int) {
+	if path := u.GetPath(); u.IsMoving() && len(path) > 0 {
+		end := path[len(path)-1]
+		return end.X, end.Y
+	}
+	return u.TileX, u.TileY
+}
+
+// crowded reports whether another unit will rest closer than the minimum spacing to (tileX, tileY)
+func (um *UnitManager) crowded(unit *Unit, tileX, tileY int) bool {
+	for _, other := range um.OrderedUnits() {
+		if other == unit || !other.IsAlive || other.IsCarried() {
+			continue
+		}
+		otherX, otherY := other.restingTile()
+		if chebyshevDistance(tileX, tileY, otherX, otherY) < um.spacing.MinSpacing {
+			return true
+		}
+	}
+	return false
+}
+
+// spreadTile finds the nearest free tile around a unit that isn't crowded, searching a few rings out
+func (um *UnitManager) spreadTile(unit *Unit) (int, int, bool) {
+	maxRadius := um.spacing.MinSpacing + 2
+	for radius := 1; radius <= maxRadius; radius++ {
+		for y := unit.TileY - radius; y <= unit.TileY+radius; y++ {
+			for x := unit.TileX - radius; x <= unit.TileX+radius; x++ {
+				if chebyshevDistance(x, y, unit.TileX, unit.TileY) != radius {
+					continue
+				}
+				if um.validatePosition(x, y) == nil && !um.crowded(unit, x, y) {
+					return x, y, true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,105 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// stackOnto slides a unit onto another unit's tile without pathing, as a formation move can leave them
+func stackOnto(um *units.UnitManager, unit, onto *units.Unit) {
+	unit.MovableEntity.SetPosition(onto.X, onto.Y)
+	um.Update()
+}
+
+// Test that co-located idle units are nudged onto distinct tiles, newest first, within the per-frame bound
+func TestSpreadSeparatesCoLocatedUnits(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	created := make([]*units.Unit, 0, 3)
+	for x := 4; x < 7; x++ {
+		unit, err := um.CreateUnit(entities.UnitWarrior, x, 4, "")
+		if err != nil {
+			t.Fatalf("CreateUnit failed: %v", err)
+		}
+		created = append(created, unit)
+	}
+	stackOnto(um, created[1], created[0])
+	stackOnto(um, created[2], created[0])
+	if created[2].TileX != 4 || created[1].TileX != 4 {
+		t.Fatalf("setup: units at x %d and %d, want all stacked on x 4", created[1].TileX, created[2].TileX)
+	}
+	
+	um.SetIdleSpacing(units.IdleSpacing{MinSpacing: 1, MaxNudgesPerFrame: 1})
+	um.Update()
+	if created[0].IsMoving() || created[1].IsMoving() || !created[2].IsMoving() {
+		t.Errorf("first frame moving = %v, %v, %v; want only the newest unit nudged", created[0].IsMoving(), created[1].IsMoving(), created[2].IsMoving())
+	}
+	
+	for frame := 0; frame < 200; frame++ {
+		um.Update()
+	}
+	seen := map[[2]int]string{}
+	for _, unit := range created {
+		tile := [2]int{unit.TileX, unit.TileY}
+		if other, taken := seen[tile]; taken {
+			t.Errorf("%s and %s both on %v", other, unit.ID, tile)
+		}
+		seen[tile] = unit.ID
+		if unit.IsMoving() {
+			t.Errorf("%s still moving after the spread settled", unit.ID)
+		}
+	}
+	if created[0].TileX != 4 || created[0].TileY != 4 {
+		t.Errorf("oldest unit moved to (%d, %d), want it to hold (4, 4)", created[0].TileX, created[0].TileY)
+	}
+}
+
+// Test that units already meeting the minimum spacing are never nudged, and adjacent ones are with spacing 2
+func TestSpreadLeavesSpacedUnitsAlone(t *testing.T) {
+	tests := []struct {
+		name       string
+		secondX    int
+		minSpacing int
+		wantNudge  bool
+	}{
+		{"neighbors with spacing 1", 3, 1, false},
+		{"two apart with spacing 2", 4, 2, false},
+		{"neighbors with spacing 2", 3, 2, true},
+		{"sharing nothing with the pass off", 3, 0, false},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			um := units.NewUnitManager(newTestMap(10, 10))
+			first, _ := um.CreateUnit(entities.UnitWarrior, 2, 2, "")
+			second, err := um.CreateUnit(entities.UnitWarrior, tt.secondX, 2, "")
+			if err != nil {
+				t.Fatalf("CreateUnit failed: %v", err)
+			}
+			um.SetIdleSpacing(units.IdleSpacing{MinSpacing: tt.minSpacing})
+			
+			nudged := false
+			for frame := 0; frame < 100; frame++ {
+				um.Update()
+				nudged = nudged || first.IsMoving() || second.IsMoving()
+			}
+			if nudged != tt.wantNudge {
+				t.Errorf("nudged = %v, want %v", nudged, tt.wantNudge)
+			}
+			dx := second.TileX - first.TileX
+			if dx < 0 {
+				dx = -dx
+			}
+			dy := second.TileY - first.TileY
+			if dy < 0 {
+				dy = -dy
+			}
+			if tt.minSpacing > 0 && dx < tt.minSpacing && dy < tt.minSpacing {
+				t.Errorf("units end %d, %d apart, want at least %d", dx, dy, tt.minSpacing)
+			}
+		})
+	}
+}
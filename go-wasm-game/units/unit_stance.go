@@ -0,0 +1,185 @@
+package units
+
+import (
+	"fmt"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+)
+
+// Stance controls how a unit reacts to enemies (units on a different team)
+type Stance int
+
+const (
+	StanceAggressive Stance = iota // Attacks enemies in range and chases nearby ones
+	StanceGuard                    // Holds position, attacking only enemies already in range
+	StancePassive                  // Never attacks on its own
+)
+
+const (
+	// attackAbility is the cooldown name used to pace automatic attacks
+	attackAbility = "attack"
+	// attackInterval is the time between automatic attacks from one unit
+	attackInterval = time.Second
+)
+
+// stanceNames maps the names used by the JS interface to stances
+var stanceNames = map[string]Stance{
+	"aggressive": StanceAggressive,
+	"guard":      StanceGuard,
+	"passive":    StancePassive,
+}
+
+// ParseStance converts a stance name ("aggressive", "guard", "passive") to a Stance
+func ParseStance(name string) (Stance, bool) {
+	stance, ok := stanceNames[name]
+	return stance, ok
+}
+
+// String returns the stance's name
+func (s Stance) String() string {
+	for name, stance := range stanceNames {
+		if stance == s {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+// SetStance changes how a unit reacts to enemies
+func (um *UnitManager) SetStance(unitID string, stance Stance) error {
+	unit := um.units[unitID]
+	if unit == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, unitID)
+	}
+	unit.Stance = stance
+	if stance != StanceAggressive {
+		unit.stopChasing()
+		unit.engaged = false
+	}
+	return nil
+}
+
+// InAttackRange reports whether target is within the attacker's reach, in tiles under the combat RangeMetric
+// (by default Chebyshev, so diagonal neighbors are at range 1)
+// Ranged attacks also need a clear line of sight, so walls and trees give cover
+func (um *UnitManager) InAttackRange(attacker, target *Unit) bool {
+	reach := attacker.EffectiveStats().Range
+	if reach < 1 {
+		reach = 1
+	}
+	return um.combatSystem.Distance(attacker, target) <= float64(reach) &&
+		systems.HasLineOfSight(attacker.TileX, attacker.TileY, target.TileX, target.TileY, um.gameMap)
+}
+
+// AttemptAttack queues an attack if the target is in range and the attacker's attack is off cooldown
+// Returns whether an attack was queued; no move order is ever issued
+func (um *UnitManager) AttemptAttack(attackerID, targetID string) (bool, error) {
+	attacker, target := um.units[attackerID], um.units[targetID]
+	if attacker == nil || target == nil {
+		return false, fmt.Errorf("%w: %s -> %s", ErrNotFound, attackerID, targetID)
+	}
+	if !attacker.IsAlive || !target.IsAlive || !um.InAttackRange(attacker, target) || !attacker.IsAbilityReady(attackAbility) {
+		return false, nil
+	}
+	
+	if err := um.QueueAttack(attackerID, targetID); err != nil {
+		return false, err
+	}
+	attacker.StartAbilityCooldown(attackAbility, attackInterval)
+	return true, nil
+}
+
+// updateStances runs automatic attacks (and aggressive chasing) for every living unit
+// A taunted unit reacts to its taunter instead of the nearest enemy. Once the enemy it engaged dies
+// or moves out of aggro range, an aggressive unit walks back to its home tile
+func (um *UnitManager) updateStances() {
+	for _, id := range um.unitOrder {
+		unit := um.units[id]
+		if unit == nil || !unit.IsAlive || unit.IsCarried() || unit.Stance == StancePassive {
+			continue
+		}
+		
+		enemy := um.stanceTarget(unit)
+		if enemy == nil {
+			unit.stopChasing()
+			unit.returnHome()
+			continue
+		}
+		
+		if um.InAttackRange(unit, enemy) {
+			// Close enough: a chase ends here, but a player's move order keeps going
+			unit.stopChasing()
+			um.AttemptAttack(unit.ID, enemy.ID)
+			continue
+		}
+		
+		// Only idle or already-chasing aggressive units go after enemies; guards hold position
+		if unit.Stance == StanceAggressive && tileDistance(unit, enemy) <= unit.aggroRange() && (!unit.IsMoving() || unit.chaseTargetID != "") {
+			unit.chaseTargetID = enemy.ID
+			unit.engaged = true
+			unit.RepathToTile(enemy.TileX, enemy.TileY)
+			continue
+		}
+		
+		// The enemy is out of aggro range: give up the chase and head home
+		unit.stopChasing()
+		unit.returnHome()
+	}
+}
+
+// nearestEnemy returns the closest living unit on another team, ties broken by creation order
+func (um *UnitManager) nearestEnemy(unit *Unit) *Unit {
+	var nearest *Unit
+	for _, id := range um.unitOrder {
+		other := um.units[id]
+		if other == nil || !other.IsAlive || other.IsCarried() || other.Team == unit.Team {
+			continue
+		}
+		if nearest == nil || tileDistance(unit, other) < tileDistance(unit, nearest) {
+			nearest = other
+		}
+	}
+	return nearest
+}
+
+// stopChasing ends an aggressive chase once the current step is finished, leaving ordered moves alone
+// On a smoothed path the current waypoint may be several tiles off, so the unit stops at the next tile toward it
+func (u *Unit) stopChasing() {
+	if u.chaseTargetID == "" {
+		return
+	}
+	u.chaseTargetID = ""
+	path, step := u.GetPath(), u.GetPathStep()
+	if step >= len(path) {
+		return
+	}
+	gameMap := u.movementSystem.GetGameMap()
+	tiles := systems.SegmentTiles(u.TileX, u.TileY, path[step].X, path[step].Y, gameMap)
+	if len(tiles) <= 1 {
+		u.SetPath(path[:step+1])
+		return
+	}
+	u.SetPath(append(append(systems.Path{}, path[:step]...), tiles[0]))
+	worldX, worldY := gameMap.GridToWorld(tiles[0].X, tiles[0].Y)
+	u.SetTarget(worldX-u.Width/2, worldY-u.Height/2)
+}
+
+// tileDistance is the Chebyshev distance between two units' tiles
+func tileDistance(a, b *Unit) int {
+	return chebyshevDistance(a.TileX, a.TileY, b.TileX, b.TileY)
+}
+
+// chebyshevDistance counts tile steps between two tiles when diagonals count as 1
+func chebyshevDistance(x1, y1, x2, y2 int) int {
+	dx, dy := x1-x2, y1-y2
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
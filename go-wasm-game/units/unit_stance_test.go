@@ -0,0 +1,150 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// createEnemy creates a warrior on team 1 at the given tile
+func createEnemy(t *testing.T, um *units.UnitManager, tileX, tileY int) *units.Unit {
+	t.Helper()
+	enemy, err := um.CreateUnit(entities.UnitWarrior, tileX, tileY, "")
+	if err != nil {
+		t.Fatalf("CreateUnit(%d, %d) failed: %v", tileX, tileY, err)
+	}
+	um.SetUnitTeam(enemy.ID, 1)
+	return enemy
+}
+
+// Test that a guard attacks an enemy inside its range
+func TestGuardAttacksInRangeEnemy(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	guard := createUnits(t, um, 1)[0]
+	um.SetStance(guard.ID, units.StanceGuard)
+	enemy := createEnemy(t, um, 1, 1)
+	
+	um.Update()
+	if enemy.CurrentStats.Health >= enemy.MaxStats.Health {
+		t.Errorf("enemy health = %d, want the guard to have hit it", enemy.CurrentStats.Health)
+	}
+	if guard.Status != units.StatusAttacking {
+		t.Errorf("guard status = %q, want %q", guard.Status, units.StatusAttacking)
+	}
+}
+
+// Test that a guard holds position against an enemy out of range, while an aggressive unit closes in
+func TestGuardDoesNotChase(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	guard := createUnits(t, um, 1)[0]
+	um.SetStance(guard.ID, units.StanceGuard)
+	enemy := createEnemy(t, um, 4, 0)
+	enemy.Stance = units.StancePassive
+	
+	for frame := 0; frame < 200; frame++ {
+		um.Update()
+		if guard.IsMoving() || guard.TileX != 0 || guard.TileY != 0 {
+			t.Fatalf("frame %d: guard moved to (%d, %d), want it to hold (0, 0)", frame, guard.TileX, guard.TileY)
+		}
+	}
+	if enemy.CurrentStats.Health != enemy.MaxStats.Health {
+		t.Errorf("out-of-range enemy took damage, health %d", enemy.CurrentStats.Health)
+	}
+	
+	// Switching to aggressive makes it go after the enemy and stop once in reach
+	um.SetStance(guard.ID, units.StanceAggressive)
+	for frame := 0; frame < 500; frame++ {
+		um.Update()
+	}
+	if !um.InAttackRange(guard, enemy) {
+		t.Errorf("aggressive unit at (%d, %d) never reached the enemy at (%d, %d)", guard.TileX, guard.TileY, enemy.TileX, enemy.TileY)
+	}
+	if enemy.CurrentStats.Health == enemy.MaxStats.Health {
+		t.Error("aggressive unit should attack once it closes in")
+	}
+}
+
+// Test that ending a chase along a smoothed path stops at the next tile, not at a waypoint tiles away
+func TestStopChasingOnSmoothedPath(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(12, 3))
+	chaser := createUnits(t, um, 1)[0]
+	um.SetStance(chaser.ID, units.StanceAggressive)
+	enemy := createEnemy(t, um, 5, 0)
+	enemy.Stance = units.StancePassive
+	um.Update()
+	if !chaser.IsMoving() {
+		t.Fatal("setup: aggressive unit should be chasing the enemy")
+	}
+	
+	// Swap in a smoothed route whose current waypoint lies four tiles ahead
+	chaser.SetPath(systems.Path{{X: 0, Y: 0}, {X: 4, Y: 0}})
+	chaser.SetPathStep(1)
+	um.SetStance(chaser.ID, units.StanceGuard)
+	path := chaser.GetPath()
+	if last := path[len(path)-1]; last.X != 1 || last.Y != 0 {
+		t.Errorf("path after the chase ends = %v, want it to stop at the next tile (1, 0)", path)
+	}
+	runUntilStopped(um, chaser)
+	if chaser.TileX != 1 || chaser.TileY != 0 {
+		t.Errorf("unit stopped at (%d, %d), want (1, 0)", chaser.TileX, chaser.TileY)
+	}
+}
+
+// Test that passive units never attack, and that teammates are never targeted
+func TestPassiveAndTeammatesDoNotAttack(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	created := createUnits(t, um, 2) // Same team, adjacent
+	um.SetStance(created[0].ID, units.StancePassive)
+	enemy := createEnemy(t, um, 0, 1)
+	enemy.Stance = units.StancePassive
+	
+	um.Update()
+	if enemy.CurrentStats.Health >= enemy.MaxStats.Health {
+		t.Error("the aggressive teammate should still attack the adjacent enemy")
+	}
+	if created[1].CurrentStats.Health != created[1].MaxStats.Health || created[0].CurrentStats.Health != created[0].MaxStats.Health {
+		t.Error("teammates should never be attacked")
+	}
+	if created[0].Status == units.StatusAttacking {
+		t.Error("a passive unit should never attack")
+	}
+	
+	if stance, ok := units.ParseStance("guard"); !ok || stance != units.StanceGuard || stance.String() != "guard" {
+		t.Errorf("ParseStance(\"guard\") = %v, %v", stance, ok)
+	}
+}
+
+// Test that ranged attacks need line of sight: a wall gives cover, low water does not
+func TestRangedAttackNeedsLineOfSight(t *testing.T) {
+	tests := []struct {
+		name    string
+		between world.TileType
+		inRange bool
+	}{
+		{"open ground", world.TileGrass, true},
+		{"wall gives cover", world.TileWall, false},
+		{"water does not", world.TileWater, true},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := newTestMap(10, 10)
+			um := units.NewUnitManager(gameMap)
+			archer, err := um.CreateUnit(entities.UnitArcher, 1, 4, "")
+			if err != nil {
+				t.Fatalf("CreateUnit(archer) failed: %v", err)
+			}
+			enemy := createEnemy(t, um, 4, 4)
+			gameMap.SetTile(2, 4, tt.between)
+			
+			if got := um.InAttackRange(archer, enemy); got != tt.inRange {
+				t.Errorf("InAttackRange() = %v, want %v", got, tt.inRange)
+			}
+		})
+	}
+}
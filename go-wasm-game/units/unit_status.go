@@ -0,0 +1,35 @@
+package units
+
+// Unit status values reported to the UI through getUnits
+const (
+	StatusIdle      = "idle"
+	StatusMoving    = "moving"
+	StatusAttacking = "attacking"
+	StatusFleeing   = "fleeing"
+	StatusBuilding  = "building"
+	StatusDead      = "dead"
+)
+
+// SetStatus is the single place unit status transitions happen
+// Dead units keep their "dead" status regardless of later requests
+func (u *Unit) SetStatus(status string) {
+	if u.Status == StatusDead {
+		return
+	}
+	u.Status = status
+}
+
+// FleeToTile moves the unit like MoveToTile but reports it as fleeing
+func (u *Unit) FleeToTile(tileX, tileY int) {
+	u.MoveToTile(tileX, tileY)
+	if u.IsMoving() {
+		u.SetStatus(StatusFleeing)
+	}
+}
+
+// updateMovementStatus returns a unit to idle once the movement it was reporting has finished
+func (u *Unit) updateMovementStatus(wasMoving bool) {
+	if wasMoving && !u.IsMoving() && (u.Status == StatusMoving || u.Status == StatusFleeing) {
+		u.SetStatus(StatusIdle)
+	}
+}
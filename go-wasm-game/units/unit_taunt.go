@@ -0,0 +1,76 @@
+package units
+
+import (
+	"fmt"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+)
+
+const (
+	// TauntAbility is the cooldown name of the warrior's taunt
+	TauntAbility = "taunt"
+	// TauntCooldown is how long a warrior waits between taunts
+	TauntCooldown = 10 * time.Second
+	// TauntDuration is how long taunted enemies stay fixed on the warrior
+	TauntDuration = 4 * time.Second
+	// TauntRadius is how many tiles away (diagonals count as 1) a taunt reaches
+	TauntRadius = 4
+)
+
+// Taunt makes every enemy within TauntRadius that fights on its own target the warrior for TauntDuration
+// Passive enemies ignore it. Returns how many enemies were taunted
+func (um *UnitManager) Taunt(warriorID string) (int, error) {
+	warrior := um.units[warriorID]
+	if warrior == nil {
+		return 0, fmt.Errorf("%w: %s", ErrNotFound, warriorID)
+	}
+	if !warrior.IsAlive {
+		return 0, fmt.Errorf("cannot taunt with %s: %w", warriorID, ErrUnitDead)
+	}
+	if warrior.TypeID != entities.UnitWarrior || warrior.IsCarried() {
+		return 0, fmt.Errorf("%w: %s cannot taunt", ErrAbilityUnavailable, warriorID)
+	}
+	if !warrior.IsAbilityReady(TauntAbility) {
+		return 0, fmt.Errorf("%w: %s taunt is on cooldown", ErrAbilityUnavailable, warriorID)
+	}
+
+	until := warrior.now().Add(TauntDuration)
+	taunted := 0
+	for _, id := range um.unitOrder {
+		enemy := um.units[id]
+		if enemy == nil || !enemy.IsAlive || enemy.IsCarried() || enemy.Team == warrior.Team || enemy.Stance == StancePassive {
+			continue
+		}
+		if tileDistance(warrior, enemy) > TauntRadius {
+			continue
+		}
+		enemy.TauntedBy = warriorID
+		enemy.TauntUntil = until
+		taunted++
+	}
+	warrior.StartAbilityCooldown(TauntAbility, TauntCooldown)
+	return taunted, nil
+}
+
+// IsTaunted reports whether the unit is currently forced to target its taunter
+func (u *Unit) IsTaunted() bool {
+	return u.TauntedBy != "" && u.now().Before(u.TauntUntil)
+}
+
+// stanceTarget picks who a unit's stance reacts to: a live taunter overrides the nearest enemy
+func (um *UnitManager) stanceTarget(unit *Unit) *Unit {
+	if unit.TauntedBy != "" {
+		taunter := um.units[unit.TauntedBy]
+		if unit.IsTaunted() && taunter != nil && taunter.IsAlive && !taunter.IsCarried() {
+			return taunter
+		}
+		unit.clearTaunt()
+	}
+	return um.nearestEnemy(unit)
+}
+
+// clearTaunt releases a unit from a taunt once it expires or its taunter is gone
+func (u *Unit) clearTaunt() {
+	u.TauntedBy = ""
+	u.TauntUntil = time.Time{}
+}
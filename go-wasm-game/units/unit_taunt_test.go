@@ -0,0 +1,93 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// tauntFixture places a warrior, an ally next to an enemy archer on guard, and an enemy out of taunt range
+func tauntFixture(t *testing.T) (*units.UnitManager, *fakeClock, *units.Unit, *units.Unit, *units.Unit, *units.Unit) {
+	t.Helper()
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	um := units.NewUnitManager(newTestMap(12, 12))
+	um.SetClock(clock.Now)
+	
+	warrior, err := um.CreateUnit(entities.UnitWarrior, 5, 5, "")
+	if err != nil {
+		t.Fatalf("CreateUnit(warrior) failed: %v", err)
+	}
+	ally, _ := um.CreateUnit(entities.UnitWarrior, 0, 5, "")
+	ally.Stance = units.StancePassive
+	archer, _ := um.CreateUnit(entities.UnitArcher, 1, 5, "")
+	um.SetUnitTeam(archer.ID, 1)
+	archer.Stance = units.StanceGuard
+	far := createEnemy(t, um, 11, 11)
+	far.Stance = units.StanceGuard
+	warrior.Stance = units.StancePassive
+	return um, clock, warrior, ally, archer, far
+}
+
+// Test that enemies inside the taunt radius switch their attacks to the warrior, and others are left alone
+func TestTauntRetargetsNearbyEnemies(t *testing.T) {
+	um, _, warrior, ally, archer, far := tauntFixture(t)
+	
+	taunted, err := um.Taunt(warrior.ID)
+	if err != nil {
+		t.Fatalf("Taunt() failed: %v", err)
+	}
+	if taunted != 1 {
+		t.Errorf("Taunt() affected %d enemies, want 1", taunted)
+	}
+	if archer.TauntedBy != warrior.ID || !archer.IsTaunted() {
+		t.Errorf("archer TauntedBy = %q, want %q", archer.TauntedBy, warrior.ID)
+	}
+	if far.TauntedBy != "" {
+		t.Errorf("enemy outside the radius was taunted by %q", far.TauntedBy)
+	}
+	
+	// The ally is the archer's nearest enemy, but the taunt pulls the shot onto the warrior
+	um.Update()
+	if warrior.CurrentStats.Health >= warrior.MaxStats.Health {
+		t.Errorf("warrior health = %d, want the taunted archer to have hit it", warrior.CurrentStats.Health)
+	}
+	if ally.CurrentStats.Health != ally.MaxStats.Health {
+		t.Errorf("ally health = %d, want it untouched while the archer is taunted", ally.CurrentStats.Health)
+	}
+	
+	if _, err := um.Taunt(warrior.ID); !errors.Is(err, units.ErrAbilityUnavailable) {
+		t.Errorf("Taunt() on cooldown error = %v, want ErrAbilityUnavailable", err)
+	}
+	if _, err := um.Taunt(archer.ID); !errors.Is(err, units.ErrAbilityUnavailable) {
+		t.Errorf("Taunt() by an archer error = %v, want ErrAbilityUnavailable", err)
+	}
+}
+
+// Test that a taunt wears off after its duration and the enemy returns to its nearest target
+func TestTauntExpires(t *testing.T) {
+	um, clock, warrior, ally, archer, _ := tauntFixture(t)
+	if _, err := um.Taunt(warrior.ID); err != nil {
+		t.Fatalf("Taunt() failed: %v", err)
+	}
+	
+	clock.Advance(units.TauntDuration + time.Millisecond)
+	if archer.IsTaunted() {
+		t.Errorf("IsTaunted() = true after the duration, want false")
+	}
+	
+	um.Update()
+	if archer.TauntedBy != "" {
+		t.Errorf("TauntedBy = %q after expiry, want cleared", archer.TauntedBy)
+	}
+	if ally.CurrentStats.Health >= ally.MaxStats.Health {
+		t.Errorf("ally health = %d, want the archer back on its nearest enemy", ally.CurrentStats.Health)
+	}
+	if warrior.CurrentStats.Health != warrior.MaxStats.Health {
+		t.Errorf("warrior health = %d, want it untouched after the taunt expired", warrior.CurrentStats.Health)
+	}
+}
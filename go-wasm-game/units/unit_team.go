@@ -0,0 +1,72 @@
+package units
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// teamTintWeight is how strongly a team color is mixed into a unit's native color
+const teamTintWeight = 0.5
+
+// TeamColors maps team numbers to their tint color; team 0 always uses each type's native color
+var TeamColors = map[int]string{}
+
+// SetTeamColor assigns the tint color for a team (hex "#RRGGBB")
+func SetTeamColor(team int, color string) error {
+	if team == 0 {
+		return fmt.Errorf("team 0 always uses native unit colors")
+	}
+	if _, _, _, ok := parseHexColor(color); !ok {
+		return fmt.Errorf("invalid team color %q, want #RRGGBB", color)
+	}
+	TeamColors[team] = color
+	return nil
+}
+
+// SetUnitTeam moves a unit onto a team
+func (um *UnitManager) SetUnitTeam(unitID string, team int) error {
+	unit := um.units[unitID]
+	if unit == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, unitID)
+	}
+	unit.Team = team
+	return nil
+}
+
+// teamColor returns the unit's team tint, or "" if it has none
+func (u *Unit) teamColor() string {
+	if u.Team == 0 {
+		return ""
+	}
+	return TeamColors[u.Team]
+}
+
+// TeamTintedColor blends a unit's native color toward its team color
+// An empty or unparsable team color (or base) leaves base unchanged
+func TeamTintedColor(base, teamColor string) string {
+	br, bg, bb, ok := parseHexColor(base)
+	if !ok {
+		return base
+	}
+	tr, tg, tb, ok := parseHexColor(teamColor)
+	if !ok {
+		return base
+	}
+	mix := func(b, t int) int {
+		return int(float64(b)*(1-teamTintWeight) + float64(t)*teamTintWeight + 0.5)
+	}
+	return fmt.Sprintf("#%02X%02X%02X", mix(br, tr), mix(bg, tg), mix(bb, tb))
+}
+
+// parseHexColor parses "#RRGGBB" into its channels
+func parseHexColor(color string) (int, int, int, bool) {
+	if len(color) != 7 || !strings.HasPrefix(color, "#") {
+		return 0, 0, 0, false
+	}
+	value, err := strconv.ParseUint(color[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return int(value >> 16 & 0xFF), int(value >> 8 & 0xFF), int(value & 0xFF), true
+}
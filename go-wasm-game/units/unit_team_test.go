@@ -0,0 +1,60 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that team tints blend the native color halfway toward the team color
+func TestTeamTintedColor(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      string
+		teamColor string
+		expected  string
+	}{
+		{"Blend toward red", "#000000", "#FF0000", "#800000"},
+		{"Blend mixed channels", "#8B4513", "#0000FF", "#462389"},
+		{"Same color is unchanged", "#228B22", "#228B22", "#228B22"},
+		{"No team color keeps native", "#8B4513", "", "#8B4513"},
+		{"Invalid team color keeps native", "#8B4513", "blue", "#8B4513"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := units.TeamTintedColor(tt.base, tt.teamColor); got != tt.expected {
+				t.Errorf("TeamTintedColor(%q, %q) = %q, want %q", tt.base, tt.teamColor, got, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that team 0 can't be recolored while other teams accept valid hex colors
+func TestSetTeamColor(t *testing.T) {
+	defer delete(units.TeamColors, 2)
+	
+	if err := units.SetTeamColor(0, "#FF0000"); err == nil {
+		t.Error("SetTeamColor(0) should be rejected so team 0 keeps native colors")
+	}
+	if _, exists := units.TeamColors[0]; exists {
+		t.Error("team 0 should have no tint color")
+	}
+	if err := units.SetTeamColor(2, "#00FF00"); err != nil || units.TeamColors[2] != "#00FF00" {
+		t.Errorf("SetTeamColor(2) = %v, TeamColors[2] = %q", err, units.TeamColors[2])
+	}
+	if err := units.SetTeamColor(2, "green"); err == nil {
+		t.Error("SetTeamColor should reject non-hex colors")
+	}
+	
+	um := units.NewUnitManager(newTestMap(5, 5))
+	unit := createUnits(t, um, 1)[0]
+	if unit.Team != 0 {
+		t.Errorf("new unit team = %d, want 0", unit.Team)
+	}
+	if err := um.SetUnitTeam(unit.ID, 2); err != nil || unit.Team != 2 {
+		t.Errorf("SetUnitTeam() = %v, team = %d, want 2", err, unit.Team)
+	}
+}
@@ -0,0 +1,346 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// fakeClock is a manually advanced clock for timed unit behavior
+type fakeClock struct {
+	current time.Time
+}
+
+func (fc *fakeClock) Now() time.Time { return fc.current }
+
+func (fc *fakeClock) Advance(d time.Duration) { fc.current = fc.current.Add(d) }
+
+// Test that the cooldown fraction decreases from 1 to 0 over the cooldown duration
+func TestAbilityCooldownFraction(t *testing.T) {
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	um := units.NewUnitManager(newTestMap(5, 5))
+	um.SetClock(clock.Now)
+	unit, err := um.CreateUnit(entities.UnitMage, 1, 1, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	
+	if fraction := unit.AbilityCooldownFraction("heal"); fraction != 0 {
+		t.Errorf("unused ability fraction = %v, want 0", fraction)
+	}
+	
+	unit.StartAbilityCooldown("heal", 10*time.Second)
+	
+	steps := []struct {
+		elapsed  time.Duration
+		expected float64
+	}{
+		{0, 1.0},
+		{2500 * time.Millisecond, 0.75},
+		{5 * time.Second, 0.5},
+		{9 * time.Second, 0.1},
+		{10 * time.Second, 0.0},
+		{30 * time.Second, 0.0},
+	}
+	
+	start := clock.current
+	previous := 1.0
+	for _, step := range steps {
+		clock.current = start.Add(step.elapsed)
+		fraction := unit.AbilityCooldownFraction("heal")
+		if math.Abs(fraction-step.expected) > 1e-9 {
+			t.Errorf("after %v fraction = %v, want %v", step.elapsed, fraction, step.expected)
+		}
+		if fraction > previous {
+			t.Errorf("fraction increased from %v to %v at %v", previous, fraction, step.elapsed)
+		}
+		previous = fraction
+	}
+	
+	if !unit.IsAbilityReady("heal") {
+		t.Error("ability should be ready once the cooldown has elapsed")
+	}
+	if len(unit.ActiveCooldowns()) != 0 {
+		t.Errorf("ActiveCooldowns() = %v, want none", unit.ActiveCooldowns())
+	}
+}
+
+// Test that moving a unit reports "moving" and reaching the destination returns it to "idle"
+func TestUnitStatusFollowsMovement(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	unit, err := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	if unit.Status != units.StatusIdle {
+		t.Fatalf("new unit status = %q, want %q", unit.Status, units.StatusIdle)
+	}
+	
+	if err := um.MoveUnit(unit.ID, 4, 1); err != nil {
+		t.Fatalf("MoveUnit failed: %v", err)
+	}
+	if unit.Status != units.StatusMoving {
+		t.Errorf("status after MoveUnit = %q, want %q", unit.Status, units.StatusMoving)
+	}
+	
+	for frame := 0; frame < 500 && unit.IsMoving(); frame++ {
+		um.Update()
+	}
+	if unit.IsMoving() {
+		t.Fatal("unit never reached its destination")
+	}
+	if unit.TileX != 4 || unit.TileY != 1 {
+		t.Errorf("unit stopped at (%d, %d), want (4, 1)", unit.TileX, unit.TileY)
+	}
+	if unit.Status != units.StatusIdle {
+		t.Errorf("status after arriving = %q, want %q", unit.Status, units.StatusIdle)
+	}
+}
+
+// Test that attacking sets "attacking" until the unit stops queuing attacks, and death is final
+func TestUnitStatusFollowsCombat(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(5, 5))
+	attacker, _ := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	target, _ := um.CreateUnit(entities.UnitWarrior, 2, 1, "")
+	
+	um.QueueAttack(attacker.ID, target.ID)
+	um.Update()
+	if attacker.Status != units.StatusAttacking {
+		t.Errorf("status after attacking = %q, want %q", attacker.Status, units.StatusAttacking)
+	}
+	
+	um.Update()
+	if attacker.Status != units.StatusIdle {
+		t.Errorf("status after a frame without attacks = %q, want %q", attacker.Status, units.StatusIdle)
+	}
+	
+	um.DamageUnit(target.ID, 1000)
+	target.SetStatus(units.StatusMoving)
+	if target.Status != units.StatusDead {
+		t.Errorf("dead unit status = %q, want %q", target.Status, units.StatusDead)
+	}
+}
+
+// Test that repaths are gated by the repath interval and allowed again once it elapses
+func TestRepathThrottling(t *testing.T) {
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	um := units.NewUnitManager(newTestMap(10, 10))
+	um.SetClock(clock.Now)
+	unit, err := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	unit.SetRepathInterval(time.Second)
+	
+	if !unit.CanRepath(clock.Now()) {
+		t.Fatal("a unit that never repathed should be allowed to")
+	}
+	if !unit.RepathToTile(5, 1) {
+		t.Fatal("first RepathToTile should not be throttled")
+	}
+	
+	steps := []struct {
+		advance time.Duration
+		target  int
+		allowed bool
+	}{
+		{300 * time.Millisecond, 6, false},
+		{600 * time.Millisecond, 7, false},
+		{100 * time.Millisecond, 8, true}, // Exactly one second after the first repath
+		{500 * time.Millisecond, 9, false},
+	}
+	for _, step := range steps {
+		clock.Advance(step.advance)
+		if allowed := unit.RepathToTile(step.target, 1); allowed != step.allowed {
+			t.Errorf("RepathToTile(%d, 1) allowed = %v, want %v", step.target, allowed, step.allowed)
+		}
+	}
+	
+	// Throttled calls leave the current path in place
+	path := unit.GetPath()
+	if last := path[len(path)-1]; last.X != 8 {
+		t.Errorf("path ends at x = %d, want 8 from the last allowed repath", last.X)
+	}
+}
+
+// Test that without SetClock the repath interval runs on simulated time, so it waits out paused frames
+func TestRepathThrottlingUsesSimulatedTime(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	unit, err := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	unit.SetRepathInterval(100 * time.Millisecond)
+	if !unit.RepathToTile(5, 1) {
+		t.Fatal("first RepathToTile should not be throttled")
+	}
+	
+	// Wall time passing without simulation (a paused game) must not end the cooldown
+	time.Sleep(150 * time.Millisecond)
+	if unit.RepathToTile(6, 1) {
+		t.Error("RepathToTile allowed after wall time alone, want it throttled until simulated time passes")
+	}
+	
+	um.UpdateDT(100 * time.Millisecond)
+	if !unit.RepathToTile(7, 1) {
+		t.Error("RepathToTile throttled after 100ms of simulated time, want it allowed")
+	}
+}
+
+// Test that the arrival callback fires exactly once, at path completion, and is dropped on removal
+func TestArrivalCallback(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	unit, err := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	
+	calls := 0
+	arrivedAt := [2]int{}
+	if err := um.SetArrivalCallback(unit.ID, func() {
+		calls++
+		arrivedAt = [2]int{unit.TileX, unit.TileY}
+	}); err != nil {
+		t.Fatalf("SetArrivalCallback failed: %v", err)
+	}
+	um.MoveUnit(unit.ID, 5, 1)
+	
+	// Passing through intermediate tiles must not fire the callback
+	for frame := 0; frame < 500 && unit.IsMoving(); frame++ {
+		um.Update()
+		if unit.IsMoving() && calls != 0 {
+			t.Fatalf("callback fired mid-path at (%d, %d)", unit.TileX, unit.TileY)
+		}
+	}
+	if calls != 1 || arrivedAt != [2]int{5, 1} {
+		t.Fatalf("calls = %d at %v, want 1 at (5, 1)", calls, arrivedAt)
+	}
+	
+	// Cleared after firing: later moves don't call it again
+	um.MoveUnit(unit.ID, 2, 1)
+	for frame := 0; frame < 500 && unit.IsMoving(); frame++ {
+		um.Update()
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d after a second move, want the callback to have been cleared", calls)
+	}
+	
+	// A removed unit's callback is dropped
+	um.SetArrivalCallback(unit.ID, func() { calls++ })
+	um.MoveUnit(unit.ID, 6, 1)
+	um.RemoveUnit(unit.ID)
+	for frame := 0; frame < 500; frame++ {
+		um.Update()
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, removed unit's callback should never fire", calls)
+	}
+	if err := um.SetArrivalCallback(unit.ID, func() {}); !errors.Is(err, units.ErrNotFound) {
+		t.Errorf("SetArrivalCallback on removed unit error = %v, want ErrNotFound", err)
+	}
+}
+
+// Test that a unit stopping short of its destination drops the arrival callback instead of firing it
+func TestArrivalCallbackDroppedWhenStoppedShort(t *testing.T) {
+	gameMap := newTestMap(10, 10)
+	um := units.NewUnitManager(gameMap)
+	unit, err := um.CreateUnit(entities.UnitWarrior, 1, 1, "")
+	if err != nil {
+		t.Fatalf("CreateUnit failed: %v", err)
+	}
+	
+	calls := 0
+	um.SetArrivalCallback(unit.ID, func() { calls++ })
+	um.MoveUnit(unit.ID, 8, 1)
+	um.Update()
+	
+	// A river across the whole map leaves no route, so the unit stops where it is
+	for y := 0; y < 10; y++ {
+		gameMap.SetTile(5, y, world.TileWater)
+	}
+	um.RepathAfterTileChange(5, 1)
+	for frame := 0; frame < 500; frame++ {
+		um.Update()
+	}
+	if unit.IsMoving() || unit.TileX >= 5 {
+		t.Fatalf("unit should have stopped short of the river, at (%d, %d) moving %v", unit.TileX, unit.TileY, unit.IsMoving())
+	}
+	if calls != 0 {
+		t.Fatalf("calls = %d, want 0 when the unit never reached (8, 1)", calls)
+	}
+	
+	// The dropped callback doesn't fire on a later arrival either
+	um.MoveUnit(unit.ID, 1, 3)
+	for frame := 0; frame < 500 && unit.IsMoving(); frame++ {
+		um.Update()
+	}
+	if unit.TileX != 1 || unit.TileY != 3 || calls != 0 {
+		t.Errorf("after a later move unit at (%d, %d), calls = %d, want (1, 3) and 0", unit.TileX, unit.TileY, calls)
+	}
+}
+
+// Test that several units share one flow field and each stops on arrival
+func TestMoveUnitsByFlowField(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	ids := []string{}
+	for _, pos := range [][2]int{{0, 0}, {0, 9}, {9, 9}} {
+		unit, err := um.CreateUnit(entities.UnitWarrior, pos[0], pos[1], "")
+		if err != nil {
+			t.Fatalf("CreateUnit failed: %v", err)
+		}
+		ids = append(ids, unit.ID)
+	}
+	
+	if err := um.MoveUnitsByFlowField(ids, 5, 5); err != nil {
+		t.Fatalf("MoveUnitsByFlowField failed: %v", err)
+	}
+	for frame := 0; frame < 2000; frame++ {
+		um.Update()
+	}
+	for _, id := range ids {
+		unit := um.GetUnit(id)
+		if unit.TileX != 5 || unit.TileY != 5 {
+			t.Errorf("%s ended at (%d, %d), want (5, 5)", id, unit.TileX, unit.TileY)
+		}
+		if unit.IsFollowingFlowField() || unit.Status != units.StatusIdle {
+			t.Errorf("%s should leave flow-field mode and go idle at the goal, status %q", id, unit.Status)
+		}
+	}
+	
+	if err := um.MoveUnitsByFlowField([]string{"missing"}, 5, 5); !errors.Is(err, units.ErrNotFound) {
+		t.Errorf("MoveUnitsByFlowField(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+// Test that queued moves are walked one after another in the order they were queued
+func TestQueuedMovesRunInOrder(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	unit := createUnits(t, um, 1)[0]
+	waypoints := [][2]int{{3, 0}, {3, 3}, {0, 3}}
+	for _, wp := range waypoints {
+		if err := um.QueueMove(unit.ID, wp[0], wp[1]); err != nil {
+			t.Fatalf("QueueMove(%v) failed: %v", wp, err)
+		}
+	}
+	
+	reached := 0
+	for frame := 0; frame < 2000 && (reached < len(waypoints) || unit.IsMoving()); frame++ {
+		um.Update()
+		if reached < len(waypoints) && unit.TileX == waypoints[reached][0] && unit.TileY == waypoints[reached][1] {
+			reached++
+		}
+	}
+	if reached != len(waypoints) {
+		t.Errorf("reached %d of %d waypoints in order, unit ended at (%d, %d)", reached, len(waypoints), unit.TileX, unit.TileY)
+	}
+	if unit.IsMoving() || len(unit.MoveQueue) != 0 {
+		t.Errorf("unit still moving with %d queued, want it stopped with an empty queue", len(unit.MoveQueue))
+	}
+}
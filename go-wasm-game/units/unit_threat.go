@@ -0,0 +1,116 @@
+package units
+
+import (
+	"sort"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/systems"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+const (
+	// ThreatFalloffTiles is how many tiles past its attack range an enemy's threat fades out over
+	ThreatFalloffTiles = 4
+	// ThreatMapInterval is how long UnitManager.ThreatMap reuses a computed map before recomputing it
+	ThreatMapInterval = 500 * time.Millisecond
+)
+
+// cachedThreatMap is a team's threat map and when it was computed
+type cachedThreatMap struct {
+	grid       [][]float64
+	computedAt time.Time
+}
+
+// ComputeThreatMap returns, per tile ([y][x]), how much enemy damage could reach forTeam there.
+// Each alive enemy contributes its full damage on tiles within its attack range, falling off
+// as 1/(1+n) for tiles n steps beyond it, and nothing past ThreatFalloffTiles. Friendly units add nothing
+func ComputeThreatMap(units map[string]*Unit, forTeam int, gameMap *world.Map) [][]float64 {
+	threat := make([][]float64, gameMap.Height)
+	for y := range threat {
+		threat[y] = make([]float64, gameMap.Width)
+	}
+	
+	// Sum in a fixed order so float rounding, and so every threat value, is reproducible
+	ids := make([]string, 0, len(units))
+	for id := range units {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	
+	for _, id := range ids {
+		enemy := units[id]
+		if enemy == nil || !enemy.IsAlive || enemy.IsCarried() || enemy.Team == forTeam {
+			continue
+		}
+		stats := enemy.EffectiveStats()
+		if stats.Damage <= 0 {
+			continue
+		}
+		attackRange := stats.Range
+		if attackRange < 1 {
+			attackRange = 1
+		}
+		reach := attackRange + ThreatFalloffTiles
+		damage := float64(stats.Damage)
+		
+		for y := enemy.TileY - reach; y <= enemy.TileY+reach; y++ {
+			if y < 0 || y >= gameMap.Height {
+				continue
+			}
+			for x := enemy.TileX - reach; x <= enemy.TileX+reach; x++ {
+				if x < 0 || x >= gameMap.Width {
+					continue
+				}
+				beyond := chebyshevDistance(x, y, enemy.TileX, enemy.TileY) - attackRange
+				if beyond <= 0 {
+					threat[y][x] += damage
+				} else {
+					threat[y][x] += damage / float64(1+beyond)
+				}
+			}
+		}
+	}
+	return threat
+}
+
+// ThreatMap returns the threat map for a team, recomputed at most once per ThreatMapInterval
+// Callers must treat the result as read-only; it is shared until the next recompute
+func (um *UnitManager) ThreatMap(forTeam int) [][]float64 {
+	now := time.Now()
+	if um.clock != nil {
+		now = um.clock()
+	}
+	
+	cached := um.threatMaps[forTeam]
+	if cached != nil && now.Sub(cached.computedAt) < ThreatMapInterval {
+		return cached.grid
+	}
+	if um.threatMaps == nil {
+		um.threatMaps = make(map[int]*cachedThreatMap)
+	}
+	grid := ComputeThreatMap(um.units, forTeam, um.gameMap)
+	um.threatMaps[forTeam] = &cachedThreatMap{grid: grid, computedAt: now}
+	return grid
+}
+
+// SetThreatAvoidance makes every unit's pathing add threatWeight per unit of enemy threat on each tile
+// it enters, so routes bend around danger; 0 turns it off
+func (um *UnitManager) SetThreatAvoidance(threatWeight float64) {
+	um.threatWeight = threatWeight
+	for _, unit := range um.OrderedUnits() {
+		um.applyThreatAvoidance(unit)
+	}
+}
+
+// applyThreatAvoidance points a unit's pathing at its team's current threat map
+func (um *UnitManager) applyThreatAvoidance(unit *Unit) {
+	if unit.movementSystem == nil {
+		return
+	}
+	if um.threatWeight <= 0 {
+		unit.movementSystem.SetCostModifier(nil)
+		return
+	}
+	unit.movementSystem.SetCostModifier(func(tileX, tileY int) float64 {
+		return systems.ThreatCost(um.ThreatMap(unit.Team), um.threatWeight)(tileX, tileY)
+	})
+}
@@ -0,0 +1,119 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"testing"
+	"time"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// Test that threat is strongest next to an enemy and fades with distance
+func TestThreatMapFallsOffWithDistance(t *testing.T) {
+	gameMap := newTestMap(20, 20)
+	um := units.NewUnitManager(gameMap)
+	enemy := createEnemy(t, um, 5, 5)
+	
+	threat := units.ComputeThreatMap(um.GetAllUnits(), 0, gameMap)
+	adjacent, nearby, distant := threat[5][6], threat[5][8], threat[15][15]
+	if adjacent != float64(enemy.CurrentStats.Damage) {
+		t.Errorf("adjacent threat = %v, want the enemy's full damage %d", adjacent, enemy.CurrentStats.Damage)
+	}
+	if !(adjacent > nearby && nearby > distant) {
+		t.Errorf("threat adjacent/nearby/distant = %v/%v/%v, want strictly decreasing", adjacent, nearby, distant)
+	}
+	if distant != 0 {
+		t.Errorf("threat past the falloff = %v, want 0", distant)
+	}
+	
+	// A second enemy stacks its influence on top
+	createEnemy(t, um, 7, 5)
+	stacked := units.ComputeThreatMap(um.GetAllUnits(), 0, gameMap)
+	if stacked[5][6] <= adjacent {
+		t.Errorf("threat between two enemies = %v, want more than one enemy's %v", stacked[5][6], adjacent)
+	}
+}
+
+// Test that a team's own units and dead enemies add no threat
+func TestThreatMapIgnoresFriendlyUnits(t *testing.T) {
+	gameMap := newTestMap(10, 10)
+	um := units.NewUnitManager(gameMap)
+	createUnits(t, um, 3)
+	dead := createEnemy(t, um, 5, 5)
+	dead.IsAlive = false
+	
+	threat := units.ComputeThreatMap(um.GetAllUnits(), 0, gameMap)
+	for y, row := range threat {
+		for x, value := range row {
+			if value != 0 {
+				t.Fatalf("threat at (%d, %d) = %v, want 0 with only allies alive", x, y, value)
+			}
+		}
+	}
+	
+	// The same units are the enemy from team 1's point of view
+	if enemyView := units.ComputeThreatMap(um.GetAllUnits(), 1, gameMap); enemyView[1][0] == 0 {
+		t.Errorf("team 1 threat next to team 0 units = 0, want their damage")
+	}
+}
+
+// Test that the cached threat map is reused until the throttle interval passes
+func TestThreatMapThrottle(t *testing.T) {
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	um := units.NewUnitManager(newTestMap(10, 10))
+	um.SetClock(clock.Now)
+	
+	if um.ThreatMap(0)[5][6] != 0 {
+		t.Fatalf("threat with no enemies should be 0")
+	}
+	createEnemy(t, um, 5, 5)
+	
+	clock.Advance(units.ThreatMapInterval / 2)
+	if um.ThreatMap(0)[5][6] != 0 {
+		t.Errorf("threat map recomputed before ThreatMapInterval passed")
+	}
+	clock.Advance(units.ThreatMapInterval)
+	if um.ThreatMap(0)[5][6] == 0 {
+		t.Errorf("threat map not recomputed after ThreatMapInterval")
+	}
+}
+
+// closestApproach returns the fewest tile steps (diagonals count as 1) between any path step and a tile
+func closestApproach(path []struct{ X, Y int }, tileX, tileY int) int {
+	closest := -1
+	for _, step := range path {
+		dx, dy := step.X-tileX, step.Y-tileY
+		if dx < 0 {
+			dx = -dx
+		}
+		if dy < 0 {
+			dy = -dy
+		}
+		if dy > dx {
+			dx = dy
+		}
+		if closest < 0 || dx < closest {
+			closest = dx
+		}
+	}
+	return closest
+}
+
+// Test that threat avoidance makes a unit's route bend around an enemy it would otherwise pass beside
+func TestThreatAvoidanceBendsUnitPaths(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(20, 20))
+	mover := createUnits(t, um, 1)[0]
+	enemy := createEnemy(t, um, 6, 1)
+	
+	um.MoveUnit(mover.ID, 12, 0)
+	if got := closestApproach(mover.GetPath(), enemy.TileX, enemy.TileY); got > 1 {
+		t.Fatalf("path without threat avoidance stayed %d tiles away, want it to pass next to the enemy", got)
+	}
+	
+	um.SetThreatAvoidance(1)
+	um.MoveUnit(mover.ID, 12, 0)
+	if got := closestApproach(mover.GetPath(), enemy.TileX, enemy.TileY); got <= 1 {
+		t.Errorf("threat-avoiding path came within %d tile of the enemy, want it to keep away", got)
+	}
+}
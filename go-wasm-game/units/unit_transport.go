@@ -0,0 +1,183 @@
+package units
+
+import "fmt"
+
+// IsCarried reports whether the unit is riding inside a transport
+func (u *Unit) IsCarried() bool {
+	return u.carriedBy != ""
+}
+
+// CarriedBy returns the ID of the transport carrying the unit, "" if none
+func (u *Unit) CarriedBy() string {
+	return u.carriedBy
+}
+
+// transportCapacity returns how many passengers a unit's type can carry
+func (u *Unit) transportCapacity() int {
+	typeDef, exists := u.GetTypeDef()
+	if !exists {
+		return 0
+	}
+	return typeDef.Capacity
+}
+
+// LoadUnit puts an adjacent passenger inside a transport, freeing its tile and hiding it
+func (um *UnitManager) LoadUnit(transportID, passengerID string) error {
+	transport, passenger := um.units[transportID], um.units[passengerID]
+	if transport == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, transportID)
+	}
+	if passenger == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, passengerID)
+	}
+	if !transport.IsAlive || !passenger.IsAlive {
+		return fmt.Errorf("cannot load %s into %s: %w", passengerID, transportID, ErrUnitDead)
+	}
+	if transportID == passengerID || transport.IsCarried() || passenger.IsCarried() || len(passenger.CarriedUnitIDs) > 0 {
+		return fmt.Errorf("%w: cannot load %s into %s", ErrCarried, passengerID, transportID)
+	}
+	if len(transport.CarriedUnitIDs) >= transport.transportCapacity() {
+		return fmt.Errorf("%w: %s", ErrTransportFull, transportID)
+	}
+	if !isAdjacentTile(transport.TileX, transport.TileY, passenger.TileX, passenger.TileY) {
+		return fmt.Errorf("%w: %s is not next to %s", ErrNotAdjacent, passengerID, transportID)
+	}
+
+	// The passenger gives up its tile and any orders while aboard
+	um.spatialIndex.RemoveUnit(passenger)
+	passenger.cancelBuildOrder()
+	passenger.chaseTargetID = ""
+	passenger.flowField = nil
+	passenger.ClearMoveQueue()
+	passenger.SetPath(nil)
+	passenger.SetMoving(false)
+	passenger.SetStatus(StatusIdle)
+	passenger.onArrival = nil
+	passenger.carriedBy = transportID
+	transport.CarriedUnitIDs = append(transport.CarriedUnitIDs, passengerID)
+	um.syncPassenger(transport, passenger)
+
+	return nil
+}
+
+// UnloadUnit drops a passenger onto the first free tile next to its transport
+func (um *UnitManager) UnloadUnit(transportID, passengerID string) error {
+	transport, passenger, err := um.transportAndPassenger(transportID, passengerID)
+	if err != nil {
+		return err
+	}
+
+	tileX, tileY, ok := um.freeAdjacentTile(transport.TileX, transport.TileY)
+	if !ok {
+		return fmt.Errorf("%w: no free tile next to %s", ErrOccupied, transportID)
+	}
+	um.dropPassenger(transport, passenger, tileX, tileY)
+	return nil
+}
+
+// UnloadUnitAt drops a passenger onto a specific tile, which must touch the transport's tile
+func (um *UnitManager) UnloadUnitAt(transportID, passengerID string, tileX, tileY int) error {
+	transport, passenger, err := um.transportAndPassenger(transportID, passengerID)
+	if err != nil {
+		return err
+	}
+
+	if !isAdjacentTile(transport.TileX, transport.TileY, tileX, tileY) {
+		return fmt.Errorf("%w: (%d, %d) is not next to %s", ErrNotAdjacent, tileX, tileY, transportID)
+	}
+	if err := um.validatePosition(tileX, tileY); err != nil {
+		return err
+	}
+	um.dropPassenger(transport, passenger, tileX, tileY)
+	return nil
+}
+
+// transportAndPassenger looks up a transport and a passenger it is currently carrying
+func (um *UnitManager) transportAndPassenger(transportID, passengerID string) (*Unit, *Unit, error) {
+	transport, passenger := um.units[transportID], um.units[passengerID]
+	if transport == nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrNotFound, transportID)
+	}
+	if passenger == nil || passenger.carriedBy != transportID {
+		return nil, nil, fmt.Errorf("%w: %s is not aboard %s", ErrNotFound, passengerID, transportID)
+	}
+	return transport, passenger, nil
+}
+
+// freeAdjacentTile returns the first walkable, unoccupied tile touching (tileX, tileY), orthogonal neighbors first
+func (um *UnitManager) freeAdjacentTile(tileX, tileY int) (int, int, bool) {
+	offsets := [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}, {1, -1}, {1, 1}, {-1, 1}, {-1, -1}}
+	for _, offset := range offsets {
+		x, y := tileX+offset[0], tileY+offset[1]
+		if um.validatePosition(x, y) == nil {
+			return x, y, true
+		}
+	}
+	return 0, 0, false
+}
+
+// dropPassenger takes a passenger out of its transport and places it centered on a tile
+func (um *UnitManager) dropPassenger(transport, passenger *Unit, tileX, tileY int) {
+	transport.CarriedUnitIDs = removeID(transport.CarriedUnitIDs, passenger.ID)
+	passenger.carriedBy = ""
+
+	worldX, worldY := um.gameMap.GridToWorld(tileX, tileY)
+	passenger.SetPosition(worldX-passenger.Width/2, worldY-passenger.Height/2)
+	passenger.SetTarget(passenger.X, passenger.Y)
+	passenger.TileX, passenger.TileY = tileX, tileY
+	um.spatialIndex.AddUnit(passenger)
+}
+
+// updateTransports keeps passengers riding along with their transport and ejects them from dead ones
+func (um *UnitManager) updateTransports() {
+	for _, id := range um.unitOrder {
+		transport := um.units[id]
+		if transport == nil || len(transport.CarriedUnitIDs) == 0 {
+			continue
+		}
+		if !transport.IsAlive {
+			um.releasePassengers(transport)
+			continue
+		}
+		for _, passengerID := range transport.CarriedUnitIDs {
+			if passenger := um.units[passengerID]; passenger != nil {
+				um.syncPassenger(transport, passenger)
+			}
+		}
+	}
+}
+
+// releasePassengers unloads everyone aboard a transport; passengers with nowhere to go are removed
+func (um *UnitManager) releasePassengers(transport *Unit) {
+	for _, passengerID := range append([]string(nil), transport.CarriedUnitIDs...) {
+		if um.UnloadUnit(transport.ID, passengerID) != nil {
+			um.RemoveUnit(passengerID)
+		}
+	}
+}
+
+// detachCargo clears transport links for a unit leaving the game
+func (um *UnitManager) detachCargo(unit *Unit) {
+	if transport := um.units[unit.carriedBy]; transport != nil {
+		transport.CarriedUnitIDs = removeID(transport.CarriedUnitIDs, unit.ID)
+	}
+	unit.carriedBy = ""
+	um.releasePassengers(unit)
+}
+
+// syncPassenger centers a passenger on its transport without registering it on any tile
+func (um *UnitManager) syncPassenger(transport, passenger *Unit) {
+	x, y := transport.GetPosition()
+	passenger.MovableEntity.SetPosition(x+transport.Width/2-passenger.Width/2, y+transport.Height/2-passenger.Height/2)
+	passenger.TileX, passenger.TileY = transport.TileX, transport.TileY
+}
+
+// removeID returns ids without the first occurrence of id
+func removeID(ids []string, id string) []string {
+	for i, existing := range ids {
+		if existing == id {
+			return append(ids[:i:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,132 @@
+//go:build js && wasm
+// +build js,wasm
+
+package units_test
+
+import (
+	"errors"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/entities"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/units"
+)
+
+// createTransport creates a scout, the unit type that can carry a passenger
+func createTransport(t *testing.T, um *units.UnitManager, tileX, tileY int) *units.Unit {
+	t.Helper()
+	transport, err := um.CreateUnit(entities.UnitScout, tileX, tileY, "")
+	if err != nil {
+		t.Fatalf("CreateUnit(%d, %d) failed: %v", tileX, tileY, err)
+	}
+	return transport
+}
+
+// Test that loading hides a passenger and unloading puts it back on a tile next to the transport
+func TestLoadAndUnloadUnit(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	passenger := createUnits(t, um, 1)[0]
+	transport := createTransport(t, um, 1, 0)
+
+	if err := um.LoadUnit(transport.ID, passenger.ID); err != nil {
+		t.Fatalf("LoadUnit() failed: %v", err)
+	}
+	if passenger.CarriedBy() != transport.ID {
+		t.Errorf("CarriedBy() = %q, want %q", passenger.CarriedBy(), transport.ID)
+	}
+	if len(transport.CarriedUnitIDs) != 1 || transport.CarriedUnitIDs[0] != passenger.ID {
+		t.Errorf("CarriedUnitIDs = %v, want [%s]", transport.CarriedUnitIDs, passenger.ID)
+	}
+	if err := um.MoveUnit(passenger.ID, 5, 5); !errors.Is(err, units.ErrCarried) {
+		t.Errorf("MoveUnit(carried) error = %v, want ErrCarried", err)
+	}
+
+	// A full transport refuses another passenger
+	extra, _ := um.CreateUnit(entities.UnitWarrior, 2, 0, "")
+	if err := um.LoadUnit(transport.ID, extra.ID); !errors.Is(err, units.ErrTransportFull) {
+		t.Errorf("LoadUnit(full) error = %v, want ErrTransportFull", err)
+	}
+
+	if err := um.UnloadUnit(transport.ID, passenger.ID); err != nil {
+		t.Fatalf("UnloadUnit() failed: %v", err)
+	}
+	if passenger.IsCarried() || len(transport.CarriedUnitIDs) != 0 {
+		t.Errorf("after unload carried = %v, CarriedUnitIDs = %v, want the passenger off board", passenger.IsCarried(), transport.CarriedUnitIDs)
+	}
+	dx, dy := passenger.TileX-transport.TileX, passenger.TileY-transport.TileY
+	if dx < -1 || dx > 1 || dy < -1 || dy > 1 || (dx == 0 && dy == 0) {
+		t.Errorf("unloaded at (%d, %d), want a tile next to the transport at (%d, %d)", passenger.TileX, passenger.TileY, transport.TileX, transport.TileY)
+	}
+	if units := um.GetUnitsAtTile(passenger.TileX, passenger.TileY); len(units) != 1 || units[0] != passenger {
+		t.Errorf("GetUnitsAtTile(unload tile) = %v, want the passenger", units)
+	}
+}
+
+// Test that loading and unloading both require tiles next to the transport
+func TestTransportAdjacency(t *testing.T) {
+	tests := []struct {
+		name         string
+		tileX, tileY int
+		wantErr      error
+	}{
+		{"orthogonal neighbor", 4, 3, nil},
+		{"diagonal neighbor", 4, 4, nil},
+		{"two tiles away", 5, 3, units.ErrNotAdjacent},
+		{"transport's own tile", 3, 3, units.ErrNotAdjacent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			um := units.NewUnitManager(newTestMap(10, 10))
+			transport := createTransport(t, um, 3, 3)
+			passenger, _ := um.CreateUnit(entities.UnitWarrior, 3, 2, "")
+			if err := um.LoadUnit(transport.ID, passenger.ID); err != nil {
+				t.Fatalf("LoadUnit() failed: %v", err)
+			}
+
+			err := um.UnloadUnitAt(transport.ID, passenger.ID, tt.tileX, tt.tileY)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UnloadUnitAt(%d, %d) error = %v, want %v", tt.tileX, tt.tileY, err, tt.wantErr)
+			}
+			if tt.wantErr != nil && !passenger.IsCarried() {
+				t.Errorf("passenger left the transport after a rejected unload")
+			}
+		})
+	}
+
+	um := units.NewUnitManager(newTestMap(10, 10))
+	transport := createTransport(t, um, 0, 0)
+	far, _ := um.CreateUnit(entities.UnitWarrior, 5, 5, "")
+	if err := um.LoadUnit(transport.ID, far.ID); !errors.Is(err, units.ErrNotAdjacent) {
+		t.Errorf("LoadUnit(far) error = %v, want ErrNotAdjacent", err)
+	}
+}
+
+// Test that a carried unit frees its tile and rides along with the transport
+func TestCarriedUnitsDoNotOccupyTiles(t *testing.T) {
+	um := units.NewUnitManager(newTestMap(10, 10))
+	passenger := createUnits(t, um, 1)[0]
+	transport := createTransport(t, um, 1, 0)
+	if err := um.LoadUnit(transport.ID, passenger.ID); err != nil {
+		t.Fatalf("LoadUnit() failed: %v", err)
+	}
+
+	if um.IsPositionOccupied(0, 0) {
+		t.Errorf("IsPositionOccupied(0, 0) = true, want the passenger's old tile freed")
+	}
+	if occupants := um.GetUnitsAtTile(1, 0); len(occupants) != 1 || occupants[0] != transport {
+		t.Errorf("GetUnitsAtTile(1, 0) = %v, want only the transport", occupants)
+	}
+	if _, err := um.CreateUnit(entities.UnitWarrior, 0, 0, ""); err != nil {
+		t.Errorf("CreateUnit on the freed tile failed: %v", err)
+	}
+
+	um.MoveUnit(transport.ID, 6, 4)
+	for frame := 0; frame < 600 && transport.IsMoving(); frame++ {
+		um.Update()
+	}
+	if passenger.TileX != transport.TileX || passenger.TileY != transport.TileY {
+		t.Errorf("passenger at (%d, %d), want it riding with the transport at (%d, %d)", passenger.TileX, passenger.TileY, transport.TileX, transport.TileY)
+	}
+	if occupants := um.GetUnitsAtTile(transport.TileX, transport.TileY); len(occupants) != 1 {
+		t.Errorf("GetUnitsAtTile(transport tile) has %d units, want only the transport", len(occupants))
+	}
+}
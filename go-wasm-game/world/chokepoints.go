@@ -0,0 +1,60 @@
+package world
+
+// FindChokepoints returns walkable tiles sitting in a narrow passage: the walkable run through the tile
+// is at most maxWidth tiles wide across one axis while extending further than that along the other.
+// Runs stop at unwalkable tiles, edge walls and the map border. Only the two grid axes are measured,
+// so narrow diagonal passages are not reported
+func (m *Map) FindChokepoints(maxWidth int) []struct{ X, Y int } {
+	walkable := m.WalkableGrid()
+	horizontal := make([]int, len(walkable))
+	vertical := make([]int, len(walkable))
+
+	// Horizontal runs, one row at a time
+	for y := 0; y < m.Height; y++ {
+		m.measureRuns(walkable, horizontal, m.Width, func(i int) (int, int) { return i, y })
+	}
+	// Vertical runs, one column at a time
+	for x := 0; x < m.Width; x++ {
+		m.measureRuns(walkable, vertical, m.Height, func(i int) (int, int) { return x, i })
+	}
+
+	chokepoints := make([]struct{ X, Y int }, 0)
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			index := y*m.Width + x
+			if walkable[index] == 0 {
+				continue
+			}
+			narrow, long := horizontal[index], vertical[index]
+			if narrow > long {
+				narrow, long = long, narrow
+			}
+			if narrow <= maxWidth && long > maxWidth {
+				chokepoints = append(chokepoints, struct{ X, Y int }{X: x, Y: y})
+			}
+		}
+	}
+	return chokepoints
+}
+
+// measureRuns fills runs with the length of the contiguous walkable run containing each tile of one line.
+// tileAt maps a step along the line to grid coordinates
+func (m *Map) measureRuns(walkable []byte, runs []int, length int, tileAt func(int) (int, int)) {
+	start := 0
+	for i := 1; i <= length; i++ {
+		// A run ends at the end of the line, or before a step that is blocked or crosses an edge wall
+		if i < length {
+			prevX, prevY := tileAt(i - 1)
+			x, y := tileAt(i)
+			if walkable[prevY*m.Width+prevX] == 1 && walkable[y*m.Width+x] == 1 && m.CanCrossEdge(prevX, prevY, x, y) {
+				continue
+			}
+		}
+		for j := start; j < i; j++ {
+			if x, y := tileAt(j); walkable[y*m.Width+x] == 1 {
+				runs[y*m.Width+x] = i - start
+			}
+		}
+		start = i
+	}
+}
@@ -0,0 +1,74 @@
+//go:build !js
+// +build !js
+
+package world_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newBridgeMap builds a 20x11 map: two grass fields joined across a water channel (x 8-11) by a one-tile bridge on row 5
+func newBridgeMap() *world.Map {
+	gameMap := world.NewMap(20, 11, 32.0)
+	for y := 0; y < gameMap.Height; y++ {
+		for x := 8; x <= 11; x++ {
+			if y != 5 {
+				gameMap.SetTile(x, y, world.TileWater)
+			}
+		}
+	}
+	return gameMap
+}
+
+// Test that a one-tile bridge is reported as a chokepoint while the open fields are not
+func TestFindChokepoints(t *testing.T) {
+	gameMap := newBridgeMap()
+	found := make(map[[2]int]bool)
+	for _, tile := range gameMap.FindChokepoints(1) {
+		found[[2]int{tile.X, tile.Y}] = true
+	}
+
+	for x := 8; x <= 11; x++ {
+		if !found[[2]int{x, 5}] {
+			t.Errorf("bridge tile (%d, 5) not reported as a chokepoint", x)
+		}
+	}
+	if len(found) != 4 {
+		t.Errorf("FindChokepoints(1) reported %d tiles, want only the 4 bridge tiles", len(found))
+	}
+
+	tests := []struct {
+		name string
+		x, y int
+	}{
+		{"field center", 3, 5},
+		{"bridge landing", 7, 5},
+		{"field corner", 0, 0},
+		{"far field", 15, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if found[[2]int{tt.x, tt.y}] {
+				t.Errorf("open tile (%d, %d) reported as a chokepoint", tt.x, tt.y)
+			}
+		})
+	}
+}
+
+// Test that maxWidth widens what counts as narrow
+func TestFindChokepointsWidth(t *testing.T) {
+	gameMap := newBridgeMap()
+	// Widen the bridge to three rows
+	for x := 8; x <= 11; x++ {
+		gameMap.SetTile(x, 4, world.TileGrass)
+		gameMap.SetTile(x, 6, world.TileGrass)
+	}
+
+	if got := gameMap.FindChokepoints(2); len(got) != 0 {
+		t.Errorf("FindChokepoints(2) on a 3-wide bridge = %v, want none", got)
+	}
+	if got := gameMap.FindChokepoints(3); len(got) != 12 {
+		t.Errorf("FindChokepoints(3) on a 3-wide bridge found %d tiles, want 12", len(got))
+	}
+}
@@ -0,0 +1,102 @@
+package world
+
+import (
+	"math"
+)
+
+// TileGenerator produces the tile type for a grid coordinate
+// Generators must be deterministic so that chunks can be generated in any order
+type TileGenerator func(x, y int) TileType
+
+// IsChunked returns true if the map generates its tiles lazily in chunks
+func (m *Map) IsChunked() bool {
+	return m.ChunkSize > 0 && m.generator != nil
+}
+
+// chunkKey returns the cache key for chunk coordinates
+func (m *Map) chunkKey(cx, cy int) int {
+	chunksX := (m.Width + m.ChunkSize - 1) / m.ChunkSize
+	return cy*chunksX + cx
+}
+
+// ensureChunk returns the chunk at chunk coordinates (cx, cy), generating it on first access
+func (m *Map) ensureChunk(cx, cy int) [][]TileType {
+	key := m.chunkKey(cx, cy)
+	if chunk, exists := m.chunks[key]; exists {
+		return chunk
+	}
+	
+	chunk := make([][]TileType, m.ChunkSize)
+	for localY := range chunk {
+		chunk[localY] = make([]TileType, m.ChunkSize)
+		for localX := range chunk[localY] {
+			x := cx*m.ChunkSize + localX
+			y := cy*m.ChunkSize + localY
+			// Padding beyond the map edge (last partial chunk) is left as grass
+			if x < m.Width && y < m.Height {
+				chunk[localY][localX] = m.generator(x, y)
+			}
+		}
+	}
+	
+	m.chunks[key] = chunk
+	return chunk
+}
+
+// IsChunkGenerated reports whether the chunk at chunk coordinates has been generated
+func (m *Map) IsChunkGenerated(cx, cy int) bool {
+	if !m.IsChunked() {
+		return true // Fully allocated maps are generated up front
+	}
+	_, exists := m.chunks[m.chunkKey(cx, cy)]
+	return exists
+}
+
+// GeneratedChunkCount returns how many chunks have been generated so far
+func (m *Map) GeneratedChunkCount() int {
+	return len(m.chunks)
+}
+
+// NewSeededTerrainGenerator returns a deterministic grass/water generator for chunked maps
+// Lakes come from smoothed value noise, so any tile can be generated independently
+func NewSeededTerrainGenerator(seed int64) TileGenerator {
+	const cellSize = 16.0      // Tiles per noise cell (controls lake size)
+	const waterThreshold = 0.3 // Noise below this becomes water
+	
+	return func(x, y int) TileType {
+		if valueNoise(seed, float64(x)/cellSize, float64(y)/cellSize) < waterThreshold {
+			return TileWater
+		}
+		return TileGrass
+	}
+}
+
+// valueNoise returns smoothly interpolated noise in [0, 1) for a point in noise-cell space
+func valueNoise(seed int64, x, y float64) float64 {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	tx, ty := smoothstep(x-x0), smoothstep(y-y0)
+	ix, iy := int64(x0), int64(y0)
+	
+	top := lerp(hashNoise(seed, ix, iy), hashNoise(seed, ix+1, iy), tx)
+	bottom := lerp(hashNoise(seed, ix, iy+1), hashNoise(seed, ix+1, iy+1), tx)
+	return lerp(top, bottom, ty)
+}
+
+// hashNoise returns a deterministic pseudo-random value in [0, 1) for integer lattice coordinates
+func hashNoise(seed, x, y int64) float64 {
+	h := uint64(seed) ^ uint64(x)*0x9E3779B97F4A7C15 ^ uint64(y)*0xC2B2AE3D27D4EB4F
+	h ^= h >> 33
+	h *= 0xFF51AFD7ED558CCD
+	h ^= h >> 33
+	h *= 0xC4CEB9FE1A85EC53
+	h ^= h >> 33
+	return float64(h>>11) / float64(1<<53)
+}
+
+func smoothstep(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
@@ -0,0 +1,97 @@
+//go:build !js
+// +build !js
+
+package world_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// countingGenerator wraps a generator and counts how many tiles it produced
+func countingGenerator(calls *int) world.TileGenerator {
+	return func(x, y int) world.TileType {
+		*calls++
+		if (x+y)%7 == 0 {
+			return world.TileWater
+		}
+		return world.TileGrass
+	}
+}
+
+// Test that accessing a tile generates exactly its chunk
+func TestChunkedMapGeneratesOnlyAccessedChunk(t *testing.T) {
+	calls := 0
+	gameMap := world.NewChunkedMap(100, 100, 32.0, 16, countingGenerator(&calls))
+	
+	if gameMap.GeneratedChunkCount() != 0 {
+		t.Fatalf("new chunked map generated %d chunks, want 0", gameMap.GeneratedChunkCount())
+	}
+	
+	// Tile (40, 20) lives in chunk (2, 1)
+	if tile := gameMap.GetTile(40, 20); tile != world.TileGrass {
+		t.Errorf("GetTile(40, 20) = %v, want grass", tile)
+	}
+	if gameMap.GeneratedChunkCount() != 1 {
+		t.Errorf("GeneratedChunkCount() = %d, want 1", gameMap.GeneratedChunkCount())
+	}
+	if !gameMap.IsChunkGenerated(2, 1) {
+		t.Error("chunk (2, 1) should be generated")
+	}
+	if gameMap.IsChunkGenerated(1, 1) || gameMap.IsChunkGenerated(2, 2) {
+		t.Error("neighboring chunks should not be generated")
+	}
+	if calls != 16*16 {
+		t.Errorf("generator called %d times, want %d", calls, 16*16)
+	}
+	if tile := gameMap.GetTile(43, 20); tile != world.TileWater {
+		t.Errorf("GetTile(43, 20) = %v, want water from the generator", tile)
+	}
+}
+
+// Test that repeated access doesn't regenerate a chunk and keeps edits
+func TestChunkedMapCachesChunks(t *testing.T) {
+	calls := 0
+	gameMap := world.NewChunkedMap(100, 100, 32.0, 16, countingGenerator(&calls))
+	
+	gameMap.GetTile(5, 5)
+	gameMap.SetTile(6, 6, world.TileDirtPath)
+	for i := 0; i < 10; i++ {
+		gameMap.GetTile(i, 15)
+	}
+	
+	if calls != 16*16 {
+		t.Errorf("generator called %d times after repeated access, want %d", calls, 16*16)
+	}
+	if tile := gameMap.GetTile(6, 6); tile != world.TileDirtPath {
+		t.Errorf("GetTile(6, 6) = %v, edited tile should survive caching", tile)
+	}
+	
+	// Partial chunks at the map edge only generate in-bounds tiles
+	gameMap.GetTile(99, 99)
+	if calls != 16*16+4*4 {
+		t.Errorf("generator called %d times, want %d including the partial edge chunk", calls, 16*16+4*4)
+	}
+	if tile := gameMap.GetTile(100, 99); tile != world.TileWater {
+		t.Errorf("out of bounds GetTile = %v, want water", tile)
+	}
+}
+
+// Test that the seeded generator is deterministic regardless of access order
+func TestSeededTerrainGeneratorIsDeterministic(t *testing.T) {
+	first := world.NewChunkedMap(64, 64, 32.0, 8, world.NewSeededTerrainGenerator(42))
+	second := world.NewChunkedMap(64, 64, 32.0, 8, world.NewSeededTerrainGenerator(42))
+	
+	for y := 63; y >= 0; y-- {
+		for x := 63; x >= 0; x-- {
+			second.GetTile(x, y)
+		}
+	}
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			if first.GetTile(x, y) != second.GetTile(x, y) {
+				t.Fatalf("tile (%d, %d) differs between identically seeded maps", x, y)
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package world
+
+import (
+	"math"
+)
+
+// TileDimensions returns the width and height of a single tile in world units
+// Unset dimensions fall back to the square TileSize, and height defaults to width
+func (m *Map) TileDimensions() (float64, float64) {
+	tileWidth := m.TileWidth
+	if tileWidth == 0 {
+		tileWidth = m.TileSize
+	}
+	tileHeight := m.TileHeight
+	if tileHeight == 0 {
+		tileHeight = tileWidth
+	}
+	return tileWidth, tileHeight
+}
+
+// TileAspectRatio returns tile height divided by tile width (1.0 for square tiles)
+func (m *Map) TileAspectRatio() float64 {
+	tileWidth, tileHeight := m.TileDimensions()
+	if tileWidth == 0 {
+		return 1.0
+	}
+	return tileHeight / tileWidth
+}
+
+// WorldSize returns the total map size in world units
+func (m *Map) WorldSize() (float64, float64) {
+	tileWidth, tileHeight := m.TileDimensions()
+	return float64(m.Width) * tileWidth, float64(m.Height) * tileHeight
+}
+
+// WorldToGrid converts world coordinates to grid coordinates, wrapped onto the map when Wrap is on
+func (m *Map) WorldToGrid(worldX, worldY float64) (int, int) {
+	tileWidth, tileHeight := m.TileDimensions()
+	gridX := int(math.Floor(worldX / tileWidth))
+	gridY := int(math.Floor(worldY / tileHeight))
+	return m.WrapTile(gridX, gridY)
+}
+
+// GridToWorld converts grid coordinates to world coordinates (center of tile), wrapped onto the map when Wrap is on
+func (m *Map) GridToWorld(gridX, gridY int) (float64, float64) {
+	gridX, gridY = m.WrapTile(gridX, gridY)
+	tileWidth, tileHeight := m.TileDimensions()
+	worldX := float64(gridX)*tileWidth + tileWidth/2
+	worldY := float64(gridY)*tileHeight + tileHeight/2
+	return worldX, worldY
+}
@@ -0,0 +1,87 @@
+//go:build !js
+// +build !js
+
+package world_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test coordinate conversions on a map with distinct tile width and height
+func TestCoordinateConversionsWithNonSquareTiles(t *testing.T) {
+	gameMap := world.NewMapWithTileDimensions(10, 10, 32.0, 16.0)
+	
+	worldX, worldY := gameMap.GridToWorld(3, 5)
+	if worldX != 112 || worldY != 88 {
+		t.Errorf("GridToWorld(3, 5) = (%v, %v), want (112, 88)", worldX, worldY)
+	}
+	
+	tests := []struct {
+		name           string
+		worldX, worldY float64
+		gridX, gridY   int
+	}{
+		{"Origin", 0, 0, 0, 0},
+		{"Inside first tile", 31.9, 15.9, 0, 0},
+		{"Width boundary", 32, 15.9, 1, 0},
+		{"Height boundary", 31.9, 16, 0, 1},
+		{"Deep inside map", 100, 100, 3, 6},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gridX, gridY := gameMap.WorldToGrid(tt.worldX, tt.worldY)
+			if gridX != tt.gridX || gridY != tt.gridY {
+				t.Errorf("WorldToGrid(%v, %v) = (%v, %v), want (%v, %v)",
+					tt.worldX, tt.worldY, gridX, gridY, tt.gridX, tt.gridY)
+			}
+		})
+	}
+	
+	worldWidth, worldHeight := gameMap.WorldSize()
+	if worldWidth != 320 || worldHeight != 160 {
+		t.Errorf("WorldSize() = (%v, %v), want (320, 160)", worldWidth, worldHeight)
+	}
+	if aspect := gameMap.TileAspectRatio(); aspect != 0.5 {
+		t.Errorf("TileAspectRatio() = %v, want 0.5", aspect)
+	}
+}
+
+// Test that every tile center round-trips back to the same grid cell
+func TestGridWorldRoundTrip(t *testing.T) {
+	maps := map[string]*world.Map{
+		"square":     world.NewMap(8, 6, 32.0),
+		"wide tiles": world.NewMapWithTileDimensions(8, 6, 48.0, 24.0),
+		"tall tiles": world.NewMapWithTileDimensions(8, 6, 20.0, 36.0),
+	}
+	
+	for name, gameMap := range maps {
+		t.Run(name, func(t *testing.T) {
+			for y := 0; y < gameMap.Height; y++ {
+				for x := 0; x < gameMap.Width; x++ {
+					worldX, worldY := gameMap.GridToWorld(x, y)
+					gridX, gridY := gameMap.WorldToGrid(worldX, worldY)
+					if gridX != x || gridY != y {
+						t.Fatalf("round trip of (%d, %d) returned (%d, %d)", x, y, gridX, gridY)
+					}
+				}
+			}
+		})
+	}
+}
+
+// Test that square maps keep height equal to width for backward compatibility
+func TestSquareTileDefaults(t *testing.T) {
+	gameMap := world.NewMap(4, 4, 32.0)
+	tileWidth, tileHeight := gameMap.TileDimensions()
+	if tileWidth != 32 || tileHeight != 32 || gameMap.TileSize != 32 {
+		t.Errorf("TileDimensions() = (%v, %v), TileSize = %v, want all 32", tileWidth, tileHeight, gameMap.TileSize)
+	}
+	
+	// Maps built with only TileSize fall back to square tiles
+	legacy := &world.Map{Width: 2, Height: 2, TileSize: 24.0}
+	if tileWidth, tileHeight := legacy.TileDimensions(); tileWidth != 24 || tileHeight != 24 {
+		t.Errorf("legacy TileDimensions() = (%v, %v), want (24, 24)", tileWidth, tileHeight)
+	}
+}
@@ -0,0 +1,59 @@
+package world
+
+import "math/rand"
+
+// DecorationKind identifies a purely cosmetic sprite drawn on top of terrain
+type DecorationKind int
+
+const (
+	DecorationFlower DecorationKind = iota
+	DecorationRock
+	DecorationTuft
+)
+
+// DecorationIcons maps each decoration kind to the glyph drawn for it
+var DecorationIcons = map[DecorationKind]string{
+	DecorationFlower: "🌼",
+	DecorationRock:   "🪨",
+	DecorationTuft:   "🌱",
+}
+
+// Decoration is a visual-only sprite on a tile; it never affects walkability or pathfinding
+type Decoration struct {
+	X, Y int
+	Kind DecorationKind
+	// OffsetX and OffsetY place the sprite within its tile as fractions of the tile size (0-1)
+	OffsetX, OffsetY float64
+}
+
+// GenerateDecorations replaces the map's decorations with sprites scattered over walkable grass
+// Each grass tile gets a decoration with probability density (clamped to 0-1); the same seed
+// always produces the same scatter for the same terrain
+func (m *Map) GenerateDecorations(seed int64, density float64) {
+	if density < 0 {
+		density = 0
+	} else if density > 1 {
+		density = 1
+	}
+	
+	rng := rand.New(rand.NewSource(seed))
+	m.Decorations = nil
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			// Roll for every tile so the scatter on one tile doesn't depend on its neighbors' terrain
+			roll := rng.Float64()
+			kind := DecorationKind(rng.Intn(len(DecorationIcons)))
+			offsetX, offsetY := 0.2+rng.Float64()*0.6, 0.2+rng.Float64()*0.6
+			if roll >= density || !m.isDecoratable(x, y) {
+				continue
+			}
+			m.Decorations = append(m.Decorations, Decoration{X: x, Y: y, Kind: kind, OffsetX: offsetX, OffsetY: offsetY})
+		}
+	}
+}
+
+// isDecoratable reports whether a tile is walkable grass, the only terrain decorations grow on
+func (m *Map) isDecoratable(x, y int) bool {
+	tileType := m.GetTile(x, y)
+	return tileType == TileGrass && TileDefinitions[tileType].Walkable
+}
@@ -0,0 +1,30 @@
+//go:build js
+// +build js
+
+package world
+
+import "syscall/js"
+
+// renderDecorationsLayer draws decorations on tiles that are still grass and inside the view
+func (m *Map) renderDecorationsLayer(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
+	if len(m.Decorations) == 0 {
+		return
+	}
+	
+	tileWidth, tileHeight := m.TileDimensions()
+	ctx.Set("font", "12px Arial")
+	ctx.Set("textAlign", "center")
+	ctx.Set("textBaseline", "middle")
+	for _, decoration := range m.Decorations {
+		screenX := (float64(decoration.X)+decoration.OffsetX)*tileWidth - cameraX
+		screenY := (float64(decoration.Y)+decoration.OffsetY)*tileHeight - cameraY
+		if screenX < -tileWidth || screenX > canvasWidth+tileWidth || screenY < -tileHeight || screenY > canvasHeight+tileHeight {
+			continue
+		}
+		// Tiles painted over since generation lose their decoration
+		if !m.isDecoratable(decoration.X, decoration.Y) {
+			continue
+		}
+		ctx.Call("fillText", DecorationIcons[decoration.Kind], screenX, screenY)
+	}
+}
@@ -0,0 +1,111 @@
+//go:build !js
+// +build !js
+
+package world_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// newDecorationTestMap builds a 20x20 grass map with a water lake, a dirt road and a wall
+func newDecorationTestMap() *world.Map {
+	m := world.NewMap(20, 20, 32.0)
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			switch {
+			case x < 5 && y < 5:
+				m.SetTile(x, y, world.TileWater)
+			case y == 10:
+				m.SetTile(x, y, world.TileDirtPath)
+			case x == 15:
+				m.SetTile(x, y, world.TileWall)
+			}
+		}
+	}
+	return m
+}
+
+// Test that decorations only land on walkable grass, at roughly the requested density
+func TestGenerateDecorationsOnlyOnGrass(t *testing.T) {
+	grassTiles := 0
+	m := newDecorationTestMap()
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			if m.GetTile(x, y) == world.TileGrass {
+				grassTiles++
+			}
+		}
+	}
+	
+	tests := []struct {
+		name    string
+		density float64
+		want    float64 // Expected fraction of grass tiles decorated
+	}{
+		{"none", 0, 0},
+		{"sparse", 0.1, 0.1},
+		{"half", 0.5, 0.5},
+		{"every tile", 1, 1},
+		{"clamped above one", 3, 1},
+		{"clamped below zero", -1, 0},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newDecorationTestMap()
+			m.GenerateDecorations(7, tt.density)
+			
+			seen := map[[2]int]bool{}
+			for _, d := range m.Decorations {
+				if m.GetTile(d.X, d.Y) != world.TileGrass {
+					t.Errorf("decoration at (%d, %d) on tile %v, want grass", d.X, d.Y, m.GetTile(d.X, d.Y))
+				}
+				if seen[[2]int{d.X, d.Y}] {
+					t.Errorf("two decorations on (%d, %d)", d.X, d.Y)
+				}
+				seen[[2]int{d.X, d.Y}] = true
+				if d.OffsetX < 0 || d.OffsetX > 1 || d.OffsetY < 0 || d.OffsetY > 1 {
+					t.Errorf("decoration offset (%v, %v) outside its tile", d.OffsetX, d.OffsetY)
+				}
+			}
+			
+			got := float64(len(m.Decorations)) / float64(grassTiles)
+			if math.Abs(got-tt.want) > 0.05 {
+				t.Errorf("decorated %.3f of grass tiles, want about %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test that the same seed scatters the same decorations and a different seed does not
+func TestGenerateDecorationsDeterministic(t *testing.T) {
+	a, b, c := newDecorationTestMap(), newDecorationTestMap(), newDecorationTestMap()
+	a.GenerateDecorations(42, 0.3)
+	b.GenerateDecorations(42, 0.3)
+	c.GenerateDecorations(43, 0.3)
+	
+	if len(a.Decorations) != len(b.Decorations) {
+		t.Fatalf("same seed gave %d then %d decorations", len(a.Decorations), len(b.Decorations))
+	}
+	for i := range a.Decorations {
+		if a.Decorations[i] != b.Decorations[i] {
+			t.Errorf("decoration %d = %+v then %+v, want identical for the same seed", i, a.Decorations[i], b.Decorations[i])
+		}
+	}
+	if len(a.Decorations) == len(c.Decorations) && a.Decorations[0] == c.Decorations[0] {
+		t.Errorf("seeds 42 and 43 gave the same scatter, want the seed to matter")
+	}
+}
+
+// Test that decorating a map leaves walkability untouched
+func TestGenerateDecorationsKeepsWalkability(t *testing.T) {
+	m := newDecorationTestMap()
+	before := m.WalkableGrid()
+	m.GenerateDecorations(1, 1)
+	if !bytes.Equal(before, m.WalkableGrid()) {
+		t.Errorf("WalkableGrid() changed after GenerateDecorations")
+	}
+}
@@ -0,0 +1,82 @@
+package world
+
+// tileEdge identifies the shared edge between two orthogonally adjacent tiles
+// The lower (y, then x) tile is always stored first so both directions map to the same key
+type tileEdge struct {
+	x1, y1 int
+	x2, y2 int
+}
+
+// newTileEdge returns the normalized edge between two tiles and whether they are orthogonal neighbors
+func newTileEdge(x1, y1, x2, y2 int) (tileEdge, bool) {
+	dx, dy := x2-x1, y2-y1
+	if dx*dx+dy*dy != 1 {
+		return tileEdge{}, false
+	}
+	if y2 < y1 || (y2 == y1 && x2 < x1) {
+		x1, y1, x2, y2 = x2, y2, x1, y1
+	}
+	return tileEdge{x1, y1, x2, y2}, true
+}
+
+// AddEdgeWall places a thin wall on the edge between two orthogonally adjacent tiles
+// Returns false if the tiles are not neighbors
+func (m *Map) AddEdgeWall(x1, y1, x2, y2 int) bool {
+	edge, ok := newTileEdge(x1, y1, x2, y2)
+	if !ok {
+		return false
+	}
+	if m.edgeWalls == nil {
+		m.edgeWalls = make(map[tileEdge]bool)
+	}
+	m.edgeWalls[edge] = true
+	m.notifyTileChange(edge.x1, edge.y1)
+	m.notifyTileChange(edge.x2, edge.y2)
+	return true
+}
+
+// RemoveEdgeWall removes the wall between two tiles, if any
+func (m *Map) RemoveEdgeWall(x1, y1, x2, y2 int) {
+	if edge, ok := newTileEdge(x1, y1, x2, y2); ok {
+		delete(m.edgeWalls, edge)
+		m.notifyTileChange(edge.x1, edge.y1)
+		m.notifyTileChange(edge.x2, edge.y2)
+	}
+}
+
+// HasEdgeWall returns true if a wall sits on the edge between two orthogonally adjacent tiles
+func (m *Map) HasEdgeWall(x1, y1, x2, y2 int) bool {
+	edge, ok := newTileEdge(x1, y1, x2, y2)
+	return ok && m.edgeWalls[edge]
+}
+
+// CanCrossEdge returns true if a single step between two neighboring tiles isn't blocked by an edge wall
+// or a one-way tile (see CanEnterTile). Diagonal steps pass through a corner, so any wall touching
+// that corner between the tiles blocks them
+func (m *Map) CanCrossEdge(fromX, fromY, toX, toY int) bool {
+	if !m.CanEnterTile(fromX, fromY, toX, toY) {
+		return false
+	}
+	if len(m.edgeWalls) == 0 {
+		return true
+	}
+	
+	dx, dy := toX-fromX, toY-fromY
+	if dx == 0 || dy == 0 {
+		return !m.HasEdgeWall(fromX, fromY, toX, toY)
+	}
+	
+	return !m.HasEdgeWall(fromX, fromY, toX, fromY) &&
+		!m.HasEdgeWall(fromX, fromY, fromX, toY) &&
+		!m.HasEdgeWall(toX, fromY, toX, toY) &&
+		!m.HasEdgeWall(fromX, toY, toX, toY)
+}
+
+// EdgeWalls returns every edge wall as a pair of tile coordinates
+func (m *Map) EdgeWalls() [][4]int {
+	walls := make([][4]int, 0, len(m.edgeWalls))
+	for edge := range m.edgeWalls {
+		walls = append(walls, [4]int{edge.x1, edge.y1, edge.x2, edge.y2})
+	}
+	return walls
+}
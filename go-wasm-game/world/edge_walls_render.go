@@ -0,0 +1,37 @@
+//go:build js
+// +build js
+
+package world
+
+import "syscall/js"
+
+// renderEdgeWallsLayer draws each edge wall as a thick line along the shared tile edge
+func (m *Map) renderEdgeWallsLayer(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
+	if len(m.edgeWalls) == 0 {
+		return
+	}
+	
+	tileWidth, tileHeight := m.TileDimensions()
+	ctx.Set("strokeStyle", m.TileColor(TileWall))
+	ctx.Set("lineWidth", 4)
+	ctx.Call("beginPath")
+	for edge := range m.edgeWalls {
+		// The second tile is always to the right of or below the first, so the edge is its left or top side
+		startX := float64(edge.x2)*tileWidth - cameraX
+		startY := float64(edge.y2)*tileHeight - cameraY
+		endX, endY := startX, startY
+		if edge.x2 != edge.x1 {
+			endY += tileHeight
+		} else {
+			endX += tileWidth
+		}
+		
+		if (startX < 0 && endX < 0) || (startX > canvasWidth && endX > canvasWidth) ||
+			(startY < 0 && endY < 0) || (startY > canvasHeight && endY > canvasHeight) {
+			continue
+		}
+		ctx.Call("moveTo", startX, startY)
+		ctx.Call("lineTo", endX, endY)
+	}
+	ctx.Call("stroke")
+}
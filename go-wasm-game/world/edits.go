@@ -0,0 +1,130 @@
+package world
+
+// TileEdit records a single tile change so it can be undone
+type TileEdit struct {
+	X, Y int
+	Old  TileType
+	New  TileType
+}
+
+// editState holds the undo history and dirty tiles for editor changes
+type editState struct {
+	history [][]TileEdit    // One entry per stroke, most recent last
+	dirty   map[[2]int]bool // Tiles changed since the last ClearDirty
+}
+
+// maxUndoStrokes bounds the undo history so long editing sessions don't grow without limit
+const maxUndoStrokes = 100
+
+func (m *Map) editStateOrInit() *editState {
+	if m.edits == nil {
+		m.edits = &editState{dirty: make(map[[2]int]bool)}
+	}
+	return m.edits
+}
+
+// applyStroke sets every (x, y) in the stroke and records it as one undoable step
+// Tiles that already have the new type are skipped; returns the edits actually made
+func (m *Map) applyStroke(tiles [][2]int, tileType TileType) []TileEdit {
+	var stroke []TileEdit
+	for _, tile := range tiles {
+		old := m.GetTile(tile[0], tile[1])
+		if old == tileType {
+			continue
+		}
+		m.SetTile(tile[0], tile[1], tileType)
+		stroke = append(stroke, TileEdit{X: tile[0], Y: tile[1], Old: old, New: tileType})
+	}
+	if len(stroke) == 0 {
+		return nil
+	}
+	
+	edits := m.editStateOrInit()
+	for _, edit := range stroke {
+		edits.dirty[[2]int{edit.X, edit.Y}] = true
+	}
+	edits.history = append(edits.history, stroke)
+	if len(edits.history) > maxUndoStrokes {
+		edits.history = edits.history[1:]
+	}
+	return stroke
+}
+
+// Undo reverts the most recent editor stroke; returns false if there is nothing to undo
+func (m *Map) Undo() bool {
+	if m.edits == nil || len(m.edits.history) == 0 {
+		return false
+	}
+	
+	last := len(m.edits.history) - 1
+	stroke := m.edits.history[last]
+	m.edits.history = m.edits.history[:last]
+	for i := len(stroke) - 1; i >= 0; i-- {
+		edit := stroke[i]
+		m.SetTile(edit.X, edit.Y, edit.Old)
+		m.edits.dirty[[2]int{edit.X, edit.Y}] = true
+	}
+	return true
+}
+
+// DirtyTiles returns the tiles changed by editing since the last ClearDirty
+func (m *Map) DirtyTiles() [][2]int {
+	if m.edits == nil {
+		return nil
+	}
+	tiles := make([][2]int, 0, len(m.edits.dirty))
+	for tile := range m.edits.dirty {
+		tiles = append(tiles, tile)
+	}
+	return tiles
+}
+
+// ClearDirty forgets which tiles have changed, e.g. after a redraw or save
+func (m *Map) ClearDirty() {
+	if m.edits != nil {
+		m.edits.dirty = make(map[[2]int]bool)
+	}
+}
+
+// ToggleWalkable flips a tile as one undoable edit: walkable tiles become water and unwalkable ones grass
+// Returns whether the tile is walkable afterwards, or ok false for an out-of-bounds tile
+func (m *Map) ToggleWalkable(x, y int) (walkable, ok bool) {
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return false, false
+	}
+	replacement := TileGrass
+	if m.TileDefAt(x, y).Walkable {
+		replacement = TileWater
+	}
+	m.applyStroke([][2]int{{x, y}}, replacement)
+	return m.TileDefAt(x, y).Walkable, true
+}
+
+// PaintBrush sets every tile within a circular radius of the center, as one undoable stroke
+// Returns the number of tiles changed
+func (m *Map) PaintBrush(centerX, centerY, radius int, t TileType) int {
+	return len(m.applyStroke(m.brushTiles(centerX, centerY, radius, false), t))
+}
+
+// PaintBrushOverGrass paints like PaintBrush but only replaces grass, the same rule dirt path generation uses
+func (m *Map) PaintBrushOverGrass(centerX, centerY, radius int, t TileType) int {
+	return len(m.applyStroke(m.brushTiles(centerX, centerY, radius, true), t))
+}
+
+// brushTiles lists the in-bounds tiles inside the brush circle
+func (m *Map) brushTiles(centerX, centerY, radius int, onlyOverGrass bool) [][2]int {
+	var tiles [][2]int
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			x, y := centerX+dx, centerY+dy
+			if dx*dx+dy*dy > radius*radius || x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+				continue
+			}
+			if onlyOverGrass && m.GetTile(x, y) != TileGrass {
+				continue
+			}
+			tiles = append(tiles, [2]int{x, y})
+		}
+	}
+	return tiles
+}
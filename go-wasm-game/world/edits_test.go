@@ -0,0 +1,111 @@
+//go:build !js
+// +build !js
+
+package world_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that the painted cells are exactly the tiles inside the brush circle
+func TestPaintBrushPaintsCircle(t *testing.T) {
+	gameMap := world.NewMap(11, 11, 32.0)
+	changed := gameMap.PaintBrush(5, 5, 2, world.TileDirtPath)
+	
+	expected := 0
+	for y := 0; y < 11; y++ {
+		for x := 0; x < 11; x++ {
+			dx, dy := x-5, y-5
+			inCircle := dx*dx+dy*dy <= 4
+			if inCircle {
+				expected++
+			}
+			if painted := gameMap.GetTile(x, y) == world.TileDirtPath; painted != inCircle {
+				t.Errorf("tile (%d, %d) painted = %v, want %v", x, y, painted, inCircle)
+			}
+		}
+	}
+	if changed != expected || expected != 13 {
+		t.Errorf("PaintBrush changed %d tiles, want %d (13 for radius 2)", changed, expected)
+	}
+	if len(gameMap.DirtyTiles()) != expected {
+		t.Errorf("DirtyTiles() has %d tiles, want %d", len(gameMap.DirtyTiles()), expected)
+	}
+	
+	// Brushes are clipped at the map edge
+	if changed := gameMap.PaintBrush(0, 0, 1, world.TileWater); changed != 3 {
+		t.Errorf("corner brush changed %d tiles, want 3", changed)
+	}
+}
+
+// Test that the grass-only brush leaves other terrain untouched
+func TestPaintBrushOverGrass(t *testing.T) {
+	gameMap := world.NewMap(7, 7, 32.0)
+	gameMap.SetTile(3, 3, world.TileWater)
+	gameMap.SetTile(4, 3, world.TileDirtPath)
+	
+	changed := gameMap.PaintBrushOverGrass(3, 3, 1, world.TileWall)
+	if changed != 3 {
+		t.Errorf("PaintBrushOverGrass changed %d tiles, want 3 (5 in circle minus water and dirt)", changed)
+	}
+	if gameMap.GetTile(3, 3) != world.TileWater || gameMap.GetTile(4, 3) != world.TileDirtPath {
+		t.Error("non-grass tiles should not be overwritten")
+	}
+	if gameMap.GetTile(2, 3) != world.TileWall || gameMap.GetTile(3, 2) != world.TileWall {
+		t.Error("grass tiles inside the brush should be painted")
+	}
+}
+
+// Test that undo reverts a whole stroke and nothing more
+func TestPaintBrushUndo(t *testing.T) {
+	gameMap := world.NewMap(9, 9, 32.0)
+	gameMap.PaintBrush(2, 2, 1, world.TileDirtPath)
+	gameMap.PaintBrush(3, 2, 1, world.TileWater)
+	
+	if !gameMap.Undo() {
+		t.Fatal("Undo() = false, want true after painting")
+	}
+	// Tile (2, 2) was dirt before the second stroke covered it
+	if gameMap.GetTile(2, 2) != world.TileDirtPath || gameMap.GetTile(4, 2) != world.TileGrass {
+		t.Error("undo should restore the tiles from before the last stroke")
+	}
+	
+	gameMap.Undo()
+	if gameMap.GetTile(2, 2) != world.TileGrass {
+		t.Error("second undo should restore the original grass")
+	}
+	if gameMap.Undo() {
+		t.Error("Undo() should return false once the history is empty")
+	}
+	
+	gameMap.ClearDirty()
+	if len(gameMap.DirtyTiles()) != 0 {
+		t.Error("ClearDirty should empty the dirty set")
+	}
+}
+
+// Test that ToggleWalkable flips grass and water both ways, marks the tile dirty and can be undone
+func TestToggleWalkable(t *testing.T) {
+	m := world.NewMap(5, 5, 32.0)
+	
+	walkable, ok := m.ToggleWalkable(2, 2)
+	if !ok || walkable || m.GetTile(2, 2) != world.TileWater {
+		t.Fatalf("first toggle = (%v, %v) with tile %v, want (false, true) and water", walkable, ok, m.GetTile(2, 2))
+	}
+	if dirty := m.DirtyTiles(); len(dirty) != 1 || dirty[0] != [2]int{2, 2} {
+		t.Errorf("DirtyTiles() = %v, want just (2, 2)", dirty)
+	}
+	
+	walkable, ok = m.ToggleWalkable(2, 2)
+	if !ok || !walkable || m.GetTile(2, 2) != world.TileGrass {
+		t.Errorf("second toggle = (%v, %v) with tile %v, want (true, true) and grass", walkable, ok, m.GetTile(2, 2))
+	}
+	if !m.Undo() || m.GetTile(2, 2) != world.TileWater {
+		t.Errorf("Undo() after two toggles left %v, want water", m.GetTile(2, 2))
+	}
+	
+	if _, ok := m.ToggleWalkable(5, 0); ok {
+		t.Errorf("ToggleWalkable(5, 0) on a 5x5 map reported ok")
+	}
+}
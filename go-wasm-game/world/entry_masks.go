@@ -0,0 +1,65 @@
+package world
+
+// EntryMask is a set of sides a tile may be entered from, e.g. only EntryFromNorth for a ledge
+// that can be dropped off southward but not climbed back up
+type EntryMask uint8
+
+const (
+	EntryFromNorth EntryMask = 1 << iota // Stepping in from the tile above (moving south)
+	EntryFromEast                        // Stepping in from the tile to the right (moving west)
+	EntryFromSouth                       // Stepping in from the tile below (moving north)
+	EntryFromWest                        // Stepping in from the tile to the left (moving east)
+
+	// EntryFromAll is every side; tiles default to it
+	EntryFromAll = EntryFromNorth | EntryFromEast | EntryFromSouth | EntryFromWest
+)
+
+// SetTileEntryMask restricts which sides a tile can be entered from; EntryFromAll lifts the restriction
+func (m *Map) SetTileEntryMask(x, y int, mask EntryMask) {
+	x, y = m.WrapTile(x, y)
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return
+	}
+	key := y*m.Width + x
+	if mask&EntryFromAll == EntryFromAll {
+		delete(m.entryMasks, key)
+	} else {
+		if m.entryMasks == nil {
+			m.entryMasks = make(map[int]EntryMask)
+		}
+		m.entryMasks[key] = mask & EntryFromAll
+	}
+	m.notifyTileChange(x, y)
+}
+
+// TileEntryMask returns the sides a tile can be entered from, EntryFromAll unless restricted
+func (m *Map) TileEntryMask(x, y int) EntryMask {
+	x, y = m.WrapTile(x, y)
+	if mask, restricted := m.entryMasks[y*m.Width+x]; restricted && x >= 0 && x < m.Width && y >= 0 && y < m.Height {
+		return mask
+	}
+	return EntryFromAll
+}
+
+// CanEnterTile returns true if a single step onto (toX, toY) comes from a side the tile allows.
+// A diagonal step enters through a corner, so both sides it touches must be allowed
+func (m *Map) CanEnterTile(fromX, fromY, toX, toY int) bool {
+	if len(m.entryMasks) == 0 {
+		return true
+	}
+	
+	required := EntryMask(0)
+	switch dx := toX - fromX; {
+	case dx > 0:
+		required |= EntryFromWest
+	case dx < 0:
+		required |= EntryFromEast
+	}
+	switch dy := toY - fromY; {
+	case dy > 0:
+		required |= EntryFromNorth
+	case dy < 0:
+		required |= EntryFromSouth
+	}
+	return m.TileEntryMask(toX, toY)&required == required
+}
@@ -24,8 +24,12 @@ type Bush struct {
 
 // Environment manages and renders all environmental objects
 type Environment struct {
-	trees  []Tree
-	bushes []Bush
+	trees       []Tree
+	bushes      []Bush
+	index       *Quadtree // Spatial index over trees and bushes for culling and collision
+	gameMap     *Map      // Map whose tiles the trees block sight on
+	worldWidth  float64
+	worldHeight float64
 }
 
 // renderTree renders a tree at screen coordinates
@@ -55,8 +59,7 @@ func NewEnvironment(gameMap *Map) *Environment {
 	var bushes []Bush
 	
 	// Use the map world dimensions for environment placement
-	worldWidth := float64(gameMap.Width) * gameMap.TileSize
-	worldHeight := float64(gameMap.Height) * gameMap.TileSize
+	worldWidth, worldHeight := gameMap.WorldSize()
 	
 	// Generate trees across the world area
 	treePositions := []struct{ x, y float64 }{
@@ -118,32 +121,31 @@ func NewEnvironment(gameMap *Map) *Environment {
 		})
 	}
 
-	return &Environment{
-		trees:  trees,
-		bushes: bushes,
+	env := &Environment{
+		trees:       trees,
+		bushes:      bushes,
+		worldWidth:  worldWidth,
+		worldHeight: worldHeight,
+		gameMap:     gameMap,
 	}
+	env.rebuildIndex()
+	for _, tree := range env.trees {
+		env.setTreeBlocksSight(tree, true)
+	}
+	return env
 }
 
 // Render draws all trees and bushes relative to camera
 func (e *Environment) Render(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
-	// Draw environment objects (trees and bushes) relative to camera
-	for _, tree := range e.trees {
-		screenX := tree.x - cameraX
-		screenY := tree.y - cameraY
-		
-		// Only draw if on screen
-		if screenX > -50 && screenX < canvasWidth+50 && screenY > -50 && screenY < canvasHeight+50 {
-			renderTree(ctx, Tree{x: screenX, y: screenY, trunkWidth: tree.trunkWidth, trunkHeight: tree.trunkHeight, canopyRadius: tree.canopyRadius})
+	// Only objects intersecting the view are drawn; trees come first since their IDs sort lower
+	view := Rect{X: cameraX, Y: cameraY, Width: canvasWidth, Height: canvasHeight}
+	for _, id := range e.queryIDs(view) {
+		if id < len(e.trees) {
+			tree := e.trees[id]
+			renderTree(ctx, Tree{x: tree.x - cameraX, y: tree.y - cameraY, trunkWidth: tree.trunkWidth, trunkHeight: tree.trunkHeight, canopyRadius: tree.canopyRadius})
+			continue
 		}
+		bush := e.bushes[id-len(e.trees)]
+		renderBush(ctx, Bush{x: bush.x - cameraX, y: bush.y - cameraY, radius: bush.radius})
 	}
-	for _, bush := range e.bushes {
-		screenX := bush.x - cameraX
-		screenY := bush.y - cameraY
-		
-		// Only draw if on screen
-		if screenX > -30 && screenX < canvasWidth+30 && screenY > -30 && screenY < canvasHeight+30 {
-			renderBush(ctx, Bush{x: screenX, y: screenY, radius: bush.radius})
-		}
-	}
-}
-
+}
\ No newline at end of file
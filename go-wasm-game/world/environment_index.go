@@ -0,0 +1,112 @@
+//go:build js
+// +build js
+
+package world
+
+import "sort"
+
+// indexPadding lets objects near the map edge overhang the world bounds and still be indexed
+const indexPadding = 128.0
+
+// treeBounds returns the world-space rectangle covered by a tree's trunk and canopy
+func treeBounds(tree Tree) Rect {
+	halfWidth := tree.canopyRadius
+	if tree.trunkWidth/2 > halfWidth {
+		halfWidth = tree.trunkWidth / 2
+	}
+	top := tree.y - tree.trunkHeight + 10 - tree.canopyRadius
+	return Rect{X: tree.x - halfWidth, Y: top, Width: halfWidth * 2, Height: tree.y - top}
+}
+
+// trunkBounds returns the world-space rectangle of a tree's trunk, the part that blocks movement
+func trunkBounds(tree Tree) Rect {
+	return Rect{X: tree.x - tree.trunkWidth/2, Y: tree.y - tree.trunkHeight, Width: tree.trunkWidth, Height: tree.trunkHeight}
+}
+
+// bushBounds returns the world-space rectangle covered by a bush
+func bushBounds(bush Bush) Rect {
+	return Rect{X: bush.x - bush.radius, Y: bush.y - bush.radius, Width: bush.radius * 2, Height: bush.radius * 2}
+}
+
+// rebuildIndex re-creates the quadtree from the current trees and bushes
+// Trees use IDs 0..len(trees)-1 and bushes follow, so sorting IDs keeps trees drawn before bushes
+// The root grows past the padded world bounds if an object overhangs them further
+func (e *Environment) rebuildIndex() {
+	items := make([]QuadItem, 0, len(e.trees)+len(e.bushes))
+	for i, tree := range e.trees {
+		items = append(items, QuadItem{Bounds: treeBounds(tree), ID: i})
+	}
+	for i, bush := range e.bushes {
+		items = append(items, QuadItem{Bounds: bushBounds(bush), ID: len(e.trees) + i})
+	}
+	e.index = NewQuadtreeCovering(Rect{
+		X:      -indexPadding,
+		Y:      -indexPadding,
+		Width:  e.worldWidth + indexPadding*2,
+		Height: e.worldHeight + indexPadding*2,
+	}, items)
+}
+
+// queryIDs returns the sorted IDs of all objects intersecting the region
+func (e *Environment) queryIDs(region Rect) []int {
+	items := e.index.QueryRect(region)
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// IsBlocked returns true if a world point hits a tree trunk
+func (e *Environment) IsBlocked(x, y float64) bool {
+	for _, item := range e.index.QueryPoint(x, y) {
+		if item.ID < len(e.trees) && trunkBounds(e.trees[item.ID]).Contains(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// DestroyObjectsAt removes every tree and bush covering a world point and returns how many were removed
+func (e *Environment) DestroyObjectsAt(x, y float64) int {
+	destroyed := make(map[int]bool)
+	for _, item := range e.index.QueryPoint(x, y) {
+		destroyed[item.ID] = true
+	}
+	if len(destroyed) == 0 {
+		return 0
+	}
+	
+	trees := e.trees[:0:0]
+	for i, tree := range e.trees {
+		if !destroyed[i] {
+			trees = append(trees, tree)
+		} else {
+			e.setTreeBlocksSight(tree, false)
+		}
+	}
+	bushes := e.bushes[:0:0]
+	for i, bush := range e.bushes {
+		if !destroyed[len(e.trees)+i] {
+			bushes = append(bushes, bush)
+		}
+	}
+	
+	e.trees, e.bushes = trees, bushes
+	e.rebuildIndex()
+	return len(destroyed)
+}
+
+// setTreeBlocksSight registers (or clears) the tile under a tree's trunk as sight-blocking
+func (e *Environment) setTreeBlocksSight(tree Tree, blocks bool) {
+	if e.gameMap == nil {
+		return
+	}
+	tileX, tileY := e.gameMap.WorldToGrid(tree.x, tree.y-tree.trunkHeight/2)
+	if blocks {
+		e.gameMap.AddSightBlocker(tileX, tileY)
+	} else {
+		e.gameMap.RemoveSightBlocker(tileX, tileY)
+	}
+}
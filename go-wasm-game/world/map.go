@@ -11,11 +11,28 @@ import (
 
 // Map represents a grid-based map with tiles
 type Map struct {
-	Width    int
-	Height   int
-	TileSize float64
-	Tiles    [][]TileType
-	Layers *Layers
+	Width      int
+	Height     int
+	TileSize   float64 // Square tile size (kept for compatibility, equals TileWidth)
+	TileWidth  float64
+	TileHeight float64
+	Tiles      [][]TileType // Fully allocated grid (nil for chunked maps)
+	Overlay    [][]TileType // Overlay tiles drawn over Tiles (OverlayNone = none), nil until first set
+	outOfBoundsTile *TileType // What GetTile reports outside the map, see SetOutOfBoundsTile; nil means water
+	PixelSnap  bool         // Snap tile rectangles to whole pixels so no seams show between tiles; on by default
+	Wrap       bool         // Toroidal map: coordinates, pathfinding (A*, D* Lite, flow fields) and movement wrap around the edges; off by default
+	ChunkSize  int          // Chunk edge length in tiles for lazily generated maps (0 = not chunked)
+	chunks     map[int][][]TileType
+	generator  TileGenerator
+	edits      *editState // Undo history and dirty tiles for editor changes, nil until first edit
+	edgeWalls  map[tileEdge]bool // Thin walls between adjacent tiles, nil until the first is added
+	traffic    *trafficState     // Decaying per-tile unit traffic, nil until first recorded
+	sightBlockers map[int]int    // Tile index -> objects on it that block sight (e.g. trees), nil until first set
+	entryMasks map[int]EntryMask // Tile index -> sides a one-way tile can be entered from, nil until first set
+	palette    string            // Active TilePalettes entry, "" for the default palette
+	tileListeners []func(x, y int) // Told about every tile edit that can change pathing, see OnTileChange
+	Decorations []Decoration     // Cosmetic sprites on grass; never affect walkability
+	Layers     *Layers
 }
 
 // Layer represents a rendering layer with priority and visibility
@@ -23,6 +40,7 @@ type Layer struct {
 	Name       string
 	Priority   int  // Higher priority draws on top
 	Visible    bool
+	Opacity    float64 // 0.0 (transparent) to 1.0 (opaque), applied via globalAlpha
 	RenderFunc func(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64)
 }
 
@@ -31,14 +49,22 @@ type Layers struct {
 	Layers []Layer
 }
 
-// NewMap creates a new map with the specified dimensions
+// NewMap creates a new map with the specified dimensions and square tiles
 func NewMap(width, height int, tileSize float64) *Map {
+	return NewMapWithTileDimensions(width, height, tileSize, tileSize)
+}
+
+// NewMapWithTileDimensions creates a new map with separate tile width and height
+func NewMapWithTileDimensions(width, height int, tileWidth, tileHeight float64) *Map {
 	m := &Map{
-		Width:    width,
-		Height:   height,
-		TileSize: tileSize,
-		Tiles:    make([][]TileType, height),
-		Layers: NewLayers(),
+		Width:      width,
+		Height:     height,
+		TileSize:   tileWidth,
+		TileWidth:  tileWidth,
+		TileHeight: tileHeight,
+		Tiles:      make([][]TileType, height),
+		Layers:     NewLayers(),
+		PixelSnap:  true,
 	}
 	
 	// Initialize the 2D slice
@@ -58,28 +84,34 @@ func NewMap(width, height int, tileSize float64) *Map {
 	return m
 }
 
-// GetTile returns the tile type at the given grid coordinates
-func (m *Map) GetTile(x, y int) TileType {
-	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
-		return TileWater // Out of bounds is water
+// NewChunkedMap creates a map whose tiles are generated lazily, one chunk at a time,
+// the first time any tile in the chunk is accessed. Memory grows with the explored area.
+func NewChunkedMap(width, height int, tileSize float64, chunkSize int, generator TileGenerator) *Map {
+	m := &Map{
+		Width:      width,
+		Height:     height,
+		TileSize:   tileSize,
+		TileWidth:  tileSize,
+		TileHeight: tileSize,
+		ChunkSize:  chunkSize,
+		chunks:     make(map[int][][]TileType),
+		generator:  generator,
+		Layers:     NewLayers(),
+		PixelSnap:  true,
 	}
-	return m.Tiles[y][x]
+	m.initializeLayers()
+	return m
 }
 
-// SetTile sets the tile type at the given grid coordinates
-func (m *Map) SetTile(x, y int, tileType TileType) {
-	if x >= 0 && x < m.Width && y >= 0 && y < m.Height {
-		m.Tiles[y][x] = tileType
-	}
-}
 
 // Render draws the visible portion of the map
 func (m *Map) Render(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
 	// Calculate which tiles are visible
-	startX := int(math.Max(0, math.Floor(cameraX/m.TileSize)))
-	startY := int(math.Max(0, math.Floor(cameraY/m.TileSize)))
-	endX := int(math.Min(float64(m.Width-1), math.Ceil((cameraX+canvasWidth)/m.TileSize)))
-	endY := int(math.Min(float64(m.Height-1), math.Ceil((cameraY+canvasHeight)/m.TileSize)))
+	tileWidth, tileHeight := m.TileDimensions()
+	startX := int(math.Max(0, math.Floor(cameraX/tileWidth)))
+	startY := int(math.Max(0, math.Floor(cameraY/tileHeight)))
+	endX := int(math.Min(float64(m.Width-1), math.Ceil((cameraX+canvasWidth)/tileWidth)))
+	endY := int(math.Min(float64(m.Height-1), math.Ceil((cameraY+canvasHeight)/tileHeight)))
 	
 	// Draw only visible tiles for performance
 	for y := startY; y <= endY; y++ {
@@ -87,38 +119,18 @@ func (m *Map) Render(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight f
 			tileType := m.GetTile(x, y)
 			
 			// Calculate screen position
-			screenX := float64(x)*m.TileSize - cameraX
-			screenY := float64(y)*m.TileSize - cameraY
+			screenX := float64(x)*tileWidth - cameraX
+			screenY := float64(y)*tileHeight - cameraY
 			
-			// Get tile definition and set color
-			tileDef, exists := TileDefinitions[tileType]
-			if !exists {
-				// Fallback to grass if tile type not found
-				tileDef = TileDefinitions[TileGrass]
-			}
-			
-			// For now, we'll use color (image support can be added later)
-			ctx.Set("fillStyle", tileDef.Color)
+			// Set the tile's color from the active palette (image support can be added later)
+			ctx.Set("fillStyle", m.TileColor(tileType))
 			
 			// Draw the tile
-			ctx.Call("fillRect", screenX, screenY, m.TileSize, m.TileSize)
+			ctx.Call("fillRect", screenX, screenY, tileWidth, tileHeight)
 		}
 	}
 }
 
-// WorldToGrid converts world coordinates to grid coordinates
-func (m *Map) WorldToGrid(worldX, worldY float64) (int, int) {
-	gridX := int(math.Floor(worldX / m.TileSize))
-	gridY := int(math.Floor(worldY / m.TileSize))
-	return gridX, gridY
-}
-
-// GridToWorld converts grid coordinates to world coordinates (center of tile)
-func (m *Map) GridToWorld(gridX, gridY int) (float64, float64) {
-	worldX := float64(gridX)*m.TileSize + m.TileSize/2
-	worldY := float64(gridY)*m.TileSize + m.TileSize/2
-	return worldX, worldY
-}
 
 // NewLayers creates a new layers collection
 func NewLayers() *Layers {
@@ -133,6 +145,7 @@ func (l *Layers) AddLayer(name string, priority int, visible bool, renderFunc fu
 		Name:       name,
 		Priority:   priority,
 		Visible:    visible,
+		Opacity:    1.0,
 		RenderFunc: renderFunc,
 	}
 	l.Layers = append(l.Layers, layer)
@@ -161,6 +174,22 @@ func (l *Layers) SetLayerVisibility(name string, visible bool) bool {
 	return false
 }
 
+// SetLayerOpacity sets the opacity of a layer by name, clamped to [0, 1]
+func (l *Layers) SetLayerOpacity(name string, opacity float64) bool {
+	for i, layer := range l.Layers {
+		if layer.Name == name {
+			l.Layers[i].Opacity = clampOpacity(opacity)
+			return true
+		}
+	}
+	return false
+}
+
+// clampOpacity restricts an opacity value to the valid [0, 1] range
+func clampOpacity(opacity float64) float64 {
+	return math.Max(0, math.Min(1, opacity))
+}
+
 // SetLayerPriority sets the priority of a layer by name
 func (l *Layers) SetLayerPriority(name string, priority int) bool {
 	for i, layer := range l.Layers {
@@ -193,9 +222,20 @@ func (l *Layers) sortLayers() {
 // RenderAllLayers renders all visible layers in priority order
 func (l *Layers) RenderAllLayers(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
 	for _, layer := range l.Layers {
-		if layer.Visible && layer.RenderFunc != nil {
+		if !layer.Visible || layer.RenderFunc == nil {
+			continue
+		}
+		
+		// Fully opaque layers skip the extra save/restore
+		if layer.Opacity >= 1.0 {
 			layer.RenderFunc(ctx, cameraX, cameraY, canvasWidth, canvasHeight)
+			continue
 		}
+		
+		ctx.Call("save")
+		ctx.Set("globalAlpha", layer.Opacity)
+		layer.RenderFunc(ctx, cameraX, cameraY, canvasWidth, canvasHeight)
+		ctx.Call("restore")
 	}
 }
 
@@ -204,6 +244,12 @@ func (m *Map) initializeLayers() {
 	// Add tiles layer (priority 0 - background)
 	m.Layers.AddLayer("tiles", 0, true, m.renderTilesLayer)
 	
+	// Add thin walls between tiles just above the terrain
+	m.Layers.AddLayer("edge-walls", 1, true, m.renderEdgeWallsLayer)
+	
+	// Add cosmetic decorations above the terrain but below units; hide them with setLayerVisible or toggleDecorations
+	m.Layers.AddLayer("decorations", 2, true, m.renderDecorationsLayer)
+	
 	// Objects layer will be added by main.go when trees and bushes are available
 	// Player layer will be added by main.go when player is available
 }
@@ -212,34 +258,24 @@ func (m *Map) initializeLayers() {
 func (m *Map) renderTilesLayer(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {
 	// This is the same logic as the original Render method, but only for tiles
 	// Calculate which tiles are visible
-	startX := int(math.Max(0, math.Floor(cameraX/m.TileSize)))
-	startY := int(math.Max(0, math.Floor(cameraY/m.TileSize)))
-	endX := int(math.Min(float64(m.Width-1), math.Ceil((cameraX+canvasWidth)/m.TileSize)))
-	endY := int(math.Min(float64(m.Height-1), math.Ceil((cameraY+canvasHeight)/m.TileSize)))
+	startX, startY, endX, endY := m.VisibleTileRange(cameraX, cameraY, canvasWidth, canvasHeight)
 	
 	// Draw only visible tiles for performance
 	for y := startY; y <= endY; y++ {
 		for x := startX; x <= endX; x++ {
-			tileType := m.GetTile(x, y)
-			
-			// Calculate screen position
-			screenX := float64(x)*m.TileSize - cameraX
-			screenY := float64(y)*m.TileSize - cameraY
-			
-			// Get tile definition and set color
-			tileDef, exists := TileDefinitions[tileType]
-			if !exists {
-				// Fallback to grass if tile type not found
-				tileDef = TileDefinitions[TileGrass]
-			}
+			// Calculate screen position, snapped to whole pixels unless PixelSnap is off
+			screenX, screenY, tileWidth, tileHeight := m.TileScreenRect(x, y, cameraX, cameraY)
 			
+			// Draw the base tile, then any overlay blended over it with reduced alpha
 			// For now, we'll use color (image support can be added later)
-			ctx.Set("fillStyle", tileDef.Color)
-			
-			// Draw the tile
-			ctx.Call("fillRect", screenX, screenY, m.TileSize, m.TileSize)
+			for _, op := range m.TileDrawOps(m.WrapTile(x, y)) {
+				ctx.Set("globalAlpha", op.Alpha)
+				ctx.Set("fillStyle", op.Color)
+				ctx.Call("fillRect", screenX, screenY, tileWidth, tileHeight)
+			}
 		}
 	}
+	ctx.Set("globalAlpha", 1.0)
 }
 
 // RenderWithLayers renders the map using the layer system
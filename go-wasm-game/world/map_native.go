@@ -3,25 +3,46 @@
 
 package world
 
-import (
-	"math"
-)
-
 // Map represents a grid-based map with tiles (test version without WebAssembly)
 type Map struct {
-	Width    int
-	Height   int
-	TileSize float64
-	Tiles    [][]TileType
+	Width      int
+	Height     int
+	TileSize   float64 // Square tile size (kept for compatibility, equals TileWidth)
+	TileWidth  float64
+	TileHeight float64
+	Tiles      [][]TileType // Fully allocated grid (nil for chunked maps)
+	Overlay    [][]TileType // Overlay tiles drawn over Tiles (OverlayNone = none), nil until first set
+	outOfBoundsTile *TileType // What GetTile reports outside the map, see SetOutOfBoundsTile; nil means water
+	PixelSnap  bool         // Snap tile rectangles to whole pixels so no seams show between tiles; on by default
+	Wrap       bool         // Toroidal map: coordinates, pathfinding (A*, D* Lite, flow fields) and movement wrap around the edges; off by default
+	ChunkSize  int          // Chunk edge length in tiles for lazily generated maps (0 = not chunked)
+	chunks     map[int][][]TileType
+	generator  TileGenerator
+	edits      *editState // Undo history and dirty tiles for editor changes, nil until first edit
+	edgeWalls  map[tileEdge]bool // Thin walls between adjacent tiles, nil until the first is added
+	traffic    *trafficState     // Decaying per-tile unit traffic, nil until first recorded
+	sightBlockers map[int]int    // Tile index -> objects on it that block sight (e.g. trees), nil until first set
+	entryMasks map[int]EntryMask // Tile index -> sides a one-way tile can be entered from, nil until first set
+	palette    string            // Active TilePalettes entry, "" for the default palette
+	tileListeners []func(x, y int) // Told about every tile edit that can change pathing, see OnTileChange
+	Decorations []Decoration     // Cosmetic sprites on grass; never affect walkability
 }
 
-// NewMap creates a new map with the specified dimensions
+// NewMap creates a new map with the specified dimensions and square tiles
 func NewMap(width, height int, tileSize float64) *Map {
+	return NewMapWithTileDimensions(width, height, tileSize, tileSize)
+}
+
+// NewMapWithTileDimensions creates a new map with separate tile width and height
+func NewMapWithTileDimensions(width, height int, tileWidth, tileHeight float64) *Map {
 	m := &Map{
-		Width:    width,
-		Height:   height,
-		TileSize: tileSize,
-		Tiles:    make([][]TileType, height),
+		Width:      width,
+		Height:     height,
+		TileSize:   tileWidth,
+		TileWidth:  tileWidth,
+		TileHeight: tileHeight,
+		Tiles:      make([][]TileType, height),
+		PixelSnap:  true,
 	}
 	
 	// Initialize the 2D slice
@@ -36,31 +57,20 @@ func NewMap(width, height int, tileSize float64) *Map {
 	return m
 }
 
-// GetTile returns the tile type at the specified grid coordinates
-func (m *Map) GetTile(x, y int) TileType {
-	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
-		return TileWater // Out of bounds is water
+// NewChunkedMap creates a map whose tiles are generated lazily, one chunk at a time,
+// the first time any tile in the chunk is accessed. Memory grows with the explored area.
+func NewChunkedMap(width, height int, tileSize float64, chunkSize int, generator TileGenerator) *Map {
+	return &Map{
+		Width:      width,
+		Height:     height,
+		TileSize:   tileSize,
+		TileWidth:  tileSize,
+		TileHeight: tileSize,
+		ChunkSize:  chunkSize,
+		chunks:     make(map[int][][]TileType),
+		generator:  generator,
+		PixelSnap:  true,
 	}
-	return m.Tiles[y][x]
 }
 
-// SetTile sets the tile type at the specified grid coordinates (for testing)
-func (m *Map) SetTile(x, y int, tileType TileType) {
-	if x >= 0 && x < m.Width && y >= 0 && y < m.Height {
-		m.Tiles[y][x] = tileType
-	}
-}
-
-// WorldToGrid converts world coordinates to grid coordinates
-func (m *Map) WorldToGrid(worldX, worldY float64) (int, int) {
-	gridX := int(math.Floor(worldX / m.TileSize))
-	gridY := int(math.Floor(worldY / m.TileSize))
-	return gridX, gridY
-}
 
-// GridToWorld converts grid coordinates to world coordinates (center of tile)
-func (m *Map) GridToWorld(gridX, gridY int) (float64, float64) {
-	worldX := float64(gridX)*m.TileSize + m.TileSize/2
-	worldY := float64(gridY)*m.TileSize + m.TileSize/2
-	return worldX, worldY
-}
\ No newline at end of file
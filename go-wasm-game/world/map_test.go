@@ -0,0 +1,70 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Package world_test provides unit tests for the WebAssembly map and layer system.
+package world_test
+
+import (
+	"syscall/js"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+func noopRender(ctx js.Value, cameraX, cameraY, canvasWidth, canvasHeight float64) {}
+
+// Test that layer opacity defaults to opaque and is clamped to [0, 1]
+func TestLayerOpacityClamping(t *testing.T) {
+	layers := world.NewLayers()
+	layers.AddLayer("objects", 10, true, noopRender)
+	
+	if opacity := layers.GetLayer("objects").Opacity; opacity != 1.0 {
+		t.Errorf("default Opacity = %v, want 1.0", opacity)
+	}
+	
+	tests := []struct {
+		name     string
+		value    float64
+		expected float64
+	}{
+		{"Within range", 0.4, 0.4},
+		{"Fully transparent", 0.0, 0.0},
+		{"Below range", -0.5, 0.0},
+		{"Above range", 3.0, 1.0},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !layers.SetLayerOpacity("objects", tt.value) {
+				t.Fatal("SetLayerOpacity() = false for existing layer")
+			}
+			if opacity := layers.GetLayer("objects").Opacity; opacity != tt.expected {
+				t.Errorf("Opacity = %v, want %v", opacity, tt.expected)
+			}
+		})
+	}
+	
+	if layers.SetLayerOpacity("missing", 0.5) {
+		t.Error("SetLayerOpacity() = true for missing layer, want false")
+	}
+}
+
+// Test that environment trees block sight on their trunk tile until they are destroyed
+func TestTreesBlockSight(t *testing.T) {
+	gameMap := world.NewMap(200, 200, 32.0)
+	environment := world.NewEnvironment(gameMap)
+	
+	// The first tree stands at 10% / 15% of the world with a 35px trunk
+	worldWidth, worldHeight := gameMap.WorldSize()
+	trunkX, trunkY := worldWidth*0.1, worldHeight*0.15-35.0/2
+	tileX, tileY := gameMap.WorldToGrid(trunkX, trunkY)
+	if !gameMap.BlocksSight(tileX, tileY) {
+		t.Fatalf("BlocksSight(%d, %d) = false, want the tree's trunk tile to block sight", tileX, tileY)
+	}
+	
+	if environment.DestroyObjectsAt(trunkX, trunkY) == 0 {
+		t.Fatalf("DestroyObjectsAt(trunk) removed nothing")
+	}
+	if gameMap.BlocksSight(tileX, tileY) {
+		t.Errorf("BlocksSight(%d, %d) = true after the tree was destroyed", tileX, tileY)
+	}
+}
@@ -0,0 +1,71 @@
+package world
+
+// OverlayNone marks an overlay cell with nothing drawn over the base tile
+// It shares the zero value with TileGrass, which is never used as an overlay
+const OverlayNone TileType = 0
+
+// TileDrawOp is one fill drawn for a tile cell, in order
+type TileDrawOp struct {
+	Color string
+	Alpha float64
+}
+
+// GetOverlay returns the overlay tile at the given grid coordinates, OverlayNone if there is none
+func (m *Map) GetOverlay(x, y int) TileType {
+	if m.Overlay == nil || x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return OverlayNone
+	}
+	return m.Overlay[y][x]
+}
+
+// SetOverlay places an overlay tile over a cell without touching its base tile
+// Returns false for out-of-bounds cells and tile types that are not overlays; OverlayNone clears the cell
+func (m *Map) SetOverlay(x, y int, overlay TileType) bool {
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return false
+	}
+	if overlay != OverlayNone && !TileDefinitions[overlay].Overlay {
+		return false
+	}
+	if m.Overlay == nil {
+		if overlay == OverlayNone {
+			return true
+		}
+		m.Overlay = make([][]TileType, m.Height)
+		for row := range m.Overlay {
+			m.Overlay[row] = make([]TileType, m.Width)
+		}
+	}
+	m.Overlay[y][x] = overlay
+	m.notifyTileChange(x, y)
+	return true
+}
+
+// TileDefAt returns the effective definition of a cell: the base tile's, with walkability and speed
+// taken from the overlay instead when the overlay overrides them. An overlay can block or slow walkable
+// terrain but never makes unwalkable terrain (e.g. snow-covered water) walkable
+func (m *Map) TileDefAt(x, y int) Tile {
+	tileDef, exists := TileDefinitions[m.GetTile(x, y)]
+	if !exists {
+		// Fall back to grass if tile type not found
+		tileDef = TileDefinitions[TileGrass]
+	}
+	if overlay := m.GetOverlay(x, y); overlay != OverlayNone {
+		if overlayDef := TileDefinitions[overlay]; overlayDef.OverridesWalkability && tileDef.Walkable {
+			tileDef.Walkable = overlayDef.Walkable
+			tileDef.WalkSpeed = overlayDef.WalkSpeed
+		}
+	}
+	return tileDef
+}
+
+// TileDrawOps returns the fills that render a cell: the opaque base tile, then its overlay if any,
+// colored by the active palette
+func (m *Map) TileDrawOps(x, y int) []TileDrawOp {
+	ops := []TileDrawOp{{Color: m.TileColor(m.GetTile(x, y)), Alpha: 1}}
+	if overlay := m.GetOverlay(x, y); overlay != OverlayNone {
+		overlayDef := TileDefinitions[overlay]
+		ops = append(ops, TileDrawOp{Color: m.TileColor(overlay), Alpha: overlayDef.OverlayAlpha})
+	}
+	return ops
+}
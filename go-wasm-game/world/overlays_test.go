@@ -0,0 +1,104 @@
+//go:build !js
+// +build !js
+
+package world_test
+
+import (
+	"bytes"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that a purely visual overlay leaves base walkability alone while an overriding one replaces it
+func TestOverlayWalkability(t *testing.T) {
+	tests := []struct {
+		name         string
+		base         world.TileType
+		overlay      world.TileType
+		wantWalkable bool
+		wantSpeed    float64
+	}{
+		{"no overlay on grass", world.TileGrass, world.OverlayNone, true, 1.0},
+		{"shadow over grass", world.TileGrass, world.TileShadow, true, 1.0},
+		{"shadow over water", world.TileWater, world.TileShadow, false, 0.0},
+		{"shadow over dirt keeps speed", world.TileDirtPath, world.TileShadow, true, 1.5},
+		{"snow over dirt uses its own speed", world.TileDirtPath, world.TileSnow, true, 0.7},
+		{"snow over water stays unwalkable", world.TileWater, world.TileSnow, false, 0.0},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := world.NewMap(3, 3, 32.0)
+			m.SetTile(1, 1, tt.base)
+			if !m.SetOverlay(1, 1, tt.overlay) {
+				t.Fatalf("SetOverlay(%v) refused", tt.overlay)
+			}
+			if m.GetTile(1, 1) != tt.base {
+				t.Errorf("base tile = %v after SetOverlay, want %v untouched", m.GetTile(1, 1), tt.base)
+			}
+			
+			tileDef := m.TileDefAt(1, 1)
+			if tileDef.Walkable != tt.wantWalkable || tileDef.WalkSpeed != tt.wantSpeed {
+				t.Errorf("TileDefAt = walkable %v speed %v, want %v %v", tileDef.Walkable, tileDef.WalkSpeed, tt.wantWalkable, tt.wantSpeed)
+			}
+		})
+	}
+}
+
+// Test that visual overlays don't change the walkable grid
+func TestVisualOverlayKeepsWalkableGrid(t *testing.T) {
+	m := world.NewMap(6, 6, 32.0)
+	m.SetTile(2, 2, world.TileWater)
+	before := m.WalkableGrid()
+	for y := 0; y < 6; y++ {
+		for x := 0; x < 6; x++ {
+			m.SetOverlay(x, y, world.TileShadow)
+		}
+	}
+	if !bytes.Equal(before, m.WalkableGrid()) {
+		t.Errorf("WalkableGrid() changed after adding shadow overlays")
+	}
+}
+
+// Test that an overlay cell draws its base tile opaque and then the overlay with reduced alpha
+func TestTileDrawOpsWithOverlay(t *testing.T) {
+	m := world.NewMap(3, 3, 32.0)
+	m.SetTile(0, 0, world.TileDirtPath)
+	m.SetOverlay(0, 0, world.TileSnow)
+	
+	ops := m.TileDrawOps(0, 0)
+	if len(ops) != 2 {
+		t.Fatalf("TileDrawOps(overlay cell) returned %d ops, want base and overlay", len(ops))
+	}
+	if ops[0].Color != world.TileDefinitions[world.TileDirtPath].Color || ops[0].Alpha != 1 {
+		t.Errorf("base op = %+v, want opaque dirt", ops[0])
+	}
+	snow := world.TileDefinitions[world.TileSnow]
+	if ops[1].Color != snow.Color || ops[1].Alpha != snow.OverlayAlpha || ops[1].Alpha >= 1 {
+		t.Errorf("overlay op = %+v, want translucent snow", ops[1])
+	}
+	
+	if ops := m.TileDrawOps(1, 1); len(ops) != 1 {
+		t.Errorf("TileDrawOps(plain cell) returned %d ops, want only the base", len(ops))
+	}
+}
+
+// Test that only overlay tile types can be placed as overlays, and only in bounds
+func TestSetOverlayRejectsInvalid(t *testing.T) {
+	m := world.NewMap(3, 3, 32.0)
+	if m.SetOverlay(0, 0, world.TileWater) {
+		t.Error("SetOverlay(water) accepted a terrain tile as an overlay")
+	}
+	if m.SetOverlay(3, 0, world.TileSnow) {
+		t.Error("SetOverlay accepted an out-of-bounds cell")
+	}
+	if m.GetOverlay(0, 0) != world.OverlayNone || m.GetOverlay(-1, 0) != world.OverlayNone {
+		t.Error("GetOverlay should report OverlayNone for empty and out-of-bounds cells")
+	}
+	
+	m.SetOverlay(0, 0, world.TileSnow)
+	m.SetOverlay(0, 0, world.OverlayNone)
+	if m.GetOverlay(0, 0) != world.OverlayNone {
+		t.Errorf("GetOverlay after clearing = %v, want OverlayNone", m.GetOverlay(0, 0))
+	}
+}
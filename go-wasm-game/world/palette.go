@@ -0,0 +1,49 @@
+package world
+
+// DefaultPalette is the palette name that draws every tile with its definition's Color
+const DefaultPalette = "default"
+
+// TilePalettes are the built-in color palettes, keyed by name. A palette only changes the colors tiles
+// are drawn with; TileDefinitions stay untouched. Tile types a palette doesn't list keep their own Color
+var TilePalettes = map[string]map[TileType]string{
+	DefaultPalette: {},
+	// Okabe-Ito based colors that stay distinct with red-green color blindness
+	"colorblind": {
+		TileGrass:    "#F0E442", // Yellow
+		TileWater:    "#0072B2", // Blue
+		TileDirtPath: "#D55E00", // Vermillion
+		TileWall:     "#4D4D4D", // Dark gray
+		TileBridge:   "#CC79A7", // Reddish purple
+		TileSnow:     "#FFFFFF", // White
+		TileShadow:   "#000000", // Black
+	},
+}
+
+// SetPalette switches the color palette tiles are rendered with; an unknown name leaves it unchanged and returns false
+func (m *Map) SetPalette(name string) bool {
+	if _, exists := TilePalettes[name]; !exists {
+		return false
+	}
+	m.palette = name
+	return true
+}
+
+// Palette returns the name of the active color palette
+func (m *Map) Palette() string {
+	if m.palette == "" {
+		return DefaultPalette
+	}
+	return m.palette
+}
+
+// TileColor returns the color a tile type is drawn with under the active palette
+func (m *Map) TileColor(tileType TileType) string {
+	if color, exists := TilePalettes[m.Palette()][tileType]; exists {
+		return color
+	}
+	tileDef, exists := TileDefinitions[tileType]
+	if !exists {
+		tileDef = TileDefinitions[TileGrass]
+	}
+	return tileDef.Color
+}
@@ -0,0 +1,61 @@
+//go:build !js
+// +build !js
+
+package world_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that the default palette draws every tile type with its definition's color
+func TestDefaultPaletteMatchesDefinitions(t *testing.T) {
+	gameMap := world.NewMap(4, 4, 32.0)
+	if got := gameMap.Palette(); got != world.DefaultPalette {
+		t.Fatalf("Palette() = %q, want %q", got, world.DefaultPalette)
+	}
+
+	for tileType, tileDef := range world.TileDefinitions {
+		if got := gameMap.TileColor(tileType); got != tileDef.Color {
+			t.Errorf("TileColor(%v) = %q, want definition color %q", tileType, got, tileDef.Color)
+		}
+	}
+}
+
+// Test that switching palettes changes the rendered color of each tile type without touching definitions
+func TestSetPaletteChangesRenderedColors(t *testing.T) {
+	gameMap := world.NewMap(4, 4, 32.0)
+	gameMap.SetTile(1, 1, world.TileWater)
+	gameMap.SetOverlay(1, 1, world.TileSnow)
+	original := world.TileDefinitions[world.TileWater].Color
+
+	if !gameMap.SetPalette("colorblind") {
+		t.Fatalf("SetPalette(colorblind) = false, want true")
+	}
+	for tileType, color := range world.TilePalettes["colorblind"] {
+		if got := gameMap.TileColor(tileType); got != color {
+			t.Errorf("TileColor(%v) = %q, want palette color %q", tileType, got, color)
+		}
+	}
+	if got := world.TileDefinitions[world.TileWater].Color; got != original {
+		t.Errorf("TileDefinitions[TileWater].Color = %q after SetPalette, want %q", got, original)
+	}
+
+	ops := gameMap.TileDrawOps(1, 1)
+	if len(ops) != 2 || ops[0].Color != world.TilePalettes["colorblind"][world.TileWater] || ops[1].Color != world.TilePalettes["colorblind"][world.TileSnow] {
+		t.Errorf("TileDrawOps(1, 1) = %v, want palette colors for water and snow", ops)
+	}
+
+	// An unknown palette keeps the current one
+	if gameMap.SetPalette("sepia") {
+		t.Errorf("SetPalette(sepia) = true, want false")
+	}
+	if got := gameMap.Palette(); got != "colorblind" {
+		t.Errorf("Palette() after unknown name = %q, want colorblind", got)
+	}
+
+	gameMap.SetPalette(world.DefaultPalette)
+	if got := gameMap.TileDrawOps(1, 1)[0].Color; got != original {
+		t.Errorf("base color after switching back = %q, want %q", got, original)
+	}
+}
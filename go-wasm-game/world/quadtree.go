@@ -0,0 +1,179 @@
+package world
+
+// Rect is an axis-aligned rectangle in world coordinates
+type Rect struct {
+	X, Y          float64
+	Width, Height float64
+}
+
+// Contains returns true if the point lies inside the rectangle (edges included)
+func (r Rect) Contains(x, y float64) bool {
+	return x >= r.X && x <= r.X+r.Width && y >= r.Y && y <= r.Y+r.Height
+}
+
+// Intersects returns true if the two rectangles overlap (touching edges count)
+func (r Rect) Intersects(other Rect) bool {
+	return r.X <= other.X+other.Width && other.X <= r.X+r.Width &&
+		r.Y <= other.Y+other.Height && other.Y <= r.Y+r.Height
+}
+
+// containsRect returns true if other lies completely inside the rectangle
+func (r Rect) containsRect(other Rect) bool {
+	return other.X >= r.X && other.X+other.Width <= r.X+r.Width &&
+		other.Y >= r.Y && other.Y+other.Height <= r.Y+r.Height
+}
+
+// union returns the smallest rectangle containing both rectangles
+func (r Rect) union(other Rect) Rect {
+	left, top := r.X, r.Y
+	right, bottom := r.X+r.Width, r.Y+r.Height
+	if other.X < left {
+		left = other.X
+	}
+	if other.Y < top {
+		top = other.Y
+	}
+	if other.X+other.Width > right {
+		right = other.X + other.Width
+	}
+	if other.Y+other.Height > bottom {
+		bottom = other.Y + other.Height
+	}
+	return Rect{X: left, Y: top, Width: right - left, Height: bottom - top}
+}
+
+// QuadItem is an object indexed by the quadtree: its bounds plus a caller-defined ID
+type QuadItem struct {
+	Bounds Rect
+	ID     int
+}
+
+const (
+	quadtreeCapacity = 8 // Items a node holds before splitting
+	quadtreeMaxDepth = 8 // Deepest level a node can split to
+)
+
+// Quadtree indexes rectangular objects for fast region and point queries
+// Items that straddle a split line stay in the parent node, so every item lives in exactly one node
+type Quadtree struct {
+	bounds   Rect
+	depth    int
+	items    []QuadItem
+	children []*Quadtree // Nil until split, then NW, NE, SW, SE
+}
+
+// NewQuadtree creates an empty quadtree covering the given bounds
+func NewQuadtree(bounds Rect) *Quadtree {
+	return &Quadtree{bounds: bounds}
+}
+
+// NewQuadtreeCovering creates a quadtree holding every item, growing the given bounds as needed
+// so that none is rejected
+func NewQuadtreeCovering(bounds Rect, items []QuadItem) *Quadtree {
+	for _, item := range items {
+		bounds = bounds.union(item.Bounds)
+	}
+	q := NewQuadtree(bounds)
+	for _, item := range items {
+		q.insert(item)
+	}
+	return q
+}
+
+// Insert adds an item; returns false if the item is not fully inside the tree's bounds
+func (q *Quadtree) Insert(item QuadItem) bool {
+	if !q.bounds.containsRect(item.Bounds) {
+		return false
+	}
+	q.insert(item)
+	return true
+}
+
+func (q *Quadtree) insert(item QuadItem) {
+	if q.children != nil {
+		if child := q.childFor(item.Bounds); child != nil {
+			child.insert(item)
+			return
+		}
+		q.items = append(q.items, item)
+		return
+	}
+	
+	q.items = append(q.items, item)
+	if len(q.items) > quadtreeCapacity && q.depth < quadtreeMaxDepth {
+		q.split()
+	}
+}
+
+// split creates the four children and pushes down every item that fits in one of them
+func (q *Quadtree) split() {
+	halfW, halfH := q.bounds.Width/2, q.bounds.Height/2
+	x, y := q.bounds.X, q.bounds.Y
+	q.children = []*Quadtree{
+		{bounds: Rect{x, y, halfW, halfH}, depth: q.depth + 1},
+		{bounds: Rect{x + halfW, y, halfW, halfH}, depth: q.depth + 1},
+		{bounds: Rect{x, y + halfH, halfW, halfH}, depth: q.depth + 1},
+		{bounds: Rect{x + halfW, y + halfH, halfW, halfH}, depth: q.depth + 1},
+	}
+	
+	remaining := q.items[:0]
+	for _, item := range q.items {
+		if child := q.childFor(item.Bounds); child != nil {
+			child.insert(item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+	q.items = remaining
+}
+
+// childFor returns the child that fully contains the bounds, or nil if they straddle a split
+func (q *Quadtree) childFor(bounds Rect) *Quadtree {
+	for _, child := range q.children {
+		if child.bounds.containsRect(bounds) {
+			return child
+		}
+	}
+	return nil
+}
+
+// QueryRect returns every item whose bounds intersect the region
+func (q *Quadtree) QueryRect(region Rect) []QuadItem {
+	var found []QuadItem
+	q.queryRect(region, &found)
+	return found
+}
+
+func (q *Quadtree) queryRect(region Rect, found *[]QuadItem) {
+	if !q.bounds.Intersects(region) {
+		return
+	}
+	for _, item := range q.items {
+		if item.Bounds.Intersects(region) {
+			*found = append(*found, item)
+		}
+	}
+	for _, child := range q.children {
+		child.queryRect(region, found)
+	}
+}
+
+// QueryPoint returns every item whose bounds contain the point
+func (q *Quadtree) QueryPoint(x, y float64) []QuadItem {
+	var found []QuadItem
+	for _, item := range q.QueryRect(Rect{X: x, Y: y}) {
+		if item.Bounds.Contains(x, y) {
+			found = append(found, item)
+		}
+	}
+	return found
+}
+
+// Len returns the number of items stored in the tree
+func (q *Quadtree) Len() int {
+	count := len(q.items)
+	for _, child := range q.children {
+		count += child.Len()
+	}
+	return count
+}
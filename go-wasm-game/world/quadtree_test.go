@@ -0,0 +1,146 @@
+//go:build !js
+// +build !js
+
+package world_test
+
+import (
+	"sort"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// sortedIDs returns the IDs of quadtree items in ascending order
+func sortedIDs(items []world.QuadItem) []int {
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// Test that insertion accepts items inside the bounds, rejects outside ones, and survives splitting
+func TestQuadtreeInsert(t *testing.T) {
+	tree := world.NewQuadtree(world.Rect{X: 0, Y: 0, Width: 1000, Height: 1000})
+	
+	// Enough items to force several splits, including ones straddling split lines
+	for i := 0; i < 100; i++ {
+		bounds := world.Rect{X: float64(i * 9), Y: float64((i * 37) % 990), Width: 10, Height: 10}
+		if !tree.Insert(world.QuadItem{Bounds: bounds, ID: i}) {
+			t.Fatalf("Insert(%v) = false for an item inside the bounds", bounds)
+		}
+	}
+	if tree.Len() != 100 {
+		t.Errorf("Len() = %d, want 100", tree.Len())
+	}
+	
+	if tree.Insert(world.QuadItem{Bounds: world.Rect{X: 995, Y: 10, Width: 10, Height: 10}, ID: 999}) {
+		t.Error("Insert should reject an item extending past the bounds")
+	}
+	if tree.Len() != 100 {
+		t.Errorf("Len() = %d after rejected insert, want 100", tree.Len())
+	}
+	
+	// Every inserted item is still found at its own position
+	all := tree.QueryRect(world.Rect{X: 0, Y: 0, Width: 1000, Height: 1000})
+	if len(all) != 100 {
+		t.Errorf("QueryRect over everything returned %d items, want 100", len(all))
+	}
+}
+
+// Test that QueryRect returns exactly the objects intersecting a region, compared against brute force
+func TestQuadtreeQueryRectIsExact(t *testing.T) {
+	tree := world.NewQuadtree(world.Rect{X: 0, Y: 0, Width: 512, Height: 512})
+	var items []world.QuadItem
+	for i := 0; i < 200; i++ {
+		item := world.QuadItem{
+			Bounds: world.Rect{X: float64((i * 53) % 480), Y: float64((i * 97) % 480), Width: float64(4 + i%28), Height: float64(4 + i%19)},
+			ID:     i,
+		}
+		items = append(items, item)
+		tree.Insert(item)
+	}
+	
+	regions := []world.Rect{
+		{X: 0, Y: 0, Width: 100, Height: 100},
+		{X: 250, Y: 250, Width: 20, Height: 20},
+		{X: 200, Y: 0, Width: 112, Height: 512},  // Straddles the root split
+		{X: 600, Y: 600, Width: 50, Height: 50},  // Outside the tree
+	}
+	
+	for _, region := range regions {
+		var expected []int
+		for _, item := range items {
+			if item.Bounds.Intersects(region) {
+				expected = append(expected, item.ID)
+			}
+		}
+		
+		got := sortedIDs(tree.QueryRect(region))
+		if len(got) != len(expected) {
+			t.Errorf("QueryRect(%v) returned %d items, want %d", region, len(got), len(expected))
+			continue
+		}
+		for i := range expected {
+			if got[i] != expected[i] {
+				t.Errorf("QueryRect(%v) = %v, want %v", region, got, expected)
+				break
+			}
+		}
+	}
+}
+
+// Test that QueryPoint only returns items covering the point
+func TestQuadtreeQueryPoint(t *testing.T) {
+	tree := world.NewQuadtree(world.Rect{X: 0, Y: 0, Width: 100, Height: 100})
+	tree.Insert(world.QuadItem{Bounds: world.Rect{X: 10, Y: 10, Width: 20, Height: 20}, ID: 1})
+	tree.Insert(world.QuadItem{Bounds: world.Rect{X: 25, Y: 25, Width: 20, Height: 20}, ID: 2})
+	tree.Insert(world.QuadItem{Bounds: world.Rect{X: 70, Y: 70, Width: 10, Height: 10}, ID: 3})
+	
+	tests := []struct {
+		name string
+		x, y float64
+		want []int
+	}{
+		{"Inside one item", 15, 15, []int{1}},
+		{"Overlap of two items", 27, 27, []int{1, 2}},
+		{"Empty space", 60, 10, []int{}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sortedIDs(tree.QueryPoint(tt.x, tt.y))
+			if len(got) != len(tt.want) {
+				t.Fatalf("QueryPoint(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("QueryPoint(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// Test that NewQuadtreeCovering keeps items that overhang the requested bounds, which Insert would reject
+func TestQuadtreeCoveringOutOfBoundsItems(t *testing.T) {
+	items := []world.QuadItem{
+		{Bounds: world.Rect{X: 10, Y: 10, Width: 20, Height: 20}, ID: 0},
+		{Bounds: world.Rect{X: -300, Y: 40, Width: 30, Height: 30}, ID: 1},  // Far past the left edge
+		{Bounds: world.Rect{X: 90, Y: 90, Width: 500, Height: 20}, ID: 2},   // Overhangs the right edge
+	}
+	tree := world.NewQuadtreeCovering(world.Rect{X: 0, Y: 0, Width: 100, Height: 100}, items)
+	if tree.Len() != len(items) {
+		t.Fatalf("Len() = %d, want %d", tree.Len(), len(items))
+	}
+	
+	if got := sortedIDs(tree.QueryPoint(-290, 50)); len(got) != 1 || got[0] != 1 {
+		t.Errorf("QueryPoint(-290, 50) = %v, want [1]", got)
+	}
+	if got := sortedIDs(tree.QueryPoint(400, 100)); len(got) != 1 || got[0] != 2 {
+		t.Errorf("QueryPoint(400, 100) = %v, want [2]", got)
+	}
+	if got := sortedIDs(tree.QueryRect(world.Rect{X: 0, Y: 0, Width: 40, Height: 40})); len(got) != 1 || got[0] != 0 {
+		t.Errorf("QueryRect over the first item = %v, want [0]", got)
+	}
+}
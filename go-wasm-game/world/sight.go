@@ -0,0 +1,37 @@
+package world
+
+// BlocksSight reports whether a tile stops line of sight, either by its base terrain or
+// by an object standing on it such as a tree. Overlays are ground cover and never block sight
+func (m *Map) BlocksSight(x, y int) bool {
+	if tileDef, exists := TileDefinitions[m.GetTile(x, y)]; exists && tileDef.BlocksSight {
+		return true
+	}
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return false
+	}
+	return m.sightBlockers[y*m.Width+x] > 0
+}
+
+// AddSightBlocker records an object on a tile that blocks sight; each add needs a matching RemoveSightBlocker
+func (m *Map) AddSightBlocker(x, y int) {
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return
+	}
+	if m.sightBlockers == nil {
+		m.sightBlockers = make(map[int]int)
+	}
+	m.sightBlockers[y*m.Width+x]++
+}
+
+// RemoveSightBlocker removes one sight-blocking object from a tile
+func (m *Map) RemoveSightBlocker(x, y int) {
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return
+	}
+	key := y*m.Width + x
+	if m.sightBlockers[key] <= 1 {
+		delete(m.sightBlockers, key)
+		return
+	}
+	m.sightBlockers[key]--
+}
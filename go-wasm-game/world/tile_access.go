@@ -0,0 +1,93 @@
+package world
+
+import "math"
+
+// GetTile returns the tile type at the given grid coordinates, or OutOfBoundsTile() outside the map
+// On wrapping maps every coordinate lands on the map. On chunked maps this generates the containing chunk on first access
+func (m *Map) GetTile(x, y int) TileType {
+	x, y = m.WrapTile(x, y)
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return m.OutOfBoundsTile()
+	}
+	if m.IsChunked() {
+		chunk := m.ensureChunk(x/m.ChunkSize, y/m.ChunkSize)
+		return chunk[y%m.ChunkSize][x%m.ChunkSize]
+	}
+	return m.Tiles[y][x]
+}
+
+// OutOfBoundsTile returns what GetTile reports outside the map: water unless SetOutOfBoundsTile changed it,
+// including on a zero-value Map
+func (m *Map) OutOfBoundsTile() TileType {
+	if m.outOfBoundsTile == nil {
+		return TileWater
+	}
+	return *m.outOfBoundsTile
+}
+
+// SetOutOfBoundsTile changes what GetTile reports outside the map, e.g. walls around an arena
+func (m *Map) SetOutOfBoundsTile(tileType TileType) {
+	m.outOfBoundsTile = &tileType
+}
+
+// SetTile sets the tile type at the given grid coordinates
+func (m *Map) SetTile(x, y int, tileType TileType) {
+	x, y = m.WrapTile(x, y)
+	if x >= 0 && x < m.Width && y >= 0 && y < m.Height {
+		if m.IsChunked() {
+			chunk := m.ensureChunk(x/m.ChunkSize, y/m.ChunkSize)
+			chunk[y%m.ChunkSize][x%m.ChunkSize] = tileType
+		} else {
+			m.Tiles[y][x] = tileType
+		}
+		m.notifyTileChange(x, y)
+	}
+}
+
+// OnTileChange registers fn to hear about every tile whose walkability, speed or crossings may have changed:
+// SetTile (and so brush strokes, toggles and undo), overlays, edge walls and one-way masks.
+// Writing Tiles directly bypasses it
+func (m *Map) OnTileChange(fn func(x, y int)) {
+	m.tileListeners = append(m.tileListeners, fn)
+}
+
+// notifyTileChange tells the OnTileChange listeners about an edited tile
+func (m *Map) notifyTileChange(x, y int) {
+	for _, listener := range m.tileListeners {
+		listener(x, y)
+	}
+}
+
+// WalkableGrid returns a row-major snapshot of walkability, 1 per walkable tile and 0 otherwise
+func (m *Map) WalkableGrid() []byte {
+	grid := make([]byte, m.Width*m.Height)
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			if m.TileDefAt(x, y).Walkable {
+				grid[y*m.Width+x] = 1
+			}
+		}
+	}
+	return grid
+}
+
+// CostGrid returns each tile's [y][x] cost to step onto it orthogonally: the inverse of its walk speed,
+// so fast terrain is cheap, plus extraCost(x, y) when non-nil. Unwalkable tiles cost +Inf
+func (m *Map) CostGrid(extraCost func(x, y int) float64) [][]float64 {
+	grid := make([][]float64, m.Height)
+	for y := range grid {
+		grid[y] = make([]float64, m.Width)
+		for x := range grid[y] {
+			tileDef := m.TileDefAt(x, y)
+			if !tileDef.Walkable || tileDef.WalkSpeed <= 0 {
+				grid[y][x] = math.Inf(1)
+				continue
+			}
+			grid[y][x] = 1 / tileDef.WalkSpeed
+			if extraCost != nil {
+				grid[y][x] += extraCost(x, y)
+			}
+		}
+	}
+	return grid
+}
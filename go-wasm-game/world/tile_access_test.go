@@ -0,0 +1,134 @@
+//go:build !js
+// +build !js
+
+package world_test
+
+import (
+	"math"
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that the walkability snapshot matches the tile definitions for a small constructed map
+func TestWalkableGrid(t *testing.T) {
+	gameMap := world.NewMap(4, 3, 32.0)
+	gameMap.SetTile(1, 0, world.TileWater)
+	gameMap.SetTile(2, 1, world.TileWall)
+	gameMap.SetTile(3, 1, world.TileDirtPath)
+	gameMap.SetTile(0, 2, world.TileBridge)
+	
+	expected := []byte{
+		1, 0, 1, 1,
+		1, 1, 0, 1,
+		1, 1, 1, 1,
+	}
+	grid := gameMap.WalkableGrid()
+	if len(grid) != len(expected) {
+		t.Fatalf("len(WalkableGrid()) = %d, want %d", len(grid), len(expected))
+	}
+	for i := range expected {
+		x, y := i%gameMap.Width, i/gameMap.Width
+		if grid[i] != expected[i] {
+			t.Errorf("WalkableGrid()[%d] (tile %d, %d) = %d, want %d", i, x, y, grid[i], expected[i])
+		}
+		if want := world.TileDefinitions[gameMap.GetTile(x, y)].Walkable; (grid[i] == 1) != want {
+			t.Errorf("tile (%d, %d) walkable = %v in grid, %v in TileDefinitions", x, y, grid[i] == 1, want)
+		}
+	}
+}
+
+// Test that the cost grid charges fast terrain less, marks water impassable and adds the hook's cost
+func TestCostGrid(t *testing.T) {
+	gameMap := world.NewMap(3, 2, 32.0)
+	gameMap.SetTile(1, 0, world.TileDirtPath)
+	gameMap.SetTile(2, 0, world.TileWater)
+	
+	grid := gameMap.CostGrid(nil)
+	if len(grid) != 2 || len(grid[0]) != 3 {
+		t.Fatalf("CostGrid() size = %dx%d, want 3x2", len(grid[0]), len(grid))
+	}
+	grass, dirt := grid[0][0], grid[0][1]
+	if dirt >= grass {
+		t.Errorf("dirt cost %.3f, want less than grass cost %.3f", dirt, grass)
+	}
+	if !math.IsInf(grid[0][2], 1) {
+		t.Errorf("water cost = %v, want +Inf", grid[0][2])
+	}
+	
+	hooked := gameMap.CostGrid(func(x, y int) float64 { return float64(y) })
+	if got := hooked[1][0]; got != grid[1][0]+1 {
+		t.Errorf("hooked cost at (0, 1) = %v, want %v", got, grid[1][0]+1)
+	}
+	if !math.IsInf(hooked[0][2], 1) {
+		t.Errorf("hooked water cost = %v, want +Inf", hooked[0][2])
+	}
+}
+
+// Test that GetTile reports the map's configured exterior outside its bounds
+func TestOutOfBoundsTile(t *testing.T) {
+	gameMap := world.NewMap(3, 3, 32.0)
+	edges := [][2]int{{-1, 0}, {3, 1}, {1, -1}, {2, 3}, {-5, -5}}
+	
+	for _, exterior := range []world.TileType{world.TileWater, world.TileGrass, world.TileWall} {
+		if exterior != world.TileWater {
+			gameMap.SetOutOfBoundsTile(exterior)
+		}
+		for _, edge := range edges {
+			if got := gameMap.GetTile(edge[0], edge[1]); got != exterior {
+				t.Errorf("exterior %v: GetTile(%d, %d) = %v, want %v", exterior, edge[0], edge[1], got, exterior)
+			}
+		}
+		if got := gameMap.GetTile(1, 1); got != world.TileGrass {
+			t.Errorf("exterior %v: GetTile(1, 1) = %v, want the in-bounds grass", exterior, got)
+		}
+	}
+	
+	chunked := world.NewChunkedMap(8, 8, 32.0, 4, func(x, y int) world.TileType { return world.TileGrass })
+	if got := chunked.GetTile(8, 0); got != world.TileWater {
+		t.Errorf("chunked GetTile(8, 0) = %v, want default water", got)
+	}
+	
+	// A map built without a constructor is surrounded by water too
+	literal := &world.Map{Width: 2, Height: 2, Tiles: [][]world.TileType{{world.TileGrass, world.TileGrass}, {world.TileGrass, world.TileGrass}}}
+	if got := literal.GetTile(-1, 0); got != world.TileWater {
+		t.Errorf("zero-value exterior GetTile(-1, 0) = %v, want water", got)
+	}
+	if got := (&world.Map{}).GetTile(0, 0); got != world.TileWater {
+		t.Errorf("empty Map GetTile(0, 0) = %v, want water", got)
+	}
+}
+
+// Test that every kind of pathing edit reaches OnTileChange listeners with the tiles it touched
+func TestOnTileChange(t *testing.T) {
+	tests := []struct {
+		name  string
+		edit  func(gameMap *world.Map)
+		tiles [][2]int
+	}{
+		{"set tile", func(gameMap *world.Map) { gameMap.SetTile(1, 2, world.TileWater) }, [][2]int{{1, 2}}},
+		{"toggle", func(gameMap *world.Map) { gameMap.ToggleWalkable(3, 0) }, [][2]int{{3, 0}}},
+		{"undo", func(gameMap *world.Map) { gameMap.Undo() }, [][2]int{{0, 0}}},
+		{"overlay", func(gameMap *world.Map) { gameMap.SetOverlay(2, 2, world.TileSnow) }, [][2]int{{2, 2}}},
+		{"edge wall", func(gameMap *world.Map) { gameMap.AddEdgeWall(1, 1, 1, 2) }, [][2]int{{1, 1}, {1, 2}}},
+		{"one-way tile", func(gameMap *world.Map) { gameMap.SetTileEntryMask(0, 3, world.EntryFromWest) }, [][2]int{{0, 3}}},
+		{"out of bounds", func(gameMap *world.Map) { gameMap.SetTile(9, 9, world.TileWater) }, nil},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := world.NewMap(4, 4, 32.0)
+			gameMap.PaintBrush(0, 0, 0, world.TileWall)
+			var got [][2]int
+			gameMap.OnTileChange(func(x, y int) { got = append(got, [2]int{x, y}) })
+			tt.edit(gameMap)
+			if len(got) != len(tt.tiles) {
+				t.Fatalf("listener heard %v, want %v", got, tt.tiles)
+			}
+			for i := range got {
+				if got[i] != tt.tiles[i] {
+					t.Errorf("listener heard %v, want %v", got, tt.tiles)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package world
+
+import "math"
+
+// VisibleTileRange returns the inclusive range of tiles a camera view overlaps, clamped to the map
+// Wrapping maps are not clamped; the range may run past the edges and is wrapped when drawn
+func (m *Map) VisibleTileRange(cameraX, cameraY, canvasWidth, canvasHeight float64) (int, int, int, int) {
+	tileWidth, tileHeight := m.TileDimensions()
+	if m.Wrap {
+		return int(math.Floor(cameraX / tileWidth)), int(math.Floor(cameraY / tileHeight)),
+			int(math.Ceil((cameraX + canvasWidth) / tileWidth)), int(math.Ceil((cameraY + canvasHeight) / tileHeight))
+	}
+	startX := int(math.Max(0, math.Floor(cameraX/tileWidth)))
+	startY := int(math.Max(0, math.Floor(cameraY/tileHeight)))
+	endX := int(math.Min(float64(m.Width-1), math.Ceil((cameraX+canvasWidth)/tileWidth)))
+	endY := int(math.Min(float64(m.Height-1), math.Ceil((cameraY+canvasHeight)/tileHeight)))
+	return startX, startY, endX, endY
+}
+
+// TileScreenRect returns where a tile is drawn on screen: x, y, width and height
+// With PixelSnap the top-left corner is floored and the far edges ceiled, so neighboring tiles
+// overlap by up to a pixel instead of leaving antialiased seams at fractional camera offsets.
+// A snapped tile never grows past the pixel its unsnapped edge touches, so VisibleTileRange still covers it
+func (m *Map) TileScreenRect(tileX, tileY int, cameraX, cameraY float64) (float64, float64, float64, float64) {
+	tileWidth, tileHeight := m.TileDimensions()
+	screenX := float64(tileX)*tileWidth - cameraX
+	screenY := float64(tileY)*tileHeight - cameraY
+	if !m.PixelSnap {
+		return screenX, screenY, tileWidth, tileHeight
+	}
+	
+	left, top := math.Floor(screenX), math.Floor(screenY)
+	return left, top, math.Ceil(screenX+tileWidth) - left, math.Ceil(screenY+tileHeight) - top
+}
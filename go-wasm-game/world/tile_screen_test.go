@@ -0,0 +1,72 @@
+//go:build !js
+// +build !js
+
+package world_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that snapped tile rectangles floor their corner and ceil their far edges
+func TestTileScreenRectSnapping(t *testing.T) {
+	tests := []struct {
+		name                  string
+		tileWidth, tileHeight float64
+		pixelSnap             bool
+		tileX, tileY          int
+		cameraX, cameraY      float64
+		want                  [4]float64
+	}{
+		{"fractional camera", 32, 32, true, 0, 0, 10.4, 5.75, [4]float64{-11, -6, 33, 33}},
+		{"next tile overlaps previous", 32, 32, true, 1, 1, 10.4, 5.75, [4]float64{21, 26, 33, 33}},
+		{"whole-pixel camera unchanged", 32, 32, true, 2, 1, 16, 8, [4]float64{48, 24, 32, 32}},
+		{"fractional non-square tiles", 30.5, 20.25, true, 1, 2, 0, 0, [4]float64{30, 40, 31, 21}},
+		{"snapping off keeps fractions", 32, 32, false, 1, 1, 10.4, 5.75, [4]float64{21.6, 26.25, 32, 32}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameMap := world.NewMapWithTileDimensions(10, 10, tt.tileWidth, tt.tileHeight)
+			gameMap.PixelSnap = tt.pixelSnap
+			x, y, w, h := gameMap.TileScreenRect(tt.tileX, tt.tileY, tt.cameraX, tt.cameraY)
+			if got := [4]float64{x, y, w, h}; got != tt.want {
+				t.Errorf("TileScreenRect(%d, %d) = %v, want %v", tt.tileX, tt.tileY, got, tt.want)
+			}
+		})
+	}
+	
+	if !world.NewMap(4, 4, 32.0).PixelSnap {
+		t.Errorf("NewMap() PixelSnap = false, want snapping on by default")
+	}
+}
+
+// Test that snapped neighbors never leave a gap, overdraw by at most a pixel and stay inside the culling range
+func TestTileScreenRectSeamsAndCulling(t *testing.T) {
+	gameMap := world.NewMapWithTileDimensions(40, 40, 32.3, 24.7)
+	const canvasWidth, canvasHeight = 200.0, 150.0
+	
+	for _, offset := range []float64{0, 0.25, 0.5, 10.4, 77.77, 301.9} {
+		startX, startY, endX, endY := gameMap.VisibleTileRange(offset, offset, canvasWidth, canvasHeight)
+		for x := 0; x < gameMap.Width-1; x++ {
+			left, _, width, _ := gameMap.TileScreenRect(x, 0, offset, offset)
+			nextLeft, _, _, _ := gameMap.TileScreenRect(x+1, 0, offset, offset)
+			if right := left + width; right < nextLeft || right > nextLeft+1 {
+				t.Fatalf("offset %v: tile %d ends at %v, next starts at %v; want overlap of at most 1px", offset, x, right, nextLeft)
+			}
+			
+			// Tiles the culling skips must not reach any canvas pixel once snapped
+			if x < startX || x > endX {
+				if left < canvasWidth && left+width > 0 {
+					t.Errorf("offset %v: culled tile column %d covers pixels [%v, %v)", offset, x, left, left+width)
+				}
+			}
+		}
+		for y := 0; y < gameMap.Height; y++ {
+			_, top, _, height := gameMap.TileScreenRect(0, y, offset, offset)
+			if (y < startY || y > endY) && top < canvasHeight && top+height > 0 {
+				t.Errorf("offset %v: culled tile row %d covers pixels [%v, %v)", offset, y, top, top+height)
+			}
+		}
+	}
+}
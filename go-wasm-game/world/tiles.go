@@ -6,21 +6,58 @@ package world
 // TileDefinitions contains all tile definitions with their properties
 var TileDefinitions = map[TileType]Tile{
 	TileGrass: {
+		Name:      "Grass",
 		Walkable:  true,
 		WalkSpeed: 1.0,
 		Color:     "#90EE90", // Light green
 		Image:     "",
 	},
 	TileWater: {
+		Name:      "Water",
 		Walkable:  false,
 		WalkSpeed: 0.0,
 		Color:     "#4169E1", // Royal blue
 		Image:     "",
 	},
 	TileDirtPath: {
+		Name:      "Dirt Path",
 		Walkable:  true,
 		WalkSpeed: 1.5, // 50% faster than grass
 		Color:     "#8B4513", // Saddle brown
 		Image:     "",
 	},
+	TileWall: {
+		Name:      "Wall",
+		Walkable:  false,
+		WalkSpeed: 0.0,
+		Color:     "#696969", // Dim gray
+		Image:     "",
+		BlocksSight: true, // Water is just as impassable but low, so it doesn't
+	},
+	TileBridge: {
+		Name:      "Bridge",
+		Walkable:  true,
+		WalkSpeed: 1.0,
+		Color:     "#DEB887", // Burlywood
+		Image:     "",
+	},
+	TileSnow: {
+		Name:      "Snow",
+		Walkable:  true,
+		WalkSpeed: 0.7, // 30% slower than grass
+		Color:     "#FFFAFA", // Snow white
+		Image:     "",
+		Overlay:   true,
+		OverlayAlpha: 0.6,
+		OverridesWalkability: true,
+	},
+	TileShadow: {
+		Name:      "Shadow",
+		Walkable:  true,
+		WalkSpeed: 1.0,
+		Color:     "#000000", // Black
+		Image:     "",
+		Overlay:   true,
+		OverlayAlpha: 0.3,
+	},
 }
\ No newline at end of file
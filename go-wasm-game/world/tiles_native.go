@@ -6,21 +6,58 @@ package world
 // TileDefinitions contains all tile definitions (non-WebAssembly version)
 var TileDefinitions = map[TileType]Tile{
 	TileGrass: {
+		Name:      "Grass",
 		Walkable:  true,
 		WalkSpeed: 1.0,
 		Color:     "#90EE90",
 		Image:     "",
 	},
 	TileWater: {
+		Name:      "Water",
 		Walkable:  false,
 		WalkSpeed: 0.0,
 		Color:     "#4169E1",
 		Image:     "",
 	},
 	TileDirtPath: {
+		Name:      "Dirt Path",
 		Walkable:  true,
 		WalkSpeed: 1.5,
 		Color:     "#8B4513",
 		Image:     "",
 	},
+	TileWall: {
+		Name:      "Wall",
+		Walkable:  false,
+		WalkSpeed: 0.0,
+		Color:     "#696969",
+		Image:     "",
+		BlocksSight: true,
+	},
+	TileBridge: {
+		Name:      "Bridge",
+		Walkable:  true,
+		WalkSpeed: 1.0,
+		Color:     "#DEB887",
+		Image:     "",
+	},
+	TileSnow: {
+		Name:      "Snow",
+		Walkable:  true,
+		WalkSpeed: 0.7,
+		Color:     "#FFFAFA",
+		Image:     "",
+		Overlay:   true,
+		OverlayAlpha: 0.6,
+		OverridesWalkability: true,
+	},
+	TileShadow: {
+		Name:      "Shadow",
+		Walkable:  true,
+		WalkSpeed: 1.0,
+		Color:     "#000000",
+		Image:     "",
+		Overlay:   true,
+		OverlayAlpha: 0.3,
+	},
 }
\ No newline at end of file
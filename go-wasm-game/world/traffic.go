@@ -0,0 +1,110 @@
+package world
+
+const (
+	// TrafficDecayRate is the fraction of traffic kept each time DecayTraffic runs (once per frame)
+	TrafficDecayRate = 0.995
+	// TrafficSaturation is the traffic level at which a tile earns the full pathfinding discount
+	TrafficSaturation = 20.0
+	// MaxTrafficDiscount caps how much cheaper a busy tile can become, keeping the preference mild
+	MaxTrafficDiscount = 0.5
+	// trafficFloor is the level below which a tile's traffic is forgotten
+	trafficFloor = 0.01
+)
+
+// trafficState stores traffic sparsely so huge (chunked) maps only pay for tiles units actually use
+type trafficState struct {
+	levels   map[int]float64 // y*width+x -> decaying traversal count
+	discount float64         // Pathfinding cost reduction at saturation, 0 disables it
+}
+
+// trafficKey flattens tile coordinates into a map key
+func (m *Map) trafficKey(x, y int) int {
+	return y*m.Width + x
+}
+
+// ensureTraffic allocates traffic tracking on first use
+func (m *Map) ensureTraffic() *trafficState {
+	if m.traffic == nil {
+		m.traffic = &trafficState{levels: make(map[int]float64)}
+	}
+	return m.traffic
+}
+
+// RecordTraffic notes that a unit has entered a tile
+func (m *Map) RecordTraffic(x, y int) {
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return
+	}
+	m.ensureTraffic().levels[m.trafficKey(x, y)]++
+}
+
+// TrafficAt returns the current (decayed) traffic on a tile
+func (m *Map) TrafficAt(x, y int) float64 {
+	if m.traffic == nil {
+		return 0
+	}
+	return m.traffic.levels[m.trafficKey(x, y)]
+}
+
+// DecayTraffic fades all traffic by TrafficDecayRate, forgetting tiles that have gone quiet
+func (m *Map) DecayTraffic() {
+	if m.traffic == nil {
+		return
+	}
+	for key, level := range m.traffic.levels {
+		level *= TrafficDecayRate
+		if level < trafficFloor {
+			delete(m.traffic.levels, key)
+			continue
+		}
+		m.traffic.levels[key] = level
+	}
+}
+
+// SetTrafficDiscount sets how much cheaper (0 to MaxTrafficDiscount) saturated tiles are to path through
+func (m *Map) SetTrafficDiscount(discount float64) {
+	if discount < 0 {
+		discount = 0
+	}
+	if discount > MaxTrafficDiscount {
+		discount = MaxTrafficDiscount
+	}
+	m.ensureTraffic().discount = discount
+}
+
+// TrafficCostMultiplier returns the factor applied to a tile's pathfinding cost, 1 for untraveled tiles
+func (m *Map) TrafficCostMultiplier(x, y int) float64 {
+	if m.traffic == nil || m.traffic.discount == 0 {
+		return 1.0
+	}
+	saturation := m.TrafficAt(x, y) / TrafficSaturation
+	if saturation > 1 {
+		saturation = 1
+	}
+	return 1 - m.traffic.discount*saturation
+}
+
+// MinTrafficCostMultiplier returns the cheapest any tile can become through traffic
+// Pathfinding scales its heuristic by this so discounted routes can still be found
+func (m *Map) MinTrafficCostMultiplier() float64 {
+	if m.traffic == nil {
+		return 1.0
+	}
+	return 1 - m.traffic.discount
+}
+
+// ConvertTrafficToPaths turns grass with at least threshold traffic into dirt path, returning how many tiles changed
+func (m *Map) ConvertTrafficToPaths(threshold float64) int {
+	if m.traffic == nil {
+		return 0
+	}
+	converted := 0
+	for key, level := range m.traffic.levels {
+		x, y := key%m.Width, key/m.Width
+		if level >= threshold && m.GetTile(x, y) == TileGrass {
+			m.SetTile(x, y, TileDirtPath)
+			converted++
+		}
+	}
+	return converted
+}
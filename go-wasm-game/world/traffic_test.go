@@ -0,0 +1,84 @@
+//go:build !js
+// +build !js
+
+package world_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that traffic accumulates per tile and decays away once units stop using it
+func TestTrafficIncrementAndDecay(t *testing.T) {
+	gameMap := world.NewMap(5, 5, 32.0)
+	for i := 0; i < 3; i++ {
+		gameMap.RecordTraffic(2, 3)
+	}
+	gameMap.RecordTraffic(-1, 0) // Ignored
+	
+	if level := gameMap.TrafficAt(2, 3); level != 3 {
+		t.Fatalf("TrafficAt(2, 3) = %v, want 3", level)
+	}
+	if level := gameMap.TrafficAt(3, 2); level != 0 {
+		t.Errorf("TrafficAt(3, 2) = %v, want 0 for an untraveled tile", level)
+	}
+	
+	gameMap.DecayTraffic()
+	if level := gameMap.TrafficAt(2, 3); level != 3*world.TrafficDecayRate {
+		t.Errorf("after one decay TrafficAt = %v, want %v", level, 3*world.TrafficDecayRate)
+	}
+	
+	for i := 0; i < 5000; i++ {
+		gameMap.DecayTraffic()
+	}
+	if level := gameMap.TrafficAt(2, 3); level != 0 {
+		t.Errorf("TrafficAt = %v after long inactivity, want it forgotten", level)
+	}
+}
+
+// Test the traffic discount curve and its cap
+func TestTrafficCostMultiplier(t *testing.T) {
+	gameMap := world.NewMap(5, 5, 32.0)
+	for i := 0; i < int(world.TrafficSaturation)*2; i++ {
+		gameMap.RecordTraffic(1, 1)
+	}
+	for i := 0; i < int(world.TrafficSaturation)/2; i++ {
+		gameMap.RecordTraffic(2, 2)
+	}
+	
+	if m := gameMap.TrafficCostMultiplier(1, 1); m != 1.0 {
+		t.Errorf("multiplier = %v with no discount configured, want 1", m)
+	}
+	
+	gameMap.SetTrafficDiscount(2.0) // Clamped
+	if m := gameMap.TrafficCostMultiplier(1, 1); m != 1-world.MaxTrafficDiscount {
+		t.Errorf("saturated multiplier = %v, want %v", m, 1-world.MaxTrafficDiscount)
+	}
+	if m := gameMap.TrafficCostMultiplier(2, 2); m != 1-world.MaxTrafficDiscount/2 {
+		t.Errorf("half-saturated multiplier = %v, want %v", m, 1-world.MaxTrafficDiscount/2)
+	}
+	if m := gameMap.TrafficCostMultiplier(4, 4); m != 1.0 {
+		t.Errorf("untraveled multiplier = %v, want 1", m)
+	}
+}
+
+// Test that only busy grass is worn into dirt path
+func TestConvertTrafficToPaths(t *testing.T) {
+	gameMap := world.NewMap(5, 5, 32.0)
+	gameMap.SetTile(3, 3, world.TileBridge)
+	for i := 0; i < 10; i++ {
+		gameMap.RecordTraffic(1, 1)
+		gameMap.RecordTraffic(3, 3)
+	}
+	gameMap.RecordTraffic(2, 2)
+	
+	if converted := gameMap.ConvertTrafficToPaths(5); converted != 1 {
+		t.Errorf("ConvertTrafficToPaths() = %d, want 1", converted)
+	}
+	if gameMap.GetTile(1, 1) != world.TileDirtPath {
+		t.Error("busy grass should become dirt path")
+	}
+	if gameMap.GetTile(2, 2) != world.TileGrass || gameMap.GetTile(3, 3) != world.TileBridge {
+		t.Error("quiet grass and non-grass tiles should be left alone")
+	}
+}
@@ -4,10 +4,15 @@ package world
 
 // Tile represents a terrain tile with properties
 type Tile struct {
+	Name      string // Display name for legends and tooltips
 	Walkable  bool
 	WalkSpeed float64
 	Color     string
 	Image     string // Path to image file, empty string means use color
+	Overlay   bool    // Drawn over a base tile (see Map.Overlay) rather than used as terrain
+	OverlayAlpha float64 // Opacity an overlay is drawn with on top of its base tile
+	OverridesWalkability bool // As an overlay, its Walkable and WalkSpeed replace a walkable base tile's
+	BlocksSight bool // Tall enough to stop line of sight (fog of war, ranged attacks), independent of Walkable
 }
 
 // TileType represents the type of terrain tile
@@ -17,4 +22,8 @@ const (
 	TileGrass TileType = iota
 	TileWater
 	TileDirtPath
+	TileWall   // Built by units, blocks movement
+	TileBridge // Built by units over water
+	TileSnow   // Overlay: light snow cover that slows movement
+	TileShadow // Overlay: purely visual shade
 )
\ No newline at end of file
@@ -0,0 +1,71 @@
+package world
+
+import "math"
+
+// WrapTile maps tile coordinates onto the map when Wrap is on, so (-1, y) is (Width-1, y)
+// Without Wrap the coordinates are returned unchanged
+func (m *Map) WrapTile(x, y int) (int, int) {
+	if !m.Wrap || m.Width <= 0 || m.Height <= 0 {
+		return x, y
+	}
+	return wrapInt(x, m.Width), wrapInt(y, m.Height)
+}
+
+// WrapTileDelta returns the shortest tile offset (dx, dy), going across the seam when that is shorter
+func (m *Map) WrapTileDelta(dx, dy int) (int, int) {
+	if !m.Wrap || m.Width <= 0 || m.Height <= 0 {
+		return dx, dy
+	}
+	return shortestInt(dx, m.Width), shortestInt(dy, m.Height)
+}
+
+// WrapWorld maps a world position onto the map when Wrap is on
+func (m *Map) WrapWorld(x, y float64) (float64, float64) {
+	if !m.Wrap {
+		return x, y
+	}
+	worldWidth, worldHeight := m.WorldSize()
+	if worldWidth <= 0 || worldHeight <= 0 {
+		return x, y
+	}
+	return wrapFloat(x, worldWidth), wrapFloat(y, worldHeight)
+}
+
+// WrapWorldDelta returns the shortest world offset (dx, dy), going across the seam when that is shorter
+func (m *Map) WrapWorldDelta(dx, dy float64) (float64, float64) {
+	if !m.Wrap {
+		return dx, dy
+	}
+	worldWidth, worldHeight := m.WorldSize()
+	if worldWidth <= 0 || worldHeight <= 0 {
+		return dx, dy
+	}
+	return dx - worldWidth*math.Round(dx/worldWidth), dy - worldHeight*math.Round(dy/worldHeight)
+}
+
+// wrapInt returns v modulo size in [0, size)
+func wrapInt(v, size int) int {
+	v %= size
+	if v < 0 {
+		v += size
+	}
+	return v
+}
+
+// shortestInt returns the offset equivalent to d modulo size with the smallest magnitude
+func shortestInt(d, size int) int {
+	d = wrapInt(d, size)
+	if d > size/2 {
+		d -= size
+	}
+	return d
+}
+
+// wrapFloat returns v modulo size in [0, size)
+func wrapFloat(v, size float64) float64 {
+	v = math.Mod(v, size)
+	if v < 0 {
+		v += size
+	}
+	return v
+}
@@ -0,0 +1,69 @@
+//go:build !js
+// +build !js
+
+package world_test
+
+import (
+	"testing"
+	"github.com/Tleety/Chatgpt-Test-webpage/go-wasm-game/world"
+)
+
+// Test that with Wrap on, coordinates past an edge land on the opposite edge
+func TestWrapTileCoordinates(t *testing.T) {
+	gameMap := world.NewMap(8, 6, 32.0)
+	gameMap.Wrap = true
+	gameMap.SetTile(7, 2, world.TileWater)
+	gameMap.SetTile(-1, 5, world.TileWater) // Lands on (7, 5)
+
+	tests := []struct {
+		name         string
+		x, y         int
+		wantX, wantY int
+	}{
+		{"left of the map", -1, 2, 7, 2},
+		{"right of the map", 8, 2, 0, 2},
+		{"above the map", 3, -1, 3, 5},
+		{"far past a corner", -9, 13, 7, 1},
+		{"inside the map", 4, 4, 4, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if x, y := gameMap.WrapTile(tt.x, tt.y); x != tt.wantX || y != tt.wantY {
+				t.Errorf("WrapTile(%d, %d) = (%d, %d), want (%d, %d)", tt.x, tt.y, x, y, tt.wantX, tt.wantY)
+			}
+			if got, want := gameMap.GetTile(tt.x, tt.y), gameMap.GetTile(tt.wantX, tt.wantY); got != want {
+				t.Errorf("GetTile(%d, %d) = %v, want %v from (%d, %d)", tt.x, tt.y, got, want, tt.wantX, tt.wantY)
+			}
+		})
+	}
+
+	if gameMap.GetTile(-1, 2) != world.TileWater || gameMap.GetTile(7, 5) != world.TileWater {
+		t.Errorf("wrapped GetTile/SetTile did not reach the opposite edge")
+	}
+	if x, y := gameMap.GridToWorld(-1, 2); x != 7*32+16 || y != 2*32+16 {
+		t.Errorf("GridToWorld(-1, 2) = (%v, %v), want the center of (7, 2)", x, y)
+	}
+	if x, y := gameMap.WorldToGrid(-10, 6*32+5); x != 7 || y != 0 {
+		t.Errorf("WorldToGrid past the top-left = (%d, %d), want (7, 0)", x, y)
+	}
+	if dx, dy := gameMap.WrapTileDelta(7, -5); dx != -1 || dy != 1 {
+		t.Errorf("WrapTileDelta(7, -5) = (%d, %d), want the short way (-1, 1)", dx, dy)
+	}
+}
+
+// Test that wrap is off by default and leaves coordinates alone
+func TestWrapOffByDefault(t *testing.T) {
+	gameMap := world.NewMap(8, 6, 32.0)
+	if gameMap.Wrap {
+		t.Fatalf("NewMap().Wrap = true, want wrap off by default")
+	}
+	if x, y := gameMap.WrapTile(-1, 2); x != -1 || y != 2 {
+		t.Errorf("WrapTile(-1, 2) = (%d, %d), want it unchanged", x, y)
+	}
+	if got := gameMap.GetTile(-1, 2); got != gameMap.OutOfBoundsTile() {
+		t.Errorf("GetTile(-1, 2) = %v, want OutOfBoundsTile()", got)
+	}
+	if x, y := gameMap.WorldToGrid(-10, -10); x != -1 || y != -1 {
+		t.Errorf("WorldToGrid(-10, -10) = (%d, %d), want (-1, -1)", x, y)
+	}
+}